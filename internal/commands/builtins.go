@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"github.com/AliUnipal/chat/internal/models/user"
+	"github.com/google/uuid"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const shrug = `¯\_(ツ)_/¯`
+
+type userService interface {
+	GetUser(ctx context.Context, id uuid.UUID) (user.User, error)
+}
+
+type chatService interface {
+	MuteChat(ctx context.Context, chatID, userID uuid.UUID, until time.Time) error
+}
+
+// Me sends an action in the third person: "/me waves" sends "* Alice waves".
+func Me(users userService) Command {
+	return Command{
+		Name:        "me",
+		Usage:       "<action>",
+		Description: "Say what you are doing",
+		MinArgs:     1,
+		MaxArgs:     -1,
+		Handler: func(ctx context.Context, inv Invocation) (Result, error) {
+			u, err := users.GetUser(ctx, inv.UserID)
+			if err != nil {
+				return Result{}, err
+			}
+			return Result{Message: "* " + u.FirstName + " " + inv.Text}, nil
+		},
+	}
+}
+
+// Shrug sends the text, if any, followed by a shrug.
+func Shrug() Command {
+	return Command{
+		Name:        "shrug",
+		Usage:       "[text]",
+		Description: `Append ` + shrug + ` to your message`,
+		MaxArgs:     -1,
+		Handler: func(_ context.Context, inv Invocation) (Result, error) {
+			return Result{Message: strings.TrimSpace(inv.Text + " " + shrug)}, nil
+		},
+	}
+}
+
+// Mute silences the chat for the user who runs it, as in "/mute 1h" or
+// "/mute 2d".
+func Mute(chats chatService) Command {
+	return Command{
+		Name:        "mute",
+		Usage:       "<duration>",
+		Description: "Mute this chat for a while, such as 30m, 1h or 7d",
+		MinArgs:     1,
+		MaxArgs:     1,
+		Handler: func(ctx context.Context, inv Invocation) (Result, error) {
+			d, err := ParseDuration(inv.Args[0])
+			if err != nil {
+				return Result{}, err
+			}
+			if err := chats.MuteChat(ctx, inv.ChatID, inv.UserID, inv.Now.Add(d)); err != nil {
+				return Result{}, err
+			}
+			return Result{Reply: fmt.Sprintf("Muted this chat for %s.", inv.Args[0])}, nil
+		},
+	}
+}
+
+// maxDuration is the longest duration ParseDuration accepts, about a
+// century, so that adding it to the current time cannot overflow.
+const maxDuration = 100 * 365 * 24 * time.Hour
+
+// ParseDuration parses a positive duration such as "90s", "1h30m", "2d" or
+// "1w", up to about a century. Days and weeks are whole numbers and cannot
+// be combined with other units.
+func ParseDuration(s string) (time.Duration, error) {
+	var d time.Duration
+	var err error
+	switch unit := s[max(len(s)-1, 0):]; unit {
+	case "d", "w":
+		day := 24 * time.Hour
+		if unit == "w" {
+			day *= 7
+		}
+		var n int
+		n, err = strconv.Atoi(s[:len(s)-1])
+		if err == nil && n > int(maxDuration/day) {
+			return 0, fmt.Errorf("%w: %q is longer than %d days", ErrUsage, s, maxDuration/(24*time.Hour))
+		}
+		d = time.Duration(n) * day
+	default:
+		d, err = time.ParseDuration(s)
+	}
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("%w: %q is not a duration like 30m, 1h or 7d", ErrUsage, s)
+	}
+	if d > maxDuration {
+		return 0, fmt.Errorf("%w: %q is longer than %d days", ErrUsage, s, maxDuration/(24*time.Hour))
+	}
+
+	return d, nil
+}
@@ -0,0 +1,270 @@
+// Package commands runs slash commands, text messages starting with "/",
+// before or in place of sending them.
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+var (
+	ErrUnknownCommand  = errors.New("unknown command")
+	ErrCommandDisabled = errors.New("command is disabled in this chat")
+	ErrCommandExists   = errors.New("command is already registered")
+	ErrInvalidName     = errors.New("command names are lowercase letters, digits and underscores")
+	ErrUsage           = errors.New("invalid command arguments")
+)
+
+var namePattern = regexp.MustCompile(`^[a-z0-9_]{1,32}$`)
+
+// Invocation is a command as a user ran it.
+type Invocation struct {
+	Name string
+	Args []string
+	// Text is everything after the name as it was typed, for commands that
+	// take free text.
+	Text   string
+	ChatID uuid.UUID
+	UserID uuid.UUID
+	// Now is when the command was run.
+	Now time.Time
+}
+
+// Result is what a command does with the message that ran it.
+type Result struct {
+	// Message is sent to the chat in place of the command. Nothing is sent
+	// when it is empty.
+	Message string
+	// Reply is shown only to the user who ran the command.
+	Reply string
+	// Escaped is set when the message only escaped its leading slash, so
+	// Message is the text as typed rather than a command's output.
+	Escaped bool
+}
+
+type Handler func(ctx context.Context, inv Invocation) (Result, error)
+
+type Command struct {
+	Name string
+	// Usage describes the arguments, as in "<duration>".
+	Usage       string
+	Description string
+	MinArgs     int
+	// MaxArgs is the most arguments the command takes, or -1 for no limit.
+	MaxArgs int
+	Handler Handler
+}
+
+// Store keeps which commands are disabled in which chats. Every command is
+// enabled in a chat until it is disabled there.
+type Store interface {
+	SetDisabled(ctx context.Context, chatID uuid.UUID, name string, disabled bool) error
+	GetDisabled(ctx context.Context, chatID uuid.UUID) ([]string, error)
+}
+
+type Registry struct {
+	store Store
+
+	mu       sync.RWMutex
+	commands map[string]Command
+}
+
+func NewRegistry(store Store) *Registry {
+	return &Registry{store: store, commands: make(map[string]Command)}
+}
+
+func (r *Registry) Register(c Command) error {
+	if !namePattern.MatchString(c.Name) {
+		return ErrInvalidName
+	}
+	if c.Handler == nil {
+		return errors.New("command handler is required")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.commands[c.Name]; ok {
+		return ErrCommandExists
+	}
+	r.commands[c.Name] = c
+	return nil
+}
+
+// Commands returns the commands enabled in the chat, by name.
+func (r *Registry) Commands(ctx context.Context, chatID uuid.UUID) ([]Command, error) {
+	disabled, err := r.store.GetDisabled(ctx, chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var cs []Command
+	for _, c := range r.commands {
+		if !slices.Contains(disabled, c.Name) {
+			cs = append(cs, c)
+		}
+	}
+	slices.SortFunc(cs, func(a, b Command) int { return strings.Compare(a.Name, b.Name) })
+	return cs, nil
+}
+
+func (r *Registry) Enable(ctx context.Context, chatID uuid.UUID, name string) error {
+	if _, ok := r.command(name); !ok {
+		return ErrUnknownCommand
+	}
+	return r.store.SetDisabled(ctx, chatID, name, false)
+}
+
+func (r *Registry) Disable(ctx context.Context, chatID uuid.UUID, name string) error {
+	if _, ok := r.command(name); !ok {
+		return ErrUnknownCommand
+	}
+	return r.store.SetDisabled(ctx, chatID, name, true)
+}
+
+// Run runs the command text starts with. It reports false when text is not a
+// command or names no registered one, such as "/etc is full", so it is sent
+// as it is. Starting a message with "//" sends it with one slash instead of
+// running it. Commands that depend on the time go by now.
+func (r *Registry) Run(ctx context.Context, chatID, userID uuid.UUID, text string, now time.Time) (Result, bool, error) {
+	if strings.HasPrefix(text, "//") {
+		return Result{Message: text[1:], Escaped: true}, true, nil
+	}
+	name, rest, ok := split(text)
+	if !ok {
+		return Result{}, false, nil
+	}
+
+	c, ok := r.command(name)
+	if !ok {
+		return Result{}, false, nil
+	}
+	disabled, err := r.store.GetDisabled(ctx, chatID)
+	if err != nil {
+		return Result{}, true, err
+	}
+	if slices.Contains(disabled, name) {
+		return Result{}, true, fmt.Errorf("%w: /%s", ErrCommandDisabled, name)
+	}
+	args, err := ParseArgs(rest)
+	if err != nil {
+		return Result{}, true, err
+	}
+	if len(args) < c.MinArgs || (c.MaxArgs >= 0 && len(args) > c.MaxArgs) {
+		return Result{}, true, fmt.Errorf("%w: usage: /%s %s", ErrUsage, c.Name, c.Usage)
+	}
+
+	res, err := c.Handler(ctx, Invocation{
+		Name:   name,
+		Args:   args,
+		Text:   rest,
+		ChatID: chatID,
+		UserID: userID,
+		Now:    now,
+	})
+	return res, true, err
+}
+
+func (r *Registry) command(name string) (Command, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	c, ok := r.commands[name]
+	return c, ok
+}
+
+// split returns the command name text starts with and the text after it.
+// Text that only looks like a command, such as a path like "/usr/bin", is
+// not one.
+func split(text string) (string, string, bool) {
+	if !strings.HasPrefix(text, "/") {
+		return "", "", false
+	}
+	name, rest := text[1:], ""
+	if i := strings.IndexFunc(name, unicode.IsSpace); i >= 0 {
+		name, rest = name[:i], name[i:]
+	}
+	if !namePattern.MatchString(name) {
+		return "", "", false
+	}
+
+	return name, strings.TrimSpace(rest), true
+}
+
+// ParseArgs splits arguments on spaces. Double quotes group words into one
+// argument, and a backslash escapes the next character.
+func ParseArgs(s string) ([]string, error) {
+	var args []string
+	var arg strings.Builder
+	inArg, quoted, escaped := false, false, false
+	for _, r := range s {
+		switch {
+		case escaped:
+			arg.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			inArg, escaped = true, true
+		case r == '"':
+			inArg, quoted = true, !quoted
+		case unicode.IsSpace(r) && !quoted:
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		default:
+			inArg = true
+			arg.WriteRune(r)
+		}
+	}
+	if quoted || escaped {
+		return nil, fmt.Errorf("%w: unterminated quote or escape", ErrUsage)
+	}
+	if inArg {
+		args = append(args, arg.String())
+	}
+
+	return args, nil
+}
+
+// MemoryStore keeps the disabled commands in memory.
+type MemoryStore struct {
+	mu       sync.Mutex
+	disabled map[uuid.UUID][]string
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{disabled: make(map[uuid.UUID][]string)}
+}
+
+func (s *MemoryStore) SetDisabled(_ context.Context, chatID uuid.UUID, name string, disabled bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	names := slices.DeleteFunc(s.disabled[chatID], func(n string) bool { return n == name })
+	if disabled {
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		delete(s.disabled, chatID)
+	} else {
+		s.disabled[chatID] = names
+	}
+	return nil
+}
+
+func (s *MemoryStore) GetDisabled(_ context.Context, chatID uuid.UUID) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.disabled[chatID]), nil
+}
@@ -0,0 +1,211 @@
+package commands_test
+
+import (
+	"context"
+	"errors"
+	"github.com/AliUnipal/chat/internal/commands"
+	"github.com/AliUnipal/chat/internal/commands/mocks"
+	"github.com/AliUnipal/chat/internal/models/user"
+	"github.com/google/uuid"
+	"slices"
+	"testing"
+	"time"
+)
+
+func echo() commands.Command {
+	return commands.Command{
+		Name:    "echo",
+		Usage:   "<word> [word]",
+		MinArgs: 1,
+		MaxArgs: 2,
+		Handler: func(_ context.Context, inv commands.Invocation) (commands.Result, error) {
+			return commands.Result{Reply: inv.Text}, nil
+		},
+	}
+}
+
+func TestRegistry_Run(t *testing.T) {
+	ctx := context.Background()
+	r := commands.NewRegistry(commands.NewMemoryStore())
+	if err := r.Register(echo()); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	tests := []struct {
+		text     string
+		command  bool
+		expected commands.Result
+		err      error
+	}{
+		{text: "hello"},
+		{text: "/usr/bin is a path"},
+		{text: "/ spaced"},
+		{text: "//echo sent as is", command: true, expected: commands.Result{Message: "/echo sent as is", Escaped: true}},
+		{text: "/echo hi", command: true, expected: commands.Result{Reply: "hi"}},
+		{text: "/echo\thi  there ", command: true, expected: commands.Result{Reply: "hi  there"}},
+		{text: `/echo "one argument"`, command: true, expected: commands.Result{Reply: `"one argument"`}},
+		{text: "/echo", command: true, err: commands.ErrUsage},
+		{text: "/echo a b c", command: true, err: commands.ErrUsage},
+		{text: `/echo "unterminated`, command: true, err: commands.ErrUsage},
+		{text: "/nope"},
+		{text: "/etc is full"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			res, ok, err := r.Run(ctx, uuid.New(), uuid.New(), tt.text, time.Now())
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected %v got %v", tt.err, err)
+			}
+			if ok != tt.command || res != tt.expected {
+				t.Fatalf("expected %v, %+v got %v, %+v", tt.command, tt.expected, ok, res)
+			}
+		})
+	}
+}
+
+func TestRegistry_Register(t *testing.T) {
+	r := commands.NewRegistry(commands.NewMemoryStore())
+	if err := r.Register(echo()); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if err := r.Register(echo()); !errors.Is(err, commands.ErrCommandExists) {
+		t.Fatalf("expected ErrCommandExists got %v", err)
+	}
+	bad := echo()
+	bad.Name = "Echo"
+	if err := r.Register(bad); !errors.Is(err, commands.ErrInvalidName) {
+		t.Fatalf("expected ErrInvalidName got %v", err)
+	}
+}
+
+func TestRegistry_DisablePerChat(t *testing.T) {
+	ctx := context.Background()
+	r := commands.NewRegistry(commands.NewMemoryStore())
+	if err := r.Register(echo()); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if err := r.Register(commands.Shrug()); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	chatID := uuid.New()
+	otherChatID := uuid.New()
+
+	if err := r.Disable(ctx, chatID, "echo"); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if _, _, err := r.Run(ctx, chatID, uuid.New(), "/echo hi", time.Now()); !errors.Is(err, commands.ErrCommandDisabled) {
+		t.Fatalf("expected ErrCommandDisabled got %v", err)
+	}
+	if _, _, err := r.Run(ctx, otherChatID, uuid.New(), "/echo hi", time.Now()); err != nil {
+		t.Fatalf("expected the command to work in other chats got %v", err)
+	}
+	cs, err := r.Commands(ctx, chatID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(cs) != 1 || cs[0].Name != "shrug" {
+		t.Fatalf("expected only shrug got %+v", cs)
+	}
+
+	if err := r.Enable(ctx, chatID, "echo"); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if _, _, err := r.Run(ctx, chatID, uuid.New(), "/echo hi", time.Now()); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if err := r.Disable(ctx, chatID, "nope"); !errors.Is(err, commands.ErrUnknownCommand) {
+		t.Fatalf("expected ErrUnknownCommand got %v", err)
+	}
+}
+
+func TestParseArgs(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected []string
+	}{
+		{in: "", expected: nil},
+		{in: "a  b", expected: []string{"a", "b"}},
+		{in: `"a b" c`, expected: []string{"a b", "c"}},
+		{in: `a\ b ""`, expected: []string{"a b", ""}},
+		{in: `say\"hi\"`, expected: []string{`say"hi"`}},
+	}
+	for _, tt := range tests {
+		args, err := commands.ParseArgs(tt.in)
+		if err != nil {
+			t.Fatalf("expected no error for %q got %v", tt.in, err)
+		}
+		if !slices.Equal(args, tt.expected) {
+			t.Fatalf("expected %q for %q got %q", tt.expected, tt.in, args)
+		}
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected time.Duration
+		err      bool
+	}{
+		{in: "1h", expected: time.Hour},
+		{in: "1h30m", expected: 90 * time.Minute},
+		{in: "2d", expected: 48 * time.Hour},
+		{in: "1w", expected: 7 * 24 * time.Hour},
+		{in: "0s", err: true},
+		{in: "-1h", err: true},
+		{in: "1.5d", err: true},
+		{in: "d", err: true},
+		{in: "soon", err: true},
+		{in: "36500d", expected: 36500 * 24 * time.Hour},
+		{in: "36501d", err: true},
+		{in: "9223372036854775807d", err: true},
+		{in: "106751991167w", err: true},
+		{in: "900000h", err: true},
+	}
+	for _, tt := range tests {
+		d, err := commands.ParseDuration(tt.in)
+		if (err != nil) != tt.err || d != tt.expected {
+			t.Fatalf("expected %v, error %v for %q got %v, %v", tt.expected, tt.err, tt.in, d, err)
+		}
+	}
+}
+
+func TestBuiltins(t *testing.T) {
+	ctx := context.Background()
+	chatID := uuid.New()
+	userID := uuid.New()
+	users := mocks.NewUserService(t)
+	users.EXPECT().GetUser(ctx, userID).Return(user.User{ID: userID, FirstName: "Alice"}, nil)
+	chats := mocks.NewChatService(t)
+	now := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	chats.EXPECT().MuteChat(ctx, chatID, userID, now.Add(time.Hour)).Return(nil)
+
+	r := commands.NewRegistry(commands.NewMemoryStore())
+	for _, c := range []commands.Command{commands.Me(users), commands.Shrug(), commands.Mute(chats)} {
+		if err := r.Register(c); err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+	}
+
+	tests := []struct {
+		text     string
+		expected commands.Result
+	}{
+		{text: "/me waves", expected: commands.Result{Message: "* Alice waves"}},
+		{text: "/shrug", expected: commands.Result{Message: `¯\_(ツ)_/¯`}},
+		{text: "/shrug who knows", expected: commands.Result{Message: `who knows ¯\_(ツ)_/¯`}},
+		{text: "/mute 1h", expected: commands.Result{Reply: "Muted this chat for 1h."}},
+	}
+	for _, tt := range tests {
+		res, ok, err := r.Run(ctx, chatID, userID, tt.text, now)
+		if err != nil || !ok {
+			t.Fatalf("expected %q to run got %v, %v", tt.text, ok, err)
+		}
+		if res != tt.expected {
+			t.Fatalf("expected %+v for %q got %+v", tt.expected, tt.text, res)
+		}
+	}
+
+	if _, _, err := r.Run(ctx, chatID, userID, "/mute forever", now); !errors.Is(err, commands.ErrUsage) {
+		t.Fatalf("expected ErrUsage got %v", err)
+	}
+}
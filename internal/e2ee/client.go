@@ -0,0 +1,300 @@
+// Package e2ee is a reference client for end-to-end encrypted direct chats.
+//
+// Sessions are set up with X3DH against the prekeys a user publishes to the
+// key directory in usersvc, and messages are then encrypted with a double
+// ratchet, so every message has its own key and a leaked key neither exposes
+// earlier messages nor, once both sides have replied, later ones. The output
+// of Encrypt is an opaque envelope meant to be sent as the content of a
+// message.EncryptedContentType message; the server never sees plaintext or
+// private keys.
+package e2ee
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/AliUnipal/chat/internal/models/user"
+	"github.com/google/uuid"
+	"sync"
+)
+
+var (
+	ErrNoSession        = errors.New("no encrypted session with this user")
+	ErrInvalidSignature = errors.New("signed prekey signature is invalid")
+	ErrUnknownPreKey    = errors.New("one-time prekey is unknown or already used")
+	ErrDecrypt          = errors.New("message could not be decrypted")
+)
+
+type dhPair struct {
+	priv *ecdh.PrivateKey
+	pub  *ecdh.PublicKey
+}
+
+// Client holds a user's private keys and their sessions with other users.
+// It is safe for concurrent use.
+type Client struct {
+	mu             sync.Mutex
+	signingKey     ed25519.PrivateKey
+	identityKey    *ecdh.PrivateKey
+	signedPreKey   *ecdh.PrivateKey
+	signature      []byte
+	oneTimePreKeys map[uuid.UUID]*ecdh.PrivateKey
+	sessions       map[uuid.UUID]*session
+}
+
+// NewClient generates a fresh identity for a user.
+func NewClient() (*Client, error) {
+	_, signingKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	identityKey, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	signedPreKey, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		signingKey:     signingKey,
+		identityKey:    identityKey,
+		signedPreKey:   signedPreKey,
+		signature:      ed25519.Sign(signingKey, signedKeys(identityKey.PublicKey().Bytes(), signedPreKey.PublicKey().Bytes())),
+		oneTimePreKeys: make(map[uuid.UUID]*ecdh.PrivateKey),
+		sessions:       make(map[uuid.UUID]*session),
+	}, nil
+}
+
+// IdentityKeys returns the public keys to publish to the key directory.
+func (c *Client) IdentityKeys() user.IdentityKeys {
+	return user.IdentityKeys{
+		SigningKey:   c.signingKey.Public().(ed25519.PublicKey),
+		IdentityKey:  c.identityKey.PublicKey().Bytes(),
+		SignedPreKey: c.signedPreKey.PublicKey().Bytes(),
+		Signature:    c.signature,
+	}
+}
+
+// GenerateOneTimePreKeys creates n one-time prekeys, keeping the private
+// halves and returning the public ones to publish to the key directory.
+func (c *Client) GenerateOneTimePreKeys(n int) ([]user.OneTimePreKey, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	keys := make([]user.OneTimePreKey, 0, n)
+	for range n {
+		k, err := ecdh.X25519().GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		id := uuid.New()
+		c.oneTimePreKeys[id] = k
+		keys = append(keys, user.OneTimePreKey{ID: id, Key: k.PublicKey().Bytes()})
+	}
+
+	return keys, nil
+}
+
+// HasSession reports whether the client can already encrypt to peerID.
+func (c *Client) HasSession(peerID uuid.UUID) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.sessions[peerID] != nil
+}
+
+// StartSession runs X3DH against the peer's prekey bundle, replacing any
+// existing session with them. Messages encrypted before the peer first
+// replies carry what they need to set up their side of the session.
+func (c *Client) StartSession(bundle user.PreKeyBundle) error {
+	keys := bundle.IdentityKeys
+	if len(keys.SigningKey) != ed25519.PublicKeySize ||
+		!ed25519.Verify(keys.SigningKey, signedKeys(keys.IdentityKey, keys.SignedPreKey), keys.Signature) {
+		return ErrInvalidSignature
+	}
+	identityKey, err := ecdh.X25519().NewPublicKey(keys.IdentityKey)
+	if err != nil {
+		return err
+	}
+	signedPreKey, err := ecdh.X25519().NewPublicKey(keys.SignedPreKey)
+	if err != nil {
+		return err
+	}
+	ephemeralKey, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return err
+	}
+
+	dhs := make([][]byte, 0, 4)
+	for _, pair := range []dhPair{
+		{c.identityKey, signedPreKey},
+		{ephemeralKey, identityKey},
+		{ephemeralKey, signedPreKey},
+	} {
+		dh, err := pair.priv.ECDH(pair.pub)
+		if err != nil {
+			return err
+		}
+		dhs = append(dhs, dh)
+	}
+	preKey := &preKeyHeader{
+		IdentityKey:  c.identityKey.PublicKey().Bytes(),
+		EphemeralKey: ephemeralKey.PublicKey().Bytes(),
+	}
+	if bundle.OneTimePreKey != nil {
+		oneTimePreKey, err := ecdh.X25519().NewPublicKey(bundle.OneTimePreKey.Key)
+		if err != nil {
+			return err
+		}
+		dh, err := ephemeralKey.ECDH(oneTimePreKey)
+		if err != nil {
+			return err
+		}
+		dhs = append(dhs, dh)
+		preKey.OneTimePreKeyID = bundle.OneTimePreKey.ID
+	}
+
+	sk, err := x3dhKey(dhs...)
+	if err != nil {
+		return err
+	}
+	s, err := newInitiatorSession(sk, signedPreKey, associatedData(c.identityKey.PublicKey().Bytes(), keys.IdentityKey))
+	if err != nil {
+		return err
+	}
+	s.preKey = preKey
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sessions[bundle.UserID] = s
+	return nil
+}
+
+// Encrypt seals plaintext for peerID, returning the envelope to send.
+func (c *Client) Encrypt(peerID uuid.UUID, plaintext []byte) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s := c.sessions[peerID]
+	if s == nil || s.cks == nil {
+		return nil, ErrNoSession
+	}
+	h, ciphertext, err := s.encrypt(plaintext)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(envelope{
+		Version:    envelopeVersion,
+		PreKey:     s.preKey,
+		Header:     h,
+		Ciphertext: ciphertext,
+	})
+}
+
+// Decrypt opens an envelope received from peerID, setting up the session
+// first when the envelope starts one. A message that fails to decrypt leaves
+// the session untouched.
+func (c *Client) Decrypt(peerID uuid.UUID, data []byte) ([]byte, error) {
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDecrypt, err)
+	}
+	if env.Version != envelopeVersion {
+		return nil, fmt.Errorf("%w: unsupported envelope version %d", ErrDecrypt, env.Version)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// An X3DH header the current session did not come from means the peer
+	// started a new session, for example after reinstalling.
+	s := c.sessions[peerID]
+	accepted := env.PreKey != nil && (s == nil || !bytes.Equal(s.peerEphemeralKey, env.PreKey.EphemeralKey))
+	switch {
+	case accepted:
+		var err error
+		if s, err = c.acceptSession(*env.PreKey); err != nil {
+			return nil, err
+		}
+	case s != nil:
+		s = s.clone()
+	default:
+		return nil, ErrNoSession
+	}
+
+	plaintext, err := s.decrypt(env.Header, env.Ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	// Hearing back from the peer proves they have the session, so there is
+	// no need to keep sending the X3DH header.
+	s.preKey = nil
+	if accepted {
+		delete(c.oneTimePreKeys, env.PreKey.OneTimePreKeyID)
+	}
+	c.sessions[peerID] = s
+
+	return plaintext, nil
+}
+
+// acceptSession runs the responder side of X3DH. It must be called with c.mu
+// held.
+func (c *Client) acceptSession(h preKeyHeader) (*session, error) {
+	identityKey, err := ecdh.X25519().NewPublicKey(h.IdentityKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDecrypt, err)
+	}
+	ephemeralKey, err := ecdh.X25519().NewPublicKey(h.EphemeralKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDecrypt, err)
+	}
+
+	pairs := []dhPair{
+		{c.signedPreKey, identityKey},
+		{c.identityKey, ephemeralKey},
+		{c.signedPreKey, ephemeralKey},
+	}
+	if h.OneTimePreKeyID != uuid.Nil {
+		oneTimePreKey, ok := c.oneTimePreKeys[h.OneTimePreKeyID]
+		if !ok {
+			return nil, ErrUnknownPreKey
+		}
+		pairs = append(pairs, dhPair{oneTimePreKey, ephemeralKey})
+	}
+
+	dhs := make([][]byte, 0, len(pairs))
+	for _, pair := range pairs {
+		dh, err := pair.priv.ECDH(pair.pub)
+		if err != nil {
+			return nil, err
+		}
+		dhs = append(dhs, dh)
+	}
+	sk, err := x3dhKey(dhs...)
+	if err != nil {
+		return nil, err
+	}
+
+	s := newResponderSession(sk, c.signedPreKey, associatedData(h.IdentityKey, c.identityKey.PublicKey().Bytes()))
+	s.peerEphemeralKey = h.EphemeralKey
+	return s, nil
+}
+
+// signedKeys is what the signing key signs: the identity key followed by the
+// signed prekey, which binds both to the same identity.
+func signedKeys(identityKey, signedPreKey []byte) []byte {
+	return append(append([]byte{}, identityKey...), signedPreKey...)
+}
+
+// associatedData binds every message of a session to the identity keys of
+// its initiator and responder.
+func associatedData(initiatorKey, responderKey []byte) []byte {
+	return append(append([]byte{}, initiatorKey...), responderKey...)
+}
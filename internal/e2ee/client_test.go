@@ -0,0 +1,147 @@
+package e2ee_test
+
+import (
+	"errors"
+	"github.com/AliUnipal/chat/internal/e2ee"
+	"github.com/AliUnipal/chat/internal/models/user"
+	"github.com/google/uuid"
+	"testing"
+)
+
+type party struct {
+	id     uuid.UUID
+	client *e2ee.Client
+}
+
+func newParty(t *testing.T) party {
+	t.Helper()
+	c, err := e2ee.NewClient()
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	return party{uuid.New(), c}
+}
+
+// bundle builds the prekey bundle the key directory would hand out for p.
+func (p party) bundle(t *testing.T) user.PreKeyBundle {
+	t.Helper()
+	keys, err := p.client.GenerateOneTimePreKeys(1)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	return user.PreKeyBundle{UserID: p.id, IdentityKeys: p.client.IdentityKeys(), OneTimePreKey: &keys[0]}
+}
+
+func encrypt(t *testing.T, from, to party, text string) []byte {
+	t.Helper()
+	env, err := from.client.Encrypt(to.id, []byte(text))
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	return env
+}
+
+func expectDecrypt(t *testing.T, to, from party, env []byte, want string) {
+	t.Helper()
+	got, err := to.client.Decrypt(from.id, env)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if string(got) != want {
+		t.Fatalf("expected %q got %q", want, got)
+	}
+}
+
+func TestClient_Conversation(t *testing.T) {
+	alice, bob := newParty(t), newParty(t)
+	if err := alice.client.StartSession(bob.bundle(t)); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	expectDecrypt(t, bob, alice, encrypt(t, alice, bob, "hi bob"), "hi bob")
+	expectDecrypt(t, bob, alice, encrypt(t, alice, bob, "are you there?"), "are you there?")
+	expectDecrypt(t, alice, bob, encrypt(t, bob, alice, "hi alice"), "hi alice")
+	expectDecrypt(t, bob, alice, encrypt(t, alice, bob, "great"), "great")
+	expectDecrypt(t, alice, bob, encrypt(t, bob, alice, "bye"), "bye")
+}
+
+func TestClient_OutOfOrder(t *testing.T) {
+	alice, bob := newParty(t), newParty(t)
+	if err := alice.client.StartSession(bob.bundle(t)); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	first := encrypt(t, alice, bob, "one")
+	second := encrypt(t, alice, bob, "two")
+	third := encrypt(t, alice, bob, "three")
+	expectDecrypt(t, bob, alice, third, "three")
+	expectDecrypt(t, bob, alice, first, "one")
+
+	reply := encrypt(t, bob, alice, "got them")
+	expectDecrypt(t, alice, bob, reply, "got them")
+	expectDecrypt(t, bob, alice, second, "two")
+	if _, err := bob.client.Decrypt(alice.id, second); !errors.Is(err, e2ee.ErrDecrypt) {
+		t.Fatalf("expected a replayed message to fail with %v got %v", e2ee.ErrDecrypt, err)
+	}
+}
+
+func TestClient_RejectTamperedEnvelope(t *testing.T) {
+	alice, bob := newParty(t), newParty(t)
+	if err := alice.client.StartSession(bob.bundle(t)); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	expectDecrypt(t, bob, alice, encrypt(t, alice, bob, "hello"), "hello")
+
+	env := encrypt(t, alice, bob, "pay 10")
+	tampered := append([]byte{}, env...)
+	tampered[len(tampered)-4] ^= 1
+	if _, err := bob.client.Decrypt(alice.id, tampered); !errors.Is(err, e2ee.ErrDecrypt) {
+		t.Fatalf("expected %v got %v", e2ee.ErrDecrypt, err)
+	}
+	expectDecrypt(t, bob, alice, env, "pay 10")
+}
+
+func TestClient_RejectForgedBundle(t *testing.T) {
+	alice, bob, mallory := newParty(t), newParty(t), newParty(t)
+	bundle := bob.bundle(t)
+	bundle.IdentityKeys.SignedPreKey = mallory.client.IdentityKeys().SignedPreKey
+
+	if err := alice.client.StartSession(bundle); !errors.Is(err, e2ee.ErrInvalidSignature) {
+		t.Fatalf("expected %v got %v", e2ee.ErrInvalidSignature, err)
+	}
+}
+
+func TestClient_OneTimePreKeyUsedOnce(t *testing.T) {
+	alice, bob, carol := newParty(t), newParty(t), newParty(t)
+	bundle := bob.bundle(t)
+
+	if err := alice.client.StartSession(bundle); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	expectDecrypt(t, bob, alice, encrypt(t, alice, bob, "hi"), "hi")
+
+	bundle.UserID = bob.id
+	if err := carol.client.StartSession(bundle); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if _, err := bob.client.Decrypt(carol.id, encrypt(t, carol, bob, "hi")); !errors.Is(err, e2ee.ErrUnknownPreKey) {
+		t.Fatalf("expected %v got %v", e2ee.ErrUnknownPreKey, err)
+	}
+}
+
+func TestClient_SessionWithoutOneTimePreKey(t *testing.T) {
+	alice, bob := newParty(t), newParty(t)
+	if err := alice.client.StartSession(user.PreKeyBundle{UserID: bob.id, IdentityKeys: bob.client.IdentityKeys()}); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	expectDecrypt(t, bob, alice, encrypt(t, alice, bob, "hi"), "hi")
+	expectDecrypt(t, alice, bob, encrypt(t, bob, alice, "hey"), "hey")
+}
+
+func TestClient_ReturnErrorWithoutSession(t *testing.T) {
+	alice, bob := newParty(t), newParty(t)
+	if _, err := alice.client.Encrypt(bob.id, []byte("hi")); !errors.Is(err, e2ee.ErrNoSession) {
+		t.Fatalf("expected %v got %v", e2ee.ErrNoSession, err)
+	}
+}
@@ -0,0 +1,274 @@
+package e2ee
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"maps"
+)
+
+const (
+	envelopeVersion = 1
+	// maxSkip caps how many message keys a single message can make the
+	// receiver derive ahead, so a forged header cannot make it do unbounded
+	// work.
+	maxSkip = 1000
+)
+
+// envelope is what travels through the server as the message content.
+type envelope struct {
+	Version int
+	// PreKey is set until the sender has heard back from the recipient.
+	PreKey     *preKeyHeader `json:",omitempty"`
+	Header     header
+	Ciphertext []byte
+}
+
+// preKeyHeader carries what the recipient needs for its side of X3DH.
+type preKeyHeader struct {
+	IdentityKey  []byte
+	EphemeralKey []byte
+	// OneTimePreKeyID is uuid.Nil when the recipient had no one-time prekeys
+	// left.
+	OneTimePreKeyID uuid.UUID
+}
+
+// header is the double ratchet message header: the sender's current ratchet
+// key, the length of its previous sending chain and the message number.
+type header struct {
+	DH []byte
+	PN uint32
+	N  uint32
+}
+
+type skippedKey struct {
+	dh string
+	n  uint32
+}
+
+// session is one side of a double ratchet.
+type session struct {
+	// dhs is our ratchet key pair and dhr the peer's ratchet public key.
+	dhs *ecdh.PrivateKey
+	dhr *ecdh.PublicKey
+	// rk is the root key; cks and ckr the sending and receiving chain keys.
+	rk, cks, ckr []byte
+	ns, nr, pn   uint32
+	skipped      map[skippedKey][]byte
+	ad           []byte
+	// preKey is sent with every message of a session we initiated until the
+	// peer replies.
+	preKey *preKeyHeader
+	// peerEphemeralKey is the X3DH ephemeral key of a session the peer
+	// initiated.
+	peerEphemeralKey []byte
+}
+
+func newInitiatorSession(sk []byte, peerSignedPreKey *ecdh.PublicKey, ad []byte) (*session, error) {
+	dhs, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	dh, err := dhs.ECDH(peerSignedPreKey)
+	if err != nil {
+		return nil, err
+	}
+	rk, cks, err := kdfRoot(sk, dh)
+	if err != nil {
+		return nil, err
+	}
+
+	return &session{
+		dhs:     dhs,
+		dhr:     peerSignedPreKey,
+		rk:      rk,
+		cks:     cks,
+		skipped: make(map[skippedKey][]byte),
+		ad:      ad,
+	}, nil
+}
+
+// newResponderSession starts from the signed prekey the initiator ratcheted
+// against; the first received message completes the first ratchet step.
+func newResponderSession(sk []byte, signedPreKey *ecdh.PrivateKey, ad []byte) *session {
+	return &session{
+		dhs:     signedPreKey,
+		rk:      sk,
+		skipped: make(map[skippedKey][]byte),
+		ad:      ad,
+	}
+}
+
+func (s *session) clone() *session {
+	c := *s
+	c.skipped = maps.Clone(s.skipped)
+	return &c
+}
+
+func (s *session) encrypt(plaintext []byte) (header, []byte, error) {
+	var mk []byte
+	s.cks, mk = kdfChain(s.cks)
+	h := header{DH: s.dhs.PublicKey().Bytes(), PN: s.pn, N: s.ns}
+	s.ns++
+
+	ciphertext, err := seal(mk, plaintext, s.headerAD(h))
+	if err != nil {
+		return header{}, nil, err
+	}
+	return h, ciphertext, nil
+}
+
+func (s *session) decrypt(h header, ciphertext []byte) ([]byte, error) {
+	key := skippedKey{string(h.DH), h.N}
+	if mk, ok := s.skipped[key]; ok {
+		plaintext, err := open(mk, ciphertext, s.headerAD(h))
+		if err != nil {
+			return nil, err
+		}
+		delete(s.skipped, key)
+		return plaintext, nil
+	}
+
+	if s.dhr == nil || !bytes.Equal(h.DH, s.dhr.Bytes()) {
+		if err := s.skip(h.PN); err != nil {
+			return nil, err
+		}
+		if err := s.ratchet(h.DH); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.skip(h.N); err != nil {
+		return nil, err
+	}
+
+	var mk []byte
+	s.ckr, mk = kdfChain(s.ckr)
+	s.nr++
+	return open(mk, ciphertext, s.headerAD(h))
+}
+
+// skip stores the keys of the receiving chain's messages up to, not
+// including, message number until, so they can be decrypted out of order.
+func (s *session) skip(until uint32) error {
+	if s.ckr == nil {
+		return nil
+	}
+	if until > s.nr+maxSkip {
+		return fmt.Errorf("%w: too many skipped messages", ErrDecrypt)
+	}
+	for s.nr < until {
+		var mk []byte
+		s.ckr, mk = kdfChain(s.ckr)
+		s.skipped[skippedKey{string(s.dhr.Bytes()), s.nr}] = mk
+		s.nr++
+	}
+	if len(s.skipped) > maxSkip {
+		return fmt.Errorf("%w: too many skipped messages", ErrDecrypt)
+	}
+
+	return nil
+}
+
+// ratchet performs a DH ratchet step on receiving the peer's new ratchet key.
+func (s *session) ratchet(peerKey []byte) error {
+	dhr, err := ecdh.X25519().NewPublicKey(peerKey)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDecrypt, err)
+	}
+	s.pn, s.ns, s.nr = s.ns, 0, 0
+	s.dhr = dhr
+
+	dh, err := s.dhs.ECDH(dhr)
+	if err != nil {
+		return err
+	}
+	if s.rk, s.ckr, err = kdfRoot(s.rk, dh); err != nil {
+		return err
+	}
+	if s.dhs, err = ecdh.X25519().GenerateKey(rand.Reader); err != nil {
+		return err
+	}
+	if dh, err = s.dhs.ECDH(dhr); err != nil {
+		return err
+	}
+	s.rk, s.cks, err = kdfRoot(s.rk, dh)
+	return err
+}
+
+func (s *session) headerAD(h header) []byte {
+	b, _ := json.Marshal(h)
+	return append(append([]byte{}, s.ad...), b...)
+}
+
+// x3dhKey derives the shared secret from the X3DH Diffie-Hellman outputs.
+func x3dhKey(dhs ...[]byte) ([]byte, error) {
+	secret := bytes.Repeat([]byte{0xff}, 32)
+	for _, dh := range dhs {
+		secret = append(secret, dh...)
+	}
+	return hkdf.Key(sha256.New, secret, make([]byte, sha256.Size), "chat-e2ee-x3dh", 32)
+}
+
+func kdfRoot(rk, dh []byte) (root, chain []byte, err error) {
+	out, err := hkdf.Key(sha256.New, dh, rk, "chat-e2ee-ratchet", 64)
+	if err != nil {
+		return nil, nil, err
+	}
+	return out[:32], out[32:], nil
+}
+
+func kdfChain(ck []byte) (chain, message []byte) {
+	mac := hmac.New(sha256.New, ck)
+	mac.Write([]byte{0x02})
+	chain = mac.Sum(nil)
+
+	mac = hmac.New(sha256.New, ck)
+	mac.Write([]byte{0x01})
+	return chain, mac.Sum(nil)
+}
+
+// aead derives an AES-256-GCM cipher and nonce from a message key. Every
+// message key is used once, so the derived nonce never repeats for a key.
+func aead(mk []byte) (cipher.AEAD, []byte, error) {
+	out, err := hkdf.Key(sha256.New, mk, make([]byte, sha256.Size), "chat-e2ee-message", 32+12)
+	if err != nil {
+		return nil, nil, err
+	}
+	block, err := aes.NewCipher(out[:32])
+	if err != nil {
+		return nil, nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, nil, err
+	}
+	return gcm, out[32:], nil
+}
+
+func seal(mk, plaintext, ad []byte) ([]byte, error) {
+	gcm, nonce, err := aead(mk)
+	if err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nil, nonce, plaintext, ad), nil
+}
+
+func open(mk, ciphertext, ad []byte) ([]byte, error) {
+	gcm, nonce, err := aead(mk)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, ad)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDecrypt, err)
+	}
+	return plaintext, nil
+}
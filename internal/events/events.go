@@ -0,0 +1,113 @@
+// Package events carries what happens in chats, such as new messages, from
+// the services where it happens to whoever reacts to it, such as webhooks.
+package events
+
+import (
+	"context"
+	"github.com/AliUnipal/chat/internal/models/message"
+	"github.com/google/uuid"
+	"sync"
+	"time"
+)
+
+type Type string
+
+const (
+	MessageCreated Type = "message.created"
+	MessageDeleted Type = "message.deleted"
+	// MessageUpdated fires when a delivered message changes, such as when
+	// the previews of its links are added.
+	MessageUpdated Type = "message.updated"
+	// EphemeralMessage is a message shown only to its recipient and never
+	// stored, such as the reply to a slash command.
+	EphemeralMessage Type = "message.ephemeral"
+	ChatCreated      Type = "chat.created"
+	ChatDeleted      Type = "chat.deleted"
+	MemberAdded      Type = "chat.member_added"
+	// BotInvited fires when a participant asks to add a bot to a chat with
+	// someone else, who has to accept it before the bot joins.
+	BotInvited Type = "chat.bot_invited"
+	// MemberRemoved fires when a user leaves a chat that lives on without
+	// them.
+	MemberRemoved Type = "chat.member_removed"
+	// DisappearingMessagesChanged fires when a participant changes the
+	// chat's disappearing messages timer.
+	DisappearingMessagesChanged Type = "chat.disappearing_messages_changed"
+)
+
+// Types lists every event type.
+var Types = []Type{MessageCreated, MessageDeleted, MessageUpdated, EphemeralMessage, ChatCreated, ChatDeleted, MemberAdded, BotInvited, MemberRemoved, DisappearingMessagesChanged}
+
+type Event struct {
+	ID     uuid.UUID
+	Type   Type
+	ChatID uuid.UUID
+	// UserID is who caused the event: the sender, the creator of the chat,
+	// the member added or removed or the participant who changed the chat.
+	UserID uuid.UUID
+	// Recipients are the chat's participants the event concerns.
+	Recipients []uuid.UUID
+	// Silent lists the recipients of a new message who muted the chat and
+	// are not mentioned in it. They get the message without being alerted.
+	Silent []uuid.UUID
+	// Message is set on message events. Deleted messages only carry their
+	// IDs, and their ExpiresAt when they disappeared; whoever kept a copy of
+	// a disappeared message must drop its content.
+	Message *message.Message
+	// DisappearAfter is the new timer of DisappearingMessagesChanged events,
+	// zero when disappearing messages were turned off.
+	DisappearAfter time.Duration
+	Timestamp      time.Time
+}
+
+// Handler reacts to an event. Handlers run on the publisher's goroutine, so
+// they must not block.
+type Handler func(ctx context.Context, e Event)
+
+// Bus hands every published event to every subscribed handler.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[int]Handler
+	next     int
+}
+
+func NewBus() *Bus {
+	return &Bus{handlers: make(map[int]Handler)}
+}
+
+// Subscribe adds a handler and returns a function that removes it.
+func (b *Bus) Subscribe(h Handler) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.next
+	b.next++
+	b.handlers[id] = h
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.handlers, id)
+	}
+}
+
+// Publish fills in the event's ID and timestamp when they are missing and
+// passes it to the handlers.
+func (b *Bus) Publish(ctx context.Context, e Event) {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	if e.Timestamp.IsZero() {
+		e.Timestamp = time.Now().UTC()
+	}
+
+	b.mu.RLock()
+	handlers := make([]Handler, 0, len(b.handlers))
+	for _, h := range b.handlers {
+		handlers = append(handlers, h)
+	}
+	b.mu.RUnlock()
+
+	for _, h := range handlers {
+		h(ctx, e)
+	}
+}
@@ -0,0 +1,30 @@
+package events_test
+
+import (
+	"context"
+	"github.com/AliUnipal/chat/internal/events"
+	"github.com/google/uuid"
+	"testing"
+)
+
+func TestBus(t *testing.T) {
+	bus := events.NewBus()
+	var first, second []events.Event
+	bus.Subscribe(func(_ context.Context, e events.Event) { first = append(first, e) })
+	unsubscribe := bus.Subscribe(func(_ context.Context, e events.Event) { second = append(second, e) })
+
+	chatID := uuid.New()
+	bus.Publish(context.Background(), events.Event{Type: events.ChatCreated, ChatID: chatID})
+	unsubscribe()
+	bus.Publish(context.Background(), events.Event{Type: events.ChatDeleted, ChatID: chatID})
+
+	if len(first) != 2 || len(second) != 1 {
+		t.Fatalf("expected 2 and 1 events got %d and %d", len(first), len(second))
+	}
+	if first[0].ID == uuid.Nil || first[0].Timestamp.IsZero() {
+		t.Fatalf("expected the ID and timestamp to be filled in got %+v", first[0])
+	}
+	if first[0].ID != second[0].ID {
+		t.Fatalf("expected every handler to get the same event")
+	}
+}
@@ -0,0 +1,51 @@
+package kms_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/AliUnipal/chat/internal/kms"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadKeyfile_WrapAndUnwrap(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "keys.json")
+	content := `{"current": "k2", "keys": {
+		"k1": "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY=",
+		"k2": "ZmVkY2JhOTg3NjU0MzIxMGZlZGNiYTk4NzY1NDMyMTA="
+	}}`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	k, err := kms.LoadKeyfile(path)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	keyID, wrapped, err := k.Encrypt(ctx, []byte("data key"))
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if keyID != "k2" {
+		t.Fatalf("expected the current key k2 got %q", keyID)
+	}
+	got, err := k.Decrypt(ctx, keyID, wrapped)
+	if err != nil || !bytes.Equal(got, []byte("data key")) {
+		t.Fatalf("expected the data key back got %q, %v", got, err)
+	}
+	if _, err := k.Decrypt(ctx, "k1", wrapped); err == nil {
+		t.Fatal("expected error unwrapping with the wrong key, got nil")
+	}
+	if _, err := k.Decrypt(ctx, "k3", wrapped); !errors.Is(err, kms.ErrUnknownKey) {
+		t.Fatalf("expected %v got %v", kms.ErrUnknownKey, err)
+	}
+}
+
+func TestNewKeyfile_ReturnErrorOnMissingCurrentKey(t *testing.T) {
+	if _, err := kms.NewKeyfile("k2", map[string][]byte{"k1": make([]byte, 32)}); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
@@ -0,0 +1,192 @@
+// Package linkpreview builds the cards shown for links in messages.
+//
+// The pages linked to are chosen by whoever sends a message, so the fetcher
+// treats every URL as hostile: it only connects to public addresses, checked
+// when connecting so DNS cannot be used to sneak past the check, follows a
+// bounded number of redirects and caps how long a fetch takes and how much of
+// a page is read.
+package linkpreview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/AliUnipal/chat/internal/models/message"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+var (
+	ErrInvalidURL         = errors.New("link must be an absolute http or https url")
+	ErrBlockedAddress     = errors.New("link points to an address that is not public")
+	ErrTooManyRedirects   = errors.New("link redirects too many times")
+	ErrUnsupportedContent = errors.New("link does not point to an html page")
+	ErrNoPreview          = errors.New("page has nothing to preview")
+)
+
+// userAgent identifies the fetcher to the sites it visits.
+const userAgent = "ChatLinkPreview/1.0 (+https://github.com/AliUnipal/chat)"
+
+type Config struct {
+	// Timeout bounds a whole fetch, redirects and reading the page included.
+	Timeout      time.Duration
+	MaxRedirects int
+	// MaxBytes caps how much of a page is read. Metadata past it is ignored.
+	MaxBytes int64
+	// Blocked reports whether connecting to an address is forbidden.
+	Blocked func(addr netip.Addr) bool
+}
+
+var DefaultConfig = Config{
+	Timeout:      5 * time.Second,
+	MaxRedirects: 3,
+	MaxBytes:     512 << 10,
+	Blocked:      IsPrivate,
+}
+
+// Fetcher fetches pages and builds previews out of their metadata.
+type Fetcher struct {
+	client   *http.Client
+	maxBytes int64
+}
+
+func NewFetcher(config Config) *Fetcher {
+	dialer := &net.Dialer{
+		Timeout: config.Timeout,
+		// Control runs once the host has been resolved, right before
+		// connecting, so it sees the address actually connected to.
+		Control: func(_, address string, _ syscall.RawConn) error {
+			ap, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			if config.Blocked(ap.Addr().Unmap()) {
+				return ErrBlockedAddress
+			}
+			return nil
+		},
+	}
+
+	return &Fetcher{
+		client: &http.Client{
+			Timeout: config.Timeout,
+			Transport: &http.Transport{
+				// Going through a proxy would hide the address connected to.
+				Proxy:                  nil,
+				DialContext:            dialer.DialContext,
+				TLSHandshakeTimeout:    config.Timeout,
+				ResponseHeaderTimeout:  config.Timeout,
+				MaxResponseHeaderBytes: 64 << 10,
+				MaxIdleConns:           10,
+				IdleConnTimeout:        time.Minute,
+			},
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if len(via) > config.MaxRedirects {
+					return ErrTooManyRedirects
+				}
+				if _, err := checkURL(req.URL); err != nil {
+					return err
+				}
+				return nil
+			},
+		},
+		maxBytes: config.MaxBytes,
+	}
+}
+
+// Fetch fetches the page rawURL points to and returns its preview.
+func (f *Fetcher) Fetch(ctx context.Context, rawURL string) (message.Preview, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return message.Preview{}, ErrInvalidURL
+	}
+	if u, err = checkURL(u); err != nil {
+		return message.Preview{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return message.Preview{}, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		// The client wraps what CheckRedirect and the dialer return.
+		for _, target := range []error{ErrBlockedAddress, ErrTooManyRedirects, ErrInvalidURL} {
+			if errors.Is(err, target) {
+				return message.Preview{}, target
+			}
+		}
+		return message.Preview{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return message.Preview{}, fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return message.Preview{}, ErrUnsupportedContent
+	}
+
+	p := Parse(io.LimitReader(resp.Body, f.maxBytes), resp.Request.URL)
+	if p.Title == "" && p.Description == "" {
+		return message.Preview{}, ErrNoPreview
+	}
+	p.URL = rawURL
+
+	return p, nil
+}
+
+// checkURL returns u without its credentials, if it is one the fetcher may
+// follow.
+func checkURL(u *url.URL) (*url.URL, error) {
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return nil, ErrInvalidURL
+	}
+	u.User = nil
+
+	return u, nil
+}
+
+// reserved holds the ranges that are neither private nor public, such as
+// those set aside for documentation, that no preview should come from.
+var reserved = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("192.0.2.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("198.51.100.0/24"),
+	netip.MustParsePrefix("203.0.113.0/24"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("100::/64"),
+	netip.MustParsePrefix("2001:db8::/32"),
+}
+
+// IsPrivate reports whether addr is anything but a public unicast address:
+// loopback, private, link-local, multicast or reserved.
+func IsPrivate(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsUnspecified() || addr.IsLoopback() || addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() {
+		return true
+	}
+	for _, p := range reserved {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
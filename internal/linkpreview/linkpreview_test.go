@@ -0,0 +1,215 @@
+package linkpreview_test
+
+import (
+	"context"
+	"errors"
+	"github.com/AliUnipal/chat/internal/linkpreview"
+	"github.com/AliUnipal/chat/internal/models/message"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testConfig lets the fetcher reach the loopback address httptest listens
+// on.
+var testConfig = linkpreview.Config{
+	Timeout:      time.Second,
+	MaxRedirects: 2,
+	MaxBytes:     4 << 10,
+	Blocked:      func(netip.Addr) bool { return false },
+}
+
+func serve(t *testing.T, h http.HandlerFunc) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func page(head string) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte("<!doctype html><html><head>" + head + "</head><body><p>body</p></body></html>"))
+	}
+}
+
+func TestFetch(t *testing.T) {
+	tests := []struct {
+		name     string
+		head     string
+		expected message.Preview
+	}{
+		{
+			name: "opengraph",
+			head: `<meta property="og:title" content="  A  &amp; B ">
+				<meta property="og:description" content="About it">
+				<meta property="og:image" content="/img/card.png">
+				<meta property="og:image" content="/img/second.png">
+				<meta property="og:site_name" content="Example">
+				<title>Ignored</title>`,
+			expected: message.Preview{Title: "A & B", Description: "About it", ImageURL: "/img/card.png", SiteName: "Example"},
+		},
+		{
+			name: "twitter card",
+			head: `<meta name="twitter:title" content="Tweet">
+				<meta name="twitter:description" content="Card">
+				<meta name="twitter:image" content="https://cdn.example.com/i.png">`,
+			expected: message.Preview{Title: "Tweet", Description: "Card", ImageURL: "https://cdn.example.com/i.png"},
+		},
+		{
+			name:     "title and description",
+			head:     `<title>Plain page</title><meta name="description" content="Described">`,
+			expected: message.Preview{Title: "Plain page", Description: "Described"},
+		},
+		{
+			name:     "unsafe image dropped",
+			head:     `<meta property="og:title" content="T"><meta property="og:image" content="javascript:alert(1)">`,
+			expected: message.Preview{Title: "T"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := serve(t, page(tt.head))
+			link := srv.URL + "/post?id=1"
+			if strings.HasPrefix(tt.expected.ImageURL, "/") {
+				tt.expected.ImageURL = srv.URL + tt.expected.ImageURL
+			}
+			tt.expected.URL = link
+
+			p, err := linkpreview.NewFetcher(testConfig).Fetch(context.Background(), link)
+			if err != nil {
+				t.Fatalf("expected no error got %v", err)
+			}
+			if p != tt.expected {
+				t.Fatalf("expected %+v got %+v", tt.expected, p)
+			}
+		})
+	}
+}
+
+func TestFetch_Rejects(t *testing.T) {
+	ogPage := page(`<meta property="og:title" content="T">`)
+	var redirects *httptest.Server
+	redirects = serve(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, redirects.URL+r.URL.Path+"x", http.StatusFound)
+	})
+	release := make(chan struct{})
+	slow := serve(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	})
+	t.Cleanup(func() { close(release) })
+
+	tests := []struct {
+		name   string
+		config linkpreview.Config
+		url    string
+		err    error
+	}{
+		{
+			name:   "loopback",
+			config: linkpreview.DefaultConfig,
+			url:    serve(t, ogPage).URL,
+			err:    linkpreview.ErrBlockedAddress,
+		},
+		{
+			name:   "hostname resolving to loopback",
+			config: linkpreview.DefaultConfig,
+			url:    "http://localhost:1/",
+			err:    linkpreview.ErrBlockedAddress,
+		},
+		{
+			name:   "not http",
+			config: testConfig,
+			url:    "file:///etc/passwd",
+			err:    linkpreview.ErrInvalidURL,
+		},
+		{
+			name:   "redirect loop",
+			config: testConfig,
+			url:    redirects.URL + "/",
+			err:    linkpreview.ErrTooManyRedirects,
+		},
+		{
+			name:   "redirect away from http",
+			config: testConfig,
+			url: serve(t, func(w http.ResponseWriter, r *http.Request) {
+				http.Redirect(w, r, "ftp://example.com/", http.StatusFound)
+			}).URL,
+			err: linkpreview.ErrInvalidURL,
+		},
+		{
+			name:   "not html",
+			config: testConfig,
+			url: serve(t, func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "image/png")
+			}).URL,
+			err: linkpreview.ErrUnsupportedContent,
+		},
+		{
+			name:   "metadata past the size cap",
+			config: testConfig,
+			url:    serve(t, page(`<script>`+strings.Repeat("x", 8<<10)+`</script><meta property="og:title" content="T">`)).URL,
+			err:    linkpreview.ErrNoPreview,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := linkpreview.NewFetcher(tt.config).Fetch(context.Background(), tt.url); !errors.Is(err, tt.err) {
+				t.Fatalf("expected %v got %v", tt.err, err)
+			}
+		})
+	}
+
+	t.Run("timeout", func(t *testing.T) {
+		config := testConfig
+		config.Timeout = 50 * time.Millisecond
+		start := time.Now()
+		if _, err := linkpreview.NewFetcher(config).Fetch(context.Background(), slow.URL); err == nil {
+			t.Fatalf("expected an error got nil")
+		}
+		if d := time.Since(start); d > time.Second {
+			t.Fatalf("expected the fetch to give up after the timeout, took %v", d)
+		}
+	})
+}
+
+func TestIsPrivate(t *testing.T) {
+	tests := []struct {
+		addr    string
+		private bool
+	}{
+		{"127.0.0.1", true},
+		{"10.1.2.3", true},
+		{"172.16.0.1", true},
+		{"192.168.1.1", true},
+		{"169.254.169.254", true},
+		{"100.64.0.1", true},
+		{"0.0.0.0", true},
+		{"::1", true},
+		{"::ffff:127.0.0.1", true},
+		{"fd00::1", true},
+		{"fe80::1", true},
+		{"8.8.8.8", false},
+		{"2606:4700::1111", false},
+	}
+	for _, tt := range tests {
+		if got := linkpreview.IsPrivate(netip.MustParseAddr(tt.addr)); got != tt.private {
+			t.Fatalf("%s: expected %v got %v", tt.addr, tt.private, got)
+		}
+	}
+}
+
+func TestParse_StopsAtBody(t *testing.T) {
+	base, _ := url.Parse("https://example.com/a/b")
+	p := linkpreview.Parse(strings.NewReader(`<head><title>Head</title></head><body><meta property="og:title" content="Body"></body>`), base)
+	if p.Title != "Head" {
+		t.Fatalf("expected the title from the head got %q", p.Title)
+	}
+}
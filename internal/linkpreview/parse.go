@@ -0,0 +1,123 @@
+package linkpreview
+
+import (
+	"github.com/AliUnipal/chat/internal/models/message"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	"io"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	maxTitle       = 200
+	maxDescription = 500
+	maxSiteName    = 100
+)
+
+// Parse builds a preview out of the OpenGraph and Twitter card metadata of
+// the page at pageURL, falling back to its <title> and description. Only the
+// head of the page is read. The preview's URL is left empty.
+func Parse(r io.Reader, pageURL *url.URL) message.Preview {
+	meta := make(map[string]string)
+	var title string
+
+	z := html.NewTokenizer(r)
+	for inTitle := false; ; {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			return preview(meta, title, pageURL)
+		case html.TextToken:
+			if inTitle && title == "" {
+				title = string(z.Text())
+			}
+		case html.EndTagToken:
+			if name, _ := z.TagName(); atom.Lookup(name) == atom.Title {
+				inTitle = false
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			switch atom.Lookup(name) {
+			case atom.Body:
+				return preview(meta, title, pageURL)
+			case atom.Title:
+				inTitle = tt == html.StartTagToken
+			case atom.Meta:
+				var key, content string
+				for hasAttr {
+					var k, v []byte
+					k, v, hasAttr = z.TagAttr()
+					switch string(k) {
+					case "property", "name":
+						key = strings.ToLower(strings.TrimSpace(string(v)))
+					case "content":
+						content = string(v)
+					}
+				}
+				// The first of repeated tags, such as og:image, wins.
+				if _, ok := meta[key]; key != "" && !ok {
+					meta[key] = content
+				}
+			}
+		}
+	}
+}
+
+func preview(meta map[string]string, title string, pageURL *url.URL) message.Preview {
+	first := func(keys ...string) string {
+		for _, k := range keys {
+			if v := clean(meta[k]); v != "" {
+				return v
+			}
+		}
+		return ""
+	}
+
+	p := message.Preview{
+		Title:       truncate(first("og:title", "twitter:title"), maxTitle),
+		Description: truncate(first("og:description", "twitter:description", "description"), maxDescription),
+		SiteName:    truncate(first("og:site_name"), maxSiteName),
+	}
+	if p.Title == "" {
+		p.Title = truncate(clean(title), maxTitle)
+	}
+	if image := first("og:image:secure_url", "og:image", "og:image:url", "twitter:image", "twitter:image:src"); image != "" {
+		p.ImageURL = resolve(pageURL, image)
+	}
+
+	return p
+}
+
+// resolve returns ref resolved against the page it was found on, or nothing
+// when it is not an http or https URL.
+func resolve(pageURL *url.URL, ref string) string {
+	u, err := url.Parse(ref)
+	if err != nil {
+		return ""
+	}
+	if pageURL != nil {
+		u = pageURL.ResolveReference(u)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ""
+	}
+	u.User = nil
+
+	return u.String()
+}
+
+// clean collapses whitespace and drops invalid UTF-8.
+func clean(s string) string {
+	return strings.Join(strings.Fields(strings.ToValidUTF8(s, "")), " ")
+}
+
+// truncate shortens s to at most n runes, marking the cut with an ellipsis.
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+
+	return string([]rune(s)[:n-1]) + "…"
+}
@@ -1,14 +1,61 @@
 package chat
 
 import (
-	"github.com/AliUnipal/chat/internal/models/message"
 	"github.com/AliUnipal/chat/internal/models/user"
 	"github.com/google/uuid"
+	"time"
 )
 
+// Chat is a chat as seen by CurrentUser, with OtherUser as the counterpart.
 type Chat struct {
 	ID          uuid.UUID
 	CurrentUser user.User
 	OtherUser   user.User
-	Messages    []message.Message
+	// DisplayName is CurrentUser's nickname for OtherUser, falling back to
+	// OtherUser's full name.
+	DisplayName string
+	Pinned      bool
+	Archived    bool
+	Muted       bool
+	MutedUntil  time.Time
+	Request     RequestState
+	// Encrypted chats are end-to-end encrypted, so the server cannot read
+	// their messages.
+	Encrypted bool
+	// DisappearAfter is how long messages sent to the chat are kept; zero
+	// when they are kept for good.
+	DisappearAfter time.Duration
+	// InvitedBots are the bots a participant asked to add that are waiting
+	// for the other person to accept them.
+	InvitedBots []BotInvite
+	LastMessage *MessagePreview
+	// Mentions counts the messages mentioning CurrentUser that they have
+	// not read yet.
+	Mentions int
+}
+
+// RequestState tells whether the chat is a message request from someone
+// outside the recipient's contacts that has not been accepted yet.
+type RequestState int
+
+const (
+	NoRequest RequestState = iota
+	// IncomingRequest is waiting for CurrentUser to accept it.
+	IncomingRequest
+	// OutgoingRequest is waiting for OtherUser to accept it.
+	OutgoingRequest
+)
+
+// MessagePreview summarises the latest message of a chat for the chat list.
+type MessagePreview struct {
+	SenderID  uuid.UUID
+	Text      string
+	Timestamp time.Time
+}
+
+// BotInvite is a bot InviterID asked to add to the chat.
+type BotInvite struct {
+	BotID     uuid.UUID
+	InviterID uuid.UUID
+	InvitedAt time.Time
 }
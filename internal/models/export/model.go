@@ -0,0 +1,28 @@
+package export
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type Status int
+
+const (
+	Pending Status = iota
+	Running
+	Completed
+	Failed
+)
+
+// Job is a request for a copy of a user's personal data.
+type Job struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
+	Status Status
+	// Progress goes from 0 to 100.
+	Progress int
+	// Error explains why a Failed job failed.
+	Error       string
+	CreatedAt   time.Time
+	CompletedAt time.Time
+}
@@ -12,12 +12,119 @@ type Message struct {
 	Content     []byte
 	ContentType ContentType
 	Timestamp   time.Time
+	// Quarantined is only ever set on messages shown to their sender, while
+	// they wait for moderation.
+	Quarantined bool
+	Mentions    []Mention
+	// Entities format the content of a rich text message.
+	Entities []Entity
+	// Previews are the cards of the links in a text message. They are added
+	// after the message is sent, once the links have been fetched.
+	Previews []Preview
+	// ExpiresAt is when a message sent to a chat with disappearing messages
+	// is deleted. It is zero for messages that are kept.
+	ExpiresAt time.Time
+	// Imported messages were added from a chat history exported elsewhere,
+	// rather than sent here.
+	Imported bool
 }
 
+// Preview is the card shown for a link, built from the metadata of the page
+// it points to.
+type Preview struct {
+	// URL is the link as it appears in the message.
+	URL         string
+	Title       string
+	Description string
+	ImageURL    string
+	SiteName    string
+}
+
+// Mention is an @mention in a text message. Offset and Length locate the
+// mention, "@" included, in bytes of the content.
+type Mention struct {
+	// UserID is the mentioned user, or uuid.Nil for @all.
+	UserID uuid.UUID
+	Offset int
+	Length int
+}
+
+// Mentioned reports whether the mentions include userID, directly or through
+// @all.
+func Mentioned(mentions []Mention, userID uuid.UUID) bool {
+	for _, m := range mentions {
+		if m.UserID == userID || m.UserID == uuid.Nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Entity formats a run of a rich text message. Offset and Length locate the
+// run in bytes of the content, which is the message's plain text.
+type Entity struct {
+	Type   EntityType
+	Offset int
+	Length int
+	// URL is the target of a link. It is always an absolute http, https or
+	// mailto URL.
+	URL string
+	// Language is what a code block was tagged with, if anything.
+	Language string
+}
+
+type EntityType int
+
+const (
+	BoldEntity EntityType = iota
+	ItalicEntity
+	CodeEntity
+	CodeBlockEntity
+	LinkEntity
+	// BulletItemEntity and NumberedItemEntity cover the text of a list item.
+	// The item's marker, "• " or its number, stays in the content before it.
+	BulletItemEntity
+	NumberedItemEntity
+)
+
 type ContentType int
 
 const (
 	TextContentType ContentType = iota
 	ImageContentType
 	FileContentType
+	// EncryptedContentType marks an end-to-end encrypted envelope. Only the
+	// chat participants' clients can open it.
+	EncryptedContentType
+	// RichTextContentType is text formatted by entities. It is sent as
+	// markdown and stored as plain text along with the entities parsed out
+	// of it.
+	RichTextContentType
 )
+
+// IsText reports whether the content is readable text, formatted or not.
+func (t ContentType) IsText() bool {
+	return t == TextContentType || t == RichTextContentType
+}
+
+// ScheduledMessage is a message its sender wrote to be sent later, at
+// SendAt.
+type ScheduledMessage struct {
+	ID          uuid.UUID
+	SenderID    uuid.UUID
+	ChatID      uuid.UUID
+	Content     []byte
+	ContentType ContentType
+	SendAt      time.Time
+	CreatedAt   time.Time
+}
+
+// TypingEvent tells a chat participant that UserID started or stopped typing
+// in ChatID. A started typing signal ends on its own at ExpiresAt unless the
+// user renews it, which is announced by another event with a later ExpiresAt.
+type TypingEvent struct {
+	ChatID    uuid.UUID
+	UserID    uuid.UUID
+	Typing    bool
+	ExpiresAt time.Time
+}
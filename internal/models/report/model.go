@@ -0,0 +1,79 @@
+package report
+
+import (
+	"github.com/AliUnipal/chat/internal/models/message"
+	"github.com/AliUnipal/chat/internal/models/user"
+	"github.com/google/uuid"
+	"time"
+)
+
+// Target is what a report is about: the message MessageID in ChatID when it
+// is set, otherwise the user UserID, optionally in the context of ChatID.
+type Target struct {
+	UserID    uuid.UUID
+	ChatID    uuid.UUID
+	MessageID uuid.UUID
+}
+
+type Reason int
+
+const (
+	Spam Reason = iota
+	Harassment
+	HateSpeech
+	Violence
+	Other
+)
+
+type Status int
+
+const (
+	Open Status = iota
+	InReview
+	Resolved
+	Dismissed
+)
+
+type Report struct {
+	ID         uuid.UUID
+	ReporterID uuid.UUID
+	Target     Target
+	Reason     Reason
+	Status     Status
+	// AssigneeID is the moderator handling the report, uuid.Nil until one is
+	// assigned.
+	AssigneeID uuid.UUID
+	// ReportedUser and Snapshot are copies taken when the report was made, so
+	// they survive the user editing their profile or the messages being
+	// deleted. Snapshot holds the reported message with the messages around
+	// it, or the reported user's latest messages in the chat.
+	ReportedUser user.User
+	Snapshot     []message.Message
+	// Resolution is the note the moderator left when closing the report.
+	Resolution string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type AuditAction int
+
+const (
+	ReportAssigned AuditAction = iota
+	MessageDeleted
+	UserSuspended
+	ReportDismissed
+	// MessageReleased delivers a message moderation quarantined.
+	MessageReleased
+)
+
+// AuditEntry records an action a moderator took. ReportID is uuid.Nil for
+// actions taken outside of a report, such as reviewing quarantined messages.
+type AuditEntry struct {
+	ID          uuid.UUID
+	ModeratorID uuid.UUID
+	ReportID    uuid.UUID
+	Action      AuditAction
+	Target      Target
+	Note        string
+	CreatedAt   time.Time
+}
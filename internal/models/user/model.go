@@ -1,6 +1,9 @@
 package user
 
-import "github.com/google/uuid"
+import (
+	"github.com/google/uuid"
+	"time"
+)
 
 type User struct {
 	ID        uuid.UUID
@@ -8,4 +11,107 @@ type User struct {
 	FirstName string
 	LastName  string
 	Username  string
+	CreatedAt time.Time
+	Status    AccountStatus
+	Type      Type
+	// OwnerID is the user who created a bot or imported a user.
+	OwnerID uuid.UUID
+}
+
+type Type int
+
+const (
+	Human Type = iota
+	// Bot users are automated accounts driven through the bot API by the
+	// user who created them.
+	Bot
+	// Imported users stand in for the people of a chat history someone
+	// imported, who have no account of their own. Only the messages the
+	// import added are theirs.
+	Imported
+)
+
+type AccountStatus int
+
+const (
+	Active AccountStatus = iota
+	// Suspended users were stopped from sending by a moderator.
+	Suspended
+	// Deactivated users closed their account themselves and can reopen it.
+	Deactivated
+	// Deleted users had their profile anonymized; there is no way back.
+	Deleted
+)
+
+// AccountState is a user's account status, with the reason and expiry of a
+// suspension. A zero Until means the suspension has no end.
+type AccountState struct {
+	Status AccountStatus
+	Reason string
+	Until  time.Time
+}
+
+// Audience selects who a privacy setting lets through.
+type Audience int
+
+const (
+	Everyone Audience = iota
+	ContactsOnly
+	Nobody
+)
+
+type PrivacySettings struct {
+	// StartChat controls who can start a direct chat with the user.
+	StartChat Audience
+	// ProfileImage controls who can see the user's profile image.
+	ProfileImage Audience
+	// LastSeen controls who can see when the user was last active.
+	LastSeen Audience
+}
+
+// Status is whether a user is currently reachable.
+type Status int
+
+const (
+	Offline Status = iota
+	Online
+	// Away means the user is still connected but has stopped sending
+	// heartbeats for a while.
+	Away
+)
+
+type Presence struct {
+	UserID uuid.UUID
+	Status Status
+	// LastSeen is the last time the user was active. It is zero when the
+	// user's privacy settings hide it from the viewer.
+	LastSeen time.Time
+}
+
+// IdentityKeys are the long-lived public keys a user publishes so others can
+// start end-to-end encrypted sessions with them.
+type IdentityKeys struct {
+	// SigningKey is an Ed25519 key that vouches for the other keys.
+	SigningKey []byte
+	// IdentityKey is the user's long-lived X25519 key.
+	IdentityKey []byte
+	// SignedPreKey is a medium-term X25519 key.
+	SignedPreKey []byte
+	// Signature is SigningKey's signature over IdentityKey followed by
+	// SignedPreKey.
+	Signature []byte
+}
+
+// OneTimePreKey is an X25519 key handed out to at most one session initiator.
+type OneTimePreKey struct {
+	ID  uuid.UUID
+	Key []byte
+}
+
+// PreKeyBundle is what a client needs to start an encrypted session with
+// UserID. OneTimePreKey is nil once UserID has run out of them.
+type PreKeyBundle struct {
+	UserID        uuid.UUID
+	IdentityKeys  IdentityKeys
+	OneTimePreKey *OneTimePreKey
 }
@@ -0,0 +1,53 @@
+package webhook
+
+import (
+	"github.com/AliUnipal/chat/internal/events"
+	"github.com/google/uuid"
+	"time"
+)
+
+// Subscription sends the events of OwnerID's chats, or of ChatID only when it
+// is set, to URL.
+type Subscription struct {
+	ID      uuid.UUID
+	OwnerID uuid.UUID
+	ChatID  uuid.UUID
+	URL     string
+	// EventTypes filters the events sent. Empty means every type.
+	EventTypes []events.Type
+	// Secret signs the payloads. It is only returned when the subscription
+	// is created.
+	Secret    string
+	CreatedAt time.Time
+}
+
+type DeliveryStatus int
+
+const (
+	Pending DeliveryStatus = iota
+	Succeeded
+	// DeadLettered deliveries ran out of attempts and wait for a manual
+	// redelivery.
+	DeadLettered
+)
+
+// Delivery is an event on its way to a subscription.
+type Delivery struct {
+	ID             uuid.UUID
+	SubscriptionID uuid.UUID
+	EventID        uuid.UUID
+	EventType      events.Type
+	Status         DeliveryStatus
+	// Attempts logs every attempt, the oldest first.
+	Attempts      []Attempt
+	NextAttemptAt time.Time
+	CreatedAt     time.Time
+}
+
+type Attempt struct {
+	At       time.Time
+	Duration time.Duration
+	// StatusCode is zero when no response came back.
+	StatusCode int
+	Error      string
+}
@@ -0,0 +1,276 @@
+package moderation
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"github.com/AliUnipal/chat/internal/models/message"
+	"github.com/AliUnipal/chat/internal/models/user"
+	"github.com/google/uuid"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// BannedWords acts on text messages containing any of a list of words or
+// phrases. Matching ignores case, accents, compatibility forms such as
+// fullwidth letters, invisible characters and punctuation between words.
+type BannedWords struct {
+	action  Action
+	phrases []string
+}
+
+func NewBannedWords(action Action, phrases ...string) *BannedWords {
+	f := &BannedWords{action: action}
+	for _, p := range phrases {
+		if n := normalize(p); strings.TrimSpace(n) != "" {
+			f.phrases = append(f.phrases, n)
+		}
+	}
+
+	return f
+}
+
+func (f *BannedWords) Name() string {
+	return "banned-words"
+}
+
+func (f *BannedWords) Check(_ context.Context, in Input) (Decision, error) {
+	t, ok := text(in)
+	if !ok {
+		return Decision{}, nil
+	}
+
+	normalized := normalize(t)
+	for _, p := range f.phrases {
+		if strings.Contains(normalized, p) {
+			return Decision{Action: f.action, Reason: fmt.Sprintf("contains banned phrase %q", strings.TrimSpace(p))}, nil
+		}
+	}
+
+	return Decision{}, nil
+}
+
+// URLBlocklist acts on text messages linking to a blocked domain or any of
+// its subdomains.
+type URLBlocklist struct {
+	action  Action
+	domains []string
+}
+
+func NewURLBlocklist(action Action, domains ...string) *URLBlocklist {
+	f := &URLBlocklist{action: action}
+	for _, d := range domains {
+		f.domains = append(f.domains, strings.Trim(strings.ToLower(norm.NFKC.String(d)), "."))
+	}
+
+	return f
+}
+
+func (f *URLBlocklist) Name() string {
+	return "url-blocklist"
+}
+
+func (f *URLBlocklist) Check(_ context.Context, in Input) (Decision, error) {
+	t, ok := text(in)
+	if !ok {
+		return Decision{}, nil
+	}
+
+	for _, host := range hosts(t) {
+		for _, d := range f.domains {
+			if host == d || strings.HasSuffix(host, "."+d) {
+				return Decision{Action: f.action, Reason: fmt.Sprintf("links to blocked domain %q", d)}, nil
+			}
+		}
+	}
+
+	return Decision{}, nil
+}
+
+// DuplicateFlood rejects a message when its sender has already had the same
+// content accepted max times within window. Rejected messages are not
+// counted, so being refused does not prolong a flood.
+type DuplicateFlood struct {
+	max    int
+	window time.Duration
+
+	mu     sync.Mutex
+	recent map[uuid.UUID][]sent
+	checks int
+}
+
+type sent struct {
+	digest [sha256.Size]byte
+	at     time.Time
+}
+
+func NewDuplicateFlood(max int, window time.Duration) *DuplicateFlood {
+	return &DuplicateFlood{max: max, window: window, recent: make(map[uuid.UUID][]sent)}
+}
+
+func (f *DuplicateFlood) Name() string {
+	return "duplicate-flood"
+}
+
+func (f *DuplicateFlood) Check(_ context.Context, in Input) (Decision, error) {
+	digest := duplicateDigest(in)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.checks++
+	if f.checks%1024 == 0 {
+		for senderID := range f.recent {
+			f.prune(senderID, in.SentAt)
+		}
+	}
+	f.prune(in.SenderID, in.SentAt)
+
+	n := 0
+	for _, s := range f.recent[in.SenderID] {
+		if s.digest == digest {
+			n++
+		}
+	}
+	if n >= f.max {
+		return Decision{Action: Reject, Reason: fmt.Sprintf("same message sent %d times within %v", n+1, f.window)}, nil
+	}
+
+	return Decision{}, nil
+}
+
+// Accept counts a message the pipeline let through.
+func (f *DuplicateFlood) Accept(_ context.Context, in Input) error {
+	digest := duplicateDigest(in)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.recent[in.SenderID] = append(f.recent[in.SenderID], sent{digest, in.SentAt})
+	return nil
+}
+
+// duplicateDigest identifies content regardless of how its text is written.
+func duplicateDigest(in Input) [sha256.Size]byte {
+	content := in.Content
+	if t, ok := text(in); ok {
+		content = []byte(normalize(t))
+	}
+
+	return sha256.Sum256(append([]byte{byte(in.ContentType)}, content...))
+}
+
+// prune forgets what senderID sent before the window. It must be called with
+// f.mu held.
+func (f *DuplicateFlood) prune(senderID uuid.UUID, now time.Time) {
+	msgs := slices.DeleteFunc(f.recent[senderID], func(s sent) bool {
+		return now.Sub(s.at) >= f.window
+	})
+	if len(msgs) == 0 {
+		delete(f.recent, senderID)
+	} else {
+		f.recent[senderID] = msgs
+	}
+}
+
+type userGetter interface {
+	GetUser(ctx context.Context, id uuid.UUID) (user.User, error)
+}
+
+// NewAccounts quarantines links and attachments from accounts younger than
+// minAge, a common pattern for spam accounts.
+type NewAccounts struct {
+	users  userGetter
+	minAge time.Duration
+}
+
+func NewNewAccounts(users userGetter, minAge time.Duration) *NewAccounts {
+	return &NewAccounts{users: users, minAge: minAge}
+}
+
+func (f *NewAccounts) Name() string {
+	return "new-accounts"
+}
+
+func (f *NewAccounts) Check(ctx context.Context, in Input) (Decision, error) {
+	var reason string
+	t, ok := text(in)
+	switch {
+	case in.ContentType == message.ImageContentType || in.ContentType == message.FileContentType:
+		reason = "attachment"
+	case ok && len(hosts(t)) > 0:
+		reason = "link"
+	default:
+		return Decision{}, nil
+	}
+
+	u, err := f.users.GetUser(ctx, in.SenderID)
+	if err != nil {
+		return Decision{}, err
+	}
+	if in.SentAt.Sub(u.CreatedAt) >= f.minAge {
+		return Decision{}, nil
+	}
+
+	return Decision{Action: Quarantine, Reason: fmt.Sprintf("%s from an account younger than %v", reason, f.minAge)}, nil
+}
+
+// normalize folds text for matching: compatibility forms are decomposed,
+// accents and invisible characters dropped, case folded, and everything
+// between words collapsed to single spaces. The result starts and ends with a
+// space so phrases can be matched on word boundaries.
+func normalize(s string) string {
+	t := transform.Chain(
+		norm.NFKD,
+		runes.Remove(runes.In(unicode.Mn)),
+		runes.Remove(runes.In(unicode.Cf)),
+		cases.Fold(),
+		norm.NFC,
+	)
+	folded, _, err := transform.String(t, s)
+	if err != nil {
+		folded = strings.ToLower(s)
+	}
+	words := strings.FieldsFunc(folded, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	return " " + strings.Join(words, " ") + " "
+}
+
+var hostPattern = regexp.MustCompile(`(?i)(?:https?://)?((?:[\p{L}\p{N}-]+\.)+\p{L}{2,})`)
+
+// hosts returns the lowercased host names linked to in text.
+// text returns the text of a text message followed by the targets of its
+// links, which rich text keeps out of the content.
+func text(in Input) (string, bool) {
+	if !in.ContentType.IsText() {
+		return "", false
+	}
+	var b strings.Builder
+	b.Write(in.Content)
+	for _, e := range in.Entities {
+		if e.URL != "" {
+			b.WriteString(" " + e.URL)
+		}
+	}
+
+	return b.String(), true
+}
+
+func hosts(text string) []string {
+	var r []string
+	for _, m := range hostPattern.FindAllStringSubmatch(strings.ToLower(norm.NFKC.String(text)), -1) {
+		r = append(r, m[1])
+	}
+
+	return r
+}
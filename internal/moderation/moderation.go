@@ -0,0 +1,279 @@
+// Package moderation runs messages through an ordered chain of filters
+// before they are stored.
+package moderation
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"github.com/AliUnipal/chat/internal/models/message"
+	"github.com/google/uuid"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ErrNotPending is returned when reviewing a message no decision about is
+// waiting for review.
+var ErrNotPending = errors.New("message is not pending review")
+
+// Action is what happens to a message, from least to most severe.
+type Action int
+
+const (
+	Allow Action = iota
+	// Quarantine stores the message but keeps it from the other
+	// participants until a moderator reviews it.
+	Quarantine
+	Reject
+)
+
+func (a Action) String() string {
+	switch a {
+	case Allow:
+		return "allow"
+	case Quarantine:
+		return "quarantine"
+	case Reject:
+		return "reject"
+	default:
+		return fmt.Sprintf("Action(%d)", int(a))
+	}
+}
+
+// Decision is a filter's verdict on a message.
+type Decision struct {
+	Action Action
+	// Filter is the name of the filter that decided, empty when every filter
+	// allowed the message.
+	Filter string
+	Reason string
+}
+
+// Input is the message being moderated.
+type Input struct {
+	MessageID   uuid.UUID
+	SenderID    uuid.UUID
+	ChatID      uuid.UUID
+	Content     []byte
+	ContentType message.ContentType
+	// Entities format rich text. Link targets are not in the content.
+	Entities []message.Entity
+	SentAt   time.Time
+}
+
+type Filter interface {
+	Name() string
+	Check(ctx context.Context, in Input) (Decision, error)
+}
+
+// Record is a decision kept for moderators to review. It holds what the
+// message was and why it was held, but not its content: only a short
+// excerpt of text and a digest to match copies of the same content.
+type Record struct {
+	ID          uuid.UUID
+	MessageID   uuid.UUID
+	SenderID    uuid.UUID
+	ChatID      uuid.UUID
+	ContentType message.ContentType
+	// Excerpt is the start of the text, cut to excerptLength runes. It is
+	// empty for other content and once the message is redacted.
+	Excerpt string
+	// Digest is the SHA-256 of the content, zero once the message is
+	// redacted.
+	Digest   [sha256.Size]byte
+	SentAt   time.Time
+	Decision Decision
+	Review   Review
+	// CreatedAt is when the decision was made.
+	CreatedAt time.Time
+}
+
+// ReviewStatus is what a moderator made of a decision.
+type ReviewStatus int
+
+const (
+	Pending ReviewStatus = iota
+	// Released messages were delivered after all.
+	Released
+	// Removed messages were deleted without being delivered.
+	Removed
+)
+
+type Review struct {
+	Status     ReviewStatus
+	ReviewerID uuid.UUID
+	ReviewedAt time.Time
+}
+
+// excerptLength is how many runes of text a record keeps.
+const excerptLength = 64
+
+// Accepter is implemented by filters that need to know which messages were
+// let through, such as to count them. Accept is called once the pipeline
+// allowed or quarantined a message, never for rejected ones.
+type Accepter interface {
+	Accept(ctx context.Context, in Input) error
+}
+
+type Store interface {
+	SaveRecord(ctx context.Context, r Record) error
+	GetRecords(ctx context.Context) ([]Record, error)
+	// ReviewRecords sets the review of the message's pending records,
+	// returning ErrNotPending when it has none.
+	ReviewRecords(ctx context.Context, messageID uuid.UUID, review Review) error
+	// RedactRecords drops the excerpt and digest of the message's records.
+	RedactRecords(ctx context.Context, messageID uuid.UUID) error
+}
+
+type Pipeline struct {
+	store   Store
+	filters []Filter
+}
+
+// NewPipeline runs filters in the given order, so cheap or decisive filters
+// should come first.
+func NewPipeline(store Store, filters ...Filter) *Pipeline {
+	return &Pipeline{store: store, filters: filters}
+}
+
+// Moderate runs the message through the filters and returns the most severe
+// decision, stopping at the first rejection. Every decision other than Allow
+// is recorded for review, and filters implementing Accepter are told about
+// every message that was not rejected.
+func (p *Pipeline) Moderate(ctx context.Context, in Input) (Decision, error) {
+	var d Decision
+	for _, f := range p.filters {
+		fd, err := f.Check(ctx, in)
+		if err != nil {
+			return Decision{}, fmt.Errorf("%s filter: %w", f.Name(), err)
+		}
+		if fd.Action > d.Action {
+			d = fd
+			d.Filter = f.Name()
+		}
+		if d.Action == Reject {
+			break
+		}
+	}
+	if d.Action != Reject {
+		for _, f := range p.filters {
+			if a, ok := f.(Accepter); ok {
+				if err := a.Accept(ctx, in); err != nil {
+					return Decision{}, fmt.Errorf("%s filter: %w", f.Name(), err)
+				}
+			}
+		}
+	}
+	if d.Action == Allow {
+		return d, nil
+	}
+
+	if err := p.store.SaveRecord(ctx, Record{
+		ID:          uuid.New(),
+		MessageID:   in.MessageID,
+		SenderID:    in.SenderID,
+		ChatID:      in.ChatID,
+		ContentType: in.ContentType,
+		Excerpt:     excerpt(in),
+		Digest:      sha256.Sum256(in.Content),
+		SentAt:      in.SentAt,
+		Decision:    d,
+		CreatedAt:   time.Now().UTC(),
+	}); err != nil {
+		return Decision{}, err
+	}
+
+	return d, nil
+}
+
+// GetRecords returns the recorded decisions, oldest first.
+func (p *Pipeline) GetRecords(ctx context.Context) ([]Record, error) {
+	return p.store.GetRecords(ctx)
+}
+
+// Review records that reviewerID released or removed a quarantined message.
+// It returns ErrNotPending when nothing about the message awaits review.
+func (p *Pipeline) Review(ctx context.Context, messageID, reviewerID uuid.UUID, status ReviewStatus) error {
+	if status != Released && status != Removed {
+		return fmt.Errorf("review status %d is invalid", status)
+	}
+
+	return p.store.ReviewRecords(ctx, messageID, Review{Status: status, ReviewerID: reviewerID, ReviewedAt: time.Now().UTC()})
+}
+
+// Redact drops what records keep of a message's content once the message is
+// gone, such as when it disappeared. The decisions stay for review.
+func (p *Pipeline) Redact(ctx context.Context, messageID uuid.UUID) error {
+	return p.store.RedactRecords(ctx, messageID)
+}
+
+// excerpt returns the start of a text message, marking where it was cut.
+func excerpt(in Input) string {
+	if !in.ContentType.IsText() {
+		return ""
+	}
+	r := []rune(string(in.Content))
+	if len(r) <= excerptLength {
+		return string(r)
+	}
+
+	return string(r[:excerptLength]) + "…"
+}
+
+// MemoryStore keeps decision records in memory.
+type MemoryStore struct {
+	mu      sync.Mutex
+	records []Record
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+func (s *MemoryStore) SaveRecord(_ context.Context, r Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records = append(s.records, r)
+	return nil
+}
+
+func (s *MemoryStore) GetRecords(_ context.Context) ([]Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.records), nil
+}
+
+func (s *MemoryStore) ReviewRecords(_ context.Context, messageID uuid.UUID, review Review) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	found := false
+	for i, r := range s.records {
+		if r.MessageID == messageID && r.Review.Status == Pending {
+			s.records[i].Review = review
+			found = true
+		}
+	}
+	if !found {
+		return ErrNotPending
+	}
+
+	return nil
+}
+
+func (s *MemoryStore) RedactRecords(_ context.Context, messageID uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, r := range s.records {
+		if r.MessageID == messageID {
+			s.records[i].Excerpt = ""
+			s.records[i].Digest = [sha256.Size]byte{}
+		}
+	}
+	return nil
+}
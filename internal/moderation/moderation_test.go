@@ -0,0 +1,268 @@
+package moderation_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"github.com/AliUnipal/chat/internal/models/message"
+	"github.com/AliUnipal/chat/internal/models/user"
+	"github.com/AliUnipal/chat/internal/moderation"
+	"github.com/AliUnipal/chat/internal/moderation/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"strings"
+	"testing"
+	"time"
+)
+
+func text(s string) moderation.Input {
+	return moderation.Input{
+		MessageID:   uuid.New(),
+		SenderID:    uuid.New(),
+		ChatID:      uuid.New(),
+		Content:     []byte(s),
+		ContentType: message.TextContentType,
+		SentAt:      time.Now(),
+	}
+}
+
+func TestPipeline_MostSevereDecisionIsRecorded(t *testing.T) {
+	ctx := context.Background()
+	in := text("hello")
+
+	quarantine := mocks.NewFilter(t)
+	quarantine.EXPECT().Name().Return("first")
+	quarantine.EXPECT().Check(mock.Anything, in).Return(moderation.Decision{Action: moderation.Quarantine, Reason: "suspicious"}, nil)
+	reject := mocks.NewFilter(t)
+	reject.EXPECT().Name().Return("second")
+	reject.EXPECT().Check(mock.Anything, in).Return(moderation.Decision{Action: moderation.Reject, Reason: "spam"}, nil)
+	// Filters after a rejection are not run.
+	skipped := mocks.NewFilter(t)
+
+	store := moderation.NewMemoryStore()
+	d, err := moderation.NewPipeline(store, quarantine, reject, skipped).Moderate(ctx, in)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if d.Action != moderation.Reject || d.Filter != "second" {
+		t.Fatalf("expected rejection by second got %+v", d)
+	}
+
+	records, _ := store.GetRecords(ctx)
+	if len(records) != 1 || records[0].Decision != d || records[0].MessageID != in.MessageID || records[0].Excerpt != "hello" {
+		t.Fatalf("expected the decision recorded got %+v", records)
+	}
+}
+
+func TestPipeline_AllowIsNotRecorded(t *testing.T) {
+	ctx := context.Background()
+	store := moderation.NewMemoryStore()
+	p := moderation.NewPipeline(store, moderation.NewBannedWords(moderation.Reject, "scam"))
+
+	if d, err := p.Moderate(ctx, text("hello there")); err != nil || d.Action != moderation.Allow {
+		t.Fatalf("expected allow got %+v, %v", d, err)
+	}
+	if records, _ := store.GetRecords(ctx); len(records) != 0 {
+		t.Fatalf("expected no records got %+v", records)
+	}
+}
+
+func TestPipeline_RedactKeepsDecision(t *testing.T) {
+	ctx := context.Background()
+	store := moderation.NewMemoryStore()
+	p := moderation.NewPipeline(store, moderation.NewBannedWords(moderation.Quarantine, "scam"))
+	in, other := text("a scam"), text("another scam")
+	for _, in := range []moderation.Input{in, other} {
+		if _, err := p.Moderate(ctx, in); err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+	}
+
+	if err := p.Redact(ctx, in.MessageID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	records, _ := store.GetRecords(ctx)
+	if len(records) != 2 || records[0].Excerpt != "" || records[0].Digest != [sha256.Size]byte{} || records[0].Decision.Action != moderation.Quarantine {
+		t.Fatalf("expected the decision kept without content got %+v", records)
+	}
+	if records[1].Excerpt != "another scam" || records[1].Digest != sha256.Sum256([]byte("another scam")) {
+		t.Fatalf("expected other records untouched got %+v", records[1])
+	}
+}
+
+func TestPipeline_KeepOnlyExcerpt(t *testing.T) {
+	ctx := context.Background()
+	store := moderation.NewMemoryStore()
+	p := moderation.NewPipeline(store, moderation.NewBannedWords(moderation.Quarantine, "scam"))
+	in := text("scam " + strings.Repeat("é", 100))
+	if _, err := p.Moderate(ctx, in); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	records, _ := store.GetRecords(ctx)
+	expected := "scam " + strings.Repeat("é", 59) + "…"
+	if len(records) != 1 || records[0].Excerpt != expected {
+		t.Fatalf("expected excerpt %q got %+v", expected, records)
+	}
+}
+
+func TestPipeline_Review(t *testing.T) {
+	ctx := context.Background()
+	store := moderation.NewMemoryStore()
+	p := moderation.NewPipeline(store, moderation.NewBannedWords(moderation.Quarantine, "scam"))
+	in := text("a scam")
+	if _, err := p.Moderate(ctx, in); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	reviewerID := uuid.New()
+
+	if err := p.Review(ctx, in.MessageID, reviewerID, moderation.Released); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	records, _ := store.GetRecords(ctx)
+	if len(records) != 1 || records[0].Review.Status != moderation.Released || records[0].Review.ReviewerID != reviewerID {
+		t.Fatalf("expected the record released got %+v", records)
+	}
+	if err := p.Review(ctx, in.MessageID, reviewerID, moderation.Removed); !errors.Is(err, moderation.ErrNotPending) {
+		t.Fatalf("expected %v got %v", moderation.ErrNotPending, err)
+	}
+	if err := p.Review(ctx, uuid.New(), reviewerID, moderation.Released); !errors.Is(err, moderation.ErrNotPending) {
+		t.Fatalf("expected %v got %v", moderation.ErrNotPending, err)
+	}
+}
+
+func TestPipeline_FloodCountsOnlyAccepted(t *testing.T) {
+	ctx := context.Background()
+	p := moderation.NewPipeline(moderation.NewMemoryStore(), moderation.NewDuplicateFlood(2, time.Minute), moderation.NewBannedWords(moderation.Reject, "spam"))
+	in := text("spam")
+	for range 3 {
+		if d, _ := p.Moderate(ctx, in); d.Filter != "banned-words" {
+			t.Fatalf("expected rejection by banned-words got %+v", d)
+		}
+	}
+
+	in.Content = []byte("hello")
+	for i := range 2 {
+		if d, _ := p.Moderate(ctx, in); d.Action != moderation.Allow {
+			t.Fatalf("message %d: expected allow got %+v", i, d)
+		}
+	}
+	if d, _ := p.Moderate(ctx, in); d.Filter != "duplicate-flood" {
+		t.Fatalf("expected rejection by duplicate-flood got %+v", d)
+	}
+}
+
+func TestPipeline_ReturnFilterError(t *testing.T) {
+	ctx := context.Background()
+	failing := mocks.NewFilter(t)
+	failing.EXPECT().Name().Return("failing")
+	failing.EXPECT().Check(mock.Anything, mock.Anything).Return(moderation.Decision{}, errors.New("error"))
+
+	if _, err := moderation.NewPipeline(moderation.NewMemoryStore(), failing).Moderate(ctx, text("hi")); err == nil {
+		t.Fatalf("expected error got %v", err)
+	}
+}
+
+func TestBannedWords(t *testing.T) {
+	f := moderation.NewBannedWords(moderation.Reject, "scam", "free money")
+	tests := []struct {
+		content string
+		want    moderation.Action
+	}{
+		{"this is a SCAM", moderation.Reject},
+		{"ｓｃａｍ", moderation.Reject},
+		{"scám", moderation.Reject},
+		{"s\u200bcam", moderation.Reject},
+		{"get FREE... money!", moderation.Reject},
+		{"scampi for dinner", moderation.Allow},
+		{"money for free", moderation.Allow},
+	}
+	for _, tt := range tests {
+		d, err := f.Check(context.Background(), text(tt.content))
+		if err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+		if d.Action != tt.want {
+			t.Fatalf("%q: expected %v got %v", tt.content, tt.want, d.Action)
+		}
+	}
+}
+
+func TestURLBlocklist(t *testing.T) {
+	f := moderation.NewURLBlocklist(moderation.Reject, "bad.example")
+	tests := []struct {
+		content string
+		want    moderation.Action
+	}{
+		{"see https://bad.example/path", moderation.Reject},
+		{"see www.Bad.Example", moderation.Reject},
+		{"see http://login.bad.example", moderation.Reject},
+		{"see notbad.example", moderation.Allow},
+		{"see good.example", moderation.Allow},
+	}
+	for _, tt := range tests {
+		d, _ := f.Check(context.Background(), text(tt.content))
+		if d.Action != tt.want {
+			t.Fatalf("%q: expected %v got %v", tt.content, tt.want, d.Action)
+		}
+	}
+
+	hidden := moderation.Input{
+		Content:     []byte("harmless"),
+		ContentType: message.RichTextContentType,
+		Entities:    []message.Entity{{Type: message.LinkEntity, Length: 8, URL: "https://bad.example/path"}},
+	}
+	if d, _ := f.Check(context.Background(), hidden); d.Action != moderation.Reject {
+		t.Fatalf("expected a link hidden behind its label rejected got %v", d.Action)
+	}
+}
+
+func TestDuplicateFlood(t *testing.T) {
+	ctx := context.Background()
+	f := moderation.NewDuplicateFlood(2, time.Minute)
+	in := text("hello")
+
+	for i := range 2 {
+		in.SentAt = in.SentAt.Add(time.Second)
+		if d, _ := f.Check(ctx, in); d.Action != moderation.Allow {
+			t.Fatalf("message %d: expected allow got %v", i, d.Action)
+		}
+		if err := f.Accept(ctx, in); err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+	}
+	in.Content = []byte("HELLO!")
+	if d, _ := f.Check(ctx, in); d.Action != moderation.Reject {
+		t.Fatalf("expected third copy rejected got %v", d.Action)
+	}
+
+	in.SentAt = in.SentAt.Add(time.Minute)
+	if d, _ := f.Check(ctx, in); d.Action != moderation.Allow {
+		t.Fatalf("expected allow after the window got %v", d.Action)
+	}
+}
+
+func TestNewAccounts(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	in := text("visit shop.example")
+	in.SentAt = now
+
+	users := mocks.NewUserGetter(t)
+	f := moderation.NewNewAccounts(users, 24*time.Hour)
+
+	users.EXPECT().GetUser(mock.Anything, in.SenderID).Return(user.User{ID: in.SenderID, CreatedAt: now.Add(-time.Hour)}, nil).Once()
+	if d, _ := f.Check(ctx, in); d.Action != moderation.Quarantine {
+		t.Fatalf("expected quarantine got %v", d.Action)
+	}
+
+	users.EXPECT().GetUser(mock.Anything, in.SenderID).Return(user.User{ID: in.SenderID, CreatedAt: now.Add(-48 * time.Hour)}, nil).Once()
+	if d, _ := f.Check(ctx, in); d.Action != moderation.Allow {
+		t.Fatalf("expected allow for an older account got %v", d.Action)
+	}
+
+	// Plain text needs no lookup.
+	if d, _ := f.Check(ctx, text("hello")); d.Action != moderation.Allow {
+		t.Fatalf("expected allow got %v", d.Action)
+	}
+}
@@ -0,0 +1,111 @@
+// Package ratelimit limits how fast messages can be sent using token buckets
+// per sender, per chat and across the whole service.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"time"
+)
+
+// Limit is a token bucket: it refills at Rate tokens per second up to Burst
+// tokens, and every message takes one. A zero Rate disables the bucket.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+type Config struct {
+	PerSender Limit
+	PerChat   Limit
+	Global    Limit
+}
+
+var DefaultConfig = Config{
+	PerSender: Limit{Rate: 5, Burst: 10},
+	PerChat:   Limit{Rate: 20, Burst: 40},
+	Global:    Limit{Rate: 1000, Burst: 2000},
+}
+
+// Bucket names a token bucket and the limit it refills with.
+type Bucket struct {
+	Key   string
+	Limit Limit
+}
+
+// Result is the outcome of taking a token from a set of buckets.
+type Result struct {
+	Allowed bool
+	// Denied is the bucket that will take longest to refill, and RetryAfter
+	// how long that takes, when the take was not allowed.
+	Denied     Bucket
+	RetryAfter time.Duration
+}
+
+// Store keeps the state of the token buckets. Sharing one store between
+// instances of the service, for example in Redis, makes the limits apply
+// across all of them.
+type Store interface {
+	// Take takes a token from every bucket as of now, or from none of them
+	// when any bucket is empty.
+	Take(ctx context.Context, now time.Time, buckets ...Bucket) (Result, error)
+}
+
+// Error is returned when a message is rate limited.
+type Error struct {
+	// Scope is the limit that was hit: "sender", "chat" or "global".
+	Scope string
+	// RetryAfter is how long to wait before sending again.
+	RetryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s rate limit exceeded, retry after %v", e.Scope, e.RetryAfter)
+}
+
+type Limiter struct {
+	store  Store
+	config Config
+}
+
+func New(store Store, config Config) *Limiter {
+	return &Limiter{store: store, config: config}
+}
+
+// Allow takes a token for a message from senderID to chatID, returning an
+// *Error when any of the limits is exhausted.
+func (l *Limiter) Allow(ctx context.Context, senderID, chatID uuid.UUID) error {
+	var buckets []Bucket
+	for _, b := range []Bucket{
+		{"sender:" + senderID.String(), l.config.PerSender},
+		{"chat:" + chatID.String(), l.config.PerChat},
+		{"global", l.config.Global},
+	} {
+		if b.Limit.Rate > 0 {
+			buckets = append(buckets, b)
+		}
+	}
+	if len(buckets) == 0 {
+		return nil
+	}
+
+	r, err := l.store.Take(ctx, time.Now(), buckets...)
+	if err != nil {
+		return err
+	}
+	if !r.Allowed {
+		return &Error{Scope: scope(r.Denied.Key), RetryAfter: r.RetryAfter}
+	}
+
+	return nil
+}
+
+func scope(key string) string {
+	for i, c := range key {
+		if c == ':' {
+			return key[:i]
+		}
+	}
+	return key
+}
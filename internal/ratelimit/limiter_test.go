@@ -0,0 +1,100 @@
+package ratelimit_test
+
+import (
+	"context"
+	"errors"
+	"github.com/AliUnipal/chat/internal/ratelimit"
+	"github.com/google/uuid"
+	"testing"
+	"time"
+)
+
+func TestLimiter_EnforceEachScope(t *testing.T) {
+	ctx := context.Background()
+	tight := ratelimit.Limit{Rate: 0.001, Burst: 2}
+	loose := ratelimit.Limit{Rate: 1000, Burst: 1000}
+
+	tests := []struct {
+		name   string
+		config ratelimit.Config
+		// next returns the sender and chat of the i-th message.
+		next func(i int) (uuid.UUID, uuid.UUID)
+	}{
+		{"sender", ratelimit.Config{PerSender: tight, PerChat: loose}, fixed(uuid.New(), uuid.Nil)},
+		{"chat", ratelimit.Config{PerSender: loose, PerChat: tight}, fixed(uuid.Nil, uuid.New())},
+		{"global", ratelimit.Config{PerSender: loose, Global: tight}, fixed(uuid.Nil, uuid.Nil)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := ratelimit.New(ratelimit.NewMemoryStore(), tt.config)
+			for i := range 2 {
+				senderID, chatID := tt.next(i)
+				if err := l.Allow(ctx, senderID, chatID); err != nil {
+					t.Fatalf("expected the burst to be allowed got %v", err)
+				}
+			}
+
+			senderID, chatID := tt.next(2)
+			var rateErr *ratelimit.Error
+			if err := l.Allow(ctx, senderID, chatID); !errors.As(err, &rateErr) {
+				t.Fatalf("expected a rate limit error got %v", err)
+			}
+			if rateErr.Scope != tt.name || rateErr.RetryAfter <= 0 {
+				t.Fatalf("expected a %s limit with a retry-after hint got %v", tt.name, rateErr)
+			}
+		})
+	}
+}
+
+// fixed keeps sender and chat unless they are uuid.Nil, in which case every
+// message gets a fresh one.
+func fixed(senderID, chatID uuid.UUID) func(int) (uuid.UUID, uuid.UUID) {
+	return func(int) (uuid.UUID, uuid.UUID) {
+		s, c := senderID, chatID
+		if s == uuid.Nil {
+			s = uuid.New()
+		}
+		if c == uuid.Nil {
+			c = uuid.New()
+		}
+		return s, c
+	}
+}
+
+func TestMemoryStore_RefillOverTime(t *testing.T) {
+	ctx := context.Background()
+	store := ratelimit.NewMemoryStore()
+	b := ratelimit.Bucket{Key: "sender", Limit: ratelimit.Limit{Rate: 2, Burst: 1}}
+	now := time.Now()
+
+	if r, err := store.Take(ctx, now, b); err != nil || !r.Allowed {
+		t.Fatalf("expected the first take to be allowed got %v, %v", r, err)
+	}
+	r, err := store.Take(ctx, now, b)
+	if err != nil || r.Allowed || r.RetryAfter != 500*time.Millisecond {
+		t.Fatalf("expected to wait 500ms got %v, %v", r, err)
+	}
+	if r, err := store.Take(ctx, now.Add(r.RetryAfter), b); err != nil || !r.Allowed {
+		t.Fatalf("expected the take to be allowed after waiting got %v, %v", r, err)
+	}
+}
+
+func TestMemoryStore_TakeFromAllOrNone(t *testing.T) {
+	ctx := context.Background()
+	store := ratelimit.NewMemoryStore()
+	roomy := ratelimit.Bucket{Key: "roomy", Limit: ratelimit.Limit{Rate: 1, Burst: 2}}
+	empty := ratelimit.Bucket{Key: "empty", Limit: ratelimit.Limit{Rate: 1, Burst: 1}}
+	now := time.Now()
+
+	if r, _ := store.Take(ctx, now, empty); !r.Allowed {
+		t.Fatal("expected the first take to be allowed")
+	}
+	if r, _ := store.Take(ctx, now, roomy, empty); r.Allowed || r.Denied.Key != "empty" {
+		t.Fatalf("expected the empty bucket to deny got %v", r)
+	}
+	for range 2 {
+		if r, _ := store.Take(ctx, now, roomy); !r.Allowed {
+			t.Fatal("expected the denied take to leave the other bucket untouched")
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// pruneEvery is how many takes MemoryStore lets pass between sweeps of the
+// buckets that have refilled completely and can be forgotten.
+const pruneEvery = 1024
+
+// MemoryStore keeps token buckets in memory, so its limits only apply to a
+// single instance of the service.
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	takes   int
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	limit  Limit
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: make(map[string]*bucket)}
+}
+
+func (s *MemoryStore) Take(_ context.Context, now time.Time, buckets ...Bucket) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.takes++
+	if s.takes%pruneEvery == 0 {
+		s.prune(now)
+	}
+
+	r := Result{Allowed: true}
+	state := make([]*bucket, len(buckets))
+	for i, b := range buckets {
+		st, ok := s.buckets[b.Key]
+		if !ok {
+			st = &bucket{tokens: float64(b.Limit.Burst), last: now}
+			s.buckets[b.Key] = st
+		}
+		st.limit = b.Limit
+		st.refill(now)
+		state[i] = st
+
+		if st.tokens < 1 {
+			wait := time.Duration(math.Ceil((1 - st.tokens) / b.Limit.Rate * float64(time.Second)))
+			if !r.Allowed && wait <= r.RetryAfter {
+				continue
+			}
+			r = Result{Denied: b, RetryAfter: wait}
+		}
+	}
+	if !r.Allowed {
+		return r, nil
+	}
+
+	for _, st := range state {
+		st.tokens--
+	}
+	return r, nil
+}
+
+func (b *bucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(float64(b.limit.Burst), b.tokens+elapsed*b.limit.Rate)
+		b.last = now
+	}
+}
+
+// prune forgets the buckets that are full again, as a new bucket starts out
+// full anyway.
+func (s *MemoryStore) prune(now time.Time) {
+	for key, b := range s.buckets {
+		b.refill(now)
+		if b.tokens >= float64(b.limit.Burst) {
+			delete(s.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,402 @@
+// Package richtext parses the markdown dialect rich text messages are written
+// in.
+//
+// The dialect is deliberately small: **bold** or __bold__, *italic* or
+// _italic_, `code`, [links](https://example.com), fenced code blocks and
+// bullet or numbered lists. Formatting does not span lines, and anything that
+// does not parse is kept as literal text.
+//
+// Messages are not stored as markdown, nor as HTML. Parse turns the source
+// into plain text along with entities that locate the formatting in it, so
+// the plain text doubles as the fallback for notifications and search, and
+// clients never have markup to sanitize: they escape the text and apply the
+// entities. Link targets are restricted to http, https and mailto URLs.
+package richtext
+
+import (
+	"github.com/AliUnipal/chat/internal/models/message"
+	"net/url"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxEntities bounds the formatting of a message. Text past it is kept, only
+// unformatted.
+const maxEntities = 100
+
+// urlReplacer percent-encodes what url.URL.String leaves in queries and
+// fragments that would let a URL break out of an HTML attribute.
+var urlReplacer = strings.NewReplacer(`"`, "%22", "'", "%27", "<", "%3C", ">", "%3E", "`", "%60")
+
+// Parse parses markdown into plain text and the entities formatting it,
+// ordered by offset with enclosing entities first.
+func Parse(src string) (string, []message.Entity) {
+	p := &parser{}
+	lines := strings.Split(sanitize(src), "\n")
+	for i := 0; i < len(lines); i++ {
+		if i > 0 {
+			p.text.WriteByte('\n')
+		}
+		line := lines[i]
+
+		if lang, ok := fence(line); ok {
+			end := i + 1
+			for end < len(lines) && strings.TrimSpace(lines[end]) != "```" {
+				end++
+			}
+			// An unclosed fence runs to the end of the message.
+			start := p.text.Len()
+			p.text.WriteString(strings.Join(lines[i+1:min(end, len(lines))], "\n"))
+			p.add(message.Entity{Type: message.CodeBlockEntity, Offset: start, Length: p.text.Len() - start, Language: lang})
+			i = end
+			continue
+		}
+
+		typ, marker, rest, ok := listItem(line)
+		if !ok {
+			p.line(line)
+			continue
+		}
+		p.text.WriteString(marker)
+		start := p.text.Len()
+		p.line(rest)
+		p.add(message.Entity{Type: typ, Offset: start, Length: p.text.Len() - start})
+	}
+
+	// Entities are added once their text has been written, so an enclosing
+	// entity comes after those it encloses. Reversing first keeps it first
+	// when both cover the same text.
+	slices.Reverse(p.entities)
+	slices.SortStableFunc(p.entities, func(a, b message.Entity) int {
+		if a.Offset != b.Offset {
+			return a.Offset - b.Offset
+		}
+		return b.Length - a.Length
+	})
+	return p.text.String(), p.entities
+}
+
+type parser struct {
+	text     strings.Builder
+	entities []message.Entity
+	inLink   bool
+	// idx indexes the line being parsed.
+	idx *index
+}
+
+func (p *parser) add(e message.Entity) {
+	if e.Length > 0 && len(p.entities) < maxEntities {
+		p.entities = append(p.entities, e)
+	}
+}
+
+// line writes a line of text, parsing the formatting within it.
+func (p *parser) line(s string) {
+	p.idx = newIndex(s)
+	p.inline(s, 0)
+}
+
+// inline writes s, the part of the line starting at off, parsing the
+// formatting within it.
+func (p *parser) inline(s string, off int) {
+	for i := 0; i < len(s); {
+		switch c := s[i]; {
+		case c == '\\' && i+1 < len(s) && isPunct(s[i+1]):
+			p.text.WriteByte(s[i+1])
+			i += 2
+
+		case c == '`':
+			n := run(s, i)
+			end := p.idx.closingBackticks(off+i, off+len(s)) - off
+			if end < 0 {
+				p.text.WriteString(s[i : i+n])
+				i += n
+				continue
+			}
+			code := s[i+n : end]
+			if len(code) > 1 && code[0] == ' ' && code[len(code)-1] == ' ' {
+				code = code[1 : len(code)-1]
+			}
+			start := p.text.Len()
+			p.text.WriteString(code)
+			p.add(message.Entity{Type: message.CodeEntity, Offset: start, Length: len(code)})
+			i = end + n
+
+		case c == '*' || c == '_':
+			n := run(s, i)
+			end := -1
+			if n <= 2 && opens(s, i, n) {
+				end = p.idx.next(s[i:i+n], off+i+n, off+len(s)) - off
+			}
+			if end < 0 {
+				p.text.WriteString(s[i : i+n])
+				i += n
+				continue
+			}
+			typ := message.ItalicEntity
+			if n == 2 {
+				typ = message.BoldEntity
+			}
+			start := p.text.Len()
+			p.inline(s[i+n:end], off+i+n)
+			p.add(message.Entity{Type: typ, Offset: start, Length: p.text.Len() - start})
+			i = end + n
+
+		case c == '[' && !p.inLink:
+			label, target, n, ok := p.link(s[i:], off+i)
+			if !ok {
+				p.text.WriteByte(c)
+				i++
+				continue
+			}
+			start := p.text.Len()
+			if label == "" {
+				p.text.WriteString(target)
+			} else {
+				p.inLink = true
+				p.inline(label, off+i+1)
+				p.inLink = false
+			}
+			p.add(message.Entity{Type: message.LinkEntity, Offset: start, Length: p.text.Len() - start, URL: target})
+			i += n
+
+		default:
+			p.text.WriteByte(c)
+			i++
+		}
+	}
+}
+
+// sanitize drops control characters other than tabs and newlines, and the
+// bidirectional overrides that can make text read differently than it is
+// stored.
+func sanitize(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '\n' || r == '\t':
+			return r
+		case unicode.IsControl(r),
+			r >= '\u202a' && r <= '\u202e',
+			r >= '\u2066' && r <= '\u2069',
+			r == utf8.RuneError:
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// fence reports whether line opens a fenced code block, and the language the
+// block is tagged with.
+func fence(line string) (string, bool) {
+	rest, ok := strings.CutPrefix(strings.TrimLeft(line, " "), "```")
+	if !ok || strings.Contains(rest, "`") {
+		return "", false
+	}
+	lang := strings.TrimSpace(rest)
+	if len(lang) > 32 || strings.ContainsFunc(lang, func(r rune) bool {
+		return !(r < utf8.RuneSelf && (isAlnum(byte(r)) || strings.ContainsRune("+#._-", r)))
+	}) {
+		lang = ""
+	}
+
+	return lang, true
+}
+
+// listItem splits a list item into its type, the marker it is written with in
+// the plain text, and its text. Nested lists are flattened.
+func listItem(line string) (message.EntityType, string, string, bool) {
+	s := strings.TrimLeft(line, " \t")
+	if len(s) > 2 && strings.ContainsRune("-*+", rune(s[0])) && s[1] == ' ' {
+		return message.BulletItemEntity, "• ", strings.TrimLeft(s[2:], " "), true
+	}
+
+	n := 0
+	for n < len(s) && n < 9 && s[n] >= '0' && s[n] <= '9' {
+		n++
+	}
+	if n > 0 && len(s) > n+2 && (s[n] == '.' || s[n] == ')') && s[n+1] == ' ' {
+		return message.NumberedItemEntity, s[:n] + ". ", strings.TrimLeft(s[n+2:], " "), true
+	}
+
+	return 0, "", "", false
+}
+
+// link parses a [label](url) at the start of s, the part of the line
+// starting at off, returning the label, the sanitized URL and how many bytes
+// of s the link takes.
+func (p *parser) link(s string, off int) (string, string, int, bool) {
+	end := p.idx.next("]", off+1, off+len(s)) - off
+	if end < 0 || end+1 >= len(s) || s[end+1] != '(' {
+		return "", "", 0, false
+	}
+	closing := p.idx.nextParen(off+end+2, off+len(s)) - off
+	if closing < 0 {
+		return "", "", 0, false
+	}
+	target, ok := sanitizeURL(strings.TrimSpace(s[end+2 : closing]))
+	if !ok {
+		return "", "", 0, false
+	}
+
+	return s[1:end], target, closing + 1, true
+}
+
+// sanitizeURL returns raw as an absolute http, https or mailto URL that is
+// safe to put in an HTML attribute.
+func sanitizeURL(raw string) (string, bool) {
+	if raw == "" || strings.ContainsFunc(raw, unicode.IsSpace) {
+		return "", false
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https":
+		if u.Host == "" {
+			return "", false
+		}
+	case "mailto":
+		if u.Opaque == "" {
+			return "", false
+		}
+	default:
+		return "", false
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+
+	return urlReplacer.Replace(u.String()), true
+}
+
+// opens reports whether the run of n delimiters at i can open emphasis: it
+// must be followed by text, and an underscore must not be inside a word, as
+// in snake_case.
+func opens(s string, i, n int) bool {
+	if i+n >= len(s) || s[i+n] == ' ' || s[i+n] == '\t' {
+		return false
+	}
+	return s[i] != '_' || i == 0 || !isAlnum(s[i-1])
+}
+
+// index locates what parsing a line looks ahead for: the runs of backticks
+// closing code spans, the runs of delimiters that can close emphasis, and the
+// brackets and parentheses that can close links. Looking them up instead of
+// scanning for them keeps parsing a line linear, however many of its
+// delimiters go unmatched.
+type index struct {
+	// backticks maps the start of each run of backticks to the start of the
+	// next run of as many.
+	backticks map[int]int
+	// closers holds, by the text they are written with, where the closing
+	// delimiters and brackets outside code spans and escapes start, in order.
+	closers map[string][]int
+	// parens holds where every ')' is, in order.
+	parens []int
+}
+
+func newIndex(s string) *index {
+	x := &index{backticks: make(map[int]int), closers: make(map[string][]int)}
+
+	var runs []int
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '`':
+			runs = append(runs, i)
+			i += run(s, i) - 1
+		case ')':
+			x.parens = append(x.parens, i)
+		}
+	}
+	next := make(map[int]int)
+	for _, i := range slices.Backward(runs) {
+		n := run(s, i)
+		if j, ok := next[n]; ok {
+			x.backticks[i] = j
+		}
+		next[n] = i
+	}
+
+	for i := 0; i < len(s); {
+		switch c := s[i]; {
+		case c == '\\' && i+1 < len(s) && isPunct(s[i+1]):
+			i += 2
+		case c == '`':
+			n := run(s, i)
+			if end, ok := x.backticks[i]; ok {
+				i = end
+			}
+			i += n
+		case c == '*' || c == '_':
+			n := run(s, i)
+			if n <= 2 && closes(s, i, n) {
+				x.closers[s[i:i+n]] = append(x.closers[s[i:i+n]], i)
+			}
+			i += n
+		case c == ']':
+			x.closers["]"] = append(x.closers["]"], i)
+			i++
+		default:
+			i++
+		}
+	}
+
+	return x
+}
+
+// next returns where the first closer written as delim starts at or after
+// from and before to, or -1.
+func (x *index) next(delim string, from, to int) int {
+	return first(x.closers[delim], from, to)
+}
+
+// nextParen returns where the first ')' at or after from and before to is, or
+// -1.
+func (x *index) nextParen(from, to int) int {
+	return first(x.parens, from, to)
+}
+
+// closingBackticks returns where the run of backticks closing the code span
+// opened by the run at i starts, as long as it is before to, or -1.
+func (x *index) closingBackticks(i, to int) int {
+	if end, ok := x.backticks[i]; ok && end < to {
+		return end
+	}
+	return -1
+}
+
+func first(positions []int, from, to int) int {
+	i, _ := slices.BinarySearch(positions, from)
+	if i < len(positions) && positions[i] < to {
+		return positions[i]
+	}
+	return -1
+}
+
+// closes reports whether the run of n delimiters at i can close emphasis: it
+// must follow text, and an underscore must not be inside a word.
+func closes(s string, i, n int) bool {
+	if i == 0 || s[i-1] == ' ' || s[i-1] == '\t' {
+		return false
+	}
+	return s[i] != '_' || i+n == len(s) || !isAlnum(s[i+n])
+}
+
+// run returns the length of the run of the byte at i.
+func run(s string, i int) int {
+	n := 1
+	for i+n < len(s) && s[i+n] == s[i] {
+		n++
+	}
+	return n
+}
+
+func isAlnum(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// isPunct reports whether c is ASCII punctuation, which a backslash escapes.
+func isPunct(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
@@ -0,0 +1,182 @@
+package richtext_test
+
+import (
+	"github.com/AliUnipal/chat/internal/models/message"
+	"github.com/AliUnipal/chat/internal/richtext"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		text     string
+		entities []message.Entity
+	}{
+		{
+			name: "plain",
+			src:  "just text",
+			text: "just text",
+		},
+		{
+			name: "bold and italic",
+			src:  "**bold** and _italic_ and *also*",
+			text: "bold and italic and also",
+			entities: []message.Entity{
+				{Type: message.BoldEntity, Offset: 0, Length: 4},
+				{Type: message.ItalicEntity, Offset: 9, Length: 6},
+				{Type: message.ItalicEntity, Offset: 20, Length: 4},
+			},
+		},
+		{
+			name: "nested",
+			src:  "*a **b** c*",
+			text: "a b c",
+			entities: []message.Entity{
+				{Type: message.ItalicEntity, Offset: 0, Length: 5},
+				{Type: message.BoldEntity, Offset: 2, Length: 1},
+			},
+		},
+		{
+			name: "code is not formatted",
+			src:  "run `rm *.go` now",
+			text: "run rm *.go now",
+			entities: []message.Entity{
+				{Type: message.CodeEntity, Offset: 4, Length: 7},
+			},
+		},
+		{
+			name: "unmatched delimiters are literal",
+			src:  "2 * 3 * 4, snake_case_name and **open",
+			text: "2 * 3 * 4, snake_case_name and **open",
+		},
+		{
+			name: "escapes",
+			src:  `\*not italic\* and \[not](a link)`,
+			text: "*not italic* and [not](a link)",
+		},
+		{
+			name: "link",
+			src:  "see [the **docs**](https://example.com/a?b=c) or [](mailto:a@example.com)",
+			text: "see the docs or mailto:a@example.com",
+			entities: []message.Entity{
+				{Type: message.LinkEntity, Offset: 4, Length: 8, URL: "https://example.com/a?b=c"},
+				{Type: message.BoldEntity, Offset: 8, Length: 4},
+				{Type: message.LinkEntity, Offset: 16, Length: 20, URL: "mailto:a@example.com"},
+			},
+		},
+		{
+			name: "unsafe links are literal",
+			src:  "[x](javascript:alert(1)) [y](data:text/html,hi) [z](/relative)",
+			text: "[x](javascript:alert(1)) [y](data:text/html,hi) [z](/relative)",
+		},
+		{
+			name: "link escaped for attributes",
+			src:  `[x](https://example.com/?q="><script>)`,
+			text: "x",
+			entities: []message.Entity{
+				{Type: message.LinkEntity, Offset: 0, Length: 1, URL: "https://example.com/?q=%22%3E%3Cscript%3E"},
+			},
+		},
+		{
+			name: "lists",
+			src:  "todo:\n- milk\n* **eggs**\n2) bread",
+			text: "todo:\n• milk\n• eggs\n2. bread",
+			entities: []message.Entity{
+				{Type: message.BulletItemEntity, Offset: 10, Length: 4},
+				{Type: message.BulletItemEntity, Offset: 19, Length: 4},
+				{Type: message.BoldEntity, Offset: 19, Length: 4},
+				{Type: message.NumberedItemEntity, Offset: 27, Length: 5},
+			},
+		},
+		{
+			name: "code block",
+			src:  "look:\n```go\nx := *p\n```\ndone",
+			text: "look:\nx := *p\ndone",
+			entities: []message.Entity{
+				{Type: message.CodeBlockEntity, Offset: 6, Length: 7, Language: "go"},
+			},
+		},
+		{
+			name: "unclosed code block",
+			src:  "```\n**a**",
+			text: "**a**",
+			entities: []message.Entity{
+				{Type: message.CodeBlockEntity, Offset: 0, Length: 5},
+			},
+		},
+		{
+			name: "closers in code spans are skipped",
+			src:  "*a `b*` c* and [`]` label](https://example.com)",
+			text: "a b* c and ] label",
+			entities: []message.Entity{
+				{Type: message.ItalicEntity, Offset: 0, Length: 6},
+				{Type: message.CodeEntity, Offset: 2, Length: 2},
+				{Type: message.LinkEntity, Offset: 11, Length: 7, URL: "https://example.com"},
+				{Type: message.CodeEntity, Offset: 11, Length: 1},
+			},
+		},
+		{
+			name: "emphasis closes within its link",
+			src:  "[*a](https://example.com) b*",
+			text: "*a b*",
+			entities: []message.Entity{
+				{Type: message.LinkEntity, Offset: 0, Length: 2, URL: "https://example.com"},
+			},
+		},
+		{
+			name: "control and bidi characters dropped",
+			src:  "a\u0000b\r\nc\u202ed",
+			text: "ab\ncd",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, entities := richtext.Parse(tt.src)
+			if text != tt.text {
+				t.Fatalf("expected text %q got %q", tt.text, text)
+			}
+			if !slices.Equal(entities, tt.entities) {
+				t.Fatalf("expected entities %+v got %+v", tt.entities, entities)
+			}
+		})
+	}
+}
+
+// pathological is input that made parsing quadratic, by leaving every
+// delimiter and bracket unmatched.
+var pathological = map[string]string{
+	"asterisks":    strings.Repeat("*a ", 25000),
+	"underscores":  strings.Repeat("_a ", 25000),
+	"bold":         strings.Repeat("**a ", 25000),
+	"backticks":    strings.Repeat("`a ``", 25000),
+	"brackets":     strings.Repeat("[a", 25000),
+	"link targets": strings.Repeat("[a](", 25000),
+}
+
+func TestParse_UnmatchedDelimitersAreLinear(t *testing.T) {
+	for name, src := range pathological {
+		t.Run(name, func(t *testing.T) {
+			start := time.Now()
+			if text, _ := richtext.Parse(src); len(text) == 0 {
+				t.Fatalf("expected the text kept got nothing")
+			}
+			if d := time.Since(start); d > time.Second {
+				t.Fatalf("expected parsing to take well under a second got %v", d)
+			}
+		})
+	}
+}
+
+func BenchmarkParse_Unmatched(b *testing.B) {
+	for name, src := range pathological {
+		b.Run(name, func(b *testing.B) {
+			for b.Loop() {
+				richtext.Parse(src)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// Package accountsvc runs account deletion across the user, chat, message,
+// webhook and export services.
+package accountsvc
+
+import (
+	"context"
+	"github.com/AliUnipal/chat/internal/models/user"
+	"github.com/google/uuid"
+)
+
+// MessagePolicy decides what happens to a deleted user's messages.
+type MessagePolicy int
+
+const (
+	// KeepMessages leaves the messages in place, attributed to the
+	// anonymized profile.
+	KeepMessages MessagePolicy = iota
+	// DeleteMessages removes everything the user sent.
+	DeleteMessages
+)
+
+type accountService interface {
+	DeleteAccount(ctx context.Context, userID uuid.UUID) error
+}
+
+type userService interface {
+	DeleteUser(ctx context.Context, userID uuid.UUID) error
+	GetBots(ctx context.Context, ownerID uuid.UUID) ([]user.User, error)
+}
+
+type chatService interface {
+	GetChatIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+	RemoveUser(ctx context.Context, userID uuid.UUID) error
+}
+
+type messageService interface {
+	RemoveMessagesBySender(ctx context.Context, chatID, senderID uuid.UUID) error
+	CancelScheduledMessages(ctx context.Context, userID uuid.UUID) error
+}
+
+type webhookService interface {
+	UnsubscribeAll(ctx context.Context, ownerID uuid.UUID) error
+}
+
+type exportService interface {
+	DeleteExports(ctx context.Context, userID uuid.UUID) error
+}
+
+type service struct {
+	users   userService
+	chats   chatService
+	msgs    messageService
+	hooks   webhookService
+	exports exportService
+	policy  MessagePolicy
+}
+
+var _ accountService = (*service)(nil)
+
+func NewService(users userService, chats chatService, msgs messageService, hooks webhookService, exports exportService, policy MessagePolicy) *service {
+	return &service{users: users, chats: chats, msgs: msgs, hooks: hooks, exports: exports, policy: policy}
+}
+
+// DeleteAccount anonymizes the user's profile, deletes their webhook
+// subscriptions and exports, cancels their scheduled messages, applies the
+// message policy and takes them out of their chats. The bots they created
+// are then deleted the same way. The profile goes first so the user can no
+// longer send while the rest is cleaned up.
+//
+// Every step can be run again, so a deletion that failed part way is finished
+// by retrying it. Messages are removed while the user is still in their
+// chats, so a retry finds every chat that may still hold some.
+func (s *service) DeleteAccount(ctx context.Context, userID uuid.UUID) error {
+	if err := s.users.DeleteUser(ctx, userID); err != nil {
+		return err
+	}
+	if err := s.hooks.UnsubscribeAll(ctx, userID); err != nil {
+		return err
+	}
+	if err := s.exports.DeleteExports(ctx, userID); err != nil {
+		return err
+	}
+	if err := s.msgs.CancelScheduledMessages(ctx, userID); err != nil {
+		return err
+	}
+	if s.policy == DeleteMessages {
+		chatIDs, err := s.chats.GetChatIDs(ctx, userID)
+		if err != nil {
+			return err
+		}
+		for _, id := range chatIDs {
+			if err := s.msgs.RemoveMessagesBySender(ctx, id, userID); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := s.chats.RemoveUser(ctx, userID); err != nil {
+		return err
+	}
+
+	bots, err := s.users.GetBots(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, b := range bots {
+		if err := s.DeleteAccount(ctx, b.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
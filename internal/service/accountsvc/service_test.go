@@ -0,0 +1,115 @@
+package accountsvc_test
+
+import (
+	"context"
+	"errors"
+	"github.com/AliUnipal/chat/internal/models/user"
+	"github.com/AliUnipal/chat/internal/service/accountsvc"
+	"github.com/AliUnipal/chat/internal/service/accountsvc/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestDeleteAccount_ApplyMessagePolicy(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	chatIDs := []uuid.UUID{uuid.New(), uuid.New()}
+
+	for _, policy := range []accountsvc.MessagePolicy{accountsvc.KeepMessages, accountsvc.DeleteMessages} {
+		mockUsers := mocks.NewUserService(t)
+		mockChats := mocks.NewChatService(t)
+		mockMsgs := mocks.NewMessageService(t)
+		mockHooks := mocks.NewWebhookService(t)
+		mockExports := mocks.NewExportService(t)
+		mockUsers.EXPECT().DeleteUser(ctx, userID).Return(nil)
+		mockUsers.EXPECT().GetBots(ctx, userID).Return(nil, nil)
+		mockHooks.EXPECT().UnsubscribeAll(ctx, userID).Return(nil)
+		mockExports.EXPECT().DeleteExports(ctx, userID).Return(nil)
+		mockMsgs.EXPECT().CancelScheduledMessages(ctx, userID).Return(nil)
+		var removed []*mock.Call
+		if policy == accountsvc.DeleteMessages {
+			mockChats.EXPECT().GetChatIDs(ctx, userID).Return(chatIDs, nil)
+			for _, id := range chatIDs {
+				removed = append(removed, mockMsgs.EXPECT().RemoveMessagesBySender(ctx, id, userID).Return(nil).Call)
+			}
+		}
+		mockChats.EXPECT().RemoveUser(ctx, userID).Return(nil).NotBefore(removed...)
+
+		service := accountsvc.NewService(mockUsers, mockChats, mockMsgs, mockHooks, mockExports, policy)
+		if err := service.DeleteAccount(ctx, userID); err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+	}
+}
+
+func TestDeleteAccount_FinishOnRetry(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	chatIDs := []uuid.UUID{uuid.New(), uuid.New()}
+
+	mockUsers := mocks.NewUserService(t)
+	mockChats := mocks.NewChatService(t)
+	mockMsgs := mocks.NewMessageService(t)
+	mockHooks := mocks.NewWebhookService(t)
+	mockExports := mocks.NewExportService(t)
+	mockUsers.EXPECT().DeleteUser(ctx, userID).Return(nil).Twice()
+	mockUsers.EXPECT().GetBots(ctx, userID).Return(nil, nil).Once()
+	mockHooks.EXPECT().UnsubscribeAll(ctx, userID).Return(nil).Twice()
+	mockExports.EXPECT().DeleteExports(ctx, userID).Return(nil).Twice()
+	mockMsgs.EXPECT().CancelScheduledMessages(ctx, userID).Return(nil).Twice()
+	// The user stays in their chats until their messages are gone, so the
+	// retry sees the same chats.
+	mockChats.EXPECT().GetChatIDs(ctx, userID).Return(chatIDs, nil).Twice()
+	mockMsgs.EXPECT().RemoveMessagesBySender(ctx, chatIDs[0], userID).Return(nil).Twice()
+	failed := mockMsgs.EXPECT().RemoveMessagesBySender(ctx, chatIDs[1], userID).Return(errors.New("unavailable")).Once()
+	retried := mockMsgs.EXPECT().RemoveMessagesBySender(ctx, chatIDs[1], userID).Return(nil).Once().NotBefore(failed)
+	mockChats.EXPECT().RemoveUser(ctx, userID).Return(nil).Once().NotBefore(retried)
+
+	service := accountsvc.NewService(mockUsers, mockChats, mockMsgs, mockHooks, mockExports, accountsvc.DeleteMessages)
+	if err := service.DeleteAccount(ctx, userID); err == nil {
+		t.Fatalf("expected error got %v", err)
+	}
+	if err := service.DeleteAccount(ctx, userID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+}
+
+func TestDeleteAccount_DeleteBots(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	botID := uuid.New()
+
+	mockUsers := mocks.NewUserService(t)
+	mockChats := mocks.NewChatService(t)
+	mockMsgs := mocks.NewMessageService(t)
+	mockHooks := mocks.NewWebhookService(t)
+	mockExports := mocks.NewExportService(t)
+	for _, id := range []uuid.UUID{userID, botID} {
+		mockUsers.EXPECT().DeleteUser(ctx, id).Return(nil)
+		mockHooks.EXPECT().UnsubscribeAll(ctx, id).Return(nil)
+		mockExports.EXPECT().DeleteExports(ctx, id).Return(nil)
+		mockMsgs.EXPECT().CancelScheduledMessages(ctx, id).Return(nil)
+		mockChats.EXPECT().RemoveUser(ctx, id).Return(nil)
+	}
+	mockUsers.EXPECT().GetBots(ctx, userID).Return([]user.User{{ID: botID, Type: user.Bot, OwnerID: userID}}, nil)
+	mockUsers.EXPECT().GetBots(ctx, botID).Return(nil, nil)
+
+	service := accountsvc.NewService(mockUsers, mockChats, mockMsgs, mockHooks, mockExports, accountsvc.KeepMessages)
+	if err := service.DeleteAccount(ctx, userID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+}
+
+func TestDeleteAccount_StopWhenProfileDeletionFails(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockUsers := mocks.NewUserService(t)
+	mockUsers.EXPECT().DeleteUser(ctx, userID).Return(errors.New("error"))
+
+	service := accountsvc.NewService(mockUsers, mocks.NewChatService(t), mocks.NewMessageService(t), mocks.NewWebhookService(t), mocks.NewExportService(t), accountsvc.DeleteMessages)
+	if err := service.DeleteAccount(ctx, userID); err == nil {
+		t.Fatalf("expected error got %v", err)
+	}
+}
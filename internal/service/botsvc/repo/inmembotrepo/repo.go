@@ -0,0 +1,89 @@
+package inmembotrepo
+
+import (
+	"context"
+	"github.com/AliUnipal/chat/internal/events"
+	"github.com/AliUnipal/chat/internal/models/message"
+	"github.com/AliUnipal/chat/internal/service/botsvc/repo"
+	"github.com/google/uuid"
+	"slices"
+	"sync"
+)
+
+// maxUpdates is how many updates are kept per bot. Once a bot falls further
+// behind, its oldest updates are dropped.
+const maxUpdates = 1000
+
+func New() *repository {
+	return &repository{
+		updates: make(map[uuid.UUID][]repo.Update),
+		nextIDs: make(map[uuid.UUID]int64),
+	}
+}
+
+// repository is safe for concurrent use, as updates are queued by the
+// services publishing events and fetched by long-polling bots.
+type repository struct {
+	mu      sync.RWMutex
+	updates map[uuid.UUID][]repo.Update
+	nextIDs map[uuid.UUID]int64
+}
+
+func (r *repository) AddUpdate(_ context.Context, botID uuid.UUID, e events.Event) (repo.Update, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextIDs[botID]++
+	u := repo.Update{ID: r.nextIDs[botID], BotID: botID, Event: e}
+	updates := append(r.updates[botID], u)
+	if len(updates) > maxUpdates {
+		updates = slices.Delete(updates, 0, len(updates)-maxUpdates)
+	}
+	r.updates[botID] = updates
+	return u, nil
+}
+
+// GetUpdates returns up to limit of the bot's updates starting at offset,
+// oldest first.
+func (r *repository) GetUpdates(_ context.Context, botID uuid.UUID, offset int64, limit int) ([]repo.Update, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var us []repo.Update
+	for _, u := range r.updates[botID] {
+		if len(us) == limit {
+			break
+		}
+		if u.ID >= offset {
+			us = append(us, u)
+		}
+	}
+	return us, nil
+}
+
+// RedactUpdates strips every queued update about the message down to the
+// message's IDs.
+func (r *repository) RedactUpdates(_ context.Context, messageID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, us := range r.updates {
+		for i, u := range us {
+			if m := u.Event.Message; m != nil && m.ID == messageID {
+				// The event is shared with other subscribers, so the message
+				// is replaced rather than changed.
+				us[i].Event.Message = &message.Message{ID: m.ID, SenderID: m.SenderID, ChatID: m.ChatID}
+			}
+		}
+	}
+	return nil
+}
+
+// DeleteUpdates removes the bot's updates before the given ID.
+func (r *repository) DeleteUpdates(_ context.Context, botID uuid.UUID, before int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.updates[botID] = slices.DeleteFunc(r.updates[botID], func(u repo.Update) bool { return u.ID < before })
+	return nil
+}
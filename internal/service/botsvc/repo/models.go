@@ -0,0 +1,14 @@
+package repo
+
+import (
+	"github.com/AliUnipal/chat/internal/events"
+	"github.com/google/uuid"
+)
+
+// Update is an event waiting for a bot to fetch it. IDs grow by one with
+// every update queued for the same bot.
+type Update struct {
+	ID    int64
+	BotID uuid.UUID
+	Event events.Event
+}
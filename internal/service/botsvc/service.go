@@ -0,0 +1,251 @@
+// Package botsvc is the API bots use: they authenticate with their token,
+// receive the events of their chats by long polling or on a webhook, and send
+// messages.
+package botsvc
+
+import (
+	"context"
+	"errors"
+	"github.com/AliUnipal/chat/internal/events"
+	"github.com/AliUnipal/chat/internal/models/message"
+	"github.com/AliUnipal/chat/internal/models/user"
+	"github.com/AliUnipal/chat/internal/models/webhook"
+	"github.com/AliUnipal/chat/internal/service/botsvc/repo"
+	"github.com/AliUnipal/chat/internal/service/msgsvc"
+	"github.com/AliUnipal/chat/internal/service/webhooksvc"
+	"github.com/google/uuid"
+	"sync"
+	"time"
+)
+
+// maxBatch is the most updates GetUpdates returns at once.
+const maxBatch = 100
+
+var ErrWebhookActive = errors.New("bot updates are sent to its webhook")
+
+// Update is an event of one of the bot's chats. Passing an update's ID plus
+// one as the offset of the next GetUpdates call confirms it.
+type Update struct {
+	ID    int64              `json:"update_id"`
+	Event webhooksvc.Payload `json:"event"`
+}
+
+type botService interface {
+	SendMessage(ctx context.Context, token string, chatID uuid.UUID, content []byte, contentType message.ContentType) (uuid.UUID, error)
+	GetUpdates(ctx context.Context, token string, offset int64, timeout time.Duration) ([]Update, error)
+	SetWebhook(ctx context.Context, token, url string) (webhook.Subscription, error)
+	DeleteWebhook(ctx context.Context, token string) error
+	HandleEvent(ctx context.Context, e events.Event) error
+}
+
+type botRepository interface {
+	AddUpdate(ctx context.Context, botID uuid.UUID, e events.Event) (repo.Update, error)
+	GetUpdates(ctx context.Context, botID uuid.UUID, offset int64, limit int) ([]repo.Update, error)
+	DeleteUpdates(ctx context.Context, botID uuid.UUID, before int64) error
+	RedactUpdates(ctx context.Context, messageID uuid.UUID) error
+}
+
+type userService interface {
+	AuthenticateBot(ctx context.Context, token string) (uuid.UUID, error)
+	GetUser(ctx context.Context, id uuid.UUID) (user.User, error)
+}
+
+type messageService interface {
+	CreateMessage(ctx context.Context, in msgsvc.MessageInput) (uuid.UUID, error)
+}
+
+type webhookService interface {
+	Subscribe(ctx context.Context, in webhooksvc.CreateSubscriptionInput) (webhook.Subscription, error)
+	GetSubscriptions(ctx context.Context, ownerID uuid.UUID) ([]webhook.Subscription, error)
+	Unsubscribe(ctx context.Context, ownerID, subscriptionID uuid.UUID) error
+}
+
+type service struct {
+	repo  botRepository
+	users userService
+	msgs  messageService
+	hooks webhookService
+
+	mu sync.Mutex
+	// wakeups holds a channel per polling bot that is closed when an update
+	// is queued for it.
+	wakeups map[uuid.UUID]chan struct{}
+}
+
+var _ botService = (*service)(nil)
+
+func NewService(repo botRepository, users userService, msgs messageService, hooks webhookService) *service {
+	return &service{
+		repo:    repo,
+		users:   users,
+		msgs:    msgs,
+		hooks:   hooks,
+		wakeups: make(map[uuid.UUID]chan struct{}),
+	}
+}
+
+// SendMessage sends a message from the bot to one of its chats.
+func (s *service) SendMessage(ctx context.Context, token string, chatID uuid.UUID, content []byte, contentType message.ContentType) (uuid.UUID, error) {
+	botID, err := s.users.AuthenticateBot(ctx, token)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return s.msgs.CreateMessage(ctx, msgsvc.MessageInput{
+		SenderID:    botID,
+		ChatID:      chatID,
+		Content:     content,
+		ContentType: contentType,
+	})
+}
+
+// GetUpdates confirms the updates before offset and returns the ones after
+// it. When there are none it waits up to timeout for the next one.
+func (s *service) GetUpdates(ctx context.Context, token string, offset int64, timeout time.Duration) ([]Update, error) {
+	botID, err := s.users.AuthenticateBot(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	subs, err := s.hooks.GetSubscriptions(ctx, botID)
+	if err != nil {
+		return nil, err
+	}
+	if len(subs) > 0 {
+		return nil, ErrWebhookActive
+	}
+	if err := s.repo.DeleteUpdates(ctx, botID, offset); err != nil {
+		return nil, err
+	}
+
+	deadline := time.NewTimer(max(timeout, 0))
+	defer deadline.Stop()
+	for {
+		wakeup := s.wakeup(botID)
+		us, err := s.repo.GetUpdates(ctx, botID, offset, maxBatch)
+		if err != nil {
+			return nil, err
+		}
+		if len(us) > 0 {
+			return toUpdates(us), nil
+		}
+
+		select {
+		case <-wakeup:
+		case <-deadline.C:
+			return nil, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// SetWebhook sends the bot's updates to url from now on instead of queueing
+// them for GetUpdates. The returned subscription carries the secret the
+// deliveries are signed with.
+func (s *service) SetWebhook(ctx context.Context, token, url string) (webhook.Subscription, error) {
+	botID, err := s.users.AuthenticateBot(ctx, token)
+	if err != nil {
+		return webhook.Subscription{}, err
+	}
+	if err := s.unsubscribe(ctx, botID); err != nil {
+		return webhook.Subscription{}, err
+	}
+
+	return s.hooks.Subscribe(ctx, webhooksvc.CreateSubscriptionInput{OwnerID: botID, URL: url})
+}
+
+// DeleteWebhook goes back to queueing the bot's updates for GetUpdates.
+func (s *service) DeleteWebhook(ctx context.Context, token string) error {
+	botID, err := s.users.AuthenticateBot(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	return s.unsubscribe(ctx, botID)
+}
+
+// HandleEvent queues the event for every bot among its recipients that has no
+// webhook; the webhook service delivers it to the others. Bots are not sent
+// their own messages. The content of a deleted message is redacted from the
+// updates still queued. It is meant to be subscribed to the event bus.
+func (s *service) HandleEvent(ctx context.Context, e events.Event) error {
+	var errs []error
+	if e.Type == events.MessageDeleted && e.Message != nil {
+		errs = append(errs, s.repo.RedactUpdates(ctx, e.Message.ID))
+	}
+	for _, id := range e.Recipients {
+		if e.Type == events.MessageCreated && id == e.UserID {
+			continue
+		}
+		u, err := s.users.GetUser(ctx, id)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if u.Type != user.Bot {
+			continue
+		}
+		subs, err := s.hooks.GetSubscriptions(ctx, id)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if len(subs) > 0 {
+			continue
+		}
+
+		if _, err := s.repo.AddUpdate(ctx, id, e); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		s.notify(id)
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *service) unsubscribe(ctx context.Context, botID uuid.UUID) error {
+	subs, err := s.hooks.GetSubscriptions(ctx, botID)
+	if err != nil {
+		return err
+	}
+	for _, sub := range subs {
+		if err := s.hooks.Unsubscribe(ctx, botID, sub.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// wakeup returns the channel that is closed when the next update is queued
+// for the bot.
+func (s *service) wakeup(botID uuid.UUID) <-chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ch, ok := s.wakeups[botID]
+	if !ok {
+		ch = make(chan struct{})
+		s.wakeups[botID] = ch
+	}
+	return ch
+}
+
+func (s *service) notify(botID uuid.UUID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if ch, ok := s.wakeups[botID]; ok {
+		close(ch)
+		delete(s.wakeups, botID)
+	}
+}
+
+func toUpdates(us []repo.Update) []Update {
+	r := make([]Update, len(us))
+	for i, u := range us {
+		r[i] = Update{ID: u.ID, Event: webhooksvc.NewPayload(u.Event)}
+	}
+	return r
+}
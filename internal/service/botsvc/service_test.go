@@ -0,0 +1,220 @@
+package botsvc_test
+
+import (
+	"context"
+	"errors"
+	"github.com/AliUnipal/chat/internal/events"
+	"github.com/AliUnipal/chat/internal/models/message"
+	"github.com/AliUnipal/chat/internal/models/user"
+	"github.com/AliUnipal/chat/internal/models/webhook"
+	"github.com/AliUnipal/chat/internal/service/botsvc"
+	"github.com/AliUnipal/chat/internal/service/botsvc/mocks"
+	"github.com/AliUnipal/chat/internal/service/botsvc/repo/inmembotrepo"
+	"github.com/AliUnipal/chat/internal/service/msgsvc"
+	"github.com/AliUnipal/chat/internal/service/usersvc"
+	"github.com/google/uuid"
+	"testing"
+	"time"
+)
+
+const token = "bot-token"
+
+func messageEvent(chatID, senderID uuid.UUID, text string, recipients ...uuid.UUID) events.Event {
+	return events.Event{
+		ID:         uuid.New(),
+		Type:       events.MessageCreated,
+		ChatID:     chatID,
+		UserID:     senderID,
+		Recipients: recipients,
+		Message: &message.Message{
+			ID:          uuid.New(),
+			SenderID:    senderID,
+			Content:     []byte(text),
+			ContentType: message.TextContentType,
+			Timestamp:   time.Now().UTC(),
+		},
+		Timestamp: time.Now().UTC(),
+	}
+}
+
+func TestSendMessage_SendAsBot(t *testing.T) {
+	ctx := context.Background()
+	botID := uuid.New()
+	chatID := uuid.New()
+	msgID := uuid.New()
+	userMockService := mocks.NewUserService(t)
+	msgMockService := mocks.NewMessageService(t)
+	userMockService.EXPECT().AuthenticateBot(ctx, token).Return(botID, nil)
+	msgMockService.EXPECT().CreateMessage(ctx, msgsvc.MessageInput{SenderID: botID, ChatID: chatID, Content: []byte("hi"), ContentType: message.TextContentType}).Return(msgID, nil)
+
+	service := botsvc.NewService(inmembotrepo.New(), userMockService, msgMockService, mocks.NewWebhookService(t))
+	id, err := service.SendMessage(ctx, token, chatID, []byte("hi"), message.TextContentType)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if id != msgID {
+		t.Fatalf("expected %v got %v", msgID, id)
+	}
+}
+
+func TestSendMessage_ReturnErrorOnInvalidToken(t *testing.T) {
+	ctx := context.Background()
+	userMockService := mocks.NewUserService(t)
+	userMockService.EXPECT().AuthenticateBot(ctx, token).Return(uuid.Nil, usersvc.ErrInvalidToken)
+
+	service := botsvc.NewService(inmembotrepo.New(), userMockService, mocks.NewMessageService(t), mocks.NewWebhookService(t))
+	if _, err := service.SendMessage(ctx, token, uuid.New(), []byte("hi"), message.TextContentType); !errors.Is(err, usersvc.ErrInvalidToken) {
+		t.Fatalf("expected ErrInvalidToken got %v", err)
+	}
+}
+
+func TestHandleEvent_QueueForPollingBots(t *testing.T) {
+	ctx := context.Background()
+	personID := uuid.New()
+	botID := uuid.New()
+	hookedBotID := uuid.New()
+	chatID := uuid.New()
+	userMockService := mocks.NewUserService(t)
+	hookMockService := mocks.NewWebhookService(t)
+	userMockService.EXPECT().AuthenticateBot(ctx, token).Return(botID, nil)
+	userMockService.EXPECT().GetUser(ctx, personID).Return(user.User{ID: personID}, nil)
+	userMockService.EXPECT().GetUser(ctx, botID).Return(user.User{ID: botID, Type: user.Bot}, nil)
+	userMockService.EXPECT().GetUser(ctx, hookedBotID).Return(user.User{ID: hookedBotID, Type: user.Bot}, nil)
+	hookMockService.EXPECT().GetSubscriptions(ctx, botID).Return(nil, nil)
+	hookMockService.EXPECT().GetSubscriptions(ctx, hookedBotID).Return([]webhook.Subscription{{ID: uuid.New(), OwnerID: hookedBotID}}, nil)
+
+	service := botsvc.NewService(inmembotrepo.New(), userMockService, mocks.NewMessageService(t), hookMockService)
+	if err := service.HandleEvent(ctx, messageEvent(chatID, personID, "hello", personID, botID, hookedBotID)); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if err := service.HandleEvent(ctx, messageEvent(chatID, botID, "own message", personID, botID, hookedBotID)); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	updates, err := service.GetUpdates(ctx, token, 0, 0)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(updates) != 1 || updates[0].Event.Message == nil || updates[0].Event.Message.Text != "hello" {
+		t.Fatalf("expected only the person's message got %+v", updates)
+	}
+
+	// Confirming the update removes it.
+	updates, err = service.GetUpdates(ctx, token, updates[0].ID+1, 0)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(updates) != 0 {
+		t.Fatalf("expected no updates got %+v", updates)
+	}
+}
+
+func TestHandleEvent_RedactDeletedMessage(t *testing.T) {
+	ctx := context.Background()
+	personID := uuid.New()
+	botID := uuid.New()
+	chatID := uuid.New()
+	userMockService := mocks.NewUserService(t)
+	hookMockService := mocks.NewWebhookService(t)
+	userMockService.EXPECT().AuthenticateBot(ctx, token).Return(botID, nil)
+	userMockService.EXPECT().GetUser(ctx, personID).Return(user.User{ID: personID}, nil)
+	userMockService.EXPECT().GetUser(ctx, botID).Return(user.User{ID: botID, Type: user.Bot}, nil)
+	hookMockService.EXPECT().GetSubscriptions(ctx, botID).Return(nil, nil)
+
+	service := botsvc.NewService(inmembotrepo.New(), userMockService, mocks.NewMessageService(t), hookMockService)
+	created := messageEvent(chatID, personID, "gone soon", personID, botID)
+	if err := service.HandleEvent(ctx, created); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	m := created.Message
+	deleted := events.Event{
+		ID:         uuid.New(),
+		Type:       events.MessageDeleted,
+		ChatID:     chatID,
+		UserID:     personID,
+		Recipients: []uuid.UUID{personID, botID},
+		Message:    &message.Message{ID: m.ID, SenderID: m.SenderID, ChatID: chatID, ExpiresAt: time.Now()},
+		Timestamp:  time.Now().UTC(),
+	}
+	if err := service.HandleEvent(ctx, deleted); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	updates, err := service.GetUpdates(ctx, token, 0, 0)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(updates) != 2 || updates[0].Event.Message == nil || updates[0].Event.Message.ID != m.ID || updates[0].Event.Message.Text != "" {
+		t.Fatalf("expected the queued message redacted got %+v", updates)
+	}
+	if string(m.Content) != "gone soon" {
+		t.Fatalf("expected the published event left alone got %q", m.Content)
+	}
+}
+
+func TestGetUpdates_WaitForNextUpdate(t *testing.T) {
+	ctx := context.Background()
+	personID := uuid.New()
+	botID := uuid.New()
+	userMockService := mocks.NewUserService(t)
+	hookMockService := mocks.NewWebhookService(t)
+	userMockService.EXPECT().AuthenticateBot(ctx, token).Return(botID, nil)
+	userMockService.EXPECT().GetUser(ctx, botID).Return(user.User{ID: botID, Type: user.Bot}, nil)
+	hookMockService.EXPECT().GetSubscriptions(ctx, botID).Return(nil, nil)
+	service := botsvc.NewService(inmembotrepo.New(), userMockService, mocks.NewMessageService(t), hookMockService)
+
+	done := make(chan []botsvc.Update)
+	go func() {
+		updates, err := service.GetUpdates(ctx, token, 0, 10*time.Second)
+		if err != nil {
+			t.Errorf("expected no error got %v", err)
+		}
+		done <- updates
+	}()
+	// Let the poll start waiting; the update is picked up either way.
+	time.Sleep(10 * time.Millisecond)
+	if err := service.HandleEvent(ctx, messageEvent(uuid.New(), personID, "hello", botID)); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	select {
+	case updates := <-done:
+		if len(updates) != 1 {
+			t.Fatalf("expected 1 update got %+v", updates)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected the poll to return on the new update")
+	}
+}
+
+func TestGetUpdates_ReturnNothingAfterTimeout(t *testing.T) {
+	ctx := context.Background()
+	botID := uuid.New()
+	userMockService := mocks.NewUserService(t)
+	hookMockService := mocks.NewWebhookService(t)
+	userMockService.EXPECT().AuthenticateBot(ctx, token).Return(botID, nil)
+	hookMockService.EXPECT().GetSubscriptions(ctx, botID).Return(nil, nil)
+
+	service := botsvc.NewService(inmembotrepo.New(), userMockService, mocks.NewMessageService(t), hookMockService)
+	updates, err := service.GetUpdates(ctx, token, 0, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(updates) != 0 {
+		t.Fatalf("expected no updates got %+v", updates)
+	}
+}
+
+func TestGetUpdates_ReturnErrorWithWebhook(t *testing.T) {
+	ctx := context.Background()
+	botID := uuid.New()
+	userMockService := mocks.NewUserService(t)
+	hookMockService := mocks.NewWebhookService(t)
+	userMockService.EXPECT().AuthenticateBot(ctx, token).Return(botID, nil)
+	hookMockService.EXPECT().GetSubscriptions(ctx, botID).Return([]webhook.Subscription{{ID: uuid.New(), OwnerID: botID}}, nil)
+
+	service := botsvc.NewService(inmembotrepo.New(), userMockService, mocks.NewMessageService(t), hookMockService)
+	if _, err := service.GetUpdates(ctx, token, 0, 0); !errors.Is(err, botsvc.ErrWebhookActive) {
+		t.Fatalf("expected ErrWebhookActive got %v", err)
+	}
+}
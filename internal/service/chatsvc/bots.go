@@ -0,0 +1,164 @@
+package chatsvc
+
+import (
+	"context"
+	"errors"
+	"github.com/AliUnipal/chat/internal/events"
+	"github.com/AliUnipal/chat/internal/models/user"
+	"github.com/AliUnipal/chat/internal/service/chatsvc/repo"
+	"github.com/google/uuid"
+	"slices"
+	"time"
+)
+
+var (
+	ErrNotABot            = errors.New("user is not a bot")
+	ErrBotInEncryptedChat = errors.New("bots cannot join end-to-end encrypted chats")
+	ErrInviteNotFound     = repo.ErrInviteNotFound
+)
+
+// AddBot lets a member of the chat invite a bot into it. The bot then gets
+// the chat's events and can write to it like any other member, so when the
+// chat is with someone else they are asked first: the bot only joins once
+// they call AcceptBot, and either of them can call DeclineBot instead.
+func (s *service) AddBot(ctx context.Context, chatID, userID, botID uuid.UUID) error {
+	c, err := s.chatRepo.GetChat(ctx, chatID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.chatRepo.GetMember(ctx, chatID, userID); err != nil {
+		return err
+	}
+	inviter, err := s.users.GetUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if inviter.Type == user.Bot {
+		return errors.New("only people can add bots to chats")
+	}
+	bot, err := s.users.GetUser(ctx, botID)
+	if err != nil {
+		return err
+	}
+	if bot.Type != user.Bot {
+		return ErrNotABot
+	}
+	if c.Encrypted {
+		return ErrBotInEncryptedChat
+	}
+	if err := s.users.CanStartChat(ctx, userID, botID); err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(c.Participants, func(p repo.User) bool { return p.ID != userID && p.Type != user.Bot }) {
+		return s.addBot(ctx, c, botID)
+	}
+
+	if err := s.chatRepo.InviteBot(ctx, chatID, repo.BotInvite{BotID: botID, InviterID: userID, InvitedAt: time.Now().UTC()}); err != nil {
+		return err
+	}
+	s.events.Publish(ctx, events.Event{
+		Type:       events.BotInvited,
+		ChatID:     chatID,
+		UserID:     botID,
+		Recipients: participantIDs(c),
+	})
+	return nil
+}
+
+// AcceptBot lets the bot another member invited join the chat.
+func (s *service) AcceptBot(ctx context.Context, chatID, userID, botID uuid.UUID) error {
+	c, err := s.botInvite(ctx, chatID, userID, botID)
+	if err != nil {
+		return err
+	}
+	if i := slices.IndexFunc(c.BotInvites, func(i repo.BotInvite) bool { return i.BotID == botID }); c.BotInvites[i].InviterID == userID {
+		return errors.New("bots are accepted by the other person in the chat")
+	}
+	if _, err := s.chatRepo.TakeBotInvite(ctx, chatID, botID); err != nil {
+		return err
+	}
+	// The chat may have been encrypted since the bot was invited.
+	if c.Encrypted {
+		return ErrBotInEncryptedChat
+	}
+
+	return s.addBot(ctx, c, botID)
+}
+
+// DeclineBot drops the invitation of a bot to the chat. The person asked can
+// decline it, and the one who invited the bot can take the invitation back.
+func (s *service) DeclineBot(ctx context.Context, chatID, userID, botID uuid.UUID) error {
+	if _, err := s.botInvite(ctx, chatID, userID, botID); err != nil {
+		return err
+	}
+	_, err := s.chatRepo.TakeBotInvite(ctx, chatID, botID)
+	return err
+}
+
+// botInvite returns the chat when userID is a person in it and botID is
+// invited to it.
+func (s *service) botInvite(ctx context.Context, chatID, userID, botID uuid.UUID) (repo.Chat, error) {
+	c, err := s.chatRepo.GetChat(ctx, chatID)
+	if err != nil {
+		return repo.Chat{}, err
+	}
+	if _, err := s.chatRepo.GetMember(ctx, chatID, userID); err != nil {
+		return repo.Chat{}, err
+	}
+	if slices.ContainsFunc(c.Participants, func(p repo.User) bool { return p.ID == userID && p.Type == user.Bot }) {
+		return repo.Chat{}, errors.New("only people can answer bot invitations")
+	}
+	if !slices.ContainsFunc(c.BotInvites, func(i repo.BotInvite) bool { return i.BotID == botID }) {
+		return repo.Chat{}, repo.ErrInviteNotFound
+	}
+
+	return c, nil
+}
+
+func (s *service) addBot(ctx context.Context, c repo.Chat, botID uuid.UUID) error {
+	if err := s.chatRepo.AddMember(ctx, c.ID, botID); err != nil {
+		return err
+	}
+
+	s.events.Publish(ctx, events.Event{
+		Type:       events.MemberAdded,
+		ChatID:     c.ID,
+		UserID:     botID,
+		Recipients: append(participantIDs(c), botID),
+	})
+	return nil
+}
+
+// RemoveBot takes a bot that was added to the chat back out of it. Any member
+// can remove it, and the bot can leave on its own. A bot the chat was started
+// with cannot be removed; the chat is deleted instead.
+func (s *service) RemoveBot(ctx context.Context, chatID, userID, botID uuid.UUID) error {
+	c, err := s.chatRepo.GetChat(ctx, chatID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.chatRepo.GetMember(ctx, chatID, userID); err != nil {
+		return err
+	}
+	i := slices.IndexFunc(c.Participants, func(p repo.User) bool { return p.ID == botID })
+	if i < 0 {
+		return repo.ErrMemberNotFound
+	}
+	if c.Participants[i].Type != user.Bot {
+		return ErrNotABot
+	}
+	if i < 2 {
+		return errors.New("bot started the chat and cannot be removed from it")
+	}
+	if err := s.chatRepo.RemoveParticipant(ctx, chatID, botID); err != nil {
+		return err
+	}
+
+	s.events.Publish(ctx, events.Event{
+		Type:       events.MemberRemoved,
+		ChatID:     chatID,
+		UserID:     botID,
+		Recipients: participantIDs(c),
+	})
+	return nil
+}
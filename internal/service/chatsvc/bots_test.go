@@ -0,0 +1,211 @@
+package chatsvc_test
+
+import (
+	"context"
+	"errors"
+	"github.com/AliUnipal/chat/internal/events"
+	"github.com/AliUnipal/chat/internal/models/user"
+	"github.com/AliUnipal/chat/internal/service/chatsvc"
+	"github.com/AliUnipal/chat/internal/service/chatsvc/mocks"
+	"github.com/AliUnipal/chat/internal/service/chatsvc/repo"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"slices"
+	"testing"
+)
+
+func TestAddBot(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	otherID := uuid.New()
+	otherBotID := uuid.New()
+	botID := uuid.New()
+
+	tests := []struct {
+		name      string
+		other     repo.User
+		botType   user.Type
+		encrypted bool
+		expected  error
+		event     events.Type
+	}{
+		{name: "invite", other: repo.User{ID: otherID}, botType: user.Bot, event: events.BotInvited},
+		{name: "chat with a bot", other: repo.User{ID: otherBotID, Type: user.Bot}, botType: user.Bot, event: events.MemberAdded},
+		{name: "person", other: repo.User{ID: otherID}, botType: user.Human, expected: chatsvc.ErrNotABot},
+		{name: "encrypted chat", other: repo.User{ID: otherID}, botType: user.Bot, encrypted: true, expected: chatsvc.ErrBotInEncryptedChat},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := repo.Chat{ID: uuid.New(), Participants: []repo.User{{ID: userID}, tt.other}, Encrypted: tt.encrypted}
+			chatMockRepo := mocks.NewChatRepository(t)
+			msgMockRepo := mocks.NewMessageRepository(t)
+			userMockService := mocks.NewUserService(t)
+			chatMockRepo.EXPECT().GetChat(ctx, c.ID).Return(c, nil)
+			chatMockRepo.EXPECT().GetMember(ctx, c.ID, userID).Return(repo.Member{ChatID: c.ID, UserID: userID}, nil)
+			userMockService.EXPECT().GetUser(ctx, userID).Return(user.User{ID: userID}, nil)
+			userMockService.EXPECT().GetUser(ctx, botID).Return(user.User{ID: botID, Type: tt.botType}, nil)
+			if tt.expected == nil {
+				userMockService.EXPECT().CanStartChat(ctx, userID, botID).Return(nil)
+			}
+			switch tt.event {
+			case events.BotInvited:
+				chatMockRepo.EXPECT().InviteBot(ctx, c.ID, mock.MatchedBy(func(i repo.BotInvite) bool {
+					return i.BotID == botID && i.InviterID == userID
+				})).Return(nil)
+			case events.MemberAdded:
+				chatMockRepo.EXPECT().AddMember(ctx, c.ID, botID).Return(nil)
+			}
+
+			bus := events.NewBus()
+			var published []events.Event
+			bus.Subscribe(func(_ context.Context, e events.Event) { published = append(published, e) })
+			service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, bus)
+			if err := service.AddBot(ctx, c.ID, userID, botID); !errors.Is(err, tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, err)
+			}
+			if tt.expected != nil {
+				if len(published) != 0 {
+					t.Fatalf("expected no events got %+v", published)
+				}
+				return
+			}
+			if len(published) != 1 || published[0].Type != tt.event || published[0].UserID != botID {
+				t.Fatalf("expected a %s event for the bot got %+v", tt.event, published)
+			}
+			// The bot only hears about the chat once it is in it.
+			if slices.Contains(published[0].Recipients, botID) != (tt.event == events.MemberAdded) {
+				t.Fatalf("expected the bot told only when it joins got %+v", published[0].Recipients)
+			}
+		})
+	}
+}
+
+func TestAcceptBot(t *testing.T) {
+	ctx := context.Background()
+	inviterID := uuid.New()
+	otherID := uuid.New()
+	botID := uuid.New()
+	invites := []repo.BotInvite{{BotID: botID, InviterID: inviterID}}
+
+	tests := []struct {
+		name      string
+		userID    uuid.UUID
+		invites   []repo.BotInvite
+		encrypted bool
+		expected  error
+	}{
+		{name: "accept", userID: otherID, invites: invites},
+		{name: "not invited", userID: otherID, expected: chatsvc.ErrInviteNotFound},
+		{name: "encrypted since", userID: otherID, invites: invites, encrypted: true, expected: chatsvc.ErrBotInEncryptedChat},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := repo.Chat{ID: uuid.New(), Participants: []repo.User{{ID: inviterID}, {ID: otherID}}, BotInvites: tt.invites, Encrypted: tt.encrypted}
+			chatMockRepo := mocks.NewChatRepository(t)
+			chatMockRepo.EXPECT().GetChat(ctx, c.ID).Return(c, nil)
+			chatMockRepo.EXPECT().GetMember(ctx, c.ID, tt.userID).Return(repo.Member{ChatID: c.ID, UserID: tt.userID}, nil)
+			if tt.invites != nil {
+				chatMockRepo.EXPECT().TakeBotInvite(ctx, c.ID, botID).Return(tt.invites[0], nil)
+			}
+			if tt.expected == nil {
+				chatMockRepo.EXPECT().AddMember(ctx, c.ID, botID).Return(nil)
+			}
+
+			bus := events.NewBus()
+			var published []events.Event
+			bus.Subscribe(func(_ context.Context, e events.Event) { published = append(published, e) })
+			service := chatsvc.NewService(chatMockRepo, mocks.NewMessageRepository(t), mocks.NewUserService(t), bus)
+			if err := service.AcceptBot(ctx, c.ID, tt.userID, botID); !errors.Is(err, tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, err)
+			}
+			if (tt.expected == nil) != (len(published) == 1 && published[0].Type == events.MemberAdded && len(published[0].Recipients) == 3) {
+				t.Fatalf("expected a member added event for all three members only on success got %+v", published)
+			}
+		})
+	}
+
+	t.Run("by the inviter", func(t *testing.T) {
+		c := repo.Chat{ID: uuid.New(), Participants: []repo.User{{ID: inviterID}, {ID: otherID}}, BotInvites: invites}
+		chatMockRepo := mocks.NewChatRepository(t)
+		chatMockRepo.EXPECT().GetChat(ctx, c.ID).Return(c, nil)
+		chatMockRepo.EXPECT().GetMember(ctx, c.ID, inviterID).Return(repo.Member{ChatID: c.ID, UserID: inviterID}, nil)
+
+		service := chatsvc.NewService(chatMockRepo, mocks.NewMessageRepository(t), mocks.NewUserService(t), events.NewBus())
+		if err := service.AcceptBot(ctx, c.ID, inviterID, botID); err == nil {
+			t.Fatalf("expected error got %v", err)
+		}
+	})
+}
+
+func TestDeclineBot(t *testing.T) {
+	ctx := context.Background()
+	inviterID := uuid.New()
+	otherID := uuid.New()
+	botID := uuid.New()
+	invite := repo.BotInvite{BotID: botID, InviterID: inviterID}
+
+	for _, userID := range []uuid.UUID{inviterID, otherID} {
+		c := repo.Chat{ID: uuid.New(), Participants: []repo.User{{ID: inviterID}, {ID: otherID}}, BotInvites: []repo.BotInvite{invite}}
+		chatMockRepo := mocks.NewChatRepository(t)
+		chatMockRepo.EXPECT().GetChat(ctx, c.ID).Return(c, nil)
+		chatMockRepo.EXPECT().GetMember(ctx, c.ID, userID).Return(repo.Member{ChatID: c.ID, UserID: userID}, nil)
+		chatMockRepo.EXPECT().TakeBotInvite(ctx, c.ID, botID).Return(invite, nil)
+
+		service := chatsvc.NewService(chatMockRepo, mocks.NewMessageRepository(t), mocks.NewUserService(t), events.NewBus())
+		if err := service.DeclineBot(ctx, c.ID, userID, botID); err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+	}
+}
+
+func TestRemoveBot(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	otherID := uuid.New()
+	botID := uuid.New()
+
+	tests := []struct {
+		name         string
+		participants []repo.User
+		expected     error
+	}{
+		{name: "added bot", participants: []repo.User{{ID: userID}, {ID: otherID}, {ID: botID, Type: user.Bot}}},
+		{name: "person", participants: []repo.User{{ID: userID}, {ID: otherID}, {ID: botID}}, expected: chatsvc.ErrNotABot},
+		{name: "not in chat", participants: []repo.User{{ID: userID}, {ID: otherID}}, expected: repo.ErrMemberNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := repo.Chat{ID: uuid.New(), Participants: tt.participants}
+			chatMockRepo := mocks.NewChatRepository(t)
+			msgMockRepo := mocks.NewMessageRepository(t)
+			userMockService := mocks.NewUserService(t)
+			chatMockRepo.EXPECT().GetChat(ctx, c.ID).Return(c, nil)
+			chatMockRepo.EXPECT().GetMember(ctx, c.ID, userID).Return(repo.Member{ChatID: c.ID, UserID: userID}, nil)
+			if tt.expected == nil {
+				chatMockRepo.EXPECT().RemoveParticipant(ctx, c.ID, botID).Return(nil)
+			}
+
+			service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, events.NewBus())
+			if err := service.RemoveBot(ctx, c.ID, userID, botID); !errors.Is(err, tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestRemoveBot_ReturnErrorForDirectChat(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	botID := uuid.New()
+	c := repo.Chat{ID: uuid.New(), Participants: []repo.User{{ID: userID}, {ID: botID, Type: user.Bot}}}
+	chatMockRepo := mocks.NewChatRepository(t)
+	msgMockRepo := mocks.NewMessageRepository(t)
+	userMockService := mocks.NewUserService(t)
+	chatMockRepo.EXPECT().GetChat(ctx, c.ID).Return(c, nil)
+	chatMockRepo.EXPECT().GetMember(ctx, c.ID, userID).Return(repo.Member{ChatID: c.ID, UserID: userID}, nil)
+
+	service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, events.NewBus())
+	if err := service.RemoveBot(ctx, c.ID, userID, botID); err == nil {
+		t.Fatalf("expected error got %v", err)
+	}
+}
@@ -0,0 +1,165 @@
+package chatsvc
+
+import (
+	"context"
+	"errors"
+	"github.com/AliUnipal/chat/internal/events"
+	"github.com/AliUnipal/chat/internal/models/user"
+	"github.com/AliUnipal/chat/internal/service/chatsvc/repo"
+	msgrepo "github.com/AliUnipal/chat/internal/service/msgsvc/repo"
+	"github.com/google/uuid"
+	"slices"
+	"time"
+)
+
+// DeleteChatForMe hides the chat and its history up to now from the user only.
+// The chat comes back with the messages sent after the deletion as soon as a
+// new one arrives. Once every member has deleted the chat, the history none of
+// them can see any more is purged.
+func (s *service) DeleteChatForMe(ctx context.Context, chatID, userID uuid.UUID) error {
+	m, err := s.chatRepo.GetMember(ctx, chatID, userID)
+	if err != nil {
+		return err
+	}
+	m.ClearedAt = time.Now().UTC()
+	m.ArchivedAt = time.Time{}
+	if m.PinPosition > 0 {
+		err = s.unpin(ctx, m)
+	} else {
+		err = s.chatRepo.UpdateMember(ctx, m)
+	}
+	if err != nil {
+		return err
+	}
+
+	members, err := s.chatRepo.GetMembers(ctx, chatID)
+	if err != nil {
+		return err
+	}
+	var clearedAt time.Time
+	for _, m := range members {
+		if m.ClearedAt.IsZero() {
+			return nil
+		}
+		if clearedAt.IsZero() || m.ClearedAt.Before(clearedAt) {
+			clearedAt = m.ClearedAt
+		}
+	}
+
+	return s.msgRepo.DeleteMessagesBefore(ctx, chatID, clearedAt)
+}
+
+// DeleteChatForEveryone removes a direct chat and all of its messages for both
+// participants.
+func (s *service) DeleteChatForEveryone(ctx context.Context, chatID, userID uuid.UUID) error {
+	c, err := s.chatRepo.GetChat(ctx, chatID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.chatRepo.GetMember(ctx, chatID, userID); err != nil {
+		return err
+	}
+	people := slices.DeleteFunc(slices.Clone(c.Participants), func(p repo.User) bool { return p.Type == user.Bot })
+	if len(people) > 2 {
+		return errors.New("only direct chats can be deleted for everyone")
+	}
+
+	// Messages go first so that a failed purge can be retried while the chat
+	// still exists.
+	if err := s.msgRepo.DeleteMessages(ctx, chatID); err != nil {
+		return err
+	}
+	if err := s.chatRepo.DeleteChat(ctx, chatID); err != nil {
+		return err
+	}
+
+	s.events.Publish(ctx, events.Event{
+		Type:       events.ChatDeleted,
+		ChatID:     chatID,
+		UserID:     userID,
+		Recipients: participantIDs(c),
+	})
+	return nil
+}
+
+// GetChatIDs returns the IDs of every chat the user belongs to, including the
+// ones they deleted for themselves.
+func (s *service) GetChatIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	chats, err := s.chatRepo.GetChatsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, len(chats))
+	for i, c := range chats {
+		ids[i] = c.ID
+	}
+	return ids, nil
+}
+
+// RemoveUser takes a deleted user out of every chat they belong to. The other
+// participants keep the chat, now showing the user's anonymized profile,
+// while chats nobody else is left in are deleted along with their messages.
+// It only acts on the chats the user is still in, so it can be run again
+// after failing part way.
+func (s *service) RemoveUser(ctx context.Context, userID uuid.UUID) error {
+	chats, err := s.chatRepo.GetChatsByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range chats {
+		members, err := s.chatRepo.GetMembers(ctx, c.ID)
+		if err != nil {
+			return err
+		}
+		if slices.ContainsFunc(members, func(m repo.Member) bool { return m.UserID != userID }) {
+			if err := s.chatRepo.RemoveMember(ctx, c.ID, userID); err != nil {
+				return err
+			}
+			s.events.Publish(ctx, events.Event{
+				Type:       events.MemberRemoved,
+				ChatID:     c.ID,
+				UserID:     userID,
+				Recipients: participantIDs(c.Chat),
+			})
+			continue
+		}
+		if err := s.msgRepo.DeleteMessages(ctx, c.ID); err != nil {
+			return err
+		}
+		if err := s.chatRepo.DeleteChat(ctx, c.ID); err != nil {
+			return err
+		}
+		s.events.Publish(ctx, events.Event{
+			Type:       events.ChatDeleted,
+			ChatID:     c.ID,
+			UserID:     userID,
+			Recipients: participantIDs(c.Chat),
+		})
+	}
+
+	return nil
+}
+
+// isDeleted reports whether the member deleted the chat and no message has
+// arrived since.
+func isDeleted(member repo.Member, lastMessage *msgrepo.Message) bool {
+	if member.ClearedAt.IsZero() {
+		return false
+	}
+
+	return lastMessage == nil || !lastMessage.Timestamp.After(member.ClearedAt)
+}
+
+// participantIDs returns who is told about the chat: every participant but
+// those removed from it.
+func participantIDs(c repo.Chat) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(c.Participants))
+	for _, p := range c.Participants {
+		if !p.Removed {
+			ids = append(ids, p.ID)
+		}
+	}
+	return ids
+}
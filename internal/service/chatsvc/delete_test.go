@@ -0,0 +1,172 @@
+package chatsvc_test
+
+import (
+	"context"
+	"errors"
+	"github.com/AliUnipal/chat/internal/events"
+	"github.com/AliUnipal/chat/internal/service/chatsvc"
+	"github.com/AliUnipal/chat/internal/service/chatsvc/mocks"
+	"github.com/AliUnipal/chat/internal/service/chatsvc/repo"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestDeleteChatForMe_KeepHistoryForOtherMember(t *testing.T) {
+	ctx := context.Background()
+	chatID := uuid.New()
+	userID := uuid.New()
+	otherUserID := uuid.New()
+
+	chatMockRepo := mocks.NewChatRepository(t)
+	msgMockRepo := mocks.NewMessageRepository(t)
+	userMockService := mocks.NewUserService(t)
+	chatMockRepo.EXPECT().GetMember(ctx, chatID, userID).Return(repo.Member{ChatID: chatID, UserID: userID, ArchivedAt: time.Now()}, nil)
+	chatMockRepo.EXPECT().UpdateMember(ctx, mock.MatchedBy(func(m repo.Member) bool {
+		return m.ChatID == chatID && m.UserID == userID && !m.ClearedAt.IsZero() && m.ArchivedAt.IsZero()
+	})).Return(nil)
+	chatMockRepo.EXPECT().GetMembers(ctx, chatID).Return([]repo.Member{
+		{ChatID: chatID, UserID: userID, ClearedAt: time.Now()},
+		{ChatID: chatID, UserID: otherUserID},
+	}, nil)
+
+	service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, events.NewBus())
+	if err := service.DeleteChatForMe(ctx, chatID, userID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestDeleteChatForMe_PurgeHistoryDeletedByEveryone(t *testing.T) {
+	ctx := context.Background()
+	chatID := uuid.New()
+	userID := uuid.New()
+	otherUserID := uuid.New()
+	otherClearedAt := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	chatMockRepo := mocks.NewChatRepository(t)
+	msgMockRepo := mocks.NewMessageRepository(t)
+	userMockService := mocks.NewUserService(t)
+	chatMockRepo.EXPECT().GetMember(ctx, chatID, userID).Return(repo.Member{ChatID: chatID, UserID: userID}, nil)
+	chatMockRepo.EXPECT().UpdateMember(ctx, mock.Anything).Return(nil)
+	chatMockRepo.EXPECT().GetMembers(ctx, chatID).Return([]repo.Member{
+		{ChatID: chatID, UserID: userID, ClearedAt: time.Now()},
+		{ChatID: chatID, UserID: otherUserID, ClearedAt: otherClearedAt},
+	}, nil)
+	msgMockRepo.EXPECT().DeleteMessagesBefore(ctx, chatID, otherClearedAt).Return(nil)
+
+	service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, events.NewBus())
+	if err := service.DeleteChatForMe(ctx, chatID, userID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestDeleteChatForMe_ReturnErrorOnNonMember(t *testing.T) {
+	ctx := context.Background()
+	chatID := uuid.New()
+	userID := uuid.New()
+
+	chatMockRepo := mocks.NewChatRepository(t)
+	msgMockRepo := mocks.NewMessageRepository(t)
+	userMockService := mocks.NewUserService(t)
+	chatMockRepo.EXPECT().GetMember(ctx, chatID, userID).Return(repo.Member{}, repo.ErrMemberNotFound)
+
+	service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, events.NewBus())
+	if err := service.DeleteChatForMe(ctx, chatID, userID); !errors.Is(err, chatsvc.ErrMemberNotFound) {
+		t.Fatalf("expected %v, got %v", chatsvc.ErrMemberNotFound, err)
+	}
+}
+
+func TestDeleteChatForEveryone_PurgeMessagesAndChat(t *testing.T) {
+	ctx := context.Background()
+	chatID := uuid.New()
+	userID := uuid.New()
+
+	chatMockRepo := mocks.NewChatRepository(t)
+	msgMockRepo := mocks.NewMessageRepository(t)
+	userMockService := mocks.NewUserService(t)
+	chatMockRepo.EXPECT().GetChat(ctx, chatID).Return(repo.Chat{ID: chatID, Participants: []repo.User{{ID: userID}, {ID: uuid.New()}}}, nil)
+	chatMockRepo.EXPECT().GetMember(ctx, chatID, userID).Return(repo.Member{ChatID: chatID, UserID: userID}, nil)
+	deleteMessages := msgMockRepo.EXPECT().DeleteMessages(ctx, chatID).Return(nil).Call
+	chatMockRepo.EXPECT().DeleteChat(ctx, chatID).Return(nil).NotBefore(deleteMessages)
+
+	service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, events.NewBus())
+	if err := service.DeleteChatForEveryone(ctx, chatID, userID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestDeleteChatForEveryone_KeepChatOnPurgeError(t *testing.T) {
+	ctx := context.Background()
+	chatID := uuid.New()
+	userID := uuid.New()
+
+	chatMockRepo := mocks.NewChatRepository(t)
+	msgMockRepo := mocks.NewMessageRepository(t)
+	userMockService := mocks.NewUserService(t)
+	chatMockRepo.EXPECT().GetChat(ctx, chatID).Return(repo.Chat{ID: chatID, Participants: []repo.User{{ID: userID}, {ID: uuid.New()}}}, nil)
+	chatMockRepo.EXPECT().GetMember(ctx, chatID, userID).Return(repo.Member{ChatID: chatID, UserID: userID}, nil)
+	msgMockRepo.EXPECT().DeleteMessages(ctx, chatID).Return(errors.New("error"))
+
+	service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, events.NewBus())
+	if err := service.DeleteChatForEveryone(ctx, chatID, userID); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestDeleteChatForEveryone_ReturnErrorOnNonMember(t *testing.T) {
+	ctx := context.Background()
+	chatID := uuid.New()
+	userID := uuid.New()
+
+	chatMockRepo := mocks.NewChatRepository(t)
+	msgMockRepo := mocks.NewMessageRepository(t)
+	userMockService := mocks.NewUserService(t)
+	chatMockRepo.EXPECT().GetChat(ctx, chatID).Return(repo.Chat{ID: chatID}, nil)
+	chatMockRepo.EXPECT().GetMember(ctx, chatID, userID).Return(repo.Member{}, repo.ErrMemberNotFound)
+
+	service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, events.NewBus())
+	if err := service.DeleteChatForEveryone(ctx, chatID, userID); !errors.Is(err, chatsvc.ErrMemberNotFound) {
+		t.Fatalf("expected %v, got %v", chatsvc.ErrMemberNotFound, err)
+	}
+}
+
+func TestRemoveUser_LeaveSharedChatsAndDeleteEmptyOnes(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	shared := repo.UserChat{Chat: repo.Chat{ID: uuid.New()}, Member: repo.Member{UserID: userID}}
+	abandoned := repo.UserChat{Chat: repo.Chat{ID: uuid.New()}, Member: repo.Member{UserID: userID}}
+
+	chatMockRepo := mocks.NewChatRepository(t)
+	msgMockRepo := mocks.NewMessageRepository(t)
+	userMockService := mocks.NewUserService(t)
+	chatMockRepo.EXPECT().GetChatsByUser(ctx, userID).Return([]repo.UserChat{shared, abandoned}, nil)
+	chatMockRepo.EXPECT().GetMembers(ctx, shared.ID).Return([]repo.Member{{UserID: userID}, {UserID: uuid.New()}}, nil)
+	chatMockRepo.EXPECT().RemoveMember(ctx, shared.ID, userID).Return(nil)
+	chatMockRepo.EXPECT().GetMembers(ctx, abandoned.ID).Return([]repo.Member{{UserID: userID}}, nil)
+	deleteMessages := msgMockRepo.EXPECT().DeleteMessages(ctx, abandoned.ID).Return(nil).Call
+	chatMockRepo.EXPECT().DeleteChat(ctx, abandoned.ID).Return(nil).NotBefore(deleteMessages)
+
+	service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, events.NewBus())
+	if err := service.RemoveUser(ctx, userID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestGetChatIDs(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	chats := []repo.UserChat{{Chat: repo.Chat{ID: uuid.New()}}, {Chat: repo.Chat{ID: uuid.New()}}}
+
+	chatMockRepo := mocks.NewChatRepository(t)
+	chatMockRepo.EXPECT().GetChatsByUser(ctx, userID).Return(chats, nil)
+
+	service := chatsvc.NewService(chatMockRepo, mocks.NewMessageRepository(t), mocks.NewUserService(t), events.NewBus())
+	ids, err := service.GetChatIDs(ctx, userID)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(ids) != 2 || ids[0] != chats[0].ID || ids[1] != chats[1].ID {
+		t.Fatalf("expected [%v %v], got %v", chats[0].ID, chats[1].ID, ids)
+	}
+}
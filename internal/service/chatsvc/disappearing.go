@@ -0,0 +1,55 @@
+package chatsvc
+
+import (
+	"context"
+	"errors"
+	"github.com/AliUnipal/chat/internal/events"
+	"github.com/google/uuid"
+	"time"
+)
+
+// Disappearing message timers. Besides these, any custom timer between
+// MinDisappearAfter and MaxDisappearAfter can be set.
+const (
+	DisappearOff       time.Duration = 0
+	DisappearAfterDay                = 24 * time.Hour
+	DisappearAfterWeek               = 7 * 24 * time.Hour
+
+	MinDisappearAfter = time.Minute
+	MaxDisappearAfter = 365 * 24 * time.Hour
+)
+
+var ErrInvalidDisappearAfter = errors.New("disappearing messages timer must be between a minute and a year")
+
+// SetDisappearingMessages sets how long the messages sent to the chat from
+// now on are kept before they are deleted for everyone, or turns disappearing
+// messages off with DisappearOff. Messages already sent keep the timer they
+// were sent under. Any participant can change it, and a change is announced
+// to all of them with a DisappearingMessagesChanged event.
+func (s *service) SetDisappearingMessages(ctx context.Context, chatID, userID uuid.UUID, after time.Duration) error {
+	if after != DisappearOff && (after < MinDisappearAfter || after > MaxDisappearAfter) {
+		return ErrInvalidDisappearAfter
+	}
+	if _, err := s.chatRepo.GetMember(ctx, chatID, userID); err != nil {
+		return err
+	}
+	c, err := s.chatRepo.GetChat(ctx, chatID)
+	if err != nil {
+		return err
+	}
+	if c.DisappearAfter == after {
+		return nil
+	}
+
+	if err := s.chatRepo.SetDisappearAfter(ctx, chatID, after); err != nil {
+		return err
+	}
+	s.events.Publish(ctx, events.Event{
+		Type:           events.DisappearingMessagesChanged,
+		ChatID:         chatID,
+		UserID:         userID,
+		Recipients:     participantIDs(c),
+		DisappearAfter: after,
+	})
+	return nil
+}
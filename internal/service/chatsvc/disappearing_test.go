@@ -0,0 +1,85 @@
+package chatsvc_test
+
+import (
+	"context"
+	"errors"
+	"github.com/AliUnipal/chat/internal/events"
+	"github.com/AliUnipal/chat/internal/service/chatsvc"
+	"github.com/AliUnipal/chat/internal/service/chatsvc/mocks"
+	"github.com/AliUnipal/chat/internal/service/chatsvc/repo"
+	"github.com/google/uuid"
+	"testing"
+	"time"
+)
+
+func TestSetDisappearingMessages(t *testing.T) {
+	ctx := context.Background()
+	chatID := uuid.New()
+	userID := uuid.New()
+	otherID := uuid.New()
+
+	tests := []struct {
+		name     string
+		current  time.Duration
+		after    time.Duration
+		expected error
+	}{
+		{name: "off", current: chatsvc.DisappearAfterDay, after: chatsvc.DisappearOff},
+		{name: "one day", after: chatsvc.DisappearAfterDay},
+		{name: "seven days", after: chatsvc.DisappearAfterWeek},
+		{name: "custom", after: 90 * time.Minute},
+		{name: "unchanged", current: chatsvc.DisappearAfterDay, after: chatsvc.DisappearAfterDay},
+		{name: "too short", after: time.Second, expected: chatsvc.ErrInvalidDisappearAfter},
+		{name: "negative", after: -time.Hour, expected: chatsvc.ErrInvalidDisappearAfter},
+		{name: "too long", after: 2 * chatsvc.MaxDisappearAfter, expected: chatsvc.ErrInvalidDisappearAfter},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chatMockRepo := mocks.NewChatRepository(t)
+			changed := tt.expected == nil && tt.current != tt.after
+			if tt.expected == nil {
+				chatMockRepo.EXPECT().GetMember(ctx, chatID, userID).Return(repo.Member{ChatID: chatID, UserID: userID}, nil)
+				chatMockRepo.EXPECT().GetChat(ctx, chatID).Return(repo.Chat{ID: chatID, Participants: []repo.User{{ID: userID}, {ID: otherID}}, DisappearAfter: tt.current}, nil)
+			}
+			if changed {
+				chatMockRepo.EXPECT().SetDisappearAfter(ctx, chatID, tt.after).Return(nil)
+			}
+
+			bus := events.NewBus()
+			var published []events.Event
+			bus.Subscribe(func(_ context.Context, e events.Event) { published = append(published, e) })
+			service := chatsvc.NewService(chatMockRepo, mocks.NewMessageRepository(t), mocks.NewUserService(t), bus)
+			if err := service.SetDisappearingMessages(ctx, chatID, userID, tt.after); !errors.Is(err, tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, err)
+			}
+
+			if !changed {
+				if len(published) != 0 {
+					t.Fatalf("expected no event got %+v", published)
+				}
+				return
+			}
+			if len(published) != 1 ||
+				published[0].Type != events.DisappearingMessagesChanged ||
+				published[0].UserID != userID ||
+				published[0].DisappearAfter != tt.after ||
+				len(published[0].Recipients) != 2 {
+				t.Fatalf("expected the change announced to both participants got %+v", published)
+			}
+		})
+	}
+}
+
+func TestSetDisappearingMessages_ReturnErrorOnNonMember(t *testing.T) {
+	ctx := context.Background()
+	chatID := uuid.New()
+	userID := uuid.New()
+
+	chatMockRepo := mocks.NewChatRepository(t)
+	chatMockRepo.EXPECT().GetMember(ctx, chatID, userID).Return(repo.Member{}, repo.ErrMemberNotFound)
+
+	service := chatsvc.NewService(chatMockRepo, mocks.NewMessageRepository(t), mocks.NewUserService(t), events.NewBus())
+	if err := service.SetDisappearingMessages(ctx, chatID, userID, chatsvc.DisappearAfterDay); !errors.Is(err, chatsvc.ErrMemberNotFound) {
+		t.Fatalf("expected %v, got %v", chatsvc.ErrMemberNotFound, err)
+	}
+}
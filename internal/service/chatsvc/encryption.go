@@ -0,0 +1,39 @@
+package chatsvc
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+)
+
+var ErrKeysMissing = errors.New("every participant must publish encryption keys first")
+
+// EnableEncryption switches a direct chat to end-to-end encryption. From then
+// on the chat only accepts encrypted envelopes; there is no way back, so
+// neither participant can be tricked into sending plaintext later.
+func (s *service) EnableEncryption(ctx context.Context, chatID, userID uuid.UUID) error {
+	c, err := s.chatRepo.GetChat(ctx, chatID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.chatRepo.GetMember(ctx, chatID, userID); err != nil {
+		return err
+	}
+	if c.Encrypted {
+		return nil
+	}
+	if len(c.Participants) != 2 {
+		return errors.New("only direct chats can be encrypted")
+	}
+	for _, p := range c.Participants {
+		ok, err := s.users.HasKeys(ctx, p.ID)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrKeysMissing
+		}
+	}
+
+	return s.chatRepo.EnableEncryption(ctx, chatID)
+}
@@ -0,0 +1,47 @@
+package chatsvc_test
+
+import (
+	"context"
+	"errors"
+	"github.com/AliUnipal/chat/internal/events"
+	"github.com/AliUnipal/chat/internal/service/chatsvc"
+	"github.com/AliUnipal/chat/internal/service/chatsvc/mocks"
+	"github.com/AliUnipal/chat/internal/service/chatsvc/repo"
+	"github.com/google/uuid"
+	"testing"
+)
+
+func TestEnableEncryption(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	otherID := uuid.New()
+	c := repo.Chat{ID: uuid.New(), Participants: []repo.User{{ID: userID}, {ID: otherID}}}
+
+	tests := []struct {
+		name      string
+		otherKeys bool
+		expected  error
+	}{
+		{name: "both published keys", otherKeys: true},
+		{name: "other user has no keys", expected: chatsvc.ErrKeysMissing},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chatMockRepo := mocks.NewChatRepository(t)
+			msgMockRepo := mocks.NewMessageRepository(t)
+			userMockService := mocks.NewUserService(t)
+			chatMockRepo.EXPECT().GetChat(ctx, c.ID).Return(c, nil)
+			chatMockRepo.EXPECT().GetMember(ctx, c.ID, userID).Return(repo.Member{ChatID: c.ID, UserID: userID}, nil)
+			userMockService.EXPECT().HasKeys(ctx, userID).Return(true, nil)
+			userMockService.EXPECT().HasKeys(ctx, otherID).Return(tt.otherKeys, nil)
+			if tt.expected == nil {
+				chatMockRepo.EXPECT().EnableEncryption(ctx, c.ID).Return(nil)
+			}
+
+			service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, events.NewBus())
+			if err := service.EnableEncryption(ctx, c.ID, userID); !errors.Is(err, tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
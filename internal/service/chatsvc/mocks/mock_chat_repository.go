@@ -6,6 +6,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/AliUnipal/chat/internal/service/chatsvc/repo"
 	"github.com/google/uuid"
@@ -39,6 +40,69 @@ func (_m *ChatRepository) EXPECT() *ChatRepository_Expecter {
 	return &ChatRepository_Expecter{mock: &_m.Mock}
 }
 
+// AddMember provides a mock function for the type ChatRepository
+func (_mock *ChatRepository) AddMember(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, chatID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddMember")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, chatID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ChatRepository_AddMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddMember'
+type ChatRepository_AddMember_Call struct {
+	*mock.Call
+}
+
+// AddMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID uuid.UUID
+//   - userID uuid.UUID
+func (_e *ChatRepository_Expecter) AddMember(ctx interface{}, chatID interface{}, userID interface{}) *ChatRepository_AddMember_Call {
+	return &ChatRepository_AddMember_Call{Call: _e.mock.On("AddMember", ctx, chatID, userID)}
+}
+
+func (_c *ChatRepository_AddMember_Call) Run(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID)) *ChatRepository_AddMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ChatRepository_AddMember_Call) Return(err error) *ChatRepository_AddMember_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ChatRepository_AddMember_Call) RunAndReturn(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) error) *ChatRepository_AddMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateChat provides a mock function for the type ChatRepository
 func (_mock *ChatRepository) CreateChat(ctx context.Context, chat repo.CreateChatInput) error {
 	ret := _mock.Called(ctx, chat)
@@ -96,24 +160,276 @@ func (_c *ChatRepository_CreateChat_Call) RunAndReturn(run func(ctx context.Cont
 	return _c
 }
 
+// DeleteChat provides a mock function for the type ChatRepository
+func (_mock *ChatRepository) DeleteChat(ctx context.Context, id uuid.UUID) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteChat")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ChatRepository_DeleteChat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteChat'
+type ChatRepository_DeleteChat_Call struct {
+	*mock.Call
+}
+
+// DeleteChat is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *ChatRepository_Expecter) DeleteChat(ctx interface{}, id interface{}) *ChatRepository_DeleteChat_Call {
+	return &ChatRepository_DeleteChat_Call{Call: _e.mock.On("DeleteChat", ctx, id)}
+}
+
+func (_c *ChatRepository_DeleteChat_Call) Run(run func(ctx context.Context, id uuid.UUID)) *ChatRepository_DeleteChat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ChatRepository_DeleteChat_Call) Return(err error) *ChatRepository_DeleteChat_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ChatRepository_DeleteChat_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) error) *ChatRepository_DeleteChat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnableEncryption provides a mock function for the type ChatRepository
+func (_mock *ChatRepository) EnableEncryption(ctx context.Context, chatID uuid.UUID) error {
+	ret := _mock.Called(ctx, chatID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnableEncryption")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, chatID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ChatRepository_EnableEncryption_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnableEncryption'
+type ChatRepository_EnableEncryption_Call struct {
+	*mock.Call
+}
+
+// EnableEncryption is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID uuid.UUID
+func (_e *ChatRepository_Expecter) EnableEncryption(ctx interface{}, chatID interface{}) *ChatRepository_EnableEncryption_Call {
+	return &ChatRepository_EnableEncryption_Call{Call: _e.mock.On("EnableEncryption", ctx, chatID)}
+}
+
+func (_c *ChatRepository_EnableEncryption_Call) Run(run func(ctx context.Context, chatID uuid.UUID)) *ChatRepository_EnableEncryption_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ChatRepository_EnableEncryption_Call) Return(err error) *ChatRepository_EnableEncryption_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ChatRepository_EnableEncryption_Call) RunAndReturn(run func(ctx context.Context, chatID uuid.UUID) error) *ChatRepository_EnableEncryption_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindDirectChat provides a mock function for the type ChatRepository
+func (_mock *ChatRepository) FindDirectChat(ctx context.Context, userA uuid.UUID, userB uuid.UUID) (repo.Chat, error) {
+	ret := _mock.Called(ctx, userA, userB)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDirectChat")
+	}
+
+	var r0 repo.Chat
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (repo.Chat, error)); ok {
+		return returnFunc(ctx, userA, userB)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) repo.Chat); ok {
+		r0 = returnFunc(ctx, userA, userB)
+	} else {
+		r0 = ret.Get(0).(repo.Chat)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userA, userB)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ChatRepository_FindDirectChat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindDirectChat'
+type ChatRepository_FindDirectChat_Call struct {
+	*mock.Call
+}
+
+// FindDirectChat is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userA uuid.UUID
+//   - userB uuid.UUID
+func (_e *ChatRepository_Expecter) FindDirectChat(ctx interface{}, userA interface{}, userB interface{}) *ChatRepository_FindDirectChat_Call {
+	return &ChatRepository_FindDirectChat_Call{Call: _e.mock.On("FindDirectChat", ctx, userA, userB)}
+}
+
+func (_c *ChatRepository_FindDirectChat_Call) Run(run func(ctx context.Context, userA uuid.UUID, userB uuid.UUID)) *ChatRepository_FindDirectChat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ChatRepository_FindDirectChat_Call) Return(chat repo.Chat, err error) *ChatRepository_FindDirectChat_Call {
+	_c.Call.Return(chat, err)
+	return _c
+}
+
+func (_c *ChatRepository_FindDirectChat_Call) RunAndReturn(run func(ctx context.Context, userA uuid.UUID, userB uuid.UUID) (repo.Chat, error)) *ChatRepository_FindDirectChat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChat provides a mock function for the type ChatRepository
+func (_mock *ChatRepository) GetChat(ctx context.Context, id uuid.UUID) (repo.Chat, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChat")
+	}
+
+	var r0 repo.Chat
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (repo.Chat, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) repo.Chat); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(repo.Chat)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ChatRepository_GetChat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChat'
+type ChatRepository_GetChat_Call struct {
+	*mock.Call
+}
+
+// GetChat is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *ChatRepository_Expecter) GetChat(ctx interface{}, id interface{}) *ChatRepository_GetChat_Call {
+	return &ChatRepository_GetChat_Call{Call: _e.mock.On("GetChat", ctx, id)}
+}
+
+func (_c *ChatRepository_GetChat_Call) Run(run func(ctx context.Context, id uuid.UUID)) *ChatRepository_GetChat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ChatRepository_GetChat_Call) Return(chat repo.Chat, err error) *ChatRepository_GetChat_Call {
+	_c.Call.Return(chat, err)
+	return _c
+}
+
+func (_c *ChatRepository_GetChat_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (repo.Chat, error)) *ChatRepository_GetChat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetChatsByUser provides a mock function for the type ChatRepository
-func (_mock *ChatRepository) GetChatsByUser(ctx context.Context, userID uuid.UUID) ([]*repo.Chat, error) {
+func (_mock *ChatRepository) GetChatsByUser(ctx context.Context, userID uuid.UUID) ([]repo.UserChat, error) {
 	ret := _mock.Called(ctx, userID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetChatsByUser")
 	}
 
-	var r0 []*repo.Chat
+	var r0 []repo.UserChat
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*repo.Chat, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]repo.UserChat, error)); ok {
 		return returnFunc(ctx, userID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*repo.Chat); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []repo.UserChat); ok {
 		r0 = returnFunc(ctx, userID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*repo.Chat)
+			r0 = ret.Get(0).([]repo.UserChat)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
@@ -154,12 +470,796 @@ func (_c *ChatRepository_GetChatsByUser_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *ChatRepository_GetChatsByUser_Call) Return(chats []*repo.Chat, err error) *ChatRepository_GetChatsByUser_Call {
-	_c.Call.Return(chats, err)
+func (_c *ChatRepository_GetChatsByUser_Call) Return(userChats []repo.UserChat, err error) *ChatRepository_GetChatsByUser_Call {
+	_c.Call.Return(userChats, err)
+	return _c
+}
+
+func (_c *ChatRepository_GetChatsByUser_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) ([]repo.UserChat, error)) *ChatRepository_GetChatsByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMember provides a mock function for the type ChatRepository
+func (_mock *ChatRepository) GetMember(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) (repo.Member, error) {
+	ret := _mock.Called(ctx, chatID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMember")
+	}
+
+	var r0 repo.Member
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (repo.Member, error)); ok {
+		return returnFunc(ctx, chatID, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) repo.Member); ok {
+		r0 = returnFunc(ctx, chatID, userID)
+	} else {
+		r0 = ret.Get(0).(repo.Member)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, chatID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ChatRepository_GetMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMember'
+type ChatRepository_GetMember_Call struct {
+	*mock.Call
+}
+
+// GetMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID uuid.UUID
+//   - userID uuid.UUID
+func (_e *ChatRepository_Expecter) GetMember(ctx interface{}, chatID interface{}, userID interface{}) *ChatRepository_GetMember_Call {
+	return &ChatRepository_GetMember_Call{Call: _e.mock.On("GetMember", ctx, chatID, userID)}
+}
+
+func (_c *ChatRepository_GetMember_Call) Run(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID)) *ChatRepository_GetMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ChatRepository_GetMember_Call) Return(member repo.Member, err error) *ChatRepository_GetMember_Call {
+	_c.Call.Return(member, err)
+	return _c
+}
+
+func (_c *ChatRepository_GetMember_Call) RunAndReturn(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) (repo.Member, error)) *ChatRepository_GetMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMembers provides a mock function for the type ChatRepository
+func (_mock *ChatRepository) GetMembers(ctx context.Context, chatID uuid.UUID) ([]repo.Member, error) {
+	ret := _mock.Called(ctx, chatID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMembers")
+	}
+
+	var r0 []repo.Member
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]repo.Member, error)); ok {
+		return returnFunc(ctx, chatID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []repo.Member); ok {
+		r0 = returnFunc(ctx, chatID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repo.Member)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, chatID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ChatRepository_GetMembers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMembers'
+type ChatRepository_GetMembers_Call struct {
+	*mock.Call
+}
+
+// GetMembers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID uuid.UUID
+func (_e *ChatRepository_Expecter) GetMembers(ctx interface{}, chatID interface{}) *ChatRepository_GetMembers_Call {
+	return &ChatRepository_GetMembers_Call{Call: _e.mock.On("GetMembers", ctx, chatID)}
+}
+
+func (_c *ChatRepository_GetMembers_Call) Run(run func(ctx context.Context, chatID uuid.UUID)) *ChatRepository_GetMembers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ChatRepository_GetMembers_Call) Return(members []repo.Member, err error) *ChatRepository_GetMembers_Call {
+	_c.Call.Return(members, err)
+	return _c
+}
+
+func (_c *ChatRepository_GetMembers_Call) RunAndReturn(run func(ctx context.Context, chatID uuid.UUID) ([]repo.Member, error)) *ChatRepository_GetMembers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserChats provides a mock function for the type ChatRepository
+func (_mock *ChatRepository) GetUserChats(ctx context.Context, in repo.GetUserChatsInput) ([]repo.UserChat, error) {
+	ret := _mock.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserChats")
+	}
+
+	var r0 []repo.UserChat
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repo.GetUserChatsInput) ([]repo.UserChat, error)); ok {
+		return returnFunc(ctx, in)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repo.GetUserChatsInput) []repo.UserChat); ok {
+		r0 = returnFunc(ctx, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repo.UserChat)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repo.GetUserChatsInput) error); ok {
+		r1 = returnFunc(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ChatRepository_GetUserChats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserChats'
+type ChatRepository_GetUserChats_Call struct {
+	*mock.Call
+}
+
+// GetUserChats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in repo.GetUserChatsInput
+func (_e *ChatRepository_Expecter) GetUserChats(ctx interface{}, in interface{}) *ChatRepository_GetUserChats_Call {
+	return &ChatRepository_GetUserChats_Call{Call: _e.mock.On("GetUserChats", ctx, in)}
+}
+
+func (_c *ChatRepository_GetUserChats_Call) Run(run func(ctx context.Context, in repo.GetUserChatsInput)) *ChatRepository_GetUserChats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 repo.GetUserChatsInput
+		if args[1] != nil {
+			arg1 = args[1].(repo.GetUserChatsInput)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ChatRepository_GetUserChats_Call) Return(userChats []repo.UserChat, err error) *ChatRepository_GetUserChats_Call {
+	_c.Call.Return(userChats, err)
+	return _c
+}
+
+func (_c *ChatRepository_GetUserChats_Call) RunAndReturn(run func(ctx context.Context, in repo.GetUserChatsInput) ([]repo.UserChat, error)) *ChatRepository_GetUserChats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InviteBot provides a mock function for the type ChatRepository
+func (_mock *ChatRepository) InviteBot(ctx context.Context, chatID uuid.UUID, invite repo.BotInvite) error {
+	ret := _mock.Called(ctx, chatID, invite)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InviteBot")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, repo.BotInvite) error); ok {
+		r0 = returnFunc(ctx, chatID, invite)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ChatRepository_InviteBot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InviteBot'
+type ChatRepository_InviteBot_Call struct {
+	*mock.Call
+}
+
+// InviteBot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID uuid.UUID
+//   - invite repo.BotInvite
+func (_e *ChatRepository_Expecter) InviteBot(ctx interface{}, chatID interface{}, invite interface{}) *ChatRepository_InviteBot_Call {
+	return &ChatRepository_InviteBot_Call{Call: _e.mock.On("InviteBot", ctx, chatID, invite)}
+}
+
+func (_c *ChatRepository_InviteBot_Call) Run(run func(ctx context.Context, chatID uuid.UUID, invite repo.BotInvite)) *ChatRepository_InviteBot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 repo.BotInvite
+		if args[2] != nil {
+			arg2 = args[2].(repo.BotInvite)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ChatRepository_InviteBot_Call) Return(err error) *ChatRepository_InviteBot_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ChatRepository_InviteBot_Call) RunAndReturn(run func(ctx context.Context, chatID uuid.UUID, invite repo.BotInvite) error) *ChatRepository_InviteBot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveMember provides a mock function for the type ChatRepository
+func (_mock *ChatRepository) RemoveMember(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, chatID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveMember")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, chatID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ChatRepository_RemoveMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveMember'
+type ChatRepository_RemoveMember_Call struct {
+	*mock.Call
+}
+
+// RemoveMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID uuid.UUID
+//   - userID uuid.UUID
+func (_e *ChatRepository_Expecter) RemoveMember(ctx interface{}, chatID interface{}, userID interface{}) *ChatRepository_RemoveMember_Call {
+	return &ChatRepository_RemoveMember_Call{Call: _e.mock.On("RemoveMember", ctx, chatID, userID)}
+}
+
+func (_c *ChatRepository_RemoveMember_Call) Run(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID)) *ChatRepository_RemoveMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ChatRepository_RemoveMember_Call) Return(err error) *ChatRepository_RemoveMember_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ChatRepository_RemoveMember_Call) RunAndReturn(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) error) *ChatRepository_RemoveMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveParticipant provides a mock function for the type ChatRepository
+func (_mock *ChatRepository) RemoveParticipant(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, chatID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveParticipant")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, chatID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ChatRepository_RemoveParticipant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveParticipant'
+type ChatRepository_RemoveParticipant_Call struct {
+	*mock.Call
+}
+
+// RemoveParticipant is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID uuid.UUID
+//   - userID uuid.UUID
+func (_e *ChatRepository_Expecter) RemoveParticipant(ctx interface{}, chatID interface{}, userID interface{}) *ChatRepository_RemoveParticipant_Call {
+	return &ChatRepository_RemoveParticipant_Call{Call: _e.mock.On("RemoveParticipant", ctx, chatID, userID)}
+}
+
+func (_c *ChatRepository_RemoveParticipant_Call) Run(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID)) *ChatRepository_RemoveParticipant_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ChatRepository_RemoveParticipant_Call) Return(err error) *ChatRepository_RemoveParticipant_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ChatRepository_RemoveParticipant_Call) RunAndReturn(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) error) *ChatRepository_RemoveParticipant_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetDisappearAfter provides a mock function for the type ChatRepository
+func (_mock *ChatRepository) SetDisappearAfter(ctx context.Context, chatID uuid.UUID, after time.Duration) error {
+	ret := _mock.Called(ctx, chatID, after)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDisappearAfter")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Duration) error); ok {
+		r0 = returnFunc(ctx, chatID, after)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ChatRepository_SetDisappearAfter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetDisappearAfter'
+type ChatRepository_SetDisappearAfter_Call struct {
+	*mock.Call
+}
+
+// SetDisappearAfter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID uuid.UUID
+//   - after time.Duration
+func (_e *ChatRepository_Expecter) SetDisappearAfter(ctx interface{}, chatID interface{}, after interface{}) *ChatRepository_SetDisappearAfter_Call {
+	return &ChatRepository_SetDisappearAfter_Call{Call: _e.mock.On("SetDisappearAfter", ctx, chatID, after)}
+}
+
+func (_c *ChatRepository_SetDisappearAfter_Call) Run(run func(ctx context.Context, chatID uuid.UUID, after time.Duration)) *ChatRepository_SetDisappearAfter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 time.Duration
+		if args[2] != nil {
+			arg2 = args[2].(time.Duration)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ChatRepository_SetDisappearAfter_Call) Return(err error) *ChatRepository_SetDisappearAfter_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ChatRepository_SetDisappearAfter_Call) RunAndReturn(run func(ctx context.Context, chatID uuid.UUID, after time.Duration) error) *ChatRepository_SetDisappearAfter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetLastMessageAt provides a mock function for the type ChatRepository
+func (_mock *ChatRepository) SetLastMessageAt(ctx context.Context, chatID uuid.UUID, at time.Time) error {
+	ret := _mock.Called(ctx, chatID, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetLastMessageAt")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) error); ok {
+		r0 = returnFunc(ctx, chatID, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ChatRepository_SetLastMessageAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetLastMessageAt'
+type ChatRepository_SetLastMessageAt_Call struct {
+	*mock.Call
+}
+
+// SetLastMessageAt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID uuid.UUID
+//   - at time.Time
+func (_e *ChatRepository_Expecter) SetLastMessageAt(ctx interface{}, chatID interface{}, at interface{}) *ChatRepository_SetLastMessageAt_Call {
+	return &ChatRepository_SetLastMessageAt_Call{Call: _e.mock.On("SetLastMessageAt", ctx, chatID, at)}
+}
+
+func (_c *ChatRepository_SetLastMessageAt_Call) Run(run func(ctx context.Context, chatID uuid.UUID, at time.Time)) *ChatRepository_SetLastMessageAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ChatRepository_SetLastMessageAt_Call) Return(err error) *ChatRepository_SetLastMessageAt_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ChatRepository_SetLastMessageAt_Call) RunAndReturn(run func(ctx context.Context, chatID uuid.UUID, at time.Time) error) *ChatRepository_SetLastMessageAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TakeBotInvite provides a mock function for the type ChatRepository
+func (_mock *ChatRepository) TakeBotInvite(ctx context.Context, chatID uuid.UUID, botID uuid.UUID) (repo.BotInvite, error) {
+	ret := _mock.Called(ctx, chatID, botID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TakeBotInvite")
+	}
+
+	var r0 repo.BotInvite
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (repo.BotInvite, error)); ok {
+		return returnFunc(ctx, chatID, botID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) repo.BotInvite); ok {
+		r0 = returnFunc(ctx, chatID, botID)
+	} else {
+		r0 = ret.Get(0).(repo.BotInvite)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, chatID, botID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ChatRepository_TakeBotInvite_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TakeBotInvite'
+type ChatRepository_TakeBotInvite_Call struct {
+	*mock.Call
+}
+
+// TakeBotInvite is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID uuid.UUID
+//   - botID uuid.UUID
+func (_e *ChatRepository_Expecter) TakeBotInvite(ctx interface{}, chatID interface{}, botID interface{}) *ChatRepository_TakeBotInvite_Call {
+	return &ChatRepository_TakeBotInvite_Call{Call: _e.mock.On("TakeBotInvite", ctx, chatID, botID)}
+}
+
+func (_c *ChatRepository_TakeBotInvite_Call) Run(run func(ctx context.Context, chatID uuid.UUID, botID uuid.UUID)) *ChatRepository_TakeBotInvite_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ChatRepository_TakeBotInvite_Call) Return(botInvite repo.BotInvite, err error) *ChatRepository_TakeBotInvite_Call {
+	_c.Call.Return(botInvite, err)
+	return _c
+}
+
+func (_c *ChatRepository_TakeBotInvite_Call) RunAndReturn(run func(ctx context.Context, chatID uuid.UUID, botID uuid.UUID) (repo.BotInvite, error)) *ChatRepository_TakeBotInvite_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnarchiveMembers provides a mock function for the type ChatRepository
+func (_mock *ChatRepository) UnarchiveMembers(ctx context.Context, chatID uuid.UUID, sentAt time.Time, throughMute []uuid.UUID) error {
+	ret := _mock.Called(ctx, chatID, sentAt, throughMute)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnarchiveMembers")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time, []uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, chatID, sentAt, throughMute)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ChatRepository_UnarchiveMembers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnarchiveMembers'
+type ChatRepository_UnarchiveMembers_Call struct {
+	*mock.Call
+}
+
+// UnarchiveMembers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID uuid.UUID
+//   - sentAt time.Time
+//   - throughMute []uuid.UUID
+func (_e *ChatRepository_Expecter) UnarchiveMembers(ctx interface{}, chatID interface{}, sentAt interface{}, throughMute interface{}) *ChatRepository_UnarchiveMembers_Call {
+	return &ChatRepository_UnarchiveMembers_Call{Call: _e.mock.On("UnarchiveMembers", ctx, chatID, sentAt, throughMute)}
+}
+
+func (_c *ChatRepository_UnarchiveMembers_Call) Run(run func(ctx context.Context, chatID uuid.UUID, sentAt time.Time, throughMute []uuid.UUID)) *ChatRepository_UnarchiveMembers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		var arg3 []uuid.UUID
+		if args[3] != nil {
+			arg3 = args[3].([]uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *ChatRepository_UnarchiveMembers_Call) Return(err error) *ChatRepository_UnarchiveMembers_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ChatRepository_UnarchiveMembers_Call) RunAndReturn(run func(ctx context.Context, chatID uuid.UUID, sentAt time.Time, throughMute []uuid.UUID) error) *ChatRepository_UnarchiveMembers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateMember provides a mock function for the type ChatRepository
+func (_mock *ChatRepository) UpdateMember(ctx context.Context, member repo.Member) error {
+	ret := _mock.Called(ctx, member)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateMember")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repo.Member) error); ok {
+		r0 = returnFunc(ctx, member)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ChatRepository_UpdateMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateMember'
+type ChatRepository_UpdateMember_Call struct {
+	*mock.Call
+}
+
+// UpdateMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - member repo.Member
+func (_e *ChatRepository_Expecter) UpdateMember(ctx interface{}, member interface{}) *ChatRepository_UpdateMember_Call {
+	return &ChatRepository_UpdateMember_Call{Call: _e.mock.On("UpdateMember", ctx, member)}
+}
+
+func (_c *ChatRepository_UpdateMember_Call) Run(run func(ctx context.Context, member repo.Member)) *ChatRepository_UpdateMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 repo.Member
+		if args[1] != nil {
+			arg1 = args[1].(repo.Member)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ChatRepository_UpdateMember_Call) Return(err error) *ChatRepository_UpdateMember_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ChatRepository_UpdateMember_Call) RunAndReturn(run func(ctx context.Context, member repo.Member) error) *ChatRepository_UpdateMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateRequestStatus provides a mock function for the type ChatRepository
+func (_mock *ChatRepository) UpdateRequestStatus(ctx context.Context, chatID uuid.UUID, status repo.RequestStatus) error {
+	ret := _mock.Called(ctx, chatID, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRequestStatus")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, repo.RequestStatus) error); ok {
+		r0 = returnFunc(ctx, chatID, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ChatRepository_UpdateRequestStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateRequestStatus'
+type ChatRepository_UpdateRequestStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateRequestStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID uuid.UUID
+//   - status repo.RequestStatus
+func (_e *ChatRepository_Expecter) UpdateRequestStatus(ctx interface{}, chatID interface{}, status interface{}) *ChatRepository_UpdateRequestStatus_Call {
+	return &ChatRepository_UpdateRequestStatus_Call{Call: _e.mock.On("UpdateRequestStatus", ctx, chatID, status)}
+}
+
+func (_c *ChatRepository_UpdateRequestStatus_Call) Run(run func(ctx context.Context, chatID uuid.UUID, status repo.RequestStatus)) *ChatRepository_UpdateRequestStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 repo.RequestStatus
+		if args[2] != nil {
+			arg2 = args[2].(repo.RequestStatus)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ChatRepository_UpdateRequestStatus_Call) Return(err error) *ChatRepository_UpdateRequestStatus_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *ChatRepository_GetChatsByUser_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) ([]*repo.Chat, error)) *ChatRepository_GetChatsByUser_Call {
+func (_c *ChatRepository_UpdateRequestStatus_Call) RunAndReturn(run func(ctx context.Context, chatID uuid.UUID, status repo.RequestStatus) error) *ChatRepository_UpdateRequestStatus_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -6,8 +6,11 @@ package mocks
 
 import (
 	"context"
+	"time"
 
+	"github.com/AliUnipal/chat/internal/events"
 	"github.com/AliUnipal/chat/internal/models/chat"
+	"github.com/AliUnipal/chat/internal/service/chatsvc"
 	"github.com/google/uuid"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -39,6 +42,333 @@ func (_m *ChatService) EXPECT() *ChatService_Expecter {
 	return &ChatService_Expecter{mock: &_m.Mock}
 }
 
+// AcceptBot provides a mock function for the type ChatService
+func (_mock *ChatService) AcceptBot(ctx context.Context, chatID uuid.UUID, userID uuid.UUID, botID uuid.UUID) error {
+	ret := _mock.Called(ctx, chatID, userID, botID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcceptBot")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, chatID, userID, botID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ChatService_AcceptBot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AcceptBot'
+type ChatService_AcceptBot_Call struct {
+	*mock.Call
+}
+
+// AcceptBot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID uuid.UUID
+//   - userID uuid.UUID
+//   - botID uuid.UUID
+func (_e *ChatService_Expecter) AcceptBot(ctx interface{}, chatID interface{}, userID interface{}, botID interface{}) *ChatService_AcceptBot_Call {
+	return &ChatService_AcceptBot_Call{Call: _e.mock.On("AcceptBot", ctx, chatID, userID, botID)}
+}
+
+func (_c *ChatService_AcceptBot_Call) Run(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID, botID uuid.UUID)) *ChatService_AcceptBot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		var arg3 uuid.UUID
+		if args[3] != nil {
+			arg3 = args[3].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *ChatService_AcceptBot_Call) Return(err error) *ChatService_AcceptBot_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ChatService_AcceptBot_Call) RunAndReturn(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID, botID uuid.UUID) error) *ChatService_AcceptBot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AcceptRequest provides a mock function for the type ChatService
+func (_mock *ChatService) AcceptRequest(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, chatID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcceptRequest")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, chatID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ChatService_AcceptRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AcceptRequest'
+type ChatService_AcceptRequest_Call struct {
+	*mock.Call
+}
+
+// AcceptRequest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID uuid.UUID
+//   - userID uuid.UUID
+func (_e *ChatService_Expecter) AcceptRequest(ctx interface{}, chatID interface{}, userID interface{}) *ChatService_AcceptRequest_Call {
+	return &ChatService_AcceptRequest_Call{Call: _e.mock.On("AcceptRequest", ctx, chatID, userID)}
+}
+
+func (_c *ChatService_AcceptRequest_Call) Run(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID)) *ChatService_AcceptRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ChatService_AcceptRequest_Call) Return(err error) *ChatService_AcceptRequest_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ChatService_AcceptRequest_Call) RunAndReturn(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) error) *ChatService_AcceptRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddBot provides a mock function for the type ChatService
+func (_mock *ChatService) AddBot(ctx context.Context, chatID uuid.UUID, userID uuid.UUID, botID uuid.UUID) error {
+	ret := _mock.Called(ctx, chatID, userID, botID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddBot")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, chatID, userID, botID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ChatService_AddBot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddBot'
+type ChatService_AddBot_Call struct {
+	*mock.Call
+}
+
+// AddBot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID uuid.UUID
+//   - userID uuid.UUID
+//   - botID uuid.UUID
+func (_e *ChatService_Expecter) AddBot(ctx interface{}, chatID interface{}, userID interface{}, botID interface{}) *ChatService_AddBot_Call {
+	return &ChatService_AddBot_Call{Call: _e.mock.On("AddBot", ctx, chatID, userID, botID)}
+}
+
+func (_c *ChatService_AddBot_Call) Run(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID, botID uuid.UUID)) *ChatService_AddBot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		var arg3 uuid.UUID
+		if args[3] != nil {
+			arg3 = args[3].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *ChatService_AddBot_Call) Return(err error) *ChatService_AddBot_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ChatService_AddBot_Call) RunAndReturn(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID, botID uuid.UUID) error) *ChatService_AddBot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ArchiveChat provides a mock function for the type ChatService
+func (_mock *ChatService) ArchiveChat(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, chatID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ArchiveChat")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, chatID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ChatService_ArchiveChat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ArchiveChat'
+type ChatService_ArchiveChat_Call struct {
+	*mock.Call
+}
+
+// ArchiveChat is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID uuid.UUID
+//   - userID uuid.UUID
+func (_e *ChatService_Expecter) ArchiveChat(ctx interface{}, chatID interface{}, userID interface{}) *ChatService_ArchiveChat_Call {
+	return &ChatService_ArchiveChat_Call{Call: _e.mock.On("ArchiveChat", ctx, chatID, userID)}
+}
+
+func (_c *ChatService_ArchiveChat_Call) Run(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID)) *ChatService_ArchiveChat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ChatService_ArchiveChat_Call) Return(err error) *ChatService_ArchiveChat_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ChatService_ArchiveChat_Call) RunAndReturn(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) error) *ChatService_ArchiveChat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BlockRequest provides a mock function for the type ChatService
+func (_mock *ChatService) BlockRequest(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, chatID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BlockRequest")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, chatID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ChatService_BlockRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BlockRequest'
+type ChatService_BlockRequest_Call struct {
+	*mock.Call
+}
+
+// BlockRequest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID uuid.UUID
+//   - userID uuid.UUID
+func (_e *ChatService_Expecter) BlockRequest(ctx interface{}, chatID interface{}, userID interface{}) *ChatService_BlockRequest_Call {
+	return &ChatService_BlockRequest_Call{Call: _e.mock.On("BlockRequest", ctx, chatID, userID)}
+}
+
+func (_c *ChatService_BlockRequest_Call) Run(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID)) *ChatService_BlockRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ChatService_BlockRequest_Call) Return(err error) *ChatService_BlockRequest_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ChatService_BlockRequest_Call) RunAndReturn(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) error) *ChatService_BlockRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateChat provides a mock function for the type ChatService
 func (_mock *ChatService) CreateChat(ctx context.Context, currentUserID uuid.UUID, otherUserID uuid.UUID) (uuid.UUID, error) {
 	ret := _mock.Called(ctx, currentUserID, otherUserID)
@@ -113,47 +443,38 @@ func (_c *ChatService_CreateChat_Call) RunAndReturn(run func(ctx context.Context
 	return _c
 }
 
-// GetChats provides a mock function for the type ChatService
-func (_mock *ChatService) GetChats(ctx context.Context, userID uuid.UUID) ([]chat.Chat, error) {
-	ret := _mock.Called(ctx, userID)
+// DeclineBot provides a mock function for the type ChatService
+func (_mock *ChatService) DeclineBot(ctx context.Context, chatID uuid.UUID, userID uuid.UUID, botID uuid.UUID) error {
+	ret := _mock.Called(ctx, chatID, userID, botID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetChats")
+		panic("no return value specified for DeclineBot")
 	}
 
-	var r0 []chat.Chat
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]chat.Chat, error)); ok {
-		return returnFunc(ctx, userID)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []chat.Chat); ok {
-		r0 = returnFunc(ctx, userID)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]chat.Chat)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = returnFunc(ctx, userID)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, chatID, userID, botID)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// ChatService_GetChats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChats'
-type ChatService_GetChats_Call struct {
+// ChatService_DeclineBot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeclineBot'
+type ChatService_DeclineBot_Call struct {
 	*mock.Call
 }
 
-// GetChats is a helper method to define mock.On call
+// DeclineBot is a helper method to define mock.On call
 //   - ctx context.Context
+//   - chatID uuid.UUID
 //   - userID uuid.UUID
-func (_e *ChatService_Expecter) GetChats(ctx interface{}, userID interface{}) *ChatService_GetChats_Call {
-	return &ChatService_GetChats_Call{Call: _e.mock.On("GetChats", ctx, userID)}
+//   - botID uuid.UUID
+func (_e *ChatService_Expecter) DeclineBot(ctx interface{}, chatID interface{}, userID interface{}, botID interface{}) *ChatService_DeclineBot_Call {
+	return &ChatService_DeclineBot_Call{Call: _e.mock.On("DeclineBot", ctx, chatID, userID, botID)}
 }
 
-func (_c *ChatService_GetChats_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *ChatService_GetChats_Call {
+func (_c *ChatService_DeclineBot_Call) Run(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID, botID uuid.UUID)) *ChatService_DeclineBot_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -163,20 +484,1391 @@ func (_c *ChatService_GetChats_Call) Run(run func(ctx context.Context, userID uu
 		if args[1] != nil {
 			arg1 = args[1].(uuid.UUID)
 		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		var arg3 uuid.UUID
+		if args[3] != nil {
+			arg3 = args[3].(uuid.UUID)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *ChatService_DeclineBot_Call) Return(err error) *ChatService_DeclineBot_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ChatService_DeclineBot_Call) RunAndReturn(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID, botID uuid.UUID) error) *ChatService_DeclineBot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeclineRequest provides a mock function for the type ChatService
+func (_mock *ChatService) DeclineRequest(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, chatID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeclineRequest")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, chatID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ChatService_DeclineRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeclineRequest'
+type ChatService_DeclineRequest_Call struct {
+	*mock.Call
+}
+
+// DeclineRequest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID uuid.UUID
+//   - userID uuid.UUID
+func (_e *ChatService_Expecter) DeclineRequest(ctx interface{}, chatID interface{}, userID interface{}) *ChatService_DeclineRequest_Call {
+	return &ChatService_DeclineRequest_Call{Call: _e.mock.On("DeclineRequest", ctx, chatID, userID)}
+}
+
+func (_c *ChatService_DeclineRequest_Call) Run(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID)) *ChatService_DeclineRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ChatService_DeclineRequest_Call) Return(err error) *ChatService_DeclineRequest_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ChatService_DeclineRequest_Call) RunAndReturn(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) error) *ChatService_DeclineRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteChatForEveryone provides a mock function for the type ChatService
+func (_mock *ChatService) DeleteChatForEveryone(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, chatID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteChatForEveryone")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, chatID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ChatService_DeleteChatForEveryone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteChatForEveryone'
+type ChatService_DeleteChatForEveryone_Call struct {
+	*mock.Call
+}
+
+// DeleteChatForEveryone is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID uuid.UUID
+//   - userID uuid.UUID
+func (_e *ChatService_Expecter) DeleteChatForEveryone(ctx interface{}, chatID interface{}, userID interface{}) *ChatService_DeleteChatForEveryone_Call {
+	return &ChatService_DeleteChatForEveryone_Call{Call: _e.mock.On("DeleteChatForEveryone", ctx, chatID, userID)}
+}
+
+func (_c *ChatService_DeleteChatForEveryone_Call) Run(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID)) *ChatService_DeleteChatForEveryone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ChatService_DeleteChatForEveryone_Call) Return(err error) *ChatService_DeleteChatForEveryone_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ChatService_DeleteChatForEveryone_Call) RunAndReturn(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) error) *ChatService_DeleteChatForEveryone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteChatForMe provides a mock function for the type ChatService
+func (_mock *ChatService) DeleteChatForMe(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, chatID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteChatForMe")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, chatID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ChatService_DeleteChatForMe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteChatForMe'
+type ChatService_DeleteChatForMe_Call struct {
+	*mock.Call
+}
+
+// DeleteChatForMe is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID uuid.UUID
+//   - userID uuid.UUID
+func (_e *ChatService_Expecter) DeleteChatForMe(ctx interface{}, chatID interface{}, userID interface{}) *ChatService_DeleteChatForMe_Call {
+	return &ChatService_DeleteChatForMe_Call{Call: _e.mock.On("DeleteChatForMe", ctx, chatID, userID)}
+}
+
+func (_c *ChatService_DeleteChatForMe_Call) Run(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID)) *ChatService_DeleteChatForMe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ChatService_DeleteChatForMe_Call) Return(err error) *ChatService_DeleteChatForMe_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ChatService_DeleteChatForMe_Call) RunAndReturn(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) error) *ChatService_DeleteChatForMe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnableEncryption provides a mock function for the type ChatService
+func (_mock *ChatService) EnableEncryption(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, chatID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnableEncryption")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, chatID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ChatService_EnableEncryption_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnableEncryption'
+type ChatService_EnableEncryption_Call struct {
+	*mock.Call
+}
+
+// EnableEncryption is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID uuid.UUID
+//   - userID uuid.UUID
+func (_e *ChatService_Expecter) EnableEncryption(ctx interface{}, chatID interface{}, userID interface{}) *ChatService_EnableEncryption_Call {
+	return &ChatService_EnableEncryption_Call{Call: _e.mock.On("EnableEncryption", ctx, chatID, userID)}
+}
+
+func (_c *ChatService_EnableEncryption_Call) Run(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID)) *ChatService_EnableEncryption_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ChatService_EnableEncryption_Call) Return(err error) *ChatService_EnableEncryption_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ChatService_EnableEncryption_Call) RunAndReturn(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) error) *ChatService_EnableEncryption_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindDirectChat provides a mock function for the type ChatService
+func (_mock *ChatService) FindDirectChat(ctx context.Context, userA uuid.UUID, userB uuid.UUID) (chat.Chat, error) {
+	ret := _mock.Called(ctx, userA, userB)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDirectChat")
+	}
+
+	var r0 chat.Chat
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (chat.Chat, error)); ok {
+		return returnFunc(ctx, userA, userB)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) chat.Chat); ok {
+		r0 = returnFunc(ctx, userA, userB)
+	} else {
+		r0 = ret.Get(0).(chat.Chat)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userA, userB)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ChatService_FindDirectChat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindDirectChat'
+type ChatService_FindDirectChat_Call struct {
+	*mock.Call
+}
+
+// FindDirectChat is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userA uuid.UUID
+//   - userB uuid.UUID
+func (_e *ChatService_Expecter) FindDirectChat(ctx interface{}, userA interface{}, userB interface{}) *ChatService_FindDirectChat_Call {
+	return &ChatService_FindDirectChat_Call{Call: _e.mock.On("FindDirectChat", ctx, userA, userB)}
+}
+
+func (_c *ChatService_FindDirectChat_Call) Run(run func(ctx context.Context, userA uuid.UUID, userB uuid.UUID)) *ChatService_FindDirectChat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ChatService_FindDirectChat_Call) Return(chat1 chat.Chat, err error) *ChatService_FindDirectChat_Call {
+	_c.Call.Return(chat1, err)
+	return _c
+}
+
+func (_c *ChatService_FindDirectChat_Call) RunAndReturn(run func(ctx context.Context, userA uuid.UUID, userB uuid.UUID) (chat.Chat, error)) *ChatService_FindDirectChat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChat provides a mock function for the type ChatService
+func (_mock *ChatService) GetChat(ctx context.Context, id uuid.UUID, userID uuid.UUID) (chat.Chat, error) {
+	ret := _mock.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChat")
+	}
+
+	var r0 chat.Chat
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (chat.Chat, error)); ok {
+		return returnFunc(ctx, id, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) chat.Chat); ok {
+		r0 = returnFunc(ctx, id, userID)
+	} else {
+		r0 = ret.Get(0).(chat.Chat)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ChatService_GetChat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChat'
+type ChatService_GetChat_Call struct {
+	*mock.Call
+}
+
+// GetChat is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - userID uuid.UUID
+func (_e *ChatService_Expecter) GetChat(ctx interface{}, id interface{}, userID interface{}) *ChatService_GetChat_Call {
+	return &ChatService_GetChat_Call{Call: _e.mock.On("GetChat", ctx, id, userID)}
+}
+
+func (_c *ChatService_GetChat_Call) Run(run func(ctx context.Context, id uuid.UUID, userID uuid.UUID)) *ChatService_GetChat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ChatService_GetChat_Call) Return(chat1 chat.Chat, err error) *ChatService_GetChat_Call {
+	_c.Call.Return(chat1, err)
+	return _c
+}
+
+func (_c *ChatService_GetChat_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, userID uuid.UUID) (chat.Chat, error)) *ChatService_GetChat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChatIDs provides a mock function for the type ChatService
+func (_mock *ChatService) GetChatIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChatIDs")
+	}
+
+	var r0 []uuid.UUID
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]uuid.UUID, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []uuid.UUID); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uuid.UUID)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ChatService_GetChatIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChatIDs'
+type ChatService_GetChatIDs_Call struct {
+	*mock.Call
+}
+
+// GetChatIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *ChatService_Expecter) GetChatIDs(ctx interface{}, userID interface{}) *ChatService_GetChatIDs_Call {
+	return &ChatService_GetChatIDs_Call{Call: _e.mock.On("GetChatIDs", ctx, userID)}
+}
+
+func (_c *ChatService_GetChatIDs_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *ChatService_GetChatIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ChatService_GetChatIDs_Call) Return(uUIDs []uuid.UUID, err error) *ChatService_GetChatIDs_Call {
+	_c.Call.Return(uUIDs, err)
+	return _c
+}
+
+func (_c *ChatService_GetChatIDs_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)) *ChatService_GetChatIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChats provides a mock function for the type ChatService
+func (_mock *ChatService) GetChats(ctx context.Context, in chatsvc.GetChatsInput) (chatsvc.ChatsPage, error) {
+	ret := _mock.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChats")
+	}
+
+	var r0 chatsvc.ChatsPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, chatsvc.GetChatsInput) (chatsvc.ChatsPage, error)); ok {
+		return returnFunc(ctx, in)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, chatsvc.GetChatsInput) chatsvc.ChatsPage); ok {
+		r0 = returnFunc(ctx, in)
+	} else {
+		r0 = ret.Get(0).(chatsvc.ChatsPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, chatsvc.GetChatsInput) error); ok {
+		r1 = returnFunc(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ChatService_GetChats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChats'
+type ChatService_GetChats_Call struct {
+	*mock.Call
+}
+
+// GetChats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in chatsvc.GetChatsInput
+func (_e *ChatService_Expecter) GetChats(ctx interface{}, in interface{}) *ChatService_GetChats_Call {
+	return &ChatService_GetChats_Call{Call: _e.mock.On("GetChats", ctx, in)}
+}
+
+func (_c *ChatService_GetChats_Call) Run(run func(ctx context.Context, in chatsvc.GetChatsInput)) *ChatService_GetChats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 chatsvc.GetChatsInput
+		if args[1] != nil {
+			arg1 = args[1].(chatsvc.GetChatsInput)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ChatService_GetChats_Call) Return(chatsPage chatsvc.ChatsPage, err error) *ChatService_GetChats_Call {
+	_c.Call.Return(chatsPage, err)
+	return _c
+}
+
+func (_c *ChatService_GetChats_Call) RunAndReturn(run func(ctx context.Context, in chatsvc.GetChatsInput) (chatsvc.ChatsPage, error)) *ChatService_GetChats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HandleEvent provides a mock function for the type ChatService
+func (_mock *ChatService) HandleEvent(ctx context.Context, e events.Event) error {
+	ret := _mock.Called(ctx, e)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleEvent")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, events.Event) error); ok {
+		r0 = returnFunc(ctx, e)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ChatService_HandleEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HandleEvent'
+type ChatService_HandleEvent_Call struct {
+	*mock.Call
+}
+
+// HandleEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - e events.Event
+func (_e *ChatService_Expecter) HandleEvent(ctx interface{}, e interface{}) *ChatService_HandleEvent_Call {
+	return &ChatService_HandleEvent_Call{Call: _e.mock.On("HandleEvent", ctx, e)}
+}
+
+func (_c *ChatService_HandleEvent_Call) Run(run func(ctx context.Context, e events.Event)) *ChatService_HandleEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 events.Event
+		if args[1] != nil {
+			arg1 = args[1].(events.Event)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ChatService_HandleEvent_Call) Return(err error) *ChatService_HandleEvent_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ChatService_HandleEvent_Call) RunAndReturn(run func(ctx context.Context, e events.Event) error) *ChatService_HandleEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MuteChat provides a mock function for the type ChatService
+func (_mock *ChatService) MuteChat(ctx context.Context, chatID uuid.UUID, userID uuid.UUID, until time.Time) error {
+	ret := _mock.Called(ctx, chatID, userID, until)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MuteChat")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, time.Time) error); ok {
+		r0 = returnFunc(ctx, chatID, userID, until)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ChatService_MuteChat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MuteChat'
+type ChatService_MuteChat_Call struct {
+	*mock.Call
+}
+
+// MuteChat is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID uuid.UUID
+//   - userID uuid.UUID
+//   - until time.Time
+func (_e *ChatService_Expecter) MuteChat(ctx interface{}, chatID interface{}, userID interface{}, until interface{}) *ChatService_MuteChat_Call {
+	return &ChatService_MuteChat_Call{Call: _e.mock.On("MuteChat", ctx, chatID, userID, until)}
+}
+
+func (_c *ChatService_MuteChat_Call) Run(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID, until time.Time)) *ChatService_MuteChat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *ChatService_MuteChat_Call) Return(err error) *ChatService_MuteChat_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ChatService_MuteChat_Call) RunAndReturn(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID, until time.Time) error) *ChatService_MuteChat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PinChat provides a mock function for the type ChatService
+func (_mock *ChatService) PinChat(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, chatID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PinChat")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, chatID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ChatService_PinChat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PinChat'
+type ChatService_PinChat_Call struct {
+	*mock.Call
+}
+
+// PinChat is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID uuid.UUID
+//   - userID uuid.UUID
+func (_e *ChatService_Expecter) PinChat(ctx interface{}, chatID interface{}, userID interface{}) *ChatService_PinChat_Call {
+	return &ChatService_PinChat_Call{Call: _e.mock.On("PinChat", ctx, chatID, userID)}
+}
+
+func (_c *ChatService_PinChat_Call) Run(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID)) *ChatService_PinChat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ChatService_PinChat_Call) Return(err error) *ChatService_PinChat_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ChatService_PinChat_Call) RunAndReturn(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) error) *ChatService_PinChat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReadMentions provides a mock function for the type ChatService
+func (_mock *ChatService) ReadMentions(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, chatID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReadMentions")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, chatID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ChatService_ReadMentions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadMentions'
+type ChatService_ReadMentions_Call struct {
+	*mock.Call
+}
+
+// ReadMentions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID uuid.UUID
+//   - userID uuid.UUID
+func (_e *ChatService_Expecter) ReadMentions(ctx interface{}, chatID interface{}, userID interface{}) *ChatService_ReadMentions_Call {
+	return &ChatService_ReadMentions_Call{Call: _e.mock.On("ReadMentions", ctx, chatID, userID)}
+}
+
+func (_c *ChatService_ReadMentions_Call) Run(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID)) *ChatService_ReadMentions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ChatService_ReadMentions_Call) Return(err error) *ChatService_ReadMentions_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ChatService_ReadMentions_Call) RunAndReturn(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) error) *ChatService_ReadMentions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordImport provides a mock function for the type ChatService
+func (_mock *ChatService) RecordImport(ctx context.Context, chatID uuid.UUID, lastMessageAt time.Time) error {
+	ret := _mock.Called(ctx, chatID, lastMessageAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordImport")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) error); ok {
+		r0 = returnFunc(ctx, chatID, lastMessageAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ChatService_RecordImport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordImport'
+type ChatService_RecordImport_Call struct {
+	*mock.Call
+}
+
+// RecordImport is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID uuid.UUID
+//   - lastMessageAt time.Time
+func (_e *ChatService_Expecter) RecordImport(ctx interface{}, chatID interface{}, lastMessageAt interface{}) *ChatService_RecordImport_Call {
+	return &ChatService_RecordImport_Call{Call: _e.mock.On("RecordImport", ctx, chatID, lastMessageAt)}
+}
+
+func (_c *ChatService_RecordImport_Call) Run(run func(ctx context.Context, chatID uuid.UUID, lastMessageAt time.Time)) *ChatService_RecordImport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ChatService_RecordImport_Call) Return(err error) *ChatService_RecordImport_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ChatService_RecordImport_Call) RunAndReturn(run func(ctx context.Context, chatID uuid.UUID, lastMessageAt time.Time) error) *ChatService_RecordImport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveBot provides a mock function for the type ChatService
+func (_mock *ChatService) RemoveBot(ctx context.Context, chatID uuid.UUID, userID uuid.UUID, botID uuid.UUID) error {
+	ret := _mock.Called(ctx, chatID, userID, botID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveBot")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, chatID, userID, botID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ChatService_RemoveBot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveBot'
+type ChatService_RemoveBot_Call struct {
+	*mock.Call
+}
+
+// RemoveBot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID uuid.UUID
+//   - userID uuid.UUID
+//   - botID uuid.UUID
+func (_e *ChatService_Expecter) RemoveBot(ctx interface{}, chatID interface{}, userID interface{}, botID interface{}) *ChatService_RemoveBot_Call {
+	return &ChatService_RemoveBot_Call{Call: _e.mock.On("RemoveBot", ctx, chatID, userID, botID)}
+}
+
+func (_c *ChatService_RemoveBot_Call) Run(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID, botID uuid.UUID)) *ChatService_RemoveBot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		var arg3 uuid.UUID
+		if args[3] != nil {
+			arg3 = args[3].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *ChatService_RemoveBot_Call) Return(err error) *ChatService_RemoveBot_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ChatService_RemoveBot_Call) RunAndReturn(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID, botID uuid.UUID) error) *ChatService_RemoveBot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveUser provides a mock function for the type ChatService
+func (_mock *ChatService) RemoveUser(ctx context.Context, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveUser")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ChatService_RemoveUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveUser'
+type ChatService_RemoveUser_Call struct {
+	*mock.Call
+}
+
+// RemoveUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *ChatService_Expecter) RemoveUser(ctx interface{}, userID interface{}) *ChatService_RemoveUser_Call {
+	return &ChatService_RemoveUser_Call{Call: _e.mock.On("RemoveUser", ctx, userID)}
+}
+
+func (_c *ChatService_RemoveUser_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *ChatService_RemoveUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ChatService_RemoveUser_Call) Return(err error) *ChatService_RemoveUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ChatService_RemoveUser_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) error) *ChatService_RemoveUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReorderPinnedChats provides a mock function for the type ChatService
+func (_mock *ChatService) ReorderPinnedChats(ctx context.Context, userID uuid.UUID, chatIDs []uuid.UUID) error {
+	ret := _mock.Called(ctx, userID, chatIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReorderPinnedChats")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, []uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, userID, chatIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ChatService_ReorderPinnedChats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReorderPinnedChats'
+type ChatService_ReorderPinnedChats_Call struct {
+	*mock.Call
+}
+
+// ReorderPinnedChats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - chatIDs []uuid.UUID
+func (_e *ChatService_Expecter) ReorderPinnedChats(ctx interface{}, userID interface{}, chatIDs interface{}) *ChatService_ReorderPinnedChats_Call {
+	return &ChatService_ReorderPinnedChats_Call{Call: _e.mock.On("ReorderPinnedChats", ctx, userID, chatIDs)}
+}
+
+func (_c *ChatService_ReorderPinnedChats_Call) Run(run func(ctx context.Context, userID uuid.UUID, chatIDs []uuid.UUID)) *ChatService_ReorderPinnedChats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 []uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].([]uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ChatService_ReorderPinnedChats_Call) Return(err error) *ChatService_ReorderPinnedChats_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ChatService_ReorderPinnedChats_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, chatIDs []uuid.UUID) error) *ChatService_ReorderPinnedChats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetDisappearingMessages provides a mock function for the type ChatService
+func (_mock *ChatService) SetDisappearingMessages(ctx context.Context, chatID uuid.UUID, userID uuid.UUID, after time.Duration) error {
+	ret := _mock.Called(ctx, chatID, userID, after)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDisappearingMessages")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, time.Duration) error); ok {
+		r0 = returnFunc(ctx, chatID, userID, after)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ChatService_SetDisappearingMessages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetDisappearingMessages'
+type ChatService_SetDisappearingMessages_Call struct {
+	*mock.Call
+}
+
+// SetDisappearingMessages is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID uuid.UUID
+//   - userID uuid.UUID
+//   - after time.Duration
+func (_e *ChatService_Expecter) SetDisappearingMessages(ctx interface{}, chatID interface{}, userID interface{}, after interface{}) *ChatService_SetDisappearingMessages_Call {
+	return &ChatService_SetDisappearingMessages_Call{Call: _e.mock.On("SetDisappearingMessages", ctx, chatID, userID, after)}
+}
+
+func (_c *ChatService_SetDisappearingMessages_Call) Run(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID, after time.Duration)) *ChatService_SetDisappearingMessages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		var arg3 time.Duration
+		if args[3] != nil {
+			arg3 = args[3].(time.Duration)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *ChatService_SetDisappearingMessages_Call) Return(err error) *ChatService_SetDisappearingMessages_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ChatService_SetDisappearingMessages_Call) RunAndReturn(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID, after time.Duration) error) *ChatService_SetDisappearingMessages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetNickname provides a mock function for the type ChatService
+func (_mock *ChatService) SetNickname(ctx context.Context, chatID uuid.UUID, userID uuid.UUID, nickname string) error {
+	ret := _mock.Called(ctx, chatID, userID, nickname)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetNickname")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, string) error); ok {
+		r0 = returnFunc(ctx, chatID, userID, nickname)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ChatService_SetNickname_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetNickname'
+type ChatService_SetNickname_Call struct {
+	*mock.Call
+}
+
+// SetNickname is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID uuid.UUID
+//   - userID uuid.UUID
+//   - nickname string
+func (_e *ChatService_Expecter) SetNickname(ctx interface{}, chatID interface{}, userID interface{}, nickname interface{}) *ChatService_SetNickname_Call {
+	return &ChatService_SetNickname_Call{Call: _e.mock.On("SetNickname", ctx, chatID, userID, nickname)}
+}
+
+func (_c *ChatService_SetNickname_Call) Run(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID, nickname string)) *ChatService_SetNickname_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *ChatService_SetNickname_Call) Return(err error) *ChatService_SetNickname_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ChatService_SetNickname_Call) RunAndReturn(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID, nickname string) error) *ChatService_SetNickname_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnarchiveChat provides a mock function for the type ChatService
+func (_mock *ChatService) UnarchiveChat(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, chatID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnarchiveChat")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, chatID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ChatService_UnarchiveChat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnarchiveChat'
+type ChatService_UnarchiveChat_Call struct {
+	*mock.Call
+}
+
+// UnarchiveChat is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID uuid.UUID
+//   - userID uuid.UUID
+func (_e *ChatService_Expecter) UnarchiveChat(ctx interface{}, chatID interface{}, userID interface{}) *ChatService_UnarchiveChat_Call {
+	return &ChatService_UnarchiveChat_Call{Call: _e.mock.On("UnarchiveChat", ctx, chatID, userID)}
+}
+
+func (_c *ChatService_UnarchiveChat_Call) Run(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID)) *ChatService_UnarchiveChat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ChatService_UnarchiveChat_Call) Return(err error) *ChatService_UnarchiveChat_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ChatService_UnarchiveChat_Call) RunAndReturn(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) error) *ChatService_UnarchiveChat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnmuteChat provides a mock function for the type ChatService
+func (_mock *ChatService) UnmuteChat(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, chatID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnmuteChat")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, chatID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ChatService_UnmuteChat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnmuteChat'
+type ChatService_UnmuteChat_Call struct {
+	*mock.Call
+}
+
+// UnmuteChat is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID uuid.UUID
+//   - userID uuid.UUID
+func (_e *ChatService_Expecter) UnmuteChat(ctx interface{}, chatID interface{}, userID interface{}) *ChatService_UnmuteChat_Call {
+	return &ChatService_UnmuteChat_Call{Call: _e.mock.On("UnmuteChat", ctx, chatID, userID)}
+}
+
+func (_c *ChatService_UnmuteChat_Call) Run(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID)) *ChatService_UnmuteChat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ChatService_UnmuteChat_Call) Return(err error) *ChatService_UnmuteChat_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ChatService_UnmuteChat_Call) RunAndReturn(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) error) *ChatService_UnmuteChat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnpinChat provides a mock function for the type ChatService
+func (_mock *ChatService) UnpinChat(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, chatID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnpinChat")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, chatID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ChatService_UnpinChat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnpinChat'
+type ChatService_UnpinChat_Call struct {
+	*mock.Call
+}
+
+// UnpinChat is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID uuid.UUID
+//   - userID uuid.UUID
+func (_e *ChatService_Expecter) UnpinChat(ctx interface{}, chatID interface{}, userID interface{}) *ChatService_UnpinChat_Call {
+	return &ChatService_UnpinChat_Call{Call: _e.mock.On("UnpinChat", ctx, chatID, userID)}
+}
+
+func (_c *ChatService_UnpinChat_Call) Run(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID)) *ChatService_UnpinChat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *ChatService_GetChats_Call) Return(chats []chat.Chat, err error) *ChatService_GetChats_Call {
-	_c.Call.Return(chats, err)
+func (_c *ChatService_UnpinChat_Call) Return(err error) *ChatService_UnpinChat_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *ChatService_GetChats_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) ([]chat.Chat, error)) *ChatService_GetChats_Call {
+func (_c *ChatService_UnpinChat_Call) RunAndReturn(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) error) *ChatService_UnpinChat_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -1,6 +1,7 @@
 package inmemchatrepo
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"github.com/AliUnipal/chat/internal/service/chatsvc/repo"
@@ -8,34 +9,48 @@ import (
 	"github.com/google/uuid"
 	"slices"
 	"strings"
+	"sync"
+	"time"
 )
 
 func New(userRepo userRepository) *repository {
 	return &repository{
-		make(map[string]*repo.Chat),
-		make(map[uuid.UUID][]*repo.Chat),
-		userRepo,
+		chats:       make(map[uuid.UUID]*repo.Chat),
+		directChats: make(map[string]uuid.UUID),
+		members:     make(map[uuid.UUID]map[uuid.UUID]*repo.Member),
+		userChats:   make(map[uuid.UUID][]uuid.UUID),
+		userRepo:    userRepo,
 	}
 }
 
+// repository is safe for concurrent use, as background work such as sending
+// scheduled messages and exporting data reads chats while requests change
+// them. Chats are returned as copies, so callers never share its state.
 type repository struct {
-	chats     map[string]*repo.Chat
-	userChats map[uuid.UUID][]*repo.Chat
-	userRepo  userRepository
+	mu          sync.RWMutex
+	chats       map[uuid.UUID]*repo.Chat
+	directChats map[string]uuid.UUID
+	members     map[uuid.UUID]map[uuid.UUID]*repo.Member
+	userChats   map[uuid.UUID][]uuid.UUID
+	userRepo    userRepository
 }
 
 type userRepository interface {
 	GetUser(ctx context.Context, id uuid.UUID) (userRepo.CreateUserInput, error)
 }
 
-func (r *repository) CreateChat(ctx context.Context, in repo.CreateChatInput) error {
-	ids := []string{in.CurrentUserID.String(), in.OtherUserID.String()}
+// directChatKey identifies a direct chat by its participants regardless of
+// which of them created it.
+func directChatKey(userA, userB uuid.UUID) string {
+	ids := []string{userA.String(), userB.String()}
 	slices.Sort(ids)
-	id := strings.Join(ids, "|")
-	if _, ok := r.chats[id]; ok {
-		return errors.New("chat already exists")
-	}
+	return strings.Join(ids, "|")
+}
 
+func (r *repository) CreateChat(ctx context.Context, in repo.CreateChatInput) error {
+	if in.ID == uuid.Nil {
+		return errors.New("chat id is required")
+	}
 	cu, err := r.userRepo.GetUser(ctx, in.CurrentUserID)
 	if err != nil {
 		return err
@@ -45,19 +60,482 @@ func (r *repository) CreateChat(ctx context.Context, in repo.CreateChatInput) er
 		return err
 	}
 
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.chats[in.ID]; ok {
+		return errors.New("chat already exists")
+	}
+	key := directChatKey(in.CurrentUserID, in.OtherUserID)
+	if _, ok := r.directChats[key]; ok {
+		return errors.New("chat already exists")
+	}
+
 	chat := &repo.Chat{
-		ID:          in.ID,
-		CurrentUser: repo.User(cu),
-		OtherUser:   repo.User(ou),
+		ID:           in.ID,
+		Participants: []repo.User{toUser(cu), toUser(ou)},
+		CreatedAt:    in.CreatedAt,
+	}
+	if in.Request {
+		chat.RequesterID = in.CurrentUserID
+		chat.RequestStatus = repo.RequestPending
+	}
+
+	r.chats[in.ID] = chat
+	r.directChats[key] = in.ID
+	r.members[in.ID] = map[uuid.UUID]*repo.Member{
+		in.CurrentUserID: {ChatID: in.ID, UserID: in.CurrentUserID},
+		in.OtherUserID:   {ChatID: in.ID, UserID: in.OtherUserID},
+	}
+	r.userChats[in.CurrentUserID] = append(r.userChats[in.CurrentUserID], in.ID)
+	r.userChats[in.OtherUserID] = append(r.userChats[in.OtherUserID], in.ID)
+
+	return nil
+}
+
+func (r *repository) GetChat(_ context.Context, id uuid.UUID) (repo.Chat, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	chat, ok := r.chats[id]
+	if !ok {
+		return repo.Chat{}, repo.ErrChatNotFound
+	}
+
+	return clone(chat), nil
+}
+
+func (r *repository) FindDirectChat(_ context.Context, userA, userB uuid.UUID) (repo.Chat, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	id, ok := r.directChats[directChatKey(userA, userB)]
+	if !ok {
+		return repo.Chat{}, repo.ErrChatNotFound
+	}
+
+	return clone(r.chats[id]), nil
+}
+
+func (r *repository) GetChatsByUser(_ context.Context, userID uuid.UUID) ([]repo.UserChat, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := r.userChats[userID]
+	chats := make([]repo.UserChat, len(ids))
+	for i, id := range ids {
+		chats[i] = repo.UserChat{
+			Chat:   clone(r.chats[id]),
+			Member: *r.members[id][userID],
+		}
+	}
+
+	return chats, nil
+}
+
+// GetUserChats returns the user's chats matching the filter, pinned chats
+// first in their pinned order followed by the most recently active chats.
+func (r *repository) GetUserChats(_ context.Context, in repo.GetUserChatsInput) ([]repo.UserChat, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var chats []repo.UserChat
+	for _, id := range r.userChats[in.UserID] {
+		c := repo.UserChat{Chat: *r.chats[id], Member: *r.members[id][in.UserID]}
+		if !listed(c, in.Filter, in.Now) {
+			continue
+		}
+		if in.After != nil && compareCursors(c.Cursor(), *in.After) <= 0 {
+			continue
+		}
+		chats = append(chats, c)
+	}
+	slices.SortFunc(chats, func(a, b repo.UserChat) int {
+		return compareCursors(a.Cursor(), b.Cursor())
+	})
+	chats = chats[:min(in.Limit, len(chats))]
+	for i := range chats {
+		chats[i].Chat = clone(&chats[i].Chat)
+	}
+
+	return chats, nil
+}
+
+func (r *repository) UpdateRequestStatus(_ context.Context, chatID uuid.UUID, status repo.RequestStatus) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	chat, ok := r.chats[chatID]
+	if !ok {
+		return repo.ErrChatNotFound
+	}
+
+	chat.RequestStatus = status
+	return nil
+}
+
+// UseRequestMessage records that senderID sent the one message a message
+// request allows. It returns repo.ErrRequestPending when someone else
+// writes before the request is accepted or the message was already sent.
+func (r *repository) UseRequestMessage(_ context.Context, chatID, senderID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	chat, ok := r.chats[chatID]
+	if !ok {
+		return repo.ErrChatNotFound
+	}
+	if chat.RequestStatus == repo.NoRequest {
+		return nil
+	}
+	if chat.RequesterID != senderID || chat.RequestMessageSent {
+		return repo.ErrRequestPending
+	}
+
+	chat.RequestMessageSent = true
+	return nil
+}
+
+func (r *repository) EnableEncryption(_ context.Context, chatID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	chat, ok := r.chats[chatID]
+	if !ok {
+		return repo.ErrChatNotFound
+	}
+
+	chat.Encrypted = true
+	return nil
+}
+
+func (r *repository) SetDisappearAfter(_ context.Context, chatID uuid.UUID, after time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	chat, ok := r.chats[chatID]
+	if !ok {
+		return repo.ErrChatNotFound
+	}
+
+	chat.DisappearAfter = after
+	return nil
+}
+
+// SetLastMessageAt records a message sent at the given time, unless a later
+// one was recorded already.
+func (r *repository) SetLastMessageAt(_ context.Context, chatID uuid.UUID, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	chat, ok := r.chats[chatID]
+	if !ok {
+		return repo.ErrChatNotFound
+	}
+
+	if at.After(chat.LastMessageAt) {
+		chat.LastMessageAt = at
+	}
+	return nil
+}
+
+// DeleteChat removes the chat along with its memberships.
+func (r *repository) DeleteChat(_ context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	chat, ok := r.chats[id]
+	if !ok {
+		return repo.ErrChatNotFound
+	}
+
+	for _, p := range chat.Participants {
+		r.userChats[p.ID] = slices.DeleteFunc(r.userChats[p.ID], func(chatID uuid.UUID) bool {
+			return chatID == id
+		})
+		if len(r.userChats[p.ID]) == 0 {
+			delete(r.userChats, p.ID)
+		}
+	}
+	// The first two participants started the chat; anyone after them was
+	// added later.
+	if len(chat.Participants) >= 2 {
+		delete(r.directChats, directChatKey(chat.Participants[0].ID, chat.Participants[1].ID))
+	}
+	delete(r.members, id)
+	delete(r.chats, id)
+
+	return nil
+}
+
+// RemoveMember drops the user's membership while keeping them among the
+// participants, marked removed, with their profile refreshed so a deleted
+// user shows up anonymized.
+func (r *repository) RemoveMember(ctx context.Context, chatID, userID uuid.UUID) error {
+	u, err := r.userRepo.GetUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	chat, ok := r.chats[chatID]
+	if !ok {
+		return repo.ErrChatNotFound
+	}
+	if _, ok := r.members[chatID][userID]; !ok {
+		return repo.ErrMemberNotFound
+	}
+
+	chat.Participants = slices.Clone(chat.Participants)
+	for i, p := range chat.Participants {
+		if p.ID == userID {
+			chat.Participants[i] = toUser(u)
+			chat.Participants[i].Removed = true
+		}
+	}
+	delete(r.members[chatID], userID)
+	r.userChats[userID] = slices.DeleteFunc(r.userChats[userID], func(id uuid.UUID) bool {
+		return id == chatID
+	})
+	if len(r.userChats[userID]) == 0 {
+		delete(r.userChats, userID)
+	}
+
+	return nil
+}
+
+// AddMember adds the user to the chat's participants and members.
+func (r *repository) AddMember(ctx context.Context, chatID, userID uuid.UUID) error {
+	u, err := r.userRepo.GetUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	chat, ok := r.chats[chatID]
+	if !ok {
+		return repo.ErrChatNotFound
+	}
+	if slices.ContainsFunc(chat.Participants, func(p repo.User) bool { return p.ID == userID }) {
+		return repo.ErrMemberExists
+	}
+
+	chat.Participants = append(chat.Participants, toUser(u))
+	r.members[chatID][userID] = &repo.Member{ChatID: chatID, UserID: userID}
+	r.userChats[userID] = append(r.userChats[userID], chatID)
+	return nil
+}
+
+// InviteBot records the invitation of a bot to the chat, replacing an
+// earlier invitation of the same bot.
+func (r *repository) InviteBot(_ context.Context, chatID uuid.UUID, invite repo.BotInvite) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	chat, ok := r.chats[chatID]
+	if !ok {
+		return repo.ErrChatNotFound
+	}
+	if slices.ContainsFunc(chat.Participants, func(p repo.User) bool { return p.ID == invite.BotID }) {
+		return repo.ErrMemberExists
+	}
+
+	chat.BotInvites = append(slices.DeleteFunc(slices.Clone(chat.BotInvites), func(i repo.BotInvite) bool {
+		return i.BotID == invite.BotID
+	}), invite)
+	return nil
+}
+
+// TakeBotInvite removes the invitation of the bot to the chat and returns
+// it, so only one caller can act on it.
+func (r *repository) TakeBotInvite(_ context.Context, chatID, botID uuid.UUID) (repo.BotInvite, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	chat, ok := r.chats[chatID]
+	if !ok {
+		return repo.BotInvite{}, repo.ErrChatNotFound
+	}
+	i := slices.IndexFunc(chat.BotInvites, func(i repo.BotInvite) bool { return i.BotID == botID })
+	if i < 0 {
+		return repo.BotInvite{}, repo.ErrInviteNotFound
+	}
+
+	invite := chat.BotInvites[i]
+	chat.BotInvites = slices.Delete(slices.Clone(chat.BotInvites), i, i+1)
+	return invite, nil
+}
+
+// RemoveParticipant takes the user out of the chat altogether. Unlike
+// RemoveMember, it leaves no trace of them among the participants.
+func (r *repository) RemoveParticipant(_ context.Context, chatID, userID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	chat, ok := r.chats[chatID]
+	if !ok {
+		return repo.ErrChatNotFound
+	}
+	if _, ok := r.members[chatID][userID]; !ok {
+		return repo.ErrMemberNotFound
+	}
+
+	chat.Participants = slices.DeleteFunc(slices.Clone(chat.Participants), func(p repo.User) bool { return p.ID == userID })
+	delete(r.members[chatID], userID)
+	r.userChats[userID] = slices.DeleteFunc(r.userChats[userID], func(id uuid.UUID) bool {
+		return id == chatID
+	})
+	if len(r.userChats[userID]) == 0 {
+		delete(r.userChats, userID)
+	}
+
+	return nil
+}
+
+// GetMembers returns the memberships of the chat's participants that are
+// still members.
+func (r *repository) GetMembers(_ context.Context, chatID uuid.UUID) ([]repo.Member, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	chat, ok := r.chats[chatID]
+	if !ok {
+		return nil, repo.ErrChatNotFound
 	}
 
-	r.chats[id] = chat
-	r.userChats[in.CurrentUserID] = append(r.userChats[in.CurrentUserID], chat)
-	r.userChats[in.OtherUserID] = append(r.userChats[in.OtherUserID], chat)
+	members := make([]repo.Member, 0, len(chat.Participants))
+	for _, p := range chat.Participants {
+		if m, ok := r.members[chatID][p.ID]; ok {
+			members = append(members, *m)
+		}
+	}
 
+	return members, nil
+}
+
+func (r *repository) GetMember(_ context.Context, chatID, userID uuid.UUID) (repo.Member, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	members, ok := r.members[chatID]
+	if !ok {
+		return repo.Member{}, repo.ErrChatNotFound
+	}
+	m, ok := members[userID]
+	if !ok {
+		return repo.Member{}, repo.ErrMemberNotFound
+	}
+
+	return *m, nil
+}
+
+func (r *repository) UpdateMember(_ context.Context, in repo.Member) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	members, ok := r.members[in.ChatID]
+	if !ok {
+		return repo.ErrChatNotFound
+	}
+	if _, ok := members[in.UserID]; !ok {
+		return repo.ErrMemberNotFound
+	}
+
+	m := in
+	members[in.UserID] = &m
 	return nil
 }
 
-func (r *repository) GetChatsByUser(_ context.Context, userID uuid.UUID) ([]*repo.Chat, error) {
-	return r.userChats[userID], nil
+// UnarchiveMembers brings the chat back to the inbox of the members who
+// archived it before a message sent at sentAt, unless they had it muted at
+// the time. Members listed in throughMute get it back even then.
+func (r *repository) UnarchiveMembers(_ context.Context, chatID uuid.UUID, sentAt time.Time, throughMute []uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	members, ok := r.members[chatID]
+	if !ok {
+		return repo.ErrChatNotFound
+	}
+
+	for id, m := range members {
+		if m.ArchivedAt.IsZero() || !sentAt.After(m.ArchivedAt) {
+			continue
+		}
+		if sentAt.Before(m.MutedUntil) && !slices.Contains(throughMute, id) {
+			continue
+		}
+		m.ArchivedAt = time.Time{}
+	}
+
+	return nil
+}
+
+// listed reports whether the chat shows up in its member's list under the
+// filter. Chats the member deleted stay hidden until a new message arrives.
+func listed(c repo.UserChat, f repo.ChatFilter, now time.Time) bool {
+	m := c.Member
+	if !m.ClearedAt.IsZero() && !c.LastMessageAt.After(m.ClearedAt) {
+		return false
+	}
+	incoming := c.RequestStatus != repo.NoRequest && c.RequesterID != m.UserID
+	if incoming && c.RequestStatus == repo.RequestDeclined {
+		return false
+	}
+	if f == repo.RequestChats || incoming {
+		return f == repo.RequestChats && incoming
+	}
+
+	switch f {
+	case repo.InboxChats:
+		return m.ArchivedAt.IsZero()
+	case repo.ArchivedChats:
+		return !m.ArchivedAt.IsZero()
+	case repo.PinnedChats:
+		return m.PinPosition > 0
+	case repo.MutedChats:
+		return now.Before(m.MutedUntil)
+	default:
+		return true
+	}
+}
+
+// compareCursors orders pinned chats first by their position, then the most
+// recent activity first, breaking ties by chat ID.
+func compareCursors(a, b repo.Cursor) int {
+	if a.PinPosition != b.PinPosition {
+		switch {
+		case a.PinPosition == 0:
+			return 1
+		case b.PinPosition == 0:
+			return -1
+		default:
+			return cmp.Compare(a.PinPosition, b.PinPosition)
+		}
+	}
+	if c := b.LastActivity.Compare(a.LastActivity); c != 0 {
+		return c
+	}
+
+	return cmp.Compare(a.ChatID.String(), b.ChatID.String())
+}
+
+// clone copies a chat, participants included.
+func clone(c *repo.Chat) repo.Chat {
+	r := *c
+	r.Participants = slices.Clone(c.Participants)
+	r.BotInvites = slices.Clone(c.BotInvites)
+	return r
+}
+
+func toUser(u userRepo.CreateUserInput) repo.User {
+	return repo.User{
+		ID:        u.ID,
+		ImageURL:  u.ImageURL,
+		FirstName: u.FirstName,
+		LastName:  u.LastName,
+		Username:  u.Username,
+		Type:      u.Type,
+	}
 }
@@ -0,0 +1,232 @@
+package inmemchatrepo_test
+
+import (
+	"context"
+	"errors"
+	"github.com/AliUnipal/chat/internal/service/chatsvc/repo"
+	"github.com/AliUnipal/chat/internal/service/chatsvc/repo/inmemchatrepo"
+	"github.com/AliUnipal/chat/internal/service/chatsvc/repo/inmemchatrepo/mocks"
+	userRepo "github.com/AliUnipal/chat/internal/service/usersvc/repo"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"slices"
+	"testing"
+	"time"
+)
+
+type chatRepository interface {
+	CreateChat(ctx context.Context, in repo.CreateChatInput) error
+	GetUserChats(ctx context.Context, in repo.GetUserChatsInput) ([]repo.UserChat, error)
+	GetMember(ctx context.Context, chatID, userID uuid.UUID) (repo.Member, error)
+	UpdateMember(ctx context.Context, member repo.Member) error
+	UpdateRequestStatus(ctx context.Context, chatID uuid.UUID, status repo.RequestStatus) error
+	SetLastMessageAt(ctx context.Context, chatID uuid.UUID, at time.Time) error
+	GetChat(ctx context.Context, id uuid.UUID) (repo.Chat, error)
+	InviteBot(ctx context.Context, chatID uuid.UUID, invite repo.BotInvite) error
+	TakeBotInvite(ctx context.Context, chatID, botID uuid.UUID) (repo.BotInvite, error)
+	UseRequestMessage(ctx context.Context, chatID, senderID uuid.UUID) error
+}
+
+func newRepo(t *testing.T) chatRepository {
+	t.Helper()
+	users := mocks.NewUserRepository(t)
+	users.EXPECT().GetUser(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, id uuid.UUID) (userRepo.CreateUserInput, error) {
+		return userRepo.CreateUserInput{ID: id}, nil
+	}).Maybe()
+
+	return inmemchatrepo.New(users)
+}
+
+// createChat adds a chat between the user the input names and a new user
+// and applies update to the named user's membership.
+func createChat(t *testing.T, r chatRepository, in repo.CreateChatInput, update func(m *repo.Member)) uuid.UUID {
+	t.Helper()
+	ctx := context.Background()
+	in.ID = uuid.New()
+	userID := in.CurrentUserID
+	if userID == uuid.Nil {
+		userID = in.OtherUserID
+		in.CurrentUserID = uuid.New()
+	} else {
+		in.OtherUserID = uuid.New()
+	}
+	if err := r.CreateChat(ctx, in); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if update == nil {
+		return in.ID
+	}
+
+	m, err := r.GetMember(ctx, in.ID, userID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	update(&m)
+	if err := r.UpdateMember(ctx, m); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	return in.ID
+}
+
+func listIDs(t *testing.T, r chatRepository, in repo.GetUserChatsInput) []uuid.UUID {
+	t.Helper()
+	chats, err := r.GetUserChats(context.Background(), in)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	ids := make([]uuid.UUID, len(chats))
+	for i, c := range chats {
+		ids[i] = c.ID
+	}
+	return ids
+}
+
+func TestGetUserChats_OrderAndPage(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	createdAt := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	r := newRepo(t)
+
+	oldest := createChat(t, r, repo.CreateChatInput{CurrentUserID: userID, CreatedAt: createdAt}, nil)
+	active := createChat(t, r, repo.CreateChatInput{CurrentUserID: userID, CreatedAt: createdAt}, nil)
+	newest := createChat(t, r, repo.CreateChatInput{CurrentUserID: userID, CreatedAt: createdAt.Add(2 * time.Hour)}, nil)
+	second := createChat(t, r, repo.CreateChatInput{CurrentUserID: userID, CreatedAt: createdAt}, func(m *repo.Member) { m.PinPosition = 2 })
+	first := createChat(t, r, repo.CreateChatInput{CurrentUserID: userID, CreatedAt: createdAt}, func(m *repo.Member) { m.PinPosition = 1 })
+	if err := r.SetLastMessageAt(ctx, active, createdAt.Add(3*time.Hour)); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	// An older message must not move the activity back.
+	if err := r.SetLastMessageAt(ctx, active, createdAt.Add(time.Hour)); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	expected := []uuid.UUID{first, second, active, newest, oldest}
+
+	if got := listIDs(t, r, repo.GetUserChatsInput{UserID: userID, Limit: 10}); !slices.Equal(got, expected) {
+		t.Fatalf("expected %v got %v", expected, got)
+	}
+
+	var got []uuid.UUID
+	in := repo.GetUserChatsInput{UserID: userID, Limit: 2}
+	for {
+		chats, err := r.GetUserChats(ctx, in)
+		if err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+		if len(chats) == 0 {
+			break
+		}
+		if len(chats) > in.Limit {
+			t.Fatalf("expected at most %d chats got %d", in.Limit, len(chats))
+		}
+		for _, c := range chats {
+			got = append(got, c.ID)
+		}
+		cursor := chats[len(chats)-1].Cursor()
+		in.After = &cursor
+	}
+	if !slices.Equal(got, expected) {
+		t.Fatalf("expected %v got %v", expected, got)
+	}
+}
+
+func TestGetUserChats_Filter(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	now := time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+	r := newRepo(t)
+
+	inbox := createChat(t, r, repo.CreateChatInput{CurrentUserID: userID, CreatedAt: now.Add(-time.Hour)}, nil)
+	pinned := createChat(t, r, repo.CreateChatInput{CurrentUserID: userID, CreatedAt: now.Add(-2 * time.Hour)}, func(m *repo.Member) { m.PinPosition = 1 })
+	archived := createChat(t, r, repo.CreateChatInput{CurrentUserID: userID, CreatedAt: now.Add(-3 * time.Hour)}, func(m *repo.Member) { m.ArchivedAt = now })
+	muted := createChat(t, r, repo.CreateChatInput{CurrentUserID: userID, CreatedAt: now.Add(-4 * time.Hour)}, func(m *repo.Member) { m.MutedUntil = now.Add(time.Hour) })
+	unmuted := createChat(t, r, repo.CreateChatInput{CurrentUserID: userID, CreatedAt: now.Add(-5 * time.Hour)}, func(m *repo.Member) { m.MutedUntil = now.Add(-time.Hour) })
+	createChat(t, r, repo.CreateChatInput{CurrentUserID: userID, CreatedAt: now.Add(-6 * time.Hour)}, func(m *repo.Member) { m.ClearedAt = now.Add(-time.Minute) })
+	resumed := createChat(t, r, repo.CreateChatInput{CurrentUserID: userID, CreatedAt: now.Add(-7 * time.Hour)}, func(m *repo.Member) { m.ClearedAt = now.Add(-2 * time.Hour) })
+	if err := r.SetLastMessageAt(ctx, resumed, now.Add(-90*time.Minute)); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	incoming := createChat(t, r, repo.CreateChatInput{OtherUserID: userID, CreatedAt: now.Add(-8 * time.Hour), Request: true}, nil)
+	declined := createChat(t, r, repo.CreateChatInput{OtherUserID: userID, CreatedAt: now.Add(-9 * time.Hour), Request: true}, nil)
+	if err := r.UpdateRequestStatus(ctx, declined, repo.RequestDeclined); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	outgoing := createChat(t, r, repo.CreateChatInput{CurrentUserID: userID, CreatedAt: now.Add(-10 * time.Hour), Request: true}, nil)
+	if err := r.UpdateRequestStatus(ctx, outgoing, repo.RequestDeclined); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	tests := []struct {
+		filter   repo.ChatFilter
+		expected []uuid.UUID
+	}{
+		{repo.AllChats, []uuid.UUID{pinned, inbox, resumed, archived, muted, unmuted, outgoing}},
+		{repo.InboxChats, []uuid.UUID{pinned, inbox, resumed, muted, unmuted, outgoing}},
+		{repo.ArchivedChats, []uuid.UUID{archived}},
+		{repo.PinnedChats, []uuid.UUID{pinned}},
+		{repo.MutedChats, []uuid.UUID{muted}},
+		{repo.RequestChats, []uuid.UUID{incoming}},
+	}
+	for _, tt := range tests {
+		got := listIDs(t, r, repo.GetUserChatsInput{UserID: userID, Filter: tt.filter, Now: now, Limit: 20})
+		if !slices.Equal(got, tt.expected) {
+			t.Errorf("filter %d: expected %v got %v", tt.filter, tt.expected, got)
+		}
+	}
+}
+
+func TestTakeBotInvite(t *testing.T) {
+	ctx := context.Background()
+	r := newRepo(t)
+	chatID := createChat(t, r, repo.CreateChatInput{CurrentUserID: uuid.New()}, nil)
+	botID := uuid.New()
+	first := repo.BotInvite{BotID: botID, InviterID: uuid.New()}
+	again := repo.BotInvite{BotID: botID, InviterID: uuid.New()}
+	for _, i := range []repo.BotInvite{first, again} {
+		if err := r.InviteBot(ctx, chatID, i); err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+	}
+
+	c, err := r.GetChat(ctx, chatID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if !slices.Equal(c.BotInvites, []repo.BotInvite{again}) {
+		t.Fatalf("expected the later invitation to replace the first got %+v", c.BotInvites)
+	}
+	if i, err := r.TakeBotInvite(ctx, chatID, botID); err != nil || i != again {
+		t.Fatalf("expected %+v got %+v, %v", again, i, err)
+	}
+	if _, err := r.TakeBotInvite(ctx, chatID, botID); !errors.Is(err, repo.ErrInviteNotFound) {
+		t.Fatalf("expected %v got %v", repo.ErrInviteNotFound, err)
+	}
+}
+
+func TestUseRequestMessage(t *testing.T) {
+	ctx := context.Background()
+	r := newRepo(t)
+	requesterID := uuid.New()
+	chatID := createChat(t, r, repo.CreateChatInput{CurrentUserID: requesterID, Request: true}, nil)
+
+	if err := r.UseRequestMessage(ctx, chatID, uuid.New()); !errors.Is(err, repo.ErrRequestPending) {
+		t.Fatalf("expected %v got %v", repo.ErrRequestPending, err)
+	}
+	if err := r.UseRequestMessage(ctx, chatID, requesterID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if c, _ := r.GetChat(ctx, chatID); !c.RequestMessageSent {
+		t.Fatalf("expected the request message recorded got %+v", c)
+	}
+	if err := r.UseRequestMessage(ctx, chatID, requesterID); !errors.Is(err, repo.ErrRequestPending) {
+		t.Fatalf("expected %v got %v", repo.ErrRequestPending, err)
+	}
+
+	if err := r.UpdateRequestStatus(ctx, chatID, repo.NoRequest); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if err := r.UseRequestMessage(ctx, chatID, requesterID); err != nil {
+		t.Fatalf("expected no error once accepted got %v", err)
+	}
+}
@@ -1,32 +1,148 @@
 package repo
 
 import (
+	"errors"
+	"github.com/AliUnipal/chat/internal/models/user"
 	"github.com/google/uuid"
 	"time"
 )
 
+var (
+	ErrChatNotFound   = errors.New("chat does not exist")
+	ErrMemberNotFound = errors.New("user does not belong to this chat")
+	ErrRequestPending = errors.New("message request has not been accepted")
+	ErrMemberExists   = errors.New("user already belongs to this chat")
+	ErrInviteNotFound = errors.New("bot has not been invited to this chat")
+)
+
+type RequestStatus int
+
+const (
+	NoRequest RequestStatus = iota
+	RequestPending
+	RequestDeclined
+)
+
 type User struct {
 	ID        uuid.UUID
 	ImageURL  string
 	FirstName string
 	LastName  string
 	Username  string
+	Type      user.Type
+	// Removed participants left the chat when their account was deleted.
+	// They stay so their earlier messages keep a sender, but are no longer
+	// told about the chat.
+	Removed bool
 }
 
+// Chat holds the data shared by every participant of a chat.
 type Chat struct {
-	ID          uuid.UUID
-	CurrentUser User
-	OtherUser   User
-	Messages    []Message
+	ID           uuid.UUID
+	Participants []User
+	CreatedAt    time.Time
+	// RequesterID started the chat as a message request when RequestStatus is
+	// not NoRequest.
+	RequesterID   uuid.UUID
+	RequestStatus RequestStatus
+	// RequestMessageSent is set once the requester has sent the one message
+	// a request allows, and stays set even if that message is deleted.
+	RequestMessageSent bool
+	// Encrypted chats only hold end-to-end encrypted messages.
+	Encrypted bool
+	// DisappearAfter is zero unless the chat's messages are deleted that
+	// long after they are sent.
+	DisappearAfter time.Duration
+	// LastMessageAt is when the latest message was sent, zero before the
+	// first one.
+	LastMessageAt time.Time
+	// BotInvites are the bots a participant asked to add, waiting for the
+	// other person in the chat to accept them.
+	BotInvites []BotInvite
 }
 
-type Message struct {
-	ID        uuid.UUID
-	SenderID  uuid.UUID
-	Content   []byte
-	Timestamp time.Time
+// BotInvite is a bot InviterID asked to add to a chat.
+type BotInvite struct {
+	BotID     uuid.UUID
+	InviterID uuid.UUID
+	InvitedAt time.Time
+}
+
+// LastActivity is when the latest message was sent, or when the chat was
+// created if nothing was sent since.
+func (c Chat) LastActivity() time.Time {
+	if c.LastMessageAt.After(c.CreatedAt) {
+		return c.LastMessageAt
+	}
+	return c.CreatedAt
+}
+
+// Member holds the state a single participant keeps for a chat.
+type Member struct {
+	ChatID   uuid.UUID
+	UserID   uuid.UUID
+	Nickname string
+	// PinPosition orders the member's pinned chats starting at 1; zero means
+	// the chat is not pinned.
+	PinPosition int
+	// ArchivedAt is zero unless the member archived the chat.
+	ArchivedAt time.Time
+	MutedUntil time.Time
+	// ClearedAt hides the messages sent up to it from the member.
+	ClearedAt time.Time
+	// MentionsReadAt is when the member last read their mentions in the
+	// chat; only later mentions count as unread.
+	MentionsReadAt time.Time
+}
+
+// UserChat is a chat together with the requesting user's membership.
+type UserChat struct {
+	Chat
+	Member Member
+}
+
+// Cursor returns the chat's position in its member's chat list.
+func (c UserChat) Cursor() Cursor {
+	return Cursor{PinPosition: c.Member.PinPosition, LastActivity: c.LastActivity(), ChatID: c.ID}
+}
+
+// ChatFilter narrows a chat list down to chats in a given state.
+type ChatFilter int
+
+// Incoming message requests only ever show up under RequestChats.
+const (
+	AllChats ChatFilter = iota
+	// InboxChats excludes archived chats.
+	InboxChats
+	ArchivedChats
+	PinnedChats
+	MutedChats
+	RequestChats
+)
+
+// Cursor marks a position in a chat list ordered by pin position and then by
+// latest activity.
+type Cursor struct {
+	PinPosition  int
+	LastActivity time.Time
+	ChatID       uuid.UUID
+}
+
+// GetUserChatsInput asks for a page of a user's chat list. Chats the user
+// deleted and message requests they declined are never listed.
+type GetUserChatsInput struct {
+	UserID uuid.UUID
+	Filter ChatFilter
+	// Now decides which chats are muted.
+	Now time.Time
+	// After leaves out the chats up to and including the cursor.
+	After *Cursor
+	Limit int
 }
 
 type CreateChatInput struct {
 	ID, CurrentUserID, OtherUserID uuid.UUID
+	CreatedAt                      time.Time
+	// Request makes the chat a message request from CurrentUserID.
+	Request bool
 }
@@ -0,0 +1,70 @@
+package chatsvc
+
+import (
+	"context"
+	"errors"
+	"github.com/AliUnipal/chat/internal/models/chat"
+	"github.com/AliUnipal/chat/internal/service/chatsvc/repo"
+	"github.com/google/uuid"
+)
+
+// AcceptRequest moves a message request into the recipient's chat list and
+// lifts the limit on the messages the requester can send.
+func (s *service) AcceptRequest(ctx context.Context, chatID, userID uuid.UUID) error {
+	if _, err := s.pendingRequest(ctx, chatID, userID); err != nil {
+		return err
+	}
+
+	return s.chatRepo.UpdateRequestStatus(ctx, chatID, repo.NoRequest)
+}
+
+// DeclineRequest hides a message request from the recipient for good. The
+// requester is not told and stays limited to the message they already sent.
+func (s *service) DeclineRequest(ctx context.Context, chatID, userID uuid.UUID) error {
+	if _, err := s.pendingRequest(ctx, chatID, userID); err != nil {
+		return err
+	}
+
+	return s.chatRepo.UpdateRequestStatus(ctx, chatID, repo.RequestDeclined)
+}
+
+// BlockRequest declines a message request and blocks the requester.
+func (s *service) BlockRequest(ctx context.Context, chatID, userID uuid.UUID) error {
+	c, err := s.pendingRequest(ctx, chatID, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.users.BlockUser(ctx, userID, c.RequesterID); err != nil {
+		return err
+	}
+
+	return s.chatRepo.UpdateRequestStatus(ctx, chatID, repo.RequestDeclined)
+}
+
+// pendingRequest returns the chat when it is a pending message request
+// addressed to userID.
+func (s *service) pendingRequest(ctx context.Context, chatID, userID uuid.UUID) (repo.Chat, error) {
+	c, err := s.chatRepo.GetChat(ctx, chatID)
+	if err != nil {
+		return repo.Chat{}, err
+	}
+	if _, err := s.chatRepo.GetMember(ctx, chatID, userID); err != nil {
+		return repo.Chat{}, err
+	}
+	if c.RequestStatus != repo.RequestPending || c.RequesterID == userID {
+		return repo.Chat{}, errors.New("chat is not a message request to this user")
+	}
+
+	return c, nil
+}
+
+func requestState(c repo.Chat, viewerID uuid.UUID) chat.RequestState {
+	switch {
+	case c.RequestStatus == repo.NoRequest:
+		return chat.NoRequest
+	case c.RequesterID == viewerID:
+		return chat.OutgoingRequest
+	default:
+		return chat.IncomingRequest
+	}
+}
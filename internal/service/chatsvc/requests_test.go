@@ -0,0 +1,152 @@
+package chatsvc_test
+
+import (
+	"context"
+	"github.com/AliUnipal/chat/internal/events"
+	"github.com/AliUnipal/chat/internal/models/chat"
+	"github.com/AliUnipal/chat/internal/models/user"
+	"github.com/AliUnipal/chat/internal/service/chatsvc"
+	"github.com/AliUnipal/chat/internal/service/chatsvc/mocks"
+	"github.com/AliUnipal/chat/internal/service/chatsvc/repo"
+	msgrepo "github.com/AliUnipal/chat/internal/service/msgsvc/repo"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func requestChat(requesterID, recipientID uuid.UUID, status repo.RequestStatus) repo.Chat {
+	return repo.Chat{
+		ID:            uuid.New(),
+		Participants:  []repo.User{{ID: requesterID}, {ID: recipientID}},
+		RequesterID:   requesterID,
+		RequestStatus: status,
+	}
+}
+
+func TestCreateChat_StartRequestForNonContact(t *testing.T) {
+	ctx := context.Background()
+	currentUserID := uuid.New()
+	otherUserID := uuid.New()
+
+	tests := []struct {
+		otherType user.Type
+		request   bool
+	}{
+		{user.Human, true},
+		{user.Bot, false},
+		// Imported users cannot accept a request.
+		{user.Imported, false},
+	}
+	for _, tt := range tests {
+		chatMockRepo := mocks.NewChatRepository(t)
+		msgMockRepo := mocks.NewMessageRepository(t)
+		userMockService := mocks.NewUserService(t)
+		chatMockRepo.EXPECT().FindDirectChat(ctx, currentUserID, otherUserID).Return(repo.Chat{}, repo.ErrChatNotFound)
+		userMockService.EXPECT().CanStartChat(ctx, currentUserID, otherUserID).Return(nil)
+		userMockService.EXPECT().IsContact(ctx, otherUserID, currentUserID).Return(false, nil)
+		userMockService.EXPECT().GetUser(ctx, otherUserID).Return(user.User{ID: otherUserID, Type: tt.otherType}, nil)
+		chatMockRepo.EXPECT().CreateChat(ctx, mock.MatchedBy(func(c repo.CreateChatInput) bool {
+			return c.Request == tt.request
+		})).Return(nil)
+
+		service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, events.NewBus())
+		if _, err := service.CreateChat(ctx, currentUserID, otherUserID); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+}
+
+func TestAcceptRequest_ClearRequest(t *testing.T) {
+	ctx := context.Background()
+	requesterID := uuid.New()
+	recipientID := uuid.New()
+	c := requestChat(requesterID, recipientID, repo.RequestPending)
+
+	chatMockRepo := mocks.NewChatRepository(t)
+	msgMockRepo := mocks.NewMessageRepository(t)
+	userMockService := mocks.NewUserService(t)
+	chatMockRepo.EXPECT().GetChat(ctx, c.ID).Return(c, nil)
+	chatMockRepo.EXPECT().GetMember(ctx, c.ID, recipientID).Return(repo.Member{ChatID: c.ID, UserID: recipientID}, nil)
+	chatMockRepo.EXPECT().UpdateRequestStatus(ctx, c.ID, repo.NoRequest).Return(nil)
+
+	service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, events.NewBus())
+	if err := service.AcceptRequest(ctx, c.ID, recipientID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestAcceptRequest_ReturnErrorForRequester(t *testing.T) {
+	ctx := context.Background()
+	requesterID := uuid.New()
+	c := requestChat(requesterID, uuid.New(), repo.RequestPending)
+
+	chatMockRepo := mocks.NewChatRepository(t)
+	msgMockRepo := mocks.NewMessageRepository(t)
+	userMockService := mocks.NewUserService(t)
+	chatMockRepo.EXPECT().GetChat(ctx, c.ID).Return(c, nil)
+	chatMockRepo.EXPECT().GetMember(ctx, c.ID, requesterID).Return(repo.Member{ChatID: c.ID, UserID: requesterID}, nil)
+
+	service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, events.NewBus())
+	if err := service.AcceptRequest(ctx, c.ID, requesterID); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestBlockRequest_BlockRequester(t *testing.T) {
+	ctx := context.Background()
+	requesterID := uuid.New()
+	recipientID := uuid.New()
+	c := requestChat(requesterID, recipientID, repo.RequestPending)
+
+	chatMockRepo := mocks.NewChatRepository(t)
+	msgMockRepo := mocks.NewMessageRepository(t)
+	userMockService := mocks.NewUserService(t)
+	chatMockRepo.EXPECT().GetChat(ctx, c.ID).Return(c, nil)
+	chatMockRepo.EXPECT().GetMember(ctx, c.ID, recipientID).Return(repo.Member{ChatID: c.ID, UserID: recipientID}, nil)
+	userMockService.EXPECT().BlockUser(ctx, recipientID, requesterID).Return(nil)
+	chatMockRepo.EXPECT().UpdateRequestStatus(ctx, c.ID, repo.RequestDeclined).Return(nil)
+
+	service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, events.NewBus())
+	if err := service.BlockRequest(ctx, c.ID, recipientID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestGetChats_ShowRequestState(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	regular := memberChat(userID, repo.Member{})
+	incoming := repo.UserChat{
+		Chat:   requestChat(uuid.New(), userID, repo.RequestPending),
+		Member: repo.Member{UserID: userID},
+	}
+	outgoing := repo.UserChat{
+		Chat:   requestChat(userID, uuid.New(), repo.RequestDeclined),
+		Member: repo.Member{UserID: userID},
+	}
+
+	chatMockRepo := mocks.NewChatRepository(t)
+	msgMockRepo := mocks.NewMessageRepository(t)
+	userMockService := mocks.NewUserService(t)
+	chatMockRepo.EXPECT().GetUserChats(ctx, mock.MatchedBy(func(in repo.GetUserChatsInput) bool {
+		return in.UserID == userID && in.Filter == chatsvc.RequestChats
+	})).Return([]repo.UserChat{regular, incoming, outgoing}, nil)
+	msgMockRepo.EXPECT().CountMentions(ctx, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+	msgMockRepo.EXPECT().GetLastMessages(ctx, mock.Anything, mock.Anything).Return(map[uuid.UUID]msgrepo.Message{}, nil)
+	userMockService.EXPECT().CanSeeProfileImage(ctx, mock.Anything, mock.Anything).Return(true, nil)
+
+	service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, events.NewBus())
+	page, err := service.GetChats(ctx, chatsvc.GetChatsInput{UserID: userID, Filter: chatsvc.RequestChats})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := []chat.RequestState{chat.NoRequest, chat.IncomingRequest, chat.OutgoingRequest}
+	if len(page.Chats) != len(expected) {
+		t.Fatalf("expected %d chats, got %v", len(expected), page.Chats)
+	}
+	for i, c := range page.Chats {
+		if c.Request != expected[i] {
+			t.Errorf("expected chat %d in request state %v, got %v", i, expected[i], c.Request)
+		}
+	}
+}
@@ -2,97 +2,452 @@ package chatsvc
 
 import (
 	"context"
+	"errors"
+	"github.com/AliUnipal/chat/internal/events"
 	"github.com/AliUnipal/chat/internal/models/chat"
 	"github.com/AliUnipal/chat/internal/models/message"
 	"github.com/AliUnipal/chat/internal/models/user"
 	"github.com/AliUnipal/chat/internal/service/chatsvc/repo"
+	msgrepo "github.com/AliUnipal/chat/internal/service/msgsvc/repo"
 	"github.com/google/uuid"
+	"strings"
+	"time"
+	"unicode/utf8"
 )
 
-// NOTE: Something that gives the pointer of the chat to add messages <- Repo, Dependency of chat repo.
-// Dependency for msgsvc
-// type chatRepository interface {
-//	 GetChatByUser(ctx context.Context, userID uuid.UUID, userTwo uuid) (*repo.Chat, error)
-// }
+const (
+	defaultChatsLimit = 50
+	maxChatsLimit     = 200
+	previewLength     = 100
+	maxPinnedChats    = 5
+)
+
+var (
+	ErrChatNotFound       = repo.ErrChatNotFound
+	ErrMemberNotFound     = repo.ErrMemberNotFound
+	ErrRequestPending     = repo.ErrRequestPending
+	ErrTooManyPinnedChats = errors.New("too many pinned chats")
+)
+
+// ChatFilter narrows a chat list down to chats in a given state.
+type ChatFilter = repo.ChatFilter
+
+// Incoming message requests only ever show up under RequestChats.
+const (
+	AllChats = repo.AllChats
+	// InboxChats excludes archived chats.
+	InboxChats    = repo.InboxChats
+	ArchivedChats = repo.ArchivedChats
+	PinnedChats   = repo.PinnedChats
+	MutedChats    = repo.MutedChats
+	RequestChats  = repo.RequestChats
+)
+
+type GetChatsInput struct {
+	UserID uuid.UUID
+	Filter ChatFilter
+	// Limit caps the number of chats returned, defaulting to 50 and capped at 200.
+	Limit int
+	// After continues the list from the Next cursor of a previous page.
+	After *Cursor
+}
+
+// Cursor marks a position in a chat list ordered by pin position and then by
+// latest activity.
+type Cursor = repo.Cursor
 
-// TODO:
-// 1. Delete and recreate and complete ChatService interface - Done
-// 2. a) Implement the methods in the service struct - WIP
-// 2. b) Update the ChatRepository interface to provide the necessary methods
-// 2. c) Generate mocks for the updated interfaces
-// 3. Write unit tests for the service methods
+type ChatsPage struct {
+	Chats []chat.Chat
+	// Next is nil once the last page has been returned.
+	Next *Cursor
+}
 
 type chatService interface {
 	CreateChat(ctx context.Context, currentUserID, otherUserID uuid.UUID) (uuid.UUID, error)
-	GetChats(ctx context.Context, userID uuid.UUID) ([]chat.Chat, error)
+	GetChat(ctx context.Context, id, userID uuid.UUID) (chat.Chat, error)
+	FindDirectChat(ctx context.Context, userA, userB uuid.UUID) (chat.Chat, error)
+	GetChats(ctx context.Context, in GetChatsInput) (ChatsPage, error)
+	SetNickname(ctx context.Context, chatID, userID uuid.UUID, nickname string) error
+	PinChat(ctx context.Context, chatID, userID uuid.UUID) error
+	UnpinChat(ctx context.Context, chatID, userID uuid.UUID) error
+	ReorderPinnedChats(ctx context.Context, userID uuid.UUID, chatIDs []uuid.UUID) error
+	ArchiveChat(ctx context.Context, chatID, userID uuid.UUID) error
+	UnarchiveChat(ctx context.Context, chatID, userID uuid.UUID) error
+	MuteChat(ctx context.Context, chatID, userID uuid.UUID, until time.Time) error
+	UnmuteChat(ctx context.Context, chatID, userID uuid.UUID) error
+	ReadMentions(ctx context.Context, chatID, userID uuid.UUID) error
+	DeleteChatForMe(ctx context.Context, chatID, userID uuid.UUID) error
+	DeleteChatForEveryone(ctx context.Context, chatID, userID uuid.UUID) error
+	GetChatIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+	RemoveUser(ctx context.Context, userID uuid.UUID) error
+	AcceptRequest(ctx context.Context, chatID, userID uuid.UUID) error
+	DeclineRequest(ctx context.Context, chatID, userID uuid.UUID) error
+	BlockRequest(ctx context.Context, chatID, userID uuid.UUID) error
+	EnableEncryption(ctx context.Context, chatID, userID uuid.UUID) error
+	SetDisappearingMessages(ctx context.Context, chatID, userID uuid.UUID, after time.Duration) error
+	AddBot(ctx context.Context, chatID, userID, botID uuid.UUID) error
+	AcceptBot(ctx context.Context, chatID, userID, botID uuid.UUID) error
+	DeclineBot(ctx context.Context, chatID, userID, botID uuid.UUID) error
+	RemoveBot(ctx context.Context, chatID, userID, botID uuid.UUID) error
+	HandleEvent(ctx context.Context, e events.Event) error
+	RecordImport(ctx context.Context, chatID uuid.UUID, lastMessageAt time.Time) error
 }
 
 type chatRepository interface {
 	CreateChat(ctx context.Context, chat repo.CreateChatInput) error
-	GetChatsByUser(ctx context.Context, userID uuid.UUID) ([]*repo.Chat, error)
+	GetChat(ctx context.Context, id uuid.UUID) (repo.Chat, error)
+	FindDirectChat(ctx context.Context, userA, userB uuid.UUID) (repo.Chat, error)
+	GetChatsByUser(ctx context.Context, userID uuid.UUID) ([]repo.UserChat, error)
+	GetUserChats(ctx context.Context, in repo.GetUserChatsInput) ([]repo.UserChat, error)
+	UpdateRequestStatus(ctx context.Context, chatID uuid.UUID, status repo.RequestStatus) error
+	EnableEncryption(ctx context.Context, chatID uuid.UUID) error
+	SetDisappearAfter(ctx context.Context, chatID uuid.UUID, after time.Duration) error
+	SetLastMessageAt(ctx context.Context, chatID uuid.UUID, at time.Time) error
+	DeleteChat(ctx context.Context, id uuid.UUID) error
+	RemoveMember(ctx context.Context, chatID, userID uuid.UUID) error
+	AddMember(ctx context.Context, chatID, userID uuid.UUID) error
+	InviteBot(ctx context.Context, chatID uuid.UUID, invite repo.BotInvite) error
+	TakeBotInvite(ctx context.Context, chatID, botID uuid.UUID) (repo.BotInvite, error)
+	RemoveParticipant(ctx context.Context, chatID, userID uuid.UUID) error
+	GetMembers(ctx context.Context, chatID uuid.UUID) ([]repo.Member, error)
+	GetMember(ctx context.Context, chatID, userID uuid.UUID) (repo.Member, error)
+	UpdateMember(ctx context.Context, member repo.Member) error
+	UnarchiveMembers(ctx context.Context, chatID uuid.UUID, sentAt time.Time, throughMute []uuid.UUID) error
+}
+
+type messageRepository interface {
+	GetLastMessages(ctx context.Context, chatIDs []uuid.UUID, now time.Time) (map[uuid.UUID]msgrepo.Message, error)
+	CountMentions(ctx context.Context, userID uuid.UUID, since map[uuid.UUID]time.Time, now time.Time) (map[uuid.UUID]msgrepo.MentionCount, error)
+	DeleteMessages(ctx context.Context, chatID uuid.UUID) error
+	DeleteMessagesBefore(ctx context.Context, chatID uuid.UUID, before time.Time) error
+}
+
+type userService interface {
+	CanStartChat(ctx context.Context, senderID, recipientID uuid.UUID) error
+	CanSeeProfileImage(ctx context.Context, viewerID, ownerID uuid.UUID) (bool, error)
+	IsContact(ctx context.Context, userID, contactID uuid.UUID) (bool, error)
+	BlockUser(ctx context.Context, userID, blockedID uuid.UUID) error
+	HasKeys(ctx context.Context, userID uuid.UUID) (bool, error)
+	GetUser(ctx context.Context, id uuid.UUID) (user.User, error)
+}
+
+// publisher announces chats being created, deleted and left.
+type publisher interface {
+	Publish(ctx context.Context, e events.Event)
 }
 
 var _ chatService = (*service)(nil)
 
-func NewService(chatRepo chatRepository) *service {
-	return &service{chatRepo}
+func NewService(chatRepo chatRepository, msgRepo messageRepository, users userService, events publisher) *service {
+	return &service{chatRepo, msgRepo, users, events}
 }
 
 type service struct {
 	chatRepo chatRepository
+	msgRepo  messageRepository
+	users    userService
+	events   publisher
 }
 
+// CreateChat returns the ID of the direct chat between the two users, creating
+// it only when they do not already share one and the other user's block list
+// and privacy settings allow it. A chat with someone who does not have the
+// current user as a contact starts out as a message request.
 func (s *service) CreateChat(ctx context.Context, currentUserID, otherUserID uuid.UUID) (uuid.UUID, error) {
+	c, err := s.chatRepo.FindDirectChat(ctx, currentUserID, otherUserID)
+	if err == nil {
+		return c.ID, nil
+	}
+	if !errors.Is(err, repo.ErrChatNotFound) {
+		return uuid.Nil, err
+	}
+	if err := s.users.CanStartChat(ctx, currentUserID, otherUserID); err != nil {
+		return uuid.Nil, err
+	}
+	request, err := s.needsRequest(ctx, currentUserID, otherUserID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
 	id := uuid.New()
+	now := time.Now().UTC()
 	if err := s.chatRepo.CreateChat(ctx, repo.CreateChatInput{
 		ID:            id,
 		CurrentUserID: currentUserID,
 		OtherUserID:   otherUserID,
+		CreatedAt:     now,
+		Request:       request,
 	}); err != nil {
 		return uuid.Nil, err
 	}
 
+	s.events.Publish(ctx, events.Event{
+		Type:       events.ChatCreated,
+		ChatID:     id,
+		UserID:     currentUserID,
+		Recipients: []uuid.UUID{currentUserID, otherUserID},
+		Timestamp:  now,
+	})
 	return id, nil
 }
 
-func (s *service) GetChats(ctx context.Context, userID uuid.UUID) ([]chat.Chat, error) {
-	c, err := s.chatRepo.GetChatsByUser(ctx, userID)
+// needsRequest reports whether a chat from currentUserID has to be accepted
+// by otherUserID first, which it does unless they have currentUserID as a
+// contact or are a bot. Imported users never sign in to accept a request, so
+// chats with them do not need one either.
+func (s *service) needsRequest(ctx context.Context, currentUserID, otherUserID uuid.UUID) (bool, error) {
+	contact, err := s.users.IsContact(ctx, otherUserID, currentUserID)
+	if err != nil || contact {
+		return false, err
+	}
+	other, err := s.users.GetUser(ctx, otherUserID)
+	if err != nil {
+		return false, err
+	}
+
+	return other.Type == user.Human, nil
+}
+
+// GetChat returns the chat as seen by userID, who must be one of its members.
+func (s *service) GetChat(ctx context.Context, id, userID uuid.UUID) (chat.Chat, error) {
+	c, err := s.chatRepo.GetChat(ctx, id)
+	if err != nil {
+		return chat.Chat{}, err
+	}
+	m, err := s.chatRepo.GetMember(ctx, id, userID)
+	if err != nil {
+		return chat.Chat{}, err
+	}
+
+	return s.render(ctx, c, m)
+}
+
+// FindDirectChat returns the direct chat between the two users as seen by userA.
+func (s *service) FindDirectChat(ctx context.Context, userA, userB uuid.UUID) (chat.Chat, error) {
+	c, err := s.chatRepo.FindDirectChat(ctx, userA, userB)
+	if err != nil {
+		return chat.Chat{}, err
+	}
+	m, err := s.chatRepo.GetMember(ctx, c.ID, userA)
+	if err != nil {
+		return chat.Chat{}, err
+	}
+
+	return s.render(ctx, c, m)
+}
+
+// GetChats returns a page of the user's chats matching the filter, each
+// rendered from their point of view. Pinned chats come first in their pinned
+// order, followed by the most recently active chats. Chats the user deleted
+// are left out until a new message arrives.
+func (s *service) GetChats(ctx context.Context, in GetChatsInput) (ChatsPage, error) {
+	limit := in.Limit
+	if limit < 0 {
+		return ChatsPage{}, errors.New("limit must not be negative")
+	}
+	if limit == 0 {
+		limit = defaultChatsLimit
+	}
+	limit = min(limit, maxChatsLimit)
+
+	now := time.Now()
+	userChats, err := s.chatRepo.GetUserChats(ctx, repo.GetUserChatsInput{
+		UserID: in.UserID,
+		Filter: in.Filter,
+		Now:    now,
+		After:  in.After,
+		Limit:  limit + 1,
+	})
+	if err != nil {
+		return ChatsPage{}, err
+	}
+	var next *Cursor
+	if len(userChats) > limit {
+		userChats = userChats[:limit]
+		cursor := userChats[limit-1].Cursor()
+		next = &cursor
+	}
+
+	ids := make([]uuid.UUID, len(userChats))
+	for i, c := range userChats {
+		ids[i] = c.ID
+	}
+	last, err := s.msgRepo.GetLastMessages(ctx, ids, now)
+	if err != nil {
+		return ChatsPage{}, err
+	}
+	since := make(map[uuid.UUID]time.Time, len(userChats))
+	for _, c := range userChats {
+		since[c.ID] = mentionsSince(c.Member)
+	}
+	mentions, err := s.msgRepo.CountMentions(ctx, in.UserID, since, now)
 	if err != nil {
-		return nil, err
-	}
-	chats := make([]chat.Chat, len(c))
-	for i, c := range c {
-		// TODO: Move the messages into its own service.
-		messages := make([]message.Message, len(c.Messages))
-		for j, m := range c.Messages {
-			messages[j] = message.Message{
-				ID:          m.ID,
-				SenderID:    m.SenderID,
-				Content:     m.Content,
-				ContentType: message.TextContentType,
-				Timestamp:   m.Timestamp,
-			}
+		return ChatsPage{}, err
+	}
+
+	page := ChatsPage{Chats: make([]chat.Chat, 0, len(userChats)), Next: next}
+	for _, c := range userChats {
+		var lastMessage *msgrepo.Message
+		if m, ok := last[c.ID]; ok {
+			lastMessage = &m
+		}
+		rendered := toChat(c.Chat, c.Member, lastMessage, mentions[c.ID], now)
+		if err := s.hideProfileImage(ctx, &rendered); err != nil {
+			return ChatsPage{}, err
 		}
+		page.Chats = append(page.Chats, rendered)
+	}
+
+	return page, nil
+}
+
+// SetNickname sets the name userID sees for the other participant of the chat.
+// An empty nickname restores the participant's own name.
+func (s *service) SetNickname(ctx context.Context, chatID, userID uuid.UUID, nickname string) error {
+	return s.updateMember(ctx, chatID, userID, func(m *repo.Member) {
+		m.Nickname = strings.TrimSpace(nickname)
+	})
+}
+
+func (s *service) updateMember(ctx context.Context, chatID, userID uuid.UUID, update func(m *repo.Member)) error {
+	m, err := s.chatRepo.GetMember(ctx, chatID, userID)
+	if err != nil {
+		return err
+	}
+	update(&m)
+
+	return s.chatRepo.UpdateMember(ctx, m)
+}
 
-		chats[i] = chat.Chat{
-			ID: c.ID,
-			CurrentUser: user.User{
-				ID:        c.CurrentUser.ID,
-				ImageURL:  c.CurrentUser.ImageURL,
-				FirstName: c.CurrentUser.FirstName,
-				LastName:  c.CurrentUser.LastName,
-				Username:  c.CurrentUser.Username,
-			},
-			OtherUser: user.User{
-				ID:        c.OtherUser.ID,
-				ImageURL:  c.OtherUser.ImageURL,
-				FirstName: c.OtherUser.FirstName,
-				LastName:  c.OtherUser.LastName,
-				Username:  c.OtherUser.Username,
-			},
-			Messages: messages,
+func (s *service) render(ctx context.Context, c repo.Chat, member repo.Member) (chat.Chat, error) {
+	now := time.Now()
+	last, err := s.msgRepo.GetLastMessages(ctx, []uuid.UUID{c.ID}, now)
+	if err != nil {
+		return chat.Chat{}, err
+	}
+
+	var lastMessage *msgrepo.Message
+	if m, ok := last[c.ID]; ok {
+		lastMessage = &m
+	}
+
+	mentions, err := s.msgRepo.CountMentions(ctx, member.UserID, map[uuid.UUID]time.Time{c.ID: mentionsSince(member)}, now)
+	if err != nil {
+		return chat.Chat{}, err
+	}
+
+	r := toChat(c, member, lastMessage, mentions[c.ID], now)
+	if err := s.hideProfileImage(ctx, &r); err != nil {
+		return chat.Chat{}, err
+	}
+
+	return r, nil
+}
+
+// hideProfileImage clears the counterpart's image when their privacy settings
+// keep it from the viewer.
+func (s *service) hideProfileImage(ctx context.Context, c *chat.Chat) error {
+	visible, err := s.users.CanSeeProfileImage(ctx, c.CurrentUser.ID, c.OtherUser.ID)
+	if err != nil {
+		return err
+	}
+	if !visible {
+		c.OtherUser.ImageURL = ""
+	}
+
+	return nil
+}
+
+// toChat renders the shared chat data from the point of view of member. The
+// counterpart is the other person in the chat, or a bot when the chat is
+// with a bot alone.
+func toChat(c repo.Chat, member repo.Member, lastMessage *msgrepo.Message, mentions msgrepo.MentionCount, now time.Time) chat.Chat {
+	var current, other repo.User
+	for _, p := range c.Participants {
+		switch {
+		case p.ID == member.UserID:
+			current = p
+		case other.ID == uuid.Nil || other.Type == user.Bot:
+			other = p
 		}
 	}
 
-	return chats, nil
+	displayName := member.Nickname
+	if displayName == "" {
+		displayName = strings.TrimSpace(other.FirstName + " " + other.LastName)
+	}
+
+	r := chat.Chat{
+		ID:             c.ID,
+		CurrentUser:    toUser(current),
+		OtherUser:      toUser(other),
+		DisplayName:    displayName,
+		Pinned:         member.PinPosition > 0,
+		Archived:       !member.ArchivedAt.IsZero(),
+		Muted:          now.Before(member.MutedUntil),
+		Request:        requestState(c, member.UserID),
+		Encrypted:      c.Encrypted,
+		Mentions:       mentions.Count,
+		DisappearAfter: c.DisappearAfter,
+	}
+	if r.Muted {
+		r.MutedUntil = member.MutedUntil
+	}
+	for _, i := range c.BotInvites {
+		r.InvitedBots = append(r.InvitedBots, chat.BotInvite(i))
+	}
+	if lastMessage != nil && !isDeleted(member, lastMessage) {
+		r.LastMessage = toPreview(*lastMessage)
+	}
+
+	return r
+}
+
+func later(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+
+	return a
+}
+
+func toPreview(m msgrepo.Message) *chat.MessagePreview {
+	var text string
+	switch m.ContentType {
+	case message.ImageContentType:
+		text = "[image]"
+	case message.FileContentType:
+		text = "[file]"
+	case message.EncryptedContentType:
+		text = "[encrypted message]"
+	default:
+		text = truncate(string(m.Content), previewLength)
+	}
+
+	return &chat.MessagePreview{
+		SenderID:  m.SenderID,
+		Text:      text,
+		Timestamp: m.Timestamp,
+	}
+}
+
+// truncate shortens s to at most n runes, marking the cut with an ellipsis.
+func truncate(s string, n int) string {
+	s = strings.ToValidUTF8(s, "")
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+
+	return string([]rune(s)[:n-1]) + "…"
+}
+
+func toUser(u repo.User) user.User {
+	return user.User{
+		ID:        u.ID,
+		ImageURL:  u.ImageURL,
+		FirstName: u.FirstName,
+		LastName:  u.LastName,
+		Username:  u.Username,
+		Type:      u.Type,
+	}
 }
@@ -3,88 +3,253 @@ package chatsvc_test
 import (
 	"context"
 	"errors"
+	"github.com/AliUnipal/chat/internal/events"
+	"github.com/AliUnipal/chat/internal/models/message"
 	"github.com/AliUnipal/chat/internal/service/chatsvc"
 	"github.com/AliUnipal/chat/internal/service/chatsvc/mocks"
 	"github.com/AliUnipal/chat/internal/service/chatsvc/repo"
+	msgrepo "github.com/AliUnipal/chat/internal/service/msgsvc/repo"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
+	"strings"
 	"testing"
+	"time"
+	"unicode/utf8"
 )
 
 // AAA - Arrange Act Assert
 
-func TestGetChats_ReturnChats(t *testing.T) {
+func TestGetChats_ReturnChatsFromViewerPerspective(t *testing.T) {
 	ctx := context.Background()
 	userID := uuid.New()
 	otherUserOneID := uuid.New()
-	expectedChats := []*repo.Chat{
-		&repo.Chat{
-			ID: userID,
-			CurrentUser: repo.User{
-				ID:        userID,
-				ImageURL:  "",
-				FirstName: "",
-				LastName:  "",
-				Username:  "",
+	otherUserTwoID := uuid.New()
+	createdAt := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	viewer := repo.User{ID: userID, FirstName: "Viewer"}
+	expectedChats := []repo.UserChat{
+		{
+			Chat: repo.Chat{
+				ID: uuid.New(),
+				Participants: []repo.User{
+					viewer,
+					{ID: otherUserOneID, FirstName: "Other", LastName: "One"},
+				},
+				CreatedAt: createdAt.Add(time.Hour),
 			},
-			OtherUser: repo.User{
-				ID:        otherUserOneID,
-				ImageURL:  "",
-				FirstName: "",
-				LastName:  "",
-				Username:  "",
-			},
-			Messages: nil,
+			Member: repo.Member{UserID: userID, PinPosition: 1, MutedUntil: time.Now().Add(time.Hour)},
 		},
-		&repo.Chat{
-			ID: userID,
-			CurrentUser: repo.User{
-				ID:        userID,
-				ImageURL:  "",
-				FirstName: "",
-				LastName:  "",
-				Username:  "",
-			},
-			OtherUser: repo.User{
-				ID:        uuid.UUID{},
-				ImageURL:  "",
-				FirstName: "",
-				LastName:  "",
-				Username:  "",
+		{
+			Chat: repo.Chat{
+				ID: uuid.New(),
+				Participants: []repo.User{
+					{ID: otherUserTwoID, FirstName: "Other", LastName: "Two"},
+					viewer,
+				},
+				CreatedAt: createdAt,
 			},
-			Messages: nil,
+			Member: repo.Member{UserID: userID, Nickname: "Nick", MutedUntil: time.Now().Add(-time.Hour)},
 		},
 	}
 
 	chatMockRepo := mocks.NewChatRepository(t)
-	chatMockRepo.EXPECT().GetChatsByUser(ctx, userID).Return(expectedChats, nil)
+	msgMockRepo := mocks.NewMessageRepository(t)
+	userMockService := mocks.NewUserService(t)
+	userMockService.EXPECT().CanSeeProfileImage(ctx, mock.Anything, mock.Anything).Return(true, nil)
+	chatMockRepo.EXPECT().GetUserChats(ctx, mock.MatchedBy(func(in repo.GetUserChatsInput) bool {
+		return in.UserID == userID && in.Filter == chatsvc.AllChats && in.After == nil && in.Limit == 51
+	})).Return(expectedChats, nil)
+	msgMockRepo.EXPECT().CountMentions(ctx, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+	msgMockRepo.EXPECT().GetLastMessages(ctx, []uuid.UUID{expectedChats[0].ID, expectedChats[1].ID}, mock.Anything).Return(nil, nil)
 
-	service := chatsvc.NewService(chatMockRepo)
-	chats, err := service.GetChats(ctx, userID)
+	service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, events.NewBus())
+	page, err := service.GetChats(ctx, chatsvc.GetChatsInput{UserID: userID})
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
 
-	if len(chats) != len(expectedChats) {
-		t.Fatalf("expected %d chats, got %d", len(expectedChats), len(chats))
+	if len(page.Chats) != len(expectedChats) {
+		t.Fatalf("expected %d chats, got %d", len(expectedChats), len(page.Chats))
+	}
+	if page.Next != nil {
+		t.Fatalf("expected no next page, got %v", page.Next)
 	}
 
-	for i, c := range chats {
-		if c.ID != expectedChats[i].ID || c.CurrentUser.ID != expectedChats[i].CurrentUser.ID || c.OtherUser.ID != expectedChats[i].OtherUser.ID {
+	expected := []struct {
+		otherUserID uuid.UUID
+		displayName string
+		muted       bool
+		pinned      bool
+	}{
+		{otherUserOneID, "Other One", true, true},
+		{otherUserTwoID, "Nick", false, false},
+	}
+	for i, c := range page.Chats {
+		if c.ID != expectedChats[i].ID ||
+			c.CurrentUser.ID != userID ||
+			c.OtherUser.ID != expected[i].otherUserID ||
+			c.DisplayName != expected[i].displayName ||
+			c.Muted != expected[i].muted ||
+			c.Pinned != expected[i].pinned ||
+			c.LastMessage != nil {
 			t.Errorf("expected chat \n%v, got \n%v", expectedChats[i], c)
 		}
 	}
 }
 
+func TestGetChats_ReturnChatsWithPreview(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	otherUserID := uuid.New()
+	createdAt := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	newChat := func(created time.Time) repo.UserChat {
+		return repo.UserChat{
+			Chat: repo.Chat{
+				ID:           uuid.New(),
+				Participants: []repo.User{{ID: userID}, {ID: otherUserID}},
+				CreatedAt:    created,
+			},
+			Member: repo.Member{UserID: userID},
+		}
+	}
+	text := newChat(createdAt)
+	quiet := newChat(createdAt.Add(2 * time.Hour))
+	image := newChat(createdAt)
+	userChats := []repo.UserChat{text, quiet, image}
+	longText := strings.Repeat("ab", 100)
+
+	chatMockRepo := mocks.NewChatRepository(t)
+	msgMockRepo := mocks.NewMessageRepository(t)
+	userMockService := mocks.NewUserService(t)
+	userMockService.EXPECT().CanSeeProfileImage(ctx, mock.Anything, mock.Anything).Return(true, nil)
+	chatMockRepo.EXPECT().GetUserChats(ctx, mock.Anything).Return(userChats, nil)
+	msgMockRepo.EXPECT().CountMentions(ctx, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+	msgMockRepo.EXPECT().GetLastMessages(ctx, []uuid.UUID{text.ID, quiet.ID, image.ID}, mock.Anything).Return(map[uuid.UUID]msgrepo.Message{
+		text.ID: {
+			SenderID:    otherUserID,
+			ChatID:      text.ID,
+			Content:     []byte(longText),
+			ContentType: message.TextContentType,
+			Timestamp:   createdAt.Add(3 * time.Hour),
+		},
+		image.ID: {
+			SenderID:    userID,
+			ChatID:      image.ID,
+			Content:     []byte{0xff, 0xd8},
+			ContentType: message.ImageContentType,
+			Timestamp:   createdAt.Add(time.Hour),
+		},
+	}, nil)
+
+	service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, events.NewBus())
+	page, err := service.GetChats(ctx, chatsvc.GetChatsInput{UserID: userID})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expectedOrder := []uuid.UUID{text.ID, quiet.ID, image.ID}
+	for i, c := range page.Chats {
+		if c.ID != expectedOrder[i] {
+			t.Fatalf("expected chat %d to be %v, got %v", i, expectedOrder[i], c.ID)
+		}
+	}
+
+	preview := page.Chats[0].LastMessage
+	if preview == nil ||
+		preview.SenderID != otherUserID ||
+		preview.Timestamp != createdAt.Add(3*time.Hour) ||
+		utf8.RuneCountInString(preview.Text) != 100 ||
+		!strings.HasSuffix(preview.Text, "…") {
+		t.Errorf("expected truncated text preview, got %v", preview)
+	}
+	if page.Chats[1].LastMessage != nil {
+		t.Errorf("expected no preview, got %v", page.Chats[1].LastMessage)
+	}
+	if preview := page.Chats[2].LastMessage; preview == nil || preview.Text != "[image]" {
+		t.Errorf("expected image preview, got %v", preview)
+	}
+}
+
+func TestGetChats_ReturnPages(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	createdAt := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	userChats := make([]repo.UserChat, 3)
+	for i := range userChats {
+		userChats[i] = repo.UserChat{
+			Chat: repo.Chat{
+				ID:           uuid.New(),
+				Participants: []repo.User{{ID: userID}, {ID: uuid.New()}},
+				CreatedAt:    createdAt.Add(-time.Duration(i) * time.Hour),
+			},
+			Member: repo.Member{UserID: userID},
+		}
+	}
+	cursor := userChats[1].Cursor()
+
+	chatMockRepo := mocks.NewChatRepository(t)
+	msgMockRepo := mocks.NewMessageRepository(t)
+	userMockService := mocks.NewUserService(t)
+	userMockService.EXPECT().CanSeeProfileImage(ctx, mock.Anything, mock.Anything).Return(true, nil)
+	chatMockRepo.EXPECT().GetUserChats(ctx, mock.MatchedBy(func(in repo.GetUserChatsInput) bool {
+		return in.After == nil && in.Limit == 3
+	})).Return(userChats, nil)
+	chatMockRepo.EXPECT().GetUserChats(ctx, mock.MatchedBy(func(in repo.GetUserChatsInput) bool {
+		return in.After != nil && in.After.ChatID == cursor.ChatID && in.Limit == 3
+	})).Return(userChats[2:], nil)
+	msgMockRepo.EXPECT().CountMentions(ctx, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+	msgMockRepo.EXPECT().GetLastMessages(ctx, mock.Anything, mock.Anything).Return(nil, nil)
+
+	service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, events.NewBus())
+
+	page, err := service.GetChats(ctx, chatsvc.GetChatsInput{UserID: userID, Limit: 2})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(page.Chats) != 2 || page.Chats[0].ID != userChats[0].ID || page.Chats[1].ID != userChats[1].ID {
+		t.Fatalf("expected the first two chats, got %v", page.Chats)
+	}
+	if page.Next == nil || page.Next.ChatID != cursor.ChatID || !page.Next.LastActivity.Equal(cursor.LastActivity) {
+		t.Fatalf("expected next cursor %v, got %v", cursor, page.Next)
+	}
+
+	page, err = service.GetChats(ctx, chatsvc.GetChatsInput{UserID: userID, Limit: 2, After: page.Next})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(page.Chats) != 1 || page.Chats[0].ID != userChats[2].ID {
+		t.Fatalf("expected the last chat, got %v", page.Chats)
+	}
+	if page.Next != nil {
+		t.Fatalf("expected no next page, got %v", page.Next)
+	}
+}
+
+func TestGetChats_ReturnErrorOnNegativeLimit(t *testing.T) {
+	ctx := context.Background()
+
+	chatMockRepo := mocks.NewChatRepository(t)
+	msgMockRepo := mocks.NewMessageRepository(t)
+	userMockService := mocks.NewUserService(t)
+
+	service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, events.NewBus())
+	if _, err := service.GetChats(ctx, chatsvc.GetChatsInput{UserID: uuid.New(), Limit: -1}); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
 func TestGetChats_ReturnError(t *testing.T) {
 	ctx := context.Background()
 	userID := uuid.New()
 
 	chatMockRepo := mocks.NewChatRepository(t)
-	chatMockRepo.EXPECT().GetChatsByUser(ctx, userID).Return(nil, errors.New("not found"))
+	msgMockRepo := mocks.NewMessageRepository(t)
+	userMockService := mocks.NewUserService(t)
+	chatMockRepo.EXPECT().GetUserChats(ctx, mock.Anything).Return(nil, errors.New("not found"))
 
-	service := chatsvc.NewService(chatMockRepo)
-	if _, err := service.GetChats(ctx, userID); err == nil {
+	service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, events.NewBus())
+	if _, err := service.GetChats(ctx, chatsvc.GetChatsInput{UserID: userID}); err == nil {
 		t.Fatal("expected error, got nil")
 	}
 }
@@ -95,13 +260,18 @@ func TestCreateChat_ReturnID(t *testing.T) {
 	otherUserID := uuid.New()
 
 	chatMockRepo := mocks.NewChatRepository(t)
+	msgMockRepo := mocks.NewMessageRepository(t)
+	userMockService := mocks.NewUserService(t)
+	userMockService.EXPECT().CanStartChat(mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	userMockService.EXPECT().IsContact(mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	chatMockRepo.EXPECT().FindDirectChat(mock.Anything, currentUserID, otherUserID).Return(repo.Chat{}, repo.ErrChatNotFound)
 	chatMockRepo.EXPECT().CreateChat(mock.Anything, mock.MatchedBy(func(c repo.CreateChatInput) bool {
 		return c.ID != uuid.Nil &&
 			c.CurrentUserID == currentUserID &&
 			c.OtherUserID == otherUserID
 	})).Return(nil)
 
-	service := chatsvc.NewService(chatMockRepo)
+	service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, events.NewBus())
 
 	id, err := service.CreateChat(ctx, currentUserID, otherUserID)
 	if err != nil {
@@ -117,13 +287,18 @@ func TestCreateChat_ReturnErrorOnEmptyUserOne(t *testing.T) {
 	otherUserID := uuid.New()
 
 	chatMockRepo := mocks.NewChatRepository(t)
+	msgMockRepo := mocks.NewMessageRepository(t)
+	userMockService := mocks.NewUserService(t)
+	userMockService.EXPECT().CanStartChat(mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	userMockService.EXPECT().IsContact(mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	chatMockRepo.EXPECT().FindDirectChat(mock.Anything, uuid.Nil, otherUserID).Return(repo.Chat{}, repo.ErrChatNotFound)
 	chatMockRepo.EXPECT().CreateChat(mock.Anything, mock.MatchedBy(func(c repo.CreateChatInput) bool {
 		return c.ID != uuid.Nil &&
 			c.CurrentUserID == uuid.Nil &&
 			c.OtherUserID == otherUserID
 	})).Return(errors.New("User one ID missing."))
 
-	service := chatsvc.NewService(chatMockRepo)
+	service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, events.NewBus())
 
 	if _, err := service.CreateChat(ctx, uuid.Nil, otherUserID); err == nil {
 		t.Fatal("expected error, got nil")
@@ -135,13 +310,18 @@ func TestCreateChat_ReturnErrorOnEmptyUserTwo(t *testing.T) {
 	currentUserID := uuid.New()
 
 	chatMockRepo := mocks.NewChatRepository(t)
+	msgMockRepo := mocks.NewMessageRepository(t)
+	userMockService := mocks.NewUserService(t)
+	userMockService.EXPECT().CanStartChat(mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	userMockService.EXPECT().IsContact(mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	chatMockRepo.EXPECT().FindDirectChat(mock.Anything, currentUserID, uuid.Nil).Return(repo.Chat{}, repo.ErrChatNotFound)
 	chatMockRepo.EXPECT().CreateChat(mock.Anything, mock.MatchedBy(func(c repo.CreateChatInput) bool {
 		return c.ID != uuid.Nil &&
 			c.CurrentUserID == currentUserID &&
 			c.OtherUserID == uuid.Nil
 	})).Return(errors.New("User one ID missing."))
 
-	service := chatsvc.NewService(chatMockRepo)
+	service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, events.NewBus())
 
 	if _, err := service.CreateChat(ctx, currentUserID, uuid.Nil); err == nil {
 		t.Fatal("expected error, got nil")
@@ -154,15 +334,239 @@ func TestCreateChat_ReturnError(t *testing.T) {
 	otherUserID := uuid.New()
 
 	chatMockRepo := mocks.NewChatRepository(t)
+	msgMockRepo := mocks.NewMessageRepository(t)
+	userMockService := mocks.NewUserService(t)
+	userMockService.EXPECT().CanStartChat(mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	userMockService.EXPECT().IsContact(mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	chatMockRepo.EXPECT().FindDirectChat(mock.Anything, currentUserID, otherUserID).Return(repo.Chat{}, repo.ErrChatNotFound)
 	chatMockRepo.EXPECT().CreateChat(mock.Anything, mock.MatchedBy(func(c repo.CreateChatInput) bool {
 		return c.ID != uuid.Nil &&
 			c.CurrentUserID == currentUserID &&
 			c.OtherUserID == otherUserID
 	})).Return(errors.New("error"))
 
-	service := chatsvc.NewService(chatMockRepo)
+	service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, events.NewBus())
 
 	if _, err := service.CreateChat(ctx, currentUserID, otherUserID); err == nil {
 		t.Fatal("expected error, got nil")
 	}
 }
+
+func TestCreateChat_ReturnExistingChatID(t *testing.T) {
+	ctx := context.Background()
+	currentUserID := uuid.New()
+	otherUserID := uuid.New()
+	existingID := uuid.New()
+
+	chatMockRepo := mocks.NewChatRepository(t)
+	msgMockRepo := mocks.NewMessageRepository(t)
+	userMockService := mocks.NewUserService(t)
+	chatMockRepo.EXPECT().FindDirectChat(mock.Anything, currentUserID, otherUserID).Return(repo.Chat{ID: existingID}, nil)
+
+	service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, events.NewBus())
+
+	id, err := service.CreateChat(ctx, currentUserID, otherUserID)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if id != existingID {
+		t.Fatalf("expected id %v, got %v", existingID, id)
+	}
+}
+
+func TestCreateChat_ReturnErrorOnFindDirectChat(t *testing.T) {
+	ctx := context.Background()
+	currentUserID := uuid.New()
+	otherUserID := uuid.New()
+
+	chatMockRepo := mocks.NewChatRepository(t)
+	msgMockRepo := mocks.NewMessageRepository(t)
+	userMockService := mocks.NewUserService(t)
+	chatMockRepo.EXPECT().FindDirectChat(mock.Anything, currentUserID, otherUserID).Return(repo.Chat{}, errors.New("error"))
+
+	service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, events.NewBus())
+
+	if _, err := service.CreateChat(ctx, currentUserID, otherUserID); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestGetChat_ReturnChat(t *testing.T) {
+	ctx := context.Background()
+	creatorID := uuid.New()
+	viewerID := uuid.New()
+	expectedChat := repo.Chat{
+		ID: uuid.New(),
+		Participants: []repo.User{
+			{ID: creatorID, Username: "creator"},
+			{ID: viewerID, Username: "viewer"},
+		},
+	}
+
+	chatMockRepo := mocks.NewChatRepository(t)
+	msgMockRepo := mocks.NewMessageRepository(t)
+	userMockService := mocks.NewUserService(t)
+	userMockService.EXPECT().CanSeeProfileImage(ctx, mock.Anything, mock.Anything).Return(true, nil)
+	chatMockRepo.EXPECT().GetChat(ctx, expectedChat.ID).Return(expectedChat, nil)
+	chatMockRepo.EXPECT().GetMember(ctx, expectedChat.ID, viewerID).Return(repo.Member{ChatID: expectedChat.ID, UserID: viewerID}, nil)
+	msgMockRepo.EXPECT().CountMentions(ctx, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+	msgMockRepo.EXPECT().GetLastMessages(ctx, []uuid.UUID{expectedChat.ID}, mock.Anything).Return(map[uuid.UUID]msgrepo.Message{
+		expectedChat.ID: {SenderID: creatorID, ChatID: expectedChat.ID, ContentType: message.FileContentType},
+	}, nil)
+
+	service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, events.NewBus())
+	c, err := service.GetChat(ctx, expectedChat.ID, viewerID)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if c.ID != expectedChat.ID ||
+		c.CurrentUser.ID != viewerID ||
+		c.OtherUser.ID != creatorID ||
+		c.OtherUser.Username != "creator" ||
+		c.LastMessage == nil ||
+		c.LastMessage.Text != "[file]" {
+		t.Fatalf("expected chat \n%v, got \n%v", expectedChat, c)
+	}
+}
+
+func TestGetChat_ReturnError(t *testing.T) {
+	ctx := context.Background()
+	chatID := uuid.New()
+
+	chatMockRepo := mocks.NewChatRepository(t)
+	msgMockRepo := mocks.NewMessageRepository(t)
+	userMockService := mocks.NewUserService(t)
+	chatMockRepo.EXPECT().GetChat(ctx, chatID).Return(repo.Chat{}, repo.ErrChatNotFound)
+
+	service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, events.NewBus())
+	if _, err := service.GetChat(ctx, chatID, uuid.New()); !errors.Is(err, chatsvc.ErrChatNotFound) {
+		t.Fatalf("expected %v, got %v", chatsvc.ErrChatNotFound, err)
+	}
+}
+
+func TestGetChat_ReturnErrorOnNonMember(t *testing.T) {
+	ctx := context.Background()
+	chatID := uuid.New()
+	userID := uuid.New()
+
+	chatMockRepo := mocks.NewChatRepository(t)
+	msgMockRepo := mocks.NewMessageRepository(t)
+	userMockService := mocks.NewUserService(t)
+	chatMockRepo.EXPECT().GetChat(ctx, chatID).Return(repo.Chat{ID: chatID}, nil)
+	chatMockRepo.EXPECT().GetMember(ctx, chatID, userID).Return(repo.Member{}, repo.ErrMemberNotFound)
+
+	service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, events.NewBus())
+	if _, err := service.GetChat(ctx, chatID, userID); !errors.Is(err, chatsvc.ErrMemberNotFound) {
+		t.Fatalf("expected %v, got %v", chatsvc.ErrMemberNotFound, err)
+	}
+}
+
+func TestFindDirectChat_ReturnChat(t *testing.T) {
+	ctx := context.Background()
+	userA := uuid.New()
+	userB := uuid.New()
+	expectedChat := repo.Chat{
+		ID:           uuid.New(),
+		Participants: []repo.User{{ID: userA}, {ID: userB}},
+	}
+
+	chatMockRepo := mocks.NewChatRepository(t)
+	msgMockRepo := mocks.NewMessageRepository(t)
+	userMockService := mocks.NewUserService(t)
+	userMockService.EXPECT().CanSeeProfileImage(ctx, mock.Anything, mock.Anything).Return(true, nil)
+	chatMockRepo.EXPECT().FindDirectChat(ctx, userB, userA).Return(expectedChat, nil)
+	chatMockRepo.EXPECT().GetMember(ctx, expectedChat.ID, userB).Return(repo.Member{ChatID: expectedChat.ID, UserID: userB}, nil)
+	msgMockRepo.EXPECT().CountMentions(ctx, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+	msgMockRepo.EXPECT().GetLastMessages(ctx, []uuid.UUID{expectedChat.ID}, mock.Anything).Return(nil, nil)
+
+	service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, events.NewBus())
+	c, err := service.FindDirectChat(ctx, userB, userA)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if c.ID != expectedChat.ID || c.CurrentUser.ID != userB || c.OtherUser.ID != userA {
+		t.Fatalf("expected chat %v, got %v", expectedChat, c)
+	}
+}
+
+func TestFindDirectChat_ReturnError(t *testing.T) {
+	ctx := context.Background()
+	userA := uuid.New()
+	userB := uuid.New()
+
+	chatMockRepo := mocks.NewChatRepository(t)
+	msgMockRepo := mocks.NewMessageRepository(t)
+	userMockService := mocks.NewUserService(t)
+	chatMockRepo.EXPECT().FindDirectChat(ctx, userA, userB).Return(repo.Chat{}, repo.ErrChatNotFound)
+
+	service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, events.NewBus())
+	if _, err := service.FindDirectChat(ctx, userA, userB); !errors.Is(err, chatsvc.ErrChatNotFound) {
+		t.Fatalf("expected %v, got %v", chatsvc.ErrChatNotFound, err)
+	}
+}
+
+func TestSetNickname_UpdateMember(t *testing.T) {
+	ctx := context.Background()
+	chatID := uuid.New()
+	userID := uuid.New()
+
+	chatMockRepo := mocks.NewChatRepository(t)
+	msgMockRepo := mocks.NewMessageRepository(t)
+	userMockService := mocks.NewUserService(t)
+	chatMockRepo.EXPECT().GetMember(ctx, chatID, userID).Return(repo.Member{ChatID: chatID, UserID: userID, PinPosition: 2}, nil)
+	chatMockRepo.EXPECT().UpdateMember(ctx, repo.Member{ChatID: chatID, UserID: userID, Nickname: "Nick", PinPosition: 2}).Return(nil)
+
+	service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, events.NewBus())
+	if err := service.SetNickname(ctx, chatID, userID, " Nick "); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestCreateChat_ReturnErrorWhenNotAllowed(t *testing.T) {
+	ctx := context.Background()
+	currentUserID := uuid.New()
+	otherUserID := uuid.New()
+
+	chatMockRepo := mocks.NewChatRepository(t)
+	msgMockRepo := mocks.NewMessageRepository(t)
+	userMockService := mocks.NewUserService(t)
+	chatMockRepo.EXPECT().FindDirectChat(mock.Anything, currentUserID, otherUserID).Return(repo.Chat{}, repo.ErrChatNotFound)
+	userMockService.EXPECT().CanStartChat(mock.Anything, currentUserID, otherUserID).Return(errors.New("user is blocked"))
+
+	service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, events.NewBus())
+
+	if _, err := service.CreateChat(ctx, currentUserID, otherUserID); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestGetChat_HideProfileImage(t *testing.T) {
+	ctx := context.Background()
+	viewerID := uuid.New()
+	ownerID := uuid.New()
+	expectedChat := repo.Chat{
+		ID: uuid.New(),
+		Participants: []repo.User{
+			{ID: viewerID, ImageURL: "https://viewer.png"},
+			{ID: ownerID, ImageURL: "https://owner.png"},
+		},
+	}
+
+	chatMockRepo := mocks.NewChatRepository(t)
+	msgMockRepo := mocks.NewMessageRepository(t)
+	userMockService := mocks.NewUserService(t)
+	chatMockRepo.EXPECT().GetChat(ctx, expectedChat.ID).Return(expectedChat, nil)
+	chatMockRepo.EXPECT().GetMember(ctx, expectedChat.ID, viewerID).Return(repo.Member{ChatID: expectedChat.ID, UserID: viewerID}, nil)
+	msgMockRepo.EXPECT().CountMentions(ctx, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+	msgMockRepo.EXPECT().GetLastMessages(ctx, []uuid.UUID{expectedChat.ID}, mock.Anything).Return(nil, nil)
+	userMockService.EXPECT().CanSeeProfileImage(ctx, viewerID, ownerID).Return(false, nil)
+
+	service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, events.NewBus())
+	c, err := service.GetChat(ctx, expectedChat.ID, viewerID)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if c.OtherUser.ImageURL != "" || c.CurrentUser.ImageURL != "https://viewer.png" {
+		t.Fatalf("expected only the counterpart's image to be hidden, got %v", c)
+	}
+}
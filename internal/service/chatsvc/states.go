@@ -0,0 +1,206 @@
+package chatsvc
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"github.com/AliUnipal/chat/internal/events"
+	"github.com/AliUnipal/chat/internal/models/message"
+	"github.com/AliUnipal/chat/internal/service/chatsvc/repo"
+	"github.com/google/uuid"
+	"slices"
+	"time"
+)
+
+// PinChat pins the chat to the top of the user's chat list, above any chats
+// they pinned before. Pinning an archived chat unarchives it.
+func (s *service) PinChat(ctx context.Context, chatID, userID uuid.UUID) error {
+	m, err := s.chatRepo.GetMember(ctx, chatID, userID)
+	if err != nil {
+		return err
+	}
+	if m.PinPosition > 0 {
+		return nil
+	}
+	pinned, err := s.pinnedMembers(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if len(pinned) >= maxPinnedChats {
+		return ErrTooManyPinnedChats
+	}
+
+	m.ArchivedAt = time.Time{}
+	return s.setPinOrder(ctx, append([]repo.Member{m}, pinned...))
+}
+
+func (s *service) UnpinChat(ctx context.Context, chatID, userID uuid.UUID) error {
+	m, err := s.chatRepo.GetMember(ctx, chatID, userID)
+	if err != nil {
+		return err
+	}
+	if m.PinPosition == 0 {
+		return nil
+	}
+
+	return s.unpin(ctx, m)
+}
+
+// ReorderPinnedChats sets the order of the user's pinned chats. chatIDs must
+// list every pinned chat exactly once.
+func (s *service) ReorderPinnedChats(ctx context.Context, userID uuid.UUID, chatIDs []uuid.UUID) error {
+	pinned, err := s.pinnedMembers(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if len(chatIDs) != len(pinned) {
+		return errors.New("order must list every pinned chat exactly once")
+	}
+
+	ordered := make([]repo.Member, len(chatIDs))
+	for i, id := range chatIDs {
+		j := slices.IndexFunc(pinned, func(m repo.Member) bool { return m.ChatID == id })
+		if j < 0 {
+			return errors.New("order must list every pinned chat exactly once")
+		}
+		ordered[i] = pinned[j]
+		pinned = slices.Delete(pinned, j, j+1)
+	}
+
+	return s.setPinOrder(ctx, ordered)
+}
+
+// ArchiveChat moves the chat out of the user's inbox until a new message
+// arrives while the chat is not muted. Archiving a pinned chat unpins it.
+func (s *service) ArchiveChat(ctx context.Context, chatID, userID uuid.UUID) error {
+	m, err := s.chatRepo.GetMember(ctx, chatID, userID)
+	if err != nil {
+		return err
+	}
+	m.ArchivedAt = time.Now().UTC()
+	if m.PinPosition > 0 {
+		return s.unpin(ctx, m)
+	}
+
+	return s.chatRepo.UpdateMember(ctx, m)
+}
+
+// HandleEvent records new messages as the chat's latest activity and brings
+// archived chats back to the inbox of their members, unless they muted the
+// chat. Mentioned members get it back through the mute.
+func (s *service) HandleEvent(ctx context.Context, e events.Event) error {
+	if e.Type != events.MessageCreated || e.Message == nil {
+		return nil
+	}
+
+	var throughMute []uuid.UUID
+	for _, id := range e.Recipients {
+		if message.Mentioned(e.Message.Mentions, id) {
+			throughMute = append(throughMute, id)
+		}
+	}
+
+	err := s.chatRepo.SetLastMessageAt(ctx, e.ChatID, e.Message.Timestamp)
+	if err == nil {
+		err = s.chatRepo.UnarchiveMembers(ctx, e.ChatID, e.Message.Timestamp, throughMute)
+	}
+	if errors.Is(err, repo.ErrChatNotFound) {
+		return nil
+	}
+	return err
+}
+
+// RecordImport moves the chat's last activity up to the latest message
+// imported into it. Imported history is old, so it does not unarchive the
+// chat the way new messages do.
+func (s *service) RecordImport(ctx context.Context, chatID uuid.UUID, lastMessageAt time.Time) error {
+	return s.chatRepo.SetLastMessageAt(ctx, chatID, lastMessageAt)
+}
+
+func (s *service) UnarchiveChat(ctx context.Context, chatID, userID uuid.UUID) error {
+	return s.updateMember(ctx, chatID, userID, func(m *repo.Member) {
+		m.ArchivedAt = time.Time{}
+	})
+}
+
+// MuteChat silences the chat for the user until the given time.
+func (s *service) MuteChat(ctx context.Context, chatID, userID uuid.UUID, until time.Time) error {
+	if !until.After(time.Now()) {
+		return errors.New("mute must end in the future")
+	}
+
+	return s.updateMember(ctx, chatID, userID, func(m *repo.Member) {
+		m.MutedUntil = until.UTC()
+	})
+}
+
+func (s *service) UnmuteChat(ctx context.Context, chatID, userID uuid.UUID) error {
+	return s.updateMember(ctx, chatID, userID, func(m *repo.Member) {
+		m.MutedUntil = time.Time{}
+	})
+}
+
+// ReadMentions marks the user's mentions in the chat as read, resetting its
+// mention count.
+func (s *service) ReadMentions(ctx context.Context, chatID, userID uuid.UUID) error {
+	return s.updateMember(ctx, chatID, userID, func(m *repo.Member) {
+		m.MentionsReadAt = time.Now().UTC()
+	})
+}
+
+// pinnedMembers returns the user's pinned chat memberships in pinned order.
+func (s *service) pinnedMembers(ctx context.Context, userID uuid.UUID) ([]repo.Member, error) {
+	userChats, err := s.chatRepo.GetChatsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var pinned []repo.Member
+	for _, c := range userChats {
+		if c.Member.PinPosition > 0 {
+			pinned = append(pinned, c.Member)
+		}
+	}
+	slices.SortFunc(pinned, func(a, b repo.Member) int {
+		return cmp.Compare(a.PinPosition, b.PinPosition)
+	})
+
+	return pinned, nil
+}
+
+func (s *service) unpin(ctx context.Context, m repo.Member) error {
+	pinned, err := s.pinnedMembers(ctx, m.UserID)
+	if err != nil {
+		return err
+	}
+	pinned = slices.DeleteFunc(pinned, func(p repo.Member) bool { return p.ChatID == m.ChatID })
+
+	m.PinPosition = 0
+	if err := s.chatRepo.UpdateMember(ctx, m); err != nil {
+		return err
+	}
+
+	return s.setPinOrder(ctx, pinned)
+}
+
+// setPinOrder numbers the members' pin positions in the given order, writing
+// only the memberships whose position changed.
+func (s *service) setPinOrder(ctx context.Context, members []repo.Member) error {
+	for i, m := range members {
+		if m.PinPosition == i+1 {
+			continue
+		}
+		m.PinPosition = i + 1
+		if err := s.chatRepo.UpdateMember(ctx, m); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mentionsSince returns the time after which the member's mentions in the
+// chat count as unread.
+func mentionsSince(member repo.Member) time.Time {
+	return later(member.MentionsReadAt, member.ClearedAt)
+}
@@ -0,0 +1,255 @@
+package chatsvc_test
+
+import (
+	"context"
+	"errors"
+	"github.com/AliUnipal/chat/internal/events"
+	"github.com/AliUnipal/chat/internal/models/message"
+	"github.com/AliUnipal/chat/internal/service/chatsvc"
+	"github.com/AliUnipal/chat/internal/service/chatsvc/mocks"
+	"github.com/AliUnipal/chat/internal/service/chatsvc/repo"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func memberChat(userID uuid.UUID, member repo.Member) repo.UserChat {
+	member.UserID = userID
+	if member.ChatID == uuid.Nil {
+		member.ChatID = uuid.New()
+	}
+
+	return repo.UserChat{
+		Chat: repo.Chat{
+			ID:           member.ChatID,
+			Participants: []repo.User{{ID: userID}, {ID: uuid.New()}},
+		},
+		Member: member,
+	}
+}
+
+func TestPinChat_PinAboveExistingPins(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	first := memberChat(userID, repo.Member{PinPosition: 1})
+	second := memberChat(userID, repo.Member{PinPosition: 2})
+	archived := memberChat(userID, repo.Member{ArchivedAt: time.Now()})
+
+	chatMockRepo := mocks.NewChatRepository(t)
+	msgMockRepo := mocks.NewMessageRepository(t)
+	userMockService := mocks.NewUserService(t)
+	chatMockRepo.EXPECT().GetMember(ctx, archived.ID, userID).Return(archived.Member, nil)
+	chatMockRepo.EXPECT().GetChatsByUser(ctx, userID).Return([]repo.UserChat{second, archived, first}, nil)
+	chatMockRepo.EXPECT().UpdateMember(ctx, repo.Member{ChatID: archived.ID, UserID: userID, PinPosition: 1}).Return(nil).Once()
+	chatMockRepo.EXPECT().UpdateMember(ctx, repo.Member{ChatID: first.ID, UserID: userID, PinPosition: 2}).Return(nil).Once()
+	chatMockRepo.EXPECT().UpdateMember(ctx, repo.Member{ChatID: second.ID, UserID: userID, PinPosition: 3}).Return(nil).Once()
+
+	service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, events.NewBus())
+	if err := service.PinChat(ctx, archived.ID, userID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestPinChat_ReturnErrorOnTooManyPins(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	var userChats []repo.UserChat
+	for i := range 5 {
+		userChats = append(userChats, memberChat(userID, repo.Member{PinPosition: i + 1}))
+	}
+	unpinned := memberChat(userID, repo.Member{})
+
+	chatMockRepo := mocks.NewChatRepository(t)
+	msgMockRepo := mocks.NewMessageRepository(t)
+	userMockService := mocks.NewUserService(t)
+	chatMockRepo.EXPECT().GetMember(ctx, unpinned.ID, userID).Return(unpinned.Member, nil)
+	chatMockRepo.EXPECT().GetChatsByUser(ctx, userID).Return(append(userChats, unpinned), nil)
+
+	service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, events.NewBus())
+	if err := service.PinChat(ctx, unpinned.ID, userID); !errors.Is(err, chatsvc.ErrTooManyPinnedChats) {
+		t.Fatalf("expected %v, got %v", chatsvc.ErrTooManyPinnedChats, err)
+	}
+}
+
+func TestUnpinChat_CompactPinPositions(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	first := memberChat(userID, repo.Member{PinPosition: 1})
+	second := memberChat(userID, repo.Member{PinPosition: 2})
+	third := memberChat(userID, repo.Member{PinPosition: 3})
+
+	chatMockRepo := mocks.NewChatRepository(t)
+	msgMockRepo := mocks.NewMessageRepository(t)
+	userMockService := mocks.NewUserService(t)
+	chatMockRepo.EXPECT().GetMember(ctx, first.ID, userID).Return(first.Member, nil)
+	chatMockRepo.EXPECT().GetChatsByUser(ctx, userID).Return([]repo.UserChat{first, second, third}, nil)
+	chatMockRepo.EXPECT().UpdateMember(ctx, repo.Member{ChatID: first.ID, UserID: userID}).Return(nil).Once()
+	chatMockRepo.EXPECT().UpdateMember(ctx, repo.Member{ChatID: second.ID, UserID: userID, PinPosition: 1}).Return(nil).Once()
+	chatMockRepo.EXPECT().UpdateMember(ctx, repo.Member{ChatID: third.ID, UserID: userID, PinPosition: 2}).Return(nil).Once()
+
+	service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, events.NewBus())
+	if err := service.UnpinChat(ctx, first.ID, userID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestReorderPinnedChats_UpdateChangedPositions(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	first := memberChat(userID, repo.Member{PinPosition: 1})
+	second := memberChat(userID, repo.Member{PinPosition: 2})
+	third := memberChat(userID, repo.Member{PinPosition: 3})
+
+	chatMockRepo := mocks.NewChatRepository(t)
+	msgMockRepo := mocks.NewMessageRepository(t)
+	userMockService := mocks.NewUserService(t)
+	chatMockRepo.EXPECT().GetChatsByUser(ctx, userID).Return([]repo.UserChat{first, second, third}, nil)
+	chatMockRepo.EXPECT().UpdateMember(ctx, repo.Member{ChatID: third.ID, UserID: userID, PinPosition: 1}).Return(nil).Once()
+	chatMockRepo.EXPECT().UpdateMember(ctx, repo.Member{ChatID: first.ID, UserID: userID, PinPosition: 3}).Return(nil).Once()
+
+	service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, events.NewBus())
+	if err := service.ReorderPinnedChats(ctx, userID, []uuid.UUID{third.ID, second.ID, first.ID}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestReorderPinnedChats_ReturnErrorOnIncompleteOrder(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	first := memberChat(userID, repo.Member{PinPosition: 1})
+	second := memberChat(userID, repo.Member{PinPosition: 2})
+
+	chatMockRepo := mocks.NewChatRepository(t)
+	msgMockRepo := mocks.NewMessageRepository(t)
+	userMockService := mocks.NewUserService(t)
+	chatMockRepo.EXPECT().GetChatsByUser(ctx, userID).Return([]repo.UserChat{first, second}, nil)
+
+	service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, events.NewBus())
+	if err := service.ReorderPinnedChats(ctx, userID, []uuid.UUID{first.ID, first.ID}); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestArchiveChat_UnpinChat(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	first := memberChat(userID, repo.Member{PinPosition: 1})
+	second := memberChat(userID, repo.Member{PinPosition: 2})
+
+	chatMockRepo := mocks.NewChatRepository(t)
+	msgMockRepo := mocks.NewMessageRepository(t)
+	userMockService := mocks.NewUserService(t)
+	chatMockRepo.EXPECT().GetMember(ctx, first.ID, userID).Return(first.Member, nil)
+	chatMockRepo.EXPECT().GetChatsByUser(ctx, userID).Return([]repo.UserChat{first, second}, nil)
+	chatMockRepo.EXPECT().UpdateMember(ctx, mock.MatchedBy(func(m repo.Member) bool {
+		return m.ChatID == first.ID && m.PinPosition == 0 && !m.ArchivedAt.IsZero()
+	})).Return(nil).Once()
+	chatMockRepo.EXPECT().UpdateMember(ctx, repo.Member{ChatID: second.ID, UserID: userID, PinPosition: 1}).Return(nil).Once()
+
+	service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, events.NewBus())
+	if err := service.ArchiveChat(ctx, first.ID, userID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestMuteChat_UpdateMember(t *testing.T) {
+	ctx := context.Background()
+	chatID := uuid.New()
+	userID := uuid.New()
+	until := time.Now().Add(time.Hour)
+
+	chatMockRepo := mocks.NewChatRepository(t)
+	msgMockRepo := mocks.NewMessageRepository(t)
+	userMockService := mocks.NewUserService(t)
+	chatMockRepo.EXPECT().GetMember(ctx, chatID, userID).Return(repo.Member{ChatID: chatID, UserID: userID}, nil)
+	chatMockRepo.EXPECT().UpdateMember(ctx, repo.Member{ChatID: chatID, UserID: userID, MutedUntil: until.UTC()}).Return(nil)
+
+	service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, events.NewBus())
+	if err := service.MuteChat(ctx, chatID, userID, until); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestMuteChat_ReturnErrorOnPastTime(t *testing.T) {
+	ctx := context.Background()
+
+	chatMockRepo := mocks.NewChatRepository(t)
+	msgMockRepo := mocks.NewMessageRepository(t)
+	userMockService := mocks.NewUserService(t)
+
+	service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, events.NewBus())
+	if err := service.MuteChat(ctx, uuid.New(), uuid.New(), time.Now().Add(-time.Minute)); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestMuteChat_ReturnErrorOnNonMember(t *testing.T) {
+	ctx := context.Background()
+	chatID := uuid.New()
+	userID := uuid.New()
+
+	chatMockRepo := mocks.NewChatRepository(t)
+	msgMockRepo := mocks.NewMessageRepository(t)
+	userMockService := mocks.NewUserService(t)
+	chatMockRepo.EXPECT().GetMember(ctx, chatID, userID).Return(repo.Member{}, repo.ErrMemberNotFound)
+
+	service := chatsvc.NewService(chatMockRepo, msgMockRepo, userMockService, events.NewBus())
+	if err := service.MuteChat(ctx, chatID, userID, time.Now().Add(time.Hour)); !errors.Is(err, chatsvc.ErrMemberNotFound) {
+		t.Fatalf("expected %v, got %v", chatsvc.ErrMemberNotFound, err)
+	}
+}
+
+func TestHandleEvent_UnarchiveChat(t *testing.T) {
+	ctx := context.Background()
+	chatID := uuid.New()
+	aliceID := uuid.New()
+	bobID := uuid.New()
+	sentAt := time.Now()
+
+	tests := []struct {
+		name        string
+		mentions    []message.Mention
+		throughMute []uuid.UUID
+	}{
+		{"no mentions", nil, nil},
+		{"mention", []message.Mention{{UserID: aliceID}}, []uuid.UUID{aliceID}},
+		{"mention all", []message.Mention{{UserID: aliceID}, {UserID: uuid.Nil}}, []uuid.UUID{aliceID, bobID}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chatMockRepo := mocks.NewChatRepository(t)
+			chatMockRepo.EXPECT().SetLastMessageAt(ctx, chatID, sentAt).Return(nil)
+			chatMockRepo.EXPECT().UnarchiveMembers(ctx, chatID, sentAt, tt.throughMute).Return(nil)
+
+			service := chatsvc.NewService(chatMockRepo, mocks.NewMessageRepository(t), mocks.NewUserService(t), events.NewBus())
+			err := service.HandleEvent(ctx, events.Event{
+				Type:       events.MessageCreated,
+				ChatID:     chatID,
+				UserID:     bobID,
+				Recipients: []uuid.UUID{aliceID, bobID},
+				Message:    &message.Message{ID: uuid.New(), Timestamp: sentAt, Mentions: tt.mentions},
+			})
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestReadMentions_UpdateMember(t *testing.T) {
+	ctx := context.Background()
+	chatID := uuid.New()
+	userID := uuid.New()
+
+	chatMockRepo := mocks.NewChatRepository(t)
+	chatMockRepo.EXPECT().GetMember(ctx, chatID, userID).Return(repo.Member{ChatID: chatID, UserID: userID}, nil)
+	chatMockRepo.EXPECT().UpdateMember(ctx, mock.MatchedBy(func(m repo.Member) bool {
+		return m.UserID == userID && time.Since(m.MentionsReadAt) < time.Minute
+	})).Return(nil)
+
+	service := chatsvc.NewService(chatMockRepo, mocks.NewMessageRepository(t), mocks.NewUserService(t), events.NewBus())
+	if err := service.ReadMentions(ctx, chatID, userID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
@@ -0,0 +1,315 @@
+package exportsvc
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/AliUnipal/chat/internal/models/chat"
+	"github.com/AliUnipal/chat/internal/models/message"
+	"github.com/AliUnipal/chat/internal/models/user"
+	"github.com/google/uuid"
+	"html/template"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// attachmentExtensions names attachment files after their detected type.
+var attachmentExtensions = map[string]string{
+	"image/png":       ".png",
+	"image/jpeg":      ".jpg",
+	"image/gif":       ".gif",
+	"image/webp":      ".webp",
+	"image/bmp":       ".bmp",
+	"application/pdf": ".pdf",
+	"application/zip": ".zip",
+	"audio/mpeg":      ".mp3",
+	"video/mp4":       ".mp4",
+}
+
+type profileFile struct {
+	ID           uuid.UUID     `json:"id"`
+	FirstName    string        `json:"first_name"`
+	LastName     string        `json:"last_name"`
+	Username     string        `json:"username"`
+	ImageURL     string        `json:"image_url"`
+	CreatedAt    time.Time     `json:"created_at"`
+	Privacy      privacyFile   `json:"privacy"`
+	Contacts     []contactFile `json:"contacts"`
+	BlockedUsers []uuid.UUID   `json:"blocked_users"`
+}
+
+type privacyFile struct {
+	StartChat    string `json:"start_chat"`
+	ProfileImage string `json:"profile_image"`
+	LastSeen     string `json:"last_seen"`
+}
+
+type contactFile struct {
+	ID       uuid.UUID `json:"id"`
+	Name     string    `json:"name"`
+	Username string    `json:"username"`
+}
+
+type chatFile struct {
+	ID          uuid.UUID   `json:"id"`
+	With        contactFile `json:"with"`
+	DisplayName string      `json:"display_name"`
+	Pinned      bool        `json:"pinned"`
+	Archived    bool        `json:"archived"`
+	Muted       bool        `json:"muted"`
+	Encrypted   bool        `json:"encrypted"`
+	Transcript  string      `json:"transcript"`
+}
+
+type messageFile struct {
+	ID        uuid.UUID `json:"id"`
+	SenderID  uuid.UUID `json:"sender_id"`
+	Sender    string    `json:"sender"`
+	Timestamp time.Time `json:"timestamp"`
+	Type      string    `json:"type"`
+	Text      string    `json:"text,omitempty"`
+	// Attachment is the path of the attachment relative to the chat's
+	// folder.
+	Attachment string `json:"attachment,omitempty"`
+	// Ciphertext holds end-to-end encrypted content, which the server cannot
+	// read.
+	Ciphertext []byte `json:"ciphertext,omitempty"`
+}
+
+var transcript = template.Must(template.New("transcript").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Chat with {{.Title}}</title>
+<style>
+body { font-family: sans-serif; max-width: 40em; margin: 2em auto; }
+.meta { color: #666; font-size: 0.8em; }
+.text { white-space: pre-wrap; margin: 0.2em 0 1em; }
+img { max-width: 100%; }
+</style>
+</head>
+<body>
+<h1>Chat with {{.Title}}</h1>
+{{range .Messages}}<div class="meta">{{.Sender}} &middot; {{.Timestamp.Format "2006-01-02 15:04:05 UTC"}}</div>
+{{if eq .Type "text"}}<p class="text">{{.Text}}</p>
+{{else if eq .Type "image"}}<p><img src="{{.Attachment}}" alt="Image"></p>
+{{else if eq .Type "file"}}<p><a href="{{.Attachment}}">Attachment</a></p>
+{{else}}<p class="text"><em>[encrypted message]</em></p>
+{{end}}{{end}}</body>
+</html>
+`))
+
+// build packs the user's data into a ZIP archive, reporting progress as a
+// percentage after each chat.
+func (s *service) build(ctx context.Context, userID uuid.UUID, progress func(int) error) ([]byte, error) {
+	u, err := s.users.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	privacy, err := s.users.GetPrivacySettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	contacts, err := s.users.GetContacts(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	blocked, err := s.users.GetBlockedUsers(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	chats, err := s.allChats(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	profile := profileFile{
+		ID:        u.ID,
+		FirstName: u.FirstName,
+		LastName:  u.LastName,
+		Username:  u.Username,
+		ImageURL:  u.ImageURL,
+		CreatedAt: u.CreatedAt,
+		Privacy: privacyFile{
+			StartChat:    audienceName(privacy.StartChat),
+			ProfileImage: audienceName(privacy.ProfileImage),
+			LastSeen:     audienceName(privacy.LastSeen),
+		},
+		Contacts:     make([]contactFile, 0, len(contacts)),
+		BlockedUsers: blocked,
+	}
+	for _, c := range contacts {
+		profile.Contacts = append(profile.Contacts, toContact(c))
+	}
+	if err := writeJSON(zw, "profile.json", profile); err != nil {
+		return nil, err
+	}
+
+	// The profile and the final packaging count as a step each.
+	steps := len(chats) + 2
+	if err := progress(100 / steps); err != nil {
+		return nil, err
+	}
+
+	index := make([]chatFile, 0, len(chats))
+	for i, c := range chats {
+		if err := s.writeChat(ctx, zw, u, c); err != nil {
+			return nil, err
+		}
+		index = append(index, chatFile{
+			ID:          c.ID,
+			With:        toContact(c.OtherUser),
+			DisplayName: c.DisplayName,
+			Pinned:      c.Pinned,
+			Archived:    c.Archived,
+			Muted:       c.Muted,
+			Encrypted:   c.Encrypted,
+			Transcript:  chatDir(c.ID) + "transcript.html",
+		})
+		if err := progress((i + 2) * 100 / steps); err != nil {
+			return nil, err
+		}
+	}
+	if err := writeJSON(zw, "chats.json", index); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// allChats returns every chat the user belongs to, message requests and the
+// chats they deleted for themselves included.
+func (s *service) allChats(ctx context.Context, userID uuid.UUID) ([]chat.Chat, error) {
+	ids, err := s.chats.GetChatIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	chats := make([]chat.Chat, 0, len(ids))
+	for _, id := range ids {
+		c, err := s.chats.GetChat(ctx, id, userID)
+		if err != nil {
+			return nil, err
+		}
+		chats = append(chats, c)
+	}
+	return chats, nil
+}
+
+// writeChat adds the chat's messages as JSON and as an HTML transcript, with
+// its attachments alongside them.
+func (s *service) writeChat(ctx context.Context, zw *zip.Writer, u user.User, c chat.Chat) error {
+	msgs, err := s.msgs.GetMessages(ctx, c.ID, u.ID)
+	if err != nil {
+		return err
+	}
+
+	dir := chatDir(c.ID)
+	names := map[uuid.UUID]string{
+		u.ID:           fullName(u),
+		c.OtherUser.ID: c.DisplayName,
+	}
+	files := make([]messageFile, 0, len(msgs))
+	for _, m := range msgs {
+		// Bots added to the chat send messages too.
+		if _, ok := names[m.SenderID]; !ok {
+			sender, err := s.users.GetUser(ctx, m.SenderID)
+			if err != nil {
+				return err
+			}
+			names[m.SenderID] = fullName(sender)
+		}
+		f := messageFile{
+			ID:        m.ID,
+			SenderID:  m.SenderID,
+			Sender:    names[m.SenderID],
+			Timestamp: m.Timestamp.UTC(),
+		}
+		switch m.ContentType {
+		case message.TextContentType, message.RichTextContentType:
+			f.Type = "text"
+			f.Text = string(m.Content)
+		case message.ImageContentType, message.FileContentType:
+			f.Type = "file"
+			if m.ContentType == message.ImageContentType {
+				f.Type = "image"
+			}
+			f.Attachment = "attachments/" + m.ID.String() + attachmentExtension(m.Content)
+			if err := writeFile(zw, dir+f.Attachment, m.Content); err != nil {
+				return err
+			}
+		default:
+			f.Type = "encrypted"
+			f.Ciphertext = m.Content
+		}
+		files = append(files, f)
+	}
+	if err := writeJSON(zw, dir+"messages.json", files); err != nil {
+		return err
+	}
+
+	w, err := zw.Create(dir + "transcript.html")
+	if err != nil {
+		return err
+	}
+	return transcript.Execute(w, struct {
+		Title    string
+		Messages []messageFile
+	}{c.DisplayName, files})
+}
+
+func writeJSON(zw *zip.Writer, name string, v any) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFile(zw, name, b)
+}
+
+func writeFile(zw *zip.Writer, name string, content []byte) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(content)
+	return err
+}
+
+func chatDir(chatID uuid.UUID) string {
+	return "chats/" + chatID.String() + "/"
+}
+
+func attachmentExtension(content []byte) string {
+	mediaType, _, _ := strings.Cut(http.DetectContentType(content), ";")
+	if ext, ok := attachmentExtensions[mediaType]; ok {
+		return ext
+	}
+	return ".bin"
+}
+
+func toContact(u user.User) contactFile {
+	return contactFile{ID: u.ID, Name: fullName(u), Username: u.Username}
+}
+
+func fullName(u user.User) string {
+	return strings.TrimSpace(u.FirstName + " " + u.LastName)
+}
+
+func audienceName(a user.Audience) string {
+	switch a {
+	case user.Everyone:
+		return "everyone"
+	case user.ContactsOnly:
+		return "contacts"
+	default:
+		return "nobody"
+	}
+}
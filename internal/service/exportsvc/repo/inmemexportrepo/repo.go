@@ -0,0 +1,104 @@
+package inmemexportrepo
+
+import (
+	"context"
+	"errors"
+	"github.com/AliUnipal/chat/internal/models/export"
+	"github.com/AliUnipal/chat/internal/service/exportsvc/repo"
+	"github.com/google/uuid"
+	"sync"
+)
+
+func New() *repository {
+	return &repository{
+		jobs:     make(map[uuid.UUID]repo.Job),
+		archives: make(map[uuid.UUID][]byte),
+	}
+}
+
+// repository is safe for concurrent use, as export jobs update it in the
+// background.
+type repository struct {
+	mu       sync.RWMutex
+	jobs     map[uuid.UUID]repo.Job
+	archives map[uuid.UUID][]byte
+}
+
+// CreateJob adds the job unless its user already has one pending or
+// running, in which case it returns repo.ErrJobInProgress. Stale jobs do not
+// count.
+func (r *repository) CreateJob(_ context.Context, job repo.Job) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.jobs[job.ID]; ok {
+		return errors.New("export already exists")
+	}
+	for _, j := range r.jobs {
+		if j.UserID == job.UserID && (j.Status == export.Pending || j.Status == export.Running) && !j.Stale(job.CreatedAt) {
+			return repo.ErrJobInProgress
+		}
+	}
+	r.jobs[job.ID] = job
+	return nil
+}
+
+func (r *repository) GetJob(_ context.Context, id uuid.UUID) (repo.Job, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	job, ok := r.jobs[id]
+	if !ok {
+		return repo.Job{}, repo.ErrJobNotFound
+	}
+	return job, nil
+}
+
+func (r *repository) UpdateJob(_ context.Context, job repo.Job) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.jobs[job.ID]; !ok {
+		return repo.ErrJobNotFound
+	}
+	r.jobs[job.ID] = job
+	return nil
+}
+
+// SaveArchive stores the archive of the job. It returns repo.ErrJobNotFound
+// once the job is deleted, so an export finishing late leaves nothing behind.
+func (r *repository) SaveArchive(_ context.Context, jobID uuid.UUID, archive []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.jobs[jobID]; !ok {
+		return repo.ErrJobNotFound
+	}
+	r.archives[jobID] = archive
+	return nil
+}
+
+func (r *repository) GetArchive(_ context.Context, jobID uuid.UUID) ([]byte, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	archive, ok := r.archives[jobID]
+	if !ok {
+		return nil, repo.ErrArchiveNotFound
+	}
+	return archive, nil
+}
+
+// DeleteJobs removes every job of the user along with its archive.
+func (r *repository) DeleteJobs(_ context.Context, userID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, j := range r.jobs {
+		if j.UserID == userID {
+			delete(r.jobs, id)
+			delete(r.archives, id)
+		}
+	}
+	return nil
+}
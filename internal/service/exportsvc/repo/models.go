@@ -0,0 +1,34 @@
+package repo
+
+import (
+	"errors"
+	"github.com/AliUnipal/chat/internal/models/export"
+	"github.com/google/uuid"
+	"time"
+)
+
+var (
+	ErrJobNotFound     = errors.New("export does not exist")
+	ErrArchiveNotFound = errors.New("export archive does not exist")
+	ErrJobInProgress   = errors.New("an export is already in progress")
+)
+
+// JobTimeout is how long a job may stay pending or running. A job older than
+// that is taken to have died with the process running it, so it no longer
+// blocks a new export.
+const JobTimeout = time.Hour
+
+type Job struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	Status      export.Status
+	Progress    int
+	Error       string
+	CreatedAt   time.Time
+	CompletedAt time.Time
+}
+
+// Stale reports whether the job is still pending or running past JobTimeout.
+func (j Job) Stale(now time.Time) bool {
+	return (j.Status == export.Pending || j.Status == export.Running) && now.Sub(j.CreatedAt) > JobTimeout
+}
@@ -0,0 +1,190 @@
+package exportsvc
+
+import (
+	"context"
+	"errors"
+	"github.com/AliUnipal/chat/internal/models/chat"
+	"github.com/AliUnipal/chat/internal/models/export"
+	"github.com/AliUnipal/chat/internal/models/message"
+	"github.com/AliUnipal/chat/internal/models/user"
+	"github.com/AliUnipal/chat/internal/service/exportsvc/repo"
+	"github.com/google/uuid"
+	"sync"
+	"time"
+)
+
+var (
+	ErrExportInProgress = repo.ErrJobInProgress
+	ErrExportNotReady   = errors.New("export has not completed")
+	ErrExportTimedOut   = errors.New("export timed out")
+)
+
+type exportService interface {
+	StartExport(ctx context.Context, userID uuid.UUID) (uuid.UUID, error)
+	GetExport(ctx context.Context, userID, jobID uuid.UUID) (export.Job, error)
+	GetArchive(ctx context.Context, userID, jobID uuid.UUID) ([]byte, error)
+	DeleteExports(ctx context.Context, userID uuid.UUID) error
+}
+
+type exportRepository interface {
+	CreateJob(ctx context.Context, job repo.Job) error
+	GetJob(ctx context.Context, id uuid.UUID) (repo.Job, error)
+	UpdateJob(ctx context.Context, job repo.Job) error
+	SaveArchive(ctx context.Context, jobID uuid.UUID, archive []byte) error
+	GetArchive(ctx context.Context, jobID uuid.UUID) ([]byte, error)
+	DeleteJobs(ctx context.Context, userID uuid.UUID) error
+}
+
+type userService interface {
+	GetUser(ctx context.Context, id uuid.UUID) (user.User, error)
+	GetPrivacySettings(ctx context.Context, userID uuid.UUID) (user.PrivacySettings, error)
+	GetContacts(ctx context.Context, userID uuid.UUID) ([]user.User, error)
+	GetBlockedUsers(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+}
+
+type chatService interface {
+	GetChatIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+	GetChat(ctx context.Context, id, userID uuid.UUID) (chat.Chat, error)
+}
+
+type messageService interface {
+	GetMessages(ctx context.Context, chatID, userID uuid.UUID) ([]message.Message, error)
+}
+
+type service struct {
+	repo  exportRepository
+	users userService
+	chats chatService
+	msgs  messageService
+
+	wg sync.WaitGroup
+}
+
+var _ exportService = (*service)(nil)
+
+func NewService(repo exportRepository, users userService, chats chatService, msgs messageService) *service {
+	return &service{repo: repo, users: users, chats: chats, msgs: msgs}
+}
+
+// StartExport queues a copy of everything the user can see: their profile,
+// contacts and settings, their chats, and the messages and attachments in
+// them. The archive is built in the background; GetExport reports its
+// progress. Only one export per user runs at a time; ErrExportInProgress is
+// returned while another is pending or running. An export not finished
+// within repo.JobTimeout is given up on and reported as failed.
+func (s *service) StartExport(ctx context.Context, userID uuid.UUID) (uuid.UUID, error) {
+	if _, err := s.users.GetUser(ctx, userID); err != nil {
+		return uuid.Nil, err
+	}
+	// The repository refuses a second job while one is pending or running,
+	// so concurrent requests cannot both start an export.
+	job := repo.Job{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Status:    export.Pending,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := s.repo.CreateJob(ctx, job); err != nil {
+		return uuid.Nil, err
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.run(context.WithoutCancel(ctx), job)
+	}()
+
+	return job.ID, nil
+}
+
+func (s *service) GetExport(ctx context.Context, userID, jobID uuid.UUID) (export.Job, error) {
+	job, err := s.getJob(ctx, userID, jobID)
+	if err != nil {
+		return export.Job{}, err
+	}
+
+	// The process running a stale job died before recording how it ended.
+	if job.Stale(time.Now().UTC()) {
+		job.Status = export.Failed
+		job.Error = ErrExportTimedOut.Error()
+	}
+
+	return export.Job{
+		ID:          job.ID,
+		UserID:      job.UserID,
+		Status:      job.Status,
+		Progress:    job.Progress,
+		Error:       job.Error,
+		CreatedAt:   job.CreatedAt,
+		CompletedAt: job.CompletedAt,
+	}, nil
+}
+
+// GetArchive returns the ZIP archive of a completed export.
+func (s *service) GetArchive(ctx context.Context, userID, jobID uuid.UUID) ([]byte, error) {
+	job, err := s.getJob(ctx, userID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status != export.Completed {
+		return nil, ErrExportNotReady
+	}
+
+	return s.repo.GetArchive(ctx, jobID)
+}
+
+// DeleteExports deletes the user's exports and their archives, for example
+// when their account is deleted. An export still running is dropped when it
+// finishes.
+func (s *service) DeleteExports(ctx context.Context, userID uuid.UUID) error {
+	return s.repo.DeleteJobs(ctx, userID)
+}
+
+// Wait blocks until every export started so far has finished.
+func (s *service) Wait() {
+	s.wg.Wait()
+}
+
+// getJob returns the job, hiding other users' exports as if they did not
+// exist.
+func (s *service) getJob(ctx context.Context, userID, jobID uuid.UUID) (repo.Job, error) {
+	job, err := s.repo.GetJob(ctx, jobID)
+	if err != nil {
+		return repo.Job{}, err
+	}
+	if job.UserID != userID {
+		return repo.Job{}, repo.ErrJobNotFound
+	}
+
+	return job, nil
+}
+
+// run builds the job's archive, recording it as failed if anything goes
+// wrong. Should even that not be recorded, the job goes stale after
+// repo.JobTimeout and stops blocking new exports.
+func (s *service) run(ctx context.Context, job repo.Job) {
+	job.Status = export.Running
+	err := s.repo.UpdateJob(ctx, job)
+	if err == nil {
+		// The job must not run past the point where it is reported failed.
+		buildCtx, cancel := context.WithDeadline(ctx, job.CreatedAt.Add(repo.JobTimeout))
+		var archive []byte
+		archive, err = s.build(buildCtx, job.UserID, func(progress int) error {
+			job.Progress = progress
+			return s.repo.UpdateJob(buildCtx, job)
+		})
+		if err == nil {
+			err = s.repo.SaveArchive(buildCtx, job.ID, archive)
+		}
+		cancel()
+	}
+	if err != nil {
+		job.Status = export.Failed
+		job.Error = err.Error()
+	} else {
+		job.Status = export.Completed
+		job.Progress = 100
+	}
+	job.CompletedAt = time.Now().UTC()
+	_ = s.repo.UpdateJob(ctx, job)
+}
@@ -0,0 +1,313 @@
+package exportsvc_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/AliUnipal/chat/internal/models/chat"
+	"github.com/AliUnipal/chat/internal/models/export"
+	"github.com/AliUnipal/chat/internal/models/message"
+	"github.com/AliUnipal/chat/internal/models/user"
+	"github.com/AliUnipal/chat/internal/service/exportsvc"
+	"github.com/AliUnipal/chat/internal/service/exportsvc/mocks"
+	"github.com/AliUnipal/chat/internal/service/exportsvc/repo"
+	"github.com/AliUnipal/chat/internal/service/exportsvc/repo/inmemexportrepo"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var png = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func readZip(t *testing.T, archive []byte) map[string][]byte {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		t.Fatalf("expected a zip archive got %v", err)
+	}
+	files := make(map[string][]byte)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+		files[f.Name], _ = io.ReadAll(rc)
+		rc.Close()
+	}
+	return files
+}
+
+func TestExport_BuildArchive(t *testing.T) {
+	ctx := context.Background()
+	alice := user.User{ID: uuid.New(), FirstName: "Alice", Username: "+97311111111", CreatedAt: time.Now()}
+	bob := user.User{ID: uuid.New(), FirstName: "Bob", Username: "+97322222222"}
+	bot := user.User{ID: uuid.New(), FirstName: "Helper", Type: user.Bot}
+	c := chat.Chat{ID: uuid.New(), CurrentUser: alice, OtherUser: bob, DisplayName: "Bobby"}
+	msgs := []message.Message{
+		{ID: uuid.New(), SenderID: alice.ID, ChatID: c.ID, Content: []byte("<script>alert(1)</script>"), ContentType: message.TextContentType, Timestamp: time.Now()},
+		{ID: uuid.New(), SenderID: bob.ID, ChatID: c.ID, Content: png, ContentType: message.ImageContentType, Timestamp: time.Now()},
+		{ID: uuid.New(), SenderID: bot.ID, ChatID: c.ID, Content: []byte("beep"), ContentType: message.TextContentType, Timestamp: time.Now()},
+	}
+
+	mockUsers := mocks.NewUserService(t)
+	mockChats := mocks.NewChatService(t)
+	mockMsgs := mocks.NewMessageService(t)
+	mockUsers.EXPECT().GetUser(mock.Anything, alice.ID).Return(alice, nil)
+	mockUsers.EXPECT().GetPrivacySettings(mock.Anything, alice.ID).Return(user.PrivacySettings{StartChat: user.ContactsOnly}, nil)
+	mockUsers.EXPECT().GetContacts(mock.Anything, alice.ID).Return([]user.User{bob}, nil)
+	mockUsers.EXPECT().GetBlockedUsers(mock.Anything, alice.ID).Return(nil, nil)
+	mockUsers.EXPECT().GetUser(mock.Anything, bot.ID).Return(bot, nil).Once()
+	mockChats.EXPECT().GetChatIDs(mock.Anything, alice.ID).Return([]uuid.UUID{c.ID}, nil)
+	mockChats.EXPECT().GetChat(mock.Anything, c.ID, alice.ID).Return(c, nil)
+	mockMsgs.EXPECT().GetMessages(mock.Anything, c.ID, alice.ID).Return(msgs, nil)
+
+	service := exportsvc.NewService(inmemexportrepo.New(), mockUsers, mockChats, mockMsgs)
+	jobID, err := service.StartExport(ctx, alice.ID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	service.Wait()
+
+	job, err := service.GetExport(ctx, alice.ID, jobID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if job.Status != export.Completed || job.Progress != 100 || job.CompletedAt.IsZero() {
+		t.Fatalf("expected a completed export got %+v", job)
+	}
+	if _, err := service.GetExport(ctx, bob.ID, jobID); !errors.Is(err, repo.ErrJobNotFound) {
+		t.Fatalf("expected %v got %v", repo.ErrJobNotFound, err)
+	}
+
+	archive, err := service.GetArchive(ctx, alice.ID, jobID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	files := readZip(t, archive)
+	dir := "chats/" + c.ID.String() + "/"
+
+	var profile struct {
+		Username string `json:"username"`
+		Privacy  struct {
+			StartChat string `json:"start_chat"`
+		} `json:"privacy"`
+		Contacts []struct {
+			Name string `json:"name"`
+		} `json:"contacts"`
+	}
+	if err := json.Unmarshal(files["profile.json"], &profile); err != nil {
+		t.Fatalf("expected profile.json got %v", err)
+	}
+	if profile.Username != alice.Username || profile.Privacy.StartChat != "contacts" || len(profile.Contacts) != 1 || profile.Contacts[0].Name != "Bob" {
+		t.Fatalf("expected alice's profile got %s", files["profile.json"])
+	}
+
+	var exported []struct {
+		Sender     string `json:"sender"`
+		Type       string `json:"type"`
+		Text       string `json:"text"`
+		Attachment string `json:"attachment"`
+	}
+	if err := json.Unmarshal(files[dir+"messages.json"], &exported); err != nil {
+		t.Fatalf("expected messages.json got %v", err)
+	}
+	if len(exported) != 3 || exported[0].Text != string(msgs[0].Content) || exported[1].Sender != "Bobby" || exported[1].Type != "image" || exported[2].Sender != "Helper" {
+		t.Fatalf("expected every message got %s", files[dir+"messages.json"])
+	}
+	if exported[1].Attachment != "attachments/"+msgs[1].ID.String()+".png" || !bytes.Equal(files[dir+exported[1].Attachment], png) {
+		t.Fatalf("expected the image attachment got %q", exported[1].Attachment)
+	}
+
+	html := string(files[dir+"transcript.html"])
+	if strings.Contains(html, "<script>") || !strings.Contains(html, "&lt;script&gt;") || !strings.Contains(html, exported[1].Attachment) {
+		t.Fatalf("expected an escaped transcript got %s", html)
+	}
+	if !bytes.Contains(files["chats.json"], []byte(dir+"transcript.html")) {
+		t.Fatalf("expected the chat index got %s", files["chats.json"])
+	}
+}
+
+func TestExport_RecordFailure(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockUsers := mocks.NewUserService(t)
+	mockUsers.EXPECT().GetUser(mock.Anything, userID).Return(user.User{ID: userID}, nil)
+	mockUsers.EXPECT().GetPrivacySettings(mock.Anything, userID).Return(user.PrivacySettings{}, errors.New("unavailable"))
+
+	service := exportsvc.NewService(inmemexportrepo.New(), mockUsers, mocks.NewChatService(t), mocks.NewMessageService(t))
+	jobID, err := service.StartExport(ctx, userID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	service.Wait()
+
+	job, err := service.GetExport(ctx, userID, jobID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if job.Status != export.Failed || job.Error != "unavailable" {
+		t.Fatalf("expected a failed export got %+v", job)
+	}
+	if _, err := service.GetArchive(ctx, userID, jobID); !errors.Is(err, exportsvc.ErrExportNotReady) {
+		t.Fatalf("expected %v got %v", exportsvc.ErrExportNotReady, err)
+	}
+}
+
+func TestDeleteExports(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	exportRepo := inmemexportrepo.New()
+	done := repo.Job{ID: uuid.New(), UserID: userID, Status: export.Completed}
+	running := repo.Job{ID: uuid.New(), UserID: userID, Status: export.Running}
+	other := repo.Job{ID: uuid.New(), UserID: uuid.New(), Status: export.Completed}
+	for _, job := range []repo.Job{done, running, other} {
+		if err := exportRepo.CreateJob(ctx, job); err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+	}
+	for _, job := range []repo.Job{done, other} {
+		if err := exportRepo.SaveArchive(ctx, job.ID, []byte("archive")); err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+	}
+
+	service := exportsvc.NewService(exportRepo, mocks.NewUserService(t), mocks.NewChatService(t), mocks.NewMessageService(t))
+	if err := service.DeleteExports(ctx, userID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if _, err := service.GetExport(ctx, userID, done.ID); !errors.Is(err, repo.ErrJobNotFound) {
+		t.Fatalf("expected %v got %v", repo.ErrJobNotFound, err)
+	}
+	if _, err := exportRepo.GetArchive(ctx, done.ID); !errors.Is(err, repo.ErrArchiveNotFound) {
+		t.Fatalf("expected %v got %v", repo.ErrArchiveNotFound, err)
+	}
+	// The export that was running cannot leave its archive behind.
+	if err := exportRepo.SaveArchive(ctx, running.ID, []byte("archive")); !errors.Is(err, repo.ErrJobNotFound) {
+		t.Fatalf("expected %v got %v", repo.ErrJobNotFound, err)
+	}
+	if _, err := exportRepo.GetArchive(ctx, other.ID); err != nil {
+		t.Fatalf("expected other users' exports kept got %v", err)
+	}
+}
+
+func TestStartExport_ReturnErrorWhileInProgress(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	exportRepo := inmemexportrepo.New()
+	if err := exportRepo.CreateJob(ctx, repo.Job{ID: uuid.New(), UserID: userID, Status: export.Running, CreatedAt: time.Now().UTC()}); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	mockUsers := mocks.NewUserService(t)
+	mockUsers.EXPECT().GetUser(mock.Anything, userID).Return(user.User{ID: userID}, nil)
+
+	service := exportsvc.NewService(exportRepo, mockUsers, mocks.NewChatService(t), mocks.NewMessageService(t))
+	if _, err := service.StartExport(ctx, userID); !errors.Is(err, exportsvc.ErrExportInProgress) {
+		t.Fatalf("expected %v got %v", exportsvc.ErrExportInProgress, err)
+	}
+}
+
+func TestStartExport_ReplaceStaleJob(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	exportRepo := inmemexportrepo.New()
+	stale := repo.Job{ID: uuid.New(), UserID: userID, Status: export.Running, CreatedAt: time.Now().UTC().Add(-repo.JobTimeout - time.Minute)}
+	if err := exportRepo.CreateJob(ctx, stale); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	mockUsers := mocks.NewUserService(t)
+	mockUsers.EXPECT().GetUser(mock.Anything, userID).Return(user.User{ID: userID}, nil)
+	mockUsers.EXPECT().GetPrivacySettings(mock.Anything, userID).Return(user.PrivacySettings{}, errors.New("unavailable"))
+
+	service := exportsvc.NewService(exportRepo, mockUsers, mocks.NewChatService(t), mocks.NewMessageService(t))
+	job, err := service.GetExport(ctx, userID, stale.ID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if job.Status != export.Failed || job.Error != exportsvc.ErrExportTimedOut.Error() {
+		t.Fatalf("expected a timed out export got %+v", job)
+	}
+	if _, err := service.StartExport(ctx, userID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	service.Wait()
+}
+
+func TestExport_RecordFailureToStart(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockUsers := mocks.NewUserService(t)
+	mockUsers.EXPECT().GetUser(mock.Anything, userID).Return(user.User{ID: userID}, nil)
+
+	var mu sync.Mutex
+	var last repo.Job
+	mockRepo := mocks.NewExportRepository(t)
+	mockRepo.EXPECT().CreateJob(mock.Anything, mock.Anything).Return(nil)
+	mockRepo.EXPECT().UpdateJob(mock.Anything, mock.Anything).Return(errors.New("unavailable")).Once()
+	mockRepo.EXPECT().UpdateJob(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, job repo.Job) error {
+		mu.Lock()
+		defer mu.Unlock()
+		last = job
+		return nil
+	}).Once()
+
+	service := exportsvc.NewService(mockRepo, mockUsers, mocks.NewChatService(t), mocks.NewMessageService(t))
+	if _, err := service.StartExport(ctx, userID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	service.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if last.Status != export.Failed || last.Error != "unavailable" {
+		t.Fatalf("expected a failed export got %+v", last)
+	}
+}
+
+func TestStartExport_StartOnceConcurrently(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockUsers := mocks.NewUserService(t)
+	mockUsers.EXPECT().GetUser(mock.Anything, userID).Return(user.User{ID: userID}, nil)
+	// The export runs until every request was answered.
+	release := make(chan struct{})
+	mockUsers.EXPECT().GetPrivacySettings(mock.Anything, userID).RunAndReturn(func(context.Context, uuid.UUID) (user.PrivacySettings, error) {
+		<-release
+		return user.PrivacySettings{}, errors.New("unavailable")
+	}).Once()
+
+	service := exportsvc.NewService(inmemexportrepo.New(), mockUsers, mocks.NewChatService(t), mocks.NewMessageService(t))
+	var wg sync.WaitGroup
+	var started atomic.Int32
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := service.StartExport(ctx, userID)
+			switch {
+			case err == nil:
+				started.Add(1)
+			case !errors.Is(err, exportsvc.ErrExportInProgress):
+				t.Errorf("expected %v got %v", exportsvc.ErrExportInProgress, err)
+			}
+		}()
+	}
+	wg.Wait()
+	close(release)
+	service.Wait()
+
+	if n := started.Load(); n != 1 {
+		t.Fatalf("expected 1 export started got %d", n)
+	}
+}
@@ -0,0 +1,401 @@
+package importsvc
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"github.com/AliUnipal/chat/internal/models/chat"
+	"github.com/AliUnipal/chat/internal/models/message"
+	"github.com/AliUnipal/chat/internal/models/user"
+	"github.com/AliUnipal/chat/internal/service/chatsvc"
+	"github.com/AliUnipal/chat/internal/service/msgsvc"
+	"github.com/AliUnipal/chat/internal/service/msgsvc/repo"
+	"github.com/AliUnipal/chat/internal/service/usersvc"
+	"github.com/google/uuid"
+	"io/fs"
+	"net/http"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var (
+	ErrEmptyHistory           = errors.New("export has no messages")
+	ErrGroupChat              = errors.New("only direct chats can be imported")
+	ErrOwnerNotParticipant    = errors.New("importing user is not a participant of the chat")
+	ErrNoCounterpart          = errors.New("export has no messages from the other participant")
+	ErrEncryptedChat          = errors.New("cannot import into an end-to-end encrypted chat")
+	ErrUnknownParticipantUser = errors.New("participant is mapped to a user that does not exist")
+	// ErrForeignParticipant is returned for a participant mapped to someone
+	// else's account, as the import would put words in their mouth.
+	ErrForeignParticipant = errors.New("participants can only be mapped to the importing user or to users their imports created")
+	ErrFutureMessage      = errors.New("export has messages from the future")
+)
+
+type Format int
+
+const (
+	WhatsApp Format = iota
+	Telegram
+)
+
+// History is a chat export parsed by ParseWhatsApp or ParseTelegram.
+type History struct {
+	Format       Format
+	Participants []Participant
+	Messages     []Message
+	// Skipped counts service entries such as "Alice joined", which are not
+	// imported.
+	Skipped int
+}
+
+// Participant is someone who sent messages in the export. Key identifies them
+// within it: the display name in WhatsApp exports and the sender ID in
+// Telegram ones.
+type Participant struct {
+	Key  string
+	Name string
+}
+
+type Message struct {
+	SenderKey string
+	Timestamp time.Time
+	// Text is the message text, or the caption of the attachment.
+	Text string
+	// Attachment is the path of the attached file within the export.
+	Attachment string
+	// MediaOmitted marks media the export was made without.
+	MediaOmitted bool
+}
+
+type ImportInput struct {
+	// OwnerID is the user importing the chat, who must be one of its
+	// participants.
+	OwnerID uuid.UUID
+	History History
+	// Users maps participant keys to the owner, or to users an earlier import
+	// by the owner created. Participants missing from it get a new imported
+	// user.
+	Users map[string]uuid.UUID
+	// Files holds the attachments the export refers to. It may be nil.
+	Files  fs.FS
+	DryRun bool
+}
+
+// Report describes an import, or for a dry run what an import would do.
+type Report struct {
+	DryRun       bool
+	Participants []ParticipantReport
+	// ChatID is nil on a dry run that would create the chat.
+	ChatID  uuid.UUID
+	NewChat bool
+	// Messages and Attachments count the messages added, attachments
+	// included.
+	Messages    int
+	Attachments int
+	// Duplicates counts messages already in the chat from an earlier import.
+	Duplicates int
+	// Skipped counts service entries left out of the import.
+	Skipped int
+	// MissingAttachments lists attachments not found in the export's files.
+	MissingAttachments []string
+	// OmittedMedia counts media the export was made without.
+	OmittedMedia int
+	First, Last  time.Time
+}
+
+type ParticipantReport struct {
+	Key  string
+	Name string
+	// UserID is nil on a dry run that would create the user.
+	UserID uuid.UUID
+	New    bool
+}
+
+type importService interface {
+	Import(ctx context.Context, in ImportInput) (Report, error)
+}
+
+type userService interface {
+	CreateImportedUser(ctx context.Context, ownerID uuid.UUID, in usersvc.CreateUserInput) (uuid.UUID, error)
+	GetUser(ctx context.Context, id uuid.UUID) (user.User, error)
+	GetAccountState(ctx context.Context, userID uuid.UUID) (user.AccountState, error)
+	CanMessage(ctx context.Context, senderID, recipientID uuid.UUID) error
+}
+
+type chatService interface {
+	CreateChat(ctx context.Context, currentUserID, otherUserID uuid.UUID) (uuid.UUID, error)
+	FindDirectChat(ctx context.Context, userA, userB uuid.UUID) (chat.Chat, error)
+	RecordImport(ctx context.Context, chatID uuid.UUID, lastMessageAt time.Time) error
+}
+
+type messageRepository interface {
+	CreateMessage(ctx context.Context, in repo.CreateMessageInput) error
+	GetMessages(ctx context.Context, chatID uuid.UUID) ([]repo.Message, error)
+}
+
+type service struct {
+	users    userService
+	chats    chatService
+	msgRepo  messageRepository
+	imageURL string
+}
+
+var _ importService = (*service)(nil)
+
+// NewService returns an importer that gives the users it creates imageURL as
+// their profile image.
+func NewService(users userService, chats chatService, msgRepo messageRepository, imageURL string) *service {
+	return &service{users: users, chats: chats, msgRepo: msgRepo, imageURL: imageURL}
+}
+
+// Import adds the exported history to the direct chat between its
+// participants, creating imported users for participants that are not mapped
+// to one and the chat itself when needed. Messages are only ever attributed to
+// the owner and to users their imports created, as nobody else agreed to what
+// the export says they wrote. Messages keep their original timestamps, are
+// marked as imported, and are not added again when already imported into the
+// chat. The owner must be able to send to the chat, as for any message, and
+// texts are held to the same length limit. A dry run changes nothing and
+// reports what an import would do.
+func (s *service) Import(ctx context.Context, in ImportInput) (Report, error) {
+	if len(in.History.Messages) == 0 {
+		return Report{}, ErrEmptyHistory
+	}
+	if len(in.History.Participants) > 2 {
+		return Report{}, ErrGroupChat
+	}
+	now := time.Now()
+	for _, m := range in.History.Messages {
+		if m.Timestamp.After(now) {
+			return Report{}, ErrFutureMessage
+		}
+		if len(m.Text) > msgsvc.MaxTextLength {
+			return Report{}, msgsvc.ErrContentTooLong
+		}
+	}
+	state, err := s.users.GetAccountState(ctx, in.OwnerID)
+	if err != nil {
+		return Report{}, err
+	}
+	switch state.Status {
+	case user.Suspended:
+		return Report{}, usersvc.ErrSuspended
+	case user.Deactivated, user.Deleted:
+		return Report{}, usersvc.ErrAccountClosed
+	}
+
+	report := Report{DryRun: in.DryRun, Skipped: in.History.Skipped}
+	other := -1
+	for i, p := range in.History.Participants {
+		pr := ParticipantReport{Key: p.Key, Name: p.Name, UserID: in.Users[p.Key]}
+		if pr.UserID == uuid.Nil {
+			pr.New = true
+		} else if pr.UserID != in.OwnerID {
+			u, err := s.users.GetUser(ctx, pr.UserID)
+			if err != nil {
+				return Report{}, ErrUnknownParticipantUser
+			}
+			if u.Type != user.Imported || u.OwnerID != in.OwnerID {
+				return Report{}, ErrForeignParticipant
+			}
+		}
+		if pr.UserID != in.OwnerID {
+			if other >= 0 {
+				return Report{}, ErrOwnerNotParticipant
+			}
+			other = i
+		}
+		report.Participants = append(report.Participants, pr)
+	}
+	if other < 0 {
+		return Report{}, ErrNoCounterpart
+	}
+
+	counterpart := &report.Participants[other]
+	var existing []repo.Message
+	if !counterpart.New {
+		// Blocking an imported user stops imports into their chat too.
+		if err := s.users.CanMessage(ctx, in.OwnerID, counterpart.UserID); err != nil {
+			return Report{}, err
+		}
+		c, err := s.chats.FindDirectChat(ctx, in.OwnerID, counterpart.UserID)
+		switch {
+		case err == nil:
+			if c.Encrypted {
+				return Report{}, ErrEncryptedChat
+			}
+			report.ChatID = c.ID
+			if existing, err = s.msgRepo.GetMessages(ctx, c.ID); err != nil {
+				return Report{}, err
+			}
+		case errors.Is(err, chatsvc.ErrChatNotFound):
+			report.NewChat = true
+		default:
+			return Report{}, err
+		}
+	} else {
+		report.NewChat = true
+	}
+
+	msgs, err := s.convert(in, &report, existing)
+	if err != nil {
+		return Report{}, err
+	}
+	if in.DryRun {
+		return report, nil
+	}
+
+	for i := range report.Participants {
+		p := &report.Participants[i]
+		if !p.New {
+			continue
+		}
+		if p.UserID, err = s.users.CreateImportedUser(ctx, in.OwnerID, usersvc.CreateUserInput{
+			ImageURL:  s.imageURL,
+			FirstName: p.Name,
+			Username:  importedUsername(p.Name),
+		}); err != nil {
+			return Report{}, err
+		}
+	}
+	if report.NewChat {
+		if report.ChatID, err = s.chats.CreateChat(ctx, in.OwnerID, counterpart.UserID); err != nil {
+			return Report{}, err
+		}
+	}
+
+	senders := make(map[string]uuid.UUID, len(report.Participants))
+	for _, p := range report.Participants {
+		senders[p.Key] = p.UserID
+	}
+	for _, m := range msgs {
+		m.ID = uuid.New()
+		m.ChatID = report.ChatID
+		m.SenderID = senders[m.senderKey]
+		if err := s.msgRepo.CreateMessage(ctx, m.CreateMessageInput); err != nil {
+			return Report{}, err
+		}
+	}
+	if len(msgs) > 0 {
+		if err := s.chats.RecordImport(ctx, report.ChatID, report.Last); err != nil {
+			return Report{}, err
+		}
+	}
+
+	return report, nil
+}
+
+type pendingMessage struct {
+	repo.CreateMessageInput
+	senderKey string
+}
+
+// convert turns the exported messages into the messages to add, filling in
+// the report's counts. An exported message becomes up to two messages: its
+// attachment followed by its text.
+func (s *service) convert(in ImportInput, report *Report, existing []repo.Message) ([]pendingMessage, error) {
+	senders := make(map[uuid.UUID]string, len(report.Participants))
+	for _, p := range report.Participants {
+		senders[p.UserID] = p.Key
+	}
+	seen := make(map[messageKey]bool, len(existing))
+	for _, m := range existing {
+		if key, ok := senders[m.SenderID]; ok {
+			seen[keyOf(key, m.Timestamp, m.Content)] = true
+		}
+	}
+
+	var msgs []pendingMessage
+	add := func(m Message, content []byte, contentType message.ContentType) {
+		if seen[keyOf(m.SenderKey, m.Timestamp, content)] {
+			report.Duplicates++
+			return
+		}
+		msgs = append(msgs, pendingMessage{
+			CreateMessageInput: repo.CreateMessageInput{
+				Content:     content,
+				ContentType: contentType,
+				Timestamp:   m.Timestamp,
+				Imported:    true,
+			},
+			senderKey: m.SenderKey,
+		})
+		report.Messages++
+		if contentType != message.TextContentType {
+			report.Attachments++
+		}
+		if report.First.IsZero() || m.Timestamp.Before(report.First) {
+			report.First = m.Timestamp
+		}
+		if m.Timestamp.After(report.Last) {
+			report.Last = m.Timestamp
+		}
+	}
+
+	for _, m := range in.History.Messages {
+		switch {
+		case m.MediaOmitted:
+			report.OmittedMedia++
+		case m.Attachment != "":
+			content, err := readAttachment(in.Files, m.Attachment)
+			if errors.Is(err, fs.ErrNotExist) {
+				report.MissingAttachments = append(report.MissingAttachments, m.Attachment)
+			} else if err != nil {
+				return nil, err
+			} else {
+				add(m, content, attachmentType(content))
+			}
+		}
+		if m.Text != "" {
+			add(m, []byte(m.Text), message.TextContentType)
+		}
+	}
+
+	return msgs, nil
+}
+
+type messageKey struct {
+	sender    string
+	timestamp int64
+	digest    [sha256.Size]byte
+}
+
+func keyOf(sender string, ts time.Time, content []byte) messageKey {
+	return messageKey{sender, ts.Unix(), sha256.Sum256(content)}
+}
+
+func readAttachment(files fs.FS, name string) ([]byte, error) {
+	if files == nil || !fs.ValidPath(name) {
+		return nil, fs.ErrNotExist
+	}
+	return fs.ReadFile(files, name)
+}
+
+func attachmentType(content []byte) message.ContentType {
+	if strings.HasPrefix(http.DetectContentType(content), "image/") {
+		return message.ImageContentType
+	}
+	return message.FileContentType
+}
+
+// importedUsername derives a username for a participant without an account:
+// their phone number when the export shows one instead of a name, otherwise
+// their name in lower case.
+func importedUsername(name string) string {
+	var digits strings.Builder
+	phone := true
+	for _, r := range name {
+		switch {
+		case unicode.IsDigit(r):
+			digits.WriteRune(r)
+		case !strings.ContainsRune("+-() ", r):
+			phone = false
+		}
+	}
+	if phone && digits.Len() >= 7 {
+		return "+" + digits.String()
+	}
+
+	return strings.ToLower(strings.Join(strings.Fields(name), "_"))
+}
@@ -0,0 +1,317 @@
+package importsvc_test
+
+import (
+	"context"
+	"errors"
+	"github.com/AliUnipal/chat/internal/models/chat"
+	"github.com/AliUnipal/chat/internal/models/message"
+	"github.com/AliUnipal/chat/internal/models/user"
+	"github.com/AliUnipal/chat/internal/service/importsvc"
+	"github.com/AliUnipal/chat/internal/service/importsvc/mocks"
+	"github.com/AliUnipal/chat/internal/service/msgsvc"
+	"github.com/AliUnipal/chat/internal/service/msgsvc/repo"
+	"github.com/AliUnipal/chat/internal/service/usersvc"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+const imageURL = "https://example.com/imported.png"
+
+var png = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func history() importsvc.History {
+	ts := time.Date(2023, 12, 31, 21, 41, 0, 0, time.UTC)
+	return importsvc.History{
+		Format: importsvc.WhatsApp,
+		Participants: []importsvc.Participant{
+			{Key: "Alice", Name: "Alice"},
+			{Key: "+973 3333 3333", Name: "+973 3333 3333"},
+		},
+		Messages: []importsvc.Message{
+			{SenderKey: "Alice", Timestamp: ts, Text: "Happy new year!"},
+			{SenderKey: "+973 3333 3333", Timestamp: ts.Add(time.Minute), Attachment: "IMG-1.jpg", Text: "Fireworks"},
+			{SenderKey: "+973 3333 3333", Timestamp: ts.Add(2 * time.Minute), Attachment: "IMG-2.jpg"},
+			{SenderKey: "Alice", Timestamp: ts.Add(3 * time.Minute), MediaOmitted: true},
+		},
+		Skipped: 1,
+	}
+}
+
+func TestImport_CreatesUsersChatAndMessages(t *testing.T) {
+	ctx := context.Background()
+	aliceID, newID, chatID := uuid.New(), uuid.New(), uuid.New()
+
+	mockUsers := mocks.NewUserService(t)
+	mockChats := mocks.NewChatService(t)
+	mockMsgs := mocks.NewMessageRepository(t)
+	mockUsers.EXPECT().GetAccountState(mock.Anything, aliceID).Return(user.AccountState{}, nil)
+	mockUsers.EXPECT().CreateImportedUser(mock.Anything, aliceID, usersvc.CreateUserInput{
+		ImageURL:  imageURL,
+		FirstName: "+973 3333 3333",
+		Username:  "+97333333333",
+	}).Return(newID, nil)
+	mockChats.EXPECT().CreateChat(mock.Anything, aliceID, newID).Return(chatID, nil)
+	mockChats.EXPECT().RecordImport(mock.Anything, chatID, history().Messages[1].Timestamp).Return(nil)
+	var created []repo.CreateMessageInput
+	mockMsgs.EXPECT().CreateMessage(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, in repo.CreateMessageInput) error {
+		created = append(created, in)
+		return nil
+	})
+
+	svc := importsvc.NewService(mockUsers, mockChats, mockMsgs, imageURL)
+	report, err := svc.Import(ctx, importsvc.ImportInput{
+		OwnerID: aliceID,
+		History: history(),
+		Users:   map[string]uuid.UUID{"Alice": aliceID},
+		Files:   fstest.MapFS{"IMG-1.jpg": {Data: png}},
+	})
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	if report.ChatID != chatID || !report.NewChat {
+		t.Fatalf("expected a new chat got %+v", report)
+	}
+	if report.Participants[1].UserID != newID || !report.Participants[1].New {
+		t.Fatalf("expected a new user got %+v", report.Participants[1])
+	}
+	if report.Messages != 3 || report.Attachments != 1 || report.OmittedMedia != 1 || report.Skipped != 1 {
+		t.Fatalf("unexpected counts %+v", report)
+	}
+	if len(report.MissingAttachments) != 1 || report.MissingAttachments[0] != "IMG-2.jpg" {
+		t.Fatalf("expected the missing attachment to be reported got %v", report.MissingAttachments)
+	}
+	if len(created) != 3 {
+		t.Fatalf("expected 3 messages got %d", len(created))
+	}
+	for _, m := range created {
+		if !m.Imported {
+			t.Fatalf("expected the message marked as imported got %+v", m)
+		}
+	}
+	if created[0].SenderID != aliceID || created[0].ChatID != chatID || !created[0].Timestamp.Equal(history().Messages[0].Timestamp) {
+		t.Fatalf("expected the original sender and timestamp got %+v", created[0])
+	}
+	if created[1].SenderID != newID || created[1].ContentType != message.ImageContentType || string(created[1].Content) != string(png) {
+		t.Fatalf("expected the attachment got %+v", created[1])
+	}
+	if created[2].ContentType != message.TextContentType || string(created[2].Content) != "Fireworks" {
+		t.Fatalf("expected the caption got %+v", created[2])
+	}
+}
+
+func TestImport_DryRun(t *testing.T) {
+	ctx := context.Background()
+	aliceID := uuid.New()
+
+	mockUsers := mocks.NewUserService(t)
+	mockUsers.EXPECT().GetAccountState(mock.Anything, aliceID).Return(user.AccountState{}, nil)
+
+	svc := importsvc.NewService(mockUsers, mocks.NewChatService(t), mocks.NewMessageRepository(t), imageURL)
+	report, err := svc.Import(ctx, importsvc.ImportInput{
+		OwnerID: aliceID,
+		History: history(),
+		Users:   map[string]uuid.UUID{"Alice": aliceID},
+		DryRun:  true,
+	})
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	if !report.DryRun || !report.NewChat || report.ChatID != uuid.Nil {
+		t.Fatalf("expected a dry run creating the chat got %+v", report)
+	}
+	if !report.Participants[1].New || report.Participants[1].UserID != uuid.Nil {
+		t.Fatalf("expected the user to be created got %+v", report.Participants[1])
+	}
+	if report.Messages != 2 || len(report.MissingAttachments) != 2 {
+		t.Fatalf("unexpected counts %+v", report)
+	}
+}
+
+func TestImport_SkipsDuplicates(t *testing.T) {
+	ctx := context.Background()
+	aliceID, bobID, chatID := uuid.New(), uuid.New(), uuid.New()
+	h := history()
+
+	mockUsers := mocks.NewUserService(t)
+	mockChats := mocks.NewChatService(t)
+	mockMsgs := mocks.NewMessageRepository(t)
+	mockUsers.EXPECT().GetAccountState(mock.Anything, aliceID).Return(user.AccountState{}, nil)
+	mockUsers.EXPECT().GetUser(mock.Anything, bobID).Return(user.User{ID: bobID, Type: user.Imported, OwnerID: aliceID}, nil)
+	mockUsers.EXPECT().CanMessage(mock.Anything, aliceID, bobID).Return(nil)
+	mockChats.EXPECT().FindDirectChat(mock.Anything, aliceID, bobID).Return(chat.Chat{ID: chatID}, nil)
+	mockMsgs.EXPECT().GetMessages(mock.Anything, chatID).Return([]repo.Message{
+		{SenderID: aliceID, ChatID: chatID, Content: []byte("Happy new year!"), Timestamp: h.Messages[0].Timestamp},
+	}, nil)
+	mockMsgs.EXPECT().CreateMessage(mock.Anything, mock.Anything).Return(nil).Once()
+	mockChats.EXPECT().RecordImport(mock.Anything, chatID, h.Messages[1].Timestamp).Return(nil)
+
+	svc := importsvc.NewService(mockUsers, mockChats, mockMsgs, imageURL)
+	report, err := svc.Import(ctx, importsvc.ImportInput{
+		OwnerID: aliceID,
+		History: h,
+		Users:   map[string]uuid.UUID{"Alice": aliceID, "+973 3333 3333": bobID},
+	})
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	if report.NewChat || report.ChatID != chatID {
+		t.Fatalf("expected the existing chat got %+v", report)
+	}
+	if report.Duplicates != 1 || report.Messages != 1 {
+		t.Fatalf("expected the imported message to be skipped got %+v", report)
+	}
+}
+
+func TestImport_Rejects(t *testing.T) {
+	aliceID, bobID, chatID := uuid.New(), uuid.New(), uuid.New()
+	bob := user.User{ID: bobID, Type: user.Imported, OwnerID: aliceID}
+
+	tests := []struct {
+		name    string
+		history func() importsvc.History
+		users   map[string]uuid.UUID
+		setup   func(users *mocks.UserService, chats *mocks.ChatService)
+		wantErr error
+	}{
+		{
+			name:    "empty",
+			history: func() importsvc.History { return importsvc.History{} },
+			wantErr: importsvc.ErrEmptyHistory,
+		},
+		{
+			name: "group chat",
+			history: func() importsvc.History {
+				h := history()
+				h.Participants = append(h.Participants, importsvc.Participant{Key: "Carol", Name: "Carol"})
+				return h
+			},
+			wantErr: importsvc.ErrGroupChat,
+		},
+		{
+			name: "message from the future",
+			history: func() importsvc.History {
+				h := history()
+				h.Messages[1].Timestamp = time.Now().Add(time.Hour)
+				return h
+			},
+			wantErr: importsvc.ErrFutureMessage,
+		},
+		{
+			name: "message too long",
+			history: func() importsvc.History {
+				h := history()
+				h.Messages[1].Text = strings.Repeat("a", msgsvc.MaxTextLength+1)
+				return h
+			},
+			wantErr: msgsvc.ErrContentTooLong,
+		},
+		{
+			name:    "owner suspended",
+			history: history,
+			setup: func(users *mocks.UserService, _ *mocks.ChatService) {
+				users.EXPECT().GetAccountState(mock.Anything, aliceID).Return(user.AccountState{Status: user.Suspended}, nil)
+			},
+			wantErr: usersvc.ErrSuspended,
+		},
+		{
+			name:    "owner not a participant",
+			history: history,
+			setup: func(users *mocks.UserService, _ *mocks.ChatService) {
+				users.EXPECT().GetAccountState(mock.Anything, aliceID).Return(user.AccountState{}, nil)
+			},
+			wantErr: importsvc.ErrOwnerNotParticipant,
+		},
+		{
+			name: "only the owner wrote",
+			history: func() importsvc.History {
+				h := history()
+				h.Participants = h.Participants[:1]
+				return h
+			},
+			users: map[string]uuid.UUID{"Alice": aliceID},
+			setup: func(users *mocks.UserService, _ *mocks.ChatService) {
+				users.EXPECT().GetAccountState(mock.Anything, aliceID).Return(user.AccountState{}, nil)
+			},
+			wantErr: importsvc.ErrNoCounterpart,
+		},
+		{
+			name:    "mapped user does not exist",
+			history: history,
+			users:   map[string]uuid.UUID{"Alice": aliceID, "+973 3333 3333": bobID},
+			setup: func(users *mocks.UserService, _ *mocks.ChatService) {
+				users.EXPECT().GetAccountState(mock.Anything, aliceID).Return(user.AccountState{}, nil)
+				users.EXPECT().GetUser(mock.Anything, bobID).Return(user.User{}, errors.New("user does not exist"))
+			},
+			wantErr: importsvc.ErrUnknownParticipantUser,
+		},
+		{
+			name:    "mapped to someone else",
+			history: history,
+			users:   map[string]uuid.UUID{"Alice": aliceID, "+973 3333 3333": bobID},
+			setup: func(users *mocks.UserService, _ *mocks.ChatService) {
+				users.EXPECT().GetAccountState(mock.Anything, aliceID).Return(user.AccountState{}, nil)
+				users.EXPECT().GetUser(mock.Anything, bobID).Return(user.User{ID: bobID}, nil)
+			},
+			wantErr: importsvc.ErrForeignParticipant,
+		},
+		{
+			name:    "mapped to someone else's import",
+			history: history,
+			users:   map[string]uuid.UUID{"Alice": aliceID, "+973 3333 3333": bobID},
+			setup: func(users *mocks.UserService, _ *mocks.ChatService) {
+				users.EXPECT().GetAccountState(mock.Anything, aliceID).Return(user.AccountState{}, nil)
+				users.EXPECT().GetUser(mock.Anything, bobID).Return(user.User{ID: bobID, Type: user.Imported, OwnerID: uuid.New()}, nil)
+			},
+			wantErr: importsvc.ErrForeignParticipant,
+		},
+		{
+			name:    "encrypted chat",
+			history: history,
+			users:   map[string]uuid.UUID{"Alice": aliceID, "+973 3333 3333": bobID},
+			setup: func(users *mocks.UserService, chats *mocks.ChatService) {
+				users.EXPECT().GetAccountState(mock.Anything, aliceID).Return(user.AccountState{}, nil)
+				users.EXPECT().GetUser(mock.Anything, bobID).Return(bob, nil)
+				users.EXPECT().CanMessage(mock.Anything, aliceID, bobID).Return(nil)
+				chats.EXPECT().FindDirectChat(mock.Anything, aliceID, bobID).Return(chat.Chat{ID: chatID, Encrypted: true}, nil)
+			},
+			wantErr: importsvc.ErrEncryptedChat,
+		},
+		{
+			name:    "blocked",
+			history: history,
+			users:   map[string]uuid.UUID{"Alice": aliceID, "+973 3333 3333": bobID},
+			setup: func(users *mocks.UserService, _ *mocks.ChatService) {
+				users.EXPECT().GetAccountState(mock.Anything, aliceID).Return(user.AccountState{}, nil)
+				users.EXPECT().GetUser(mock.Anything, bobID).Return(bob, nil)
+				users.EXPECT().CanMessage(mock.Anything, aliceID, bobID).Return(usersvc.ErrBlocked)
+			},
+			wantErr: usersvc.ErrBlocked,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsers := mocks.NewUserService(t)
+			mockChats := mocks.NewChatService(t)
+			if tt.setup != nil {
+				tt.setup(mockUsers, mockChats)
+			}
+
+			svc := importsvc.NewService(mockUsers, mockChats, mocks.NewMessageRepository(t), imageURL)
+			_, err := svc.Import(context.Background(), importsvc.ImportInput{
+				OwnerID: aliceID,
+				History: tt.history(),
+				Users:   tt.users,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package importsvc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// telegramFileOmitted prefixes the file path of media left out of the export.
+const telegramFileOmitted = "(File not included."
+
+type telegramExport struct {
+	Name     string            `json:"name"`
+	Type     string            `json:"type"`
+	Messages []telegramMessage `json:"messages"`
+	// Chats is only present in whole-account exports.
+	Chats json.RawMessage `json:"chats"`
+}
+
+type telegramMessage struct {
+	ID           int64        `json:"id"`
+	Type         string       `json:"type"`
+	Date         string       `json:"date"`
+	DateUnixtime string       `json:"date_unixtime"`
+	From         string       `json:"from"`
+	FromID       string       `json:"from_id"`
+	Text         telegramText `json:"text"`
+	Photo        string       `json:"photo"`
+	File         string       `json:"file"`
+}
+
+// telegramText is either a plain string or a list of plain strings and
+// formatted entities such as {"type": "bold", "text": "hi"}.
+type telegramText string
+
+func (t *telegramText) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*t = telegramText(s)
+		return nil
+	}
+
+	var parts []json.RawMessage
+	if err := json.Unmarshal(b, &parts); err != nil {
+		return errors.New("text is neither a string nor a list")
+	}
+	var sb strings.Builder
+	for _, p := range parts {
+		var entity struct {
+			Text string `json:"text"`
+		}
+		if err := json.Unmarshal(p, &s); err == nil {
+			sb.WriteString(s)
+		} else if err := json.Unmarshal(p, &entity); err == nil {
+			sb.WriteString(entity.Text)
+		} else {
+			return err
+		}
+	}
+	*t = telegramText(sb.String())
+	return nil
+}
+
+// ParseTelegram reads the result.json of a Telegram Desktop export of a single
+// chat. Messages without a Unix timestamp have their local date read in loc,
+// or UTC when loc is nil.
+func ParseTelegram(r io.Reader, loc *time.Location) (History, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	var export telegramExport
+	if err := json.NewDecoder(r).Decode(&export); err != nil {
+		return History{}, fmt.Errorf("not a Telegram chat export: %w", err)
+	}
+	if export.Chats != nil {
+		return History{}, errors.New("whole-account Telegram exports are not supported, export a single chat")
+	}
+
+	h := History{Format: Telegram}
+	seen := make(map[string]bool)
+	for _, m := range export.Messages {
+		if m.Type != "message" {
+			h.Skipped++
+			continue
+		}
+		ts, err := telegramTime(m, loc)
+		if err != nil {
+			return History{}, fmt.Errorf("message %d: %w", m.ID, err)
+		}
+
+		key := m.FromID
+		if key == "" {
+			key = m.From
+		}
+		if !seen[key] {
+			seen[key] = true
+			h.Participants = append(h.Participants, Participant{Key: key, Name: m.From})
+		}
+
+		msg := Message{SenderKey: key, Timestamp: ts, Text: string(m.Text)}
+		file := m.Photo
+		if file == "" {
+			file = m.File
+		}
+		if strings.HasPrefix(file, telegramFileOmitted) {
+			msg.MediaOmitted = true
+		} else {
+			msg.Attachment = file
+		}
+		h.Messages = append(h.Messages, msg)
+	}
+
+	return h, nil
+}
+
+func telegramTime(m telegramMessage, loc *time.Location) (time.Time, error) {
+	if m.DateUnixtime != "" {
+		sec, err := strconv.ParseInt(m.DateUnixtime, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid date_unixtime %q", m.DateUnixtime)
+		}
+		return time.Unix(sec, 0).UTC(), nil
+	}
+
+	t, err := time.ParseInLocation("2006-01-02T15:04:05", m.Date, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", m.Date)
+	}
+	return t.UTC(), nil
+}
@@ -0,0 +1,73 @@
+package importsvc_test
+
+import (
+	"github.com/AliUnipal/chat/internal/service/importsvc"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseTelegram(t *testing.T) {
+	export := `{
+  "name": "Bob",
+  "type": "personal_chat",
+  "id": 42,
+  "messages": [
+    {"id": 1, "type": "service", "date": "2023-12-31T21:40:00", "actor": "Alice", "action": "phone_call", "text": ""},
+    {"id": 2, "type": "message", "date": "2023-12-31T21:41:05", "date_unixtime": "1704058865", "from": "Alice", "from_id": "user1", "text": "Happy new year!"},
+    {"id": 3, "type": "message", "date": "2023-12-31T21:42:00", "from": "Bob", "from_id": "user2", "text": ["See ", {"type": "bold", "text": "you"}, " soon"]},
+    {"id": 4, "type": "message", "date": "2023-12-31T21:43:00", "date_unixtime": "1704058980", "from": "Bob", "from_id": "user2", "photo": "photos/photo_1.jpg", "text": "Fireworks"},
+    {"id": 5, "type": "message", "date": "2023-12-31T21:44:00", "date_unixtime": "1704059040", "from": "Bob", "from_id": "user2", "file": "(File not included. Change data exporting settings to download.)", "text": ""}
+  ]
+}`
+	h, err := importsvc.ParseTelegram(strings.NewReader(export), nil)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	if h.Format != importsvc.Telegram {
+		t.Fatalf("expected Telegram format got %v", h.Format)
+	}
+	if h.Skipped != 1 {
+		t.Fatalf("expected the service message to be skipped got %d", h.Skipped)
+	}
+	if len(h.Participants) != 2 || h.Participants[0] != (importsvc.Participant{Key: "user1", Name: "Alice"}) {
+		t.Fatalf("expected participants keyed by sender ID got %+v", h.Participants)
+	}
+	if len(h.Messages) != 4 {
+		t.Fatalf("expected 4 messages got %d", len(h.Messages))
+	}
+	if !h.Messages[0].Timestamp.Equal(time.Unix(1704058865, 0)) {
+		t.Fatalf("expected the Unix timestamp got %v", h.Messages[0].Timestamp)
+	}
+	if h.Messages[1].Text != "See you soon" {
+		t.Fatalf("expected formatted text to be flattened got %q", h.Messages[1].Text)
+	}
+	if !h.Messages[1].Timestamp.Equal(time.Date(2023, 12, 31, 21, 42, 0, 0, time.UTC)) {
+		t.Fatalf("expected the local date got %v", h.Messages[1].Timestamp)
+	}
+	if h.Messages[2].Attachment != "photos/photo_1.jpg" || h.Messages[2].Text != "Fireworks" {
+		t.Fatalf("expected a photo with a caption got %+v", h.Messages[2])
+	}
+	if !h.Messages[3].MediaOmitted {
+		t.Fatalf("expected omitted media got %+v", h.Messages[3])
+	}
+}
+
+func TestParseTelegram_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		export string
+	}{
+		{name: "not json", export: "hello"},
+		{name: "whole account", export: `{"chats": {"list": []}}`},
+		{name: "invalid date", export: `{"messages": [{"id": 1, "type": "message", "date": "yesterday", "from": "Alice", "text": "Hi"}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := importsvc.ParseTelegram(strings.NewReader(tt.export), nil); err == nil {
+				t.Fatalf("expected an error got nil")
+			}
+		})
+	}
+}
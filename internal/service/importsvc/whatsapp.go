@@ -0,0 +1,174 @@
+package importsvc
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// whatsAppLine matches the first line of a message in both the Android
+// ("31/12/2023, 21:41 - Alice: Hi") and iOS ("[31/12/2023, 9:41:05 PM]
+// Alice: Hi") export formats.
+var whatsAppLine = regexp.MustCompile(`^\[?(\d{1,2})[/.-](\d{1,2})[/.-](\d{2,4}),? (\d{1,2}):(\d{2})(?::(\d{2}))? ?([AaPp]\.? ?[Mm]\.?)?\]?(?: -)? (.*)$`)
+
+var (
+	iosAttachment     = regexp.MustCompile(`^<attached: (.+)>$`)
+	androidAttachment = regexp.MustCompile(`^(.+) \(file attached\)$`)
+)
+
+const whatsAppMediaOmitted = "<Media omitted>"
+
+type whatsAppEntry struct {
+	date        [3]int
+	hour, min   int
+	sec         int
+	meridiem    string
+	sender      string
+	lines       []string
+	isSystem    bool
+	lineNumber  int
+	rawDateText string
+}
+
+// ParseWhatsApp reads a WhatsApp ".txt" chat export. Timestamps are read in
+// loc, or UTC when loc is nil. Whether dates are written day or month first
+// is worked out from the dates themselves.
+func ParseWhatsApp(r io.Reader, loc *time.Location) (History, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	var entries []*whatsAppEntry
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for n := 1; sc.Scan(); n++ {
+		line := cleanWhatsAppLine(sc.Text())
+		m := whatsAppLine.FindStringSubmatch(line)
+		if m == nil {
+			if len(entries) == 0 {
+				if strings.TrimSpace(line) == "" {
+					continue
+				}
+				return History{}, fmt.Errorf("line %d: not a WhatsApp chat export", n)
+			}
+			last := entries[len(entries)-1]
+			last.lines = append(last.lines, line)
+			continue
+		}
+
+		e := &whatsAppEntry{lineNumber: n, rawDateText: m[1] + "/" + m[2] + "/" + m[3], meridiem: m[7]}
+		e.date[0], _ = strconv.Atoi(m[1])
+		e.date[1], _ = strconv.Atoi(m[2])
+		e.date[2], _ = strconv.Atoi(m[3])
+		e.hour, _ = strconv.Atoi(m[4])
+		e.min, _ = strconv.Atoi(m[5])
+		e.sec, _ = strconv.Atoi(m[6])
+		if sender, text, ok := strings.Cut(m[8], ": "); ok {
+			e.sender = sender
+			e.lines = []string{text}
+		} else {
+			e.isSystem = true
+		}
+		entries = append(entries, e)
+	}
+	if err := sc.Err(); err != nil {
+		return History{}, err
+	}
+
+	dayFirst := whatsAppDayFirst(entries)
+	h := History{Format: WhatsApp}
+	seen := make(map[string]bool)
+	for _, e := range entries {
+		if e.isSystem {
+			h.Skipped++
+			continue
+		}
+		ts, err := e.time(dayFirst, loc)
+		if err != nil {
+			return History{}, fmt.Errorf("line %d: %w", e.lineNumber, err)
+		}
+		if !seen[e.sender] {
+			seen[e.sender] = true
+			h.Participants = append(h.Participants, Participant{Key: e.sender, Name: e.sender})
+		}
+
+		msg := Message{SenderKey: e.sender, Timestamp: ts}
+		first := e.lines[0]
+		caption := strings.Join(e.lines[1:], "\n")
+		switch {
+		case first == whatsAppMediaOmitted:
+			msg.MediaOmitted = true
+		case iosAttachment.MatchString(first):
+			msg.Attachment = iosAttachment.FindStringSubmatch(first)[1]
+		case androidAttachment.MatchString(first):
+			msg.Attachment = androidAttachment.FindStringSubmatch(first)[1]
+		default:
+			caption = strings.Join(e.lines, "\n")
+		}
+		msg.Text = caption
+		h.Messages = append(h.Messages, msg)
+	}
+
+	return h, nil
+}
+
+// cleanWhatsAppLine drops the direction marks iOS puts in front of lines and
+// the narrow spaces it puts before AM and PM.
+func cleanWhatsAppLine(line string) string {
+	line = strings.NewReplacer("\u200e", "", "\u200f", "", "\u202f", " ", "\u00a0", " ").Replace(line)
+	return strings.TrimPrefix(line, "\ufeff")
+}
+
+// whatsAppDayFirst reports whether dates are written day first. A component
+// over 12 settles it; otherwise 12-hour clocks suggest the US month-first
+// order.
+func whatsAppDayFirst(entries []*whatsAppEntry) bool {
+	twelveHour := false
+	for _, e := range entries {
+		if e.date[0] > 12 {
+			return true
+		}
+		if e.date[1] > 12 {
+			return false
+		}
+		if e.meridiem != "" {
+			twelveHour = true
+		}
+	}
+
+	return !twelveHour
+}
+
+func (e *whatsAppEntry) time(dayFirst bool, loc *time.Location) (time.Time, error) {
+	day, month, year := e.date[0], e.date[1], e.date[2]
+	if !dayFirst {
+		day, month = month, day
+	}
+	if year < 100 {
+		year += 2000
+	}
+	hour := e.hour
+	if e.meridiem != "" {
+		if hour < 1 || hour > 12 {
+			return time.Time{}, fmt.Errorf("invalid hour %d", hour)
+		}
+		pm := strings.ContainsAny(e.meridiem, "Pp")
+		hour %= 12
+		if pm {
+			hour += 12
+		}
+	}
+	if hour > 23 || e.min > 59 || e.sec > 59 {
+		return time.Time{}, fmt.Errorf("invalid time %02d:%02d:%02d", hour, e.min, e.sec)
+	}
+
+	t := time.Date(year, time.Month(month), day, hour, e.min, e.sec, 0, loc)
+	if t.Day() != day || int(t.Month()) != month {
+		return time.Time{}, fmt.Errorf("invalid date %s", e.rawDateText)
+	}
+	return t.UTC(), nil
+}
@@ -0,0 +1,90 @@
+package importsvc_test
+
+import (
+	"github.com/AliUnipal/chat/internal/service/importsvc"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseWhatsApp_Android(t *testing.T) {
+	export := `31/12/2023, 21:40 - Messages and calls are end-to-end encrypted. No one outside of this chat can read them.
+31/12/2023, 21:41 - Alice: Happy new year!
+See you tomorrow
+31/12/2023, 21:42 - Bob: IMG-20231231-WA0001.jpg (file attached)
+Fireworks
+01/01/2024, 00:05 - Bob: <Media omitted>
+`
+	h, err := importsvc.ParseWhatsApp(strings.NewReader(export), nil)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	if h.Format != importsvc.WhatsApp {
+		t.Fatalf("expected WhatsApp format got %v", h.Format)
+	}
+	if h.Skipped != 1 {
+		t.Fatalf("expected the system message to be skipped got %d", h.Skipped)
+	}
+	if len(h.Participants) != 2 || h.Participants[0].Key != "Alice" || h.Participants[1].Key != "Bob" {
+		t.Fatalf("expected Alice and Bob got %+v", h.Participants)
+	}
+	if len(h.Messages) != 3 {
+		t.Fatalf("expected 3 messages got %d", len(h.Messages))
+	}
+	first := h.Messages[0]
+	if first.Text != "Happy new year!\nSee you tomorrow" {
+		t.Fatalf("expected a multi-line message got %q", first.Text)
+	}
+	if !first.Timestamp.Equal(time.Date(2023, 12, 31, 21, 41, 0, 0, time.UTC)) {
+		t.Fatalf("expected the original timestamp got %v", first.Timestamp)
+	}
+	if h.Messages[1].Attachment != "IMG-20231231-WA0001.jpg" || h.Messages[1].Text != "Fireworks" {
+		t.Fatalf("expected an attachment with a caption got %+v", h.Messages[1])
+	}
+	if !h.Messages[2].MediaOmitted {
+		t.Fatalf("expected omitted media got %+v", h.Messages[2])
+	}
+}
+
+func TestParseWhatsApp_IOS(t *testing.T) {
+	export := "\u200e[12/31/23, 9:41:05\u202fPM] Alice: Hi\n" +
+		"\u200e[1/1/24, 12:00:30\u202fAM] Bob: \u200e<attached: 00000012-PHOTO-2024-01-01-00-00-30.jpg>\n"
+	loc := time.FixedZone("AST", 3*60*60)
+
+	h, err := importsvc.ParseWhatsApp(strings.NewReader(export), loc)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	if len(h.Messages) != 2 {
+		t.Fatalf("expected 2 messages got %d", len(h.Messages))
+	}
+	if !h.Messages[0].Timestamp.Equal(time.Date(2023, 12, 31, 21, 41, 5, 0, loc)) {
+		t.Fatalf("expected a month-first 12-hour timestamp got %v", h.Messages[0].Timestamp)
+	}
+	if !h.Messages[1].Timestamp.Equal(time.Date(2024, 1, 1, 0, 0, 30, 0, loc)) {
+		t.Fatalf("expected midnight got %v", h.Messages[1].Timestamp)
+	}
+	if h.Messages[1].Attachment != "00000012-PHOTO-2024-01-01-00-00-30.jpg" || h.Messages[1].Text != "" {
+		t.Fatalf("expected an attachment got %+v", h.Messages[1])
+	}
+}
+
+func TestParseWhatsApp_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		export string
+	}{
+		{name: "not an export", export: "hello there\n"},
+		{name: "invalid date", export: "31/02/2023, 10:00 - Alice: Hi\n"},
+		{name: "invalid time", export: "01/02/2023, 25:00 - Alice: Hi\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := importsvc.ParseWhatsApp(strings.NewReader(tt.export), nil); err == nil {
+				t.Fatalf("expected an error got nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package msgsvc
+
+import (
+	"context"
+	"github.com/AliUnipal/chat/internal/commands"
+	"github.com/AliUnipal/chat/internal/events"
+	"github.com/AliUnipal/chat/internal/models/message"
+	"github.com/google/uuid"
+)
+
+// GetCommands returns the slash commands the user can run in the chat.
+func (s *service) GetCommands(ctx context.Context, chatID, userID uuid.UUID) ([]commands.Command, error) {
+	if _, err := s.chatRepo.GetMember(ctx, chatID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.commands.Commands(ctx, chatID)
+}
+
+// EnableCommand lets the members of the chat run the command again.
+func (s *service) EnableCommand(ctx context.Context, chatID, userID uuid.UUID, name string) error {
+	if _, err := s.chatRepo.GetMember(ctx, chatID, userID); err != nil {
+		return err
+	}
+
+	return s.commands.Enable(ctx, chatID, name)
+}
+
+// DisableCommand keeps the members of the chat from running the command.
+func (s *service) DisableCommand(ctx context.Context, chatID, userID uuid.UUID, name string) error {
+	if _, err := s.chatRepo.GetMember(ctx, chatID, userID); err != nil {
+		return err
+	}
+
+	return s.commands.Disable(ctx, chatID, name)
+}
+
+// reply shows a command's reply to the user who ran it, without storing it.
+func (s *service) reply(ctx context.Context, chatID, userID uuid.UUID, text string) {
+	now := s.clock().UTC()
+	s.events.Publish(ctx, events.Event{
+		Type:       events.EphemeralMessage,
+		ChatID:     chatID,
+		UserID:     userID,
+		Recipients: []uuid.UUID{userID},
+		Message: &message.Message{
+			ID:          uuid.New(),
+			ChatID:      chatID,
+			Content:     []byte(text),
+			ContentType: message.TextContentType,
+			Timestamp:   now,
+		},
+		Timestamp: now,
+	})
+}
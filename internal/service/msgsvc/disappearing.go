@@ -0,0 +1,79 @@
+package msgsvc
+
+import (
+	"context"
+	"errors"
+	"github.com/AliUnipal/chat/internal/events"
+	"github.com/AliUnipal/chat/internal/models/message"
+	chatrepo "github.com/AliUnipal/chat/internal/service/chatsvc/repo"
+	"github.com/AliUnipal/chat/internal/service/msgsvc/repo"
+	"github.com/google/uuid"
+	"time"
+)
+
+// DeleteExpiredMessages deletes the messages of chats with disappearing
+// messages whose time is up, and publishes a MessageDeleted event for each
+// one that was delivered. Attachments are stored as the content of their
+// message, so they go with it, and the content moderation kept is redacted.
+func (s *service) DeleteExpiredMessages(ctx context.Context) error {
+	now := s.clock().UTC()
+	expired, err := s.repo.DeleteExpiredMessages(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	chats := make(map[uuid.UUID]chatrepo.Chat)
+	var errs []error
+	for _, m := range expired {
+		if err := s.moderator.Redact(ctx, m.ID); err != nil {
+			errs = append(errs, err)
+		}
+		if m.Quarantined {
+			continue
+		}
+		c, ok := chats[m.ChatID]
+		if !ok {
+			c, err = s.chatRepo.GetChat(ctx, m.ChatID)
+			if errors.Is(err, chatrepo.ErrChatNotFound) {
+				// Deleted along with the chat; nobody is left to tell.
+				continue
+			}
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			chats[m.ChatID] = c
+		}
+
+		s.events.Publish(ctx, events.Event{
+			Type:       events.MessageDeleted,
+			ChatID:     m.ChatID,
+			UserID:     m.SenderID,
+			Recipients: participantIDs(c),
+			Message:    &message.Message{ID: m.ID, SenderID: m.SenderID, ChatID: m.ChatID, ExpiresAt: m.ExpiresAt},
+			Timestamp:  now,
+		})
+	}
+
+	return errors.Join(errs...)
+}
+
+// RunReaper deletes expired messages every interval until ctx is done.
+func (s *service) RunReaper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = s.DeleteExpiredMessages(ctx)
+		}
+	}
+}
+
+// expired reports whether a disappearing message's time is up, even if it
+// has not been deleted yet.
+func expired(m repo.Message, now time.Time) bool {
+	return !m.ExpiresAt.IsZero() && !m.ExpiresAt.After(now)
+}
@@ -0,0 +1,116 @@
+package msgsvc_test
+
+import (
+	"context"
+	"github.com/AliUnipal/chat/internal/commands"
+	"github.com/AliUnipal/chat/internal/events"
+	"github.com/AliUnipal/chat/internal/models/message"
+	"github.com/AliUnipal/chat/internal/moderation"
+	chatrepo "github.com/AliUnipal/chat/internal/service/chatsvc/repo"
+	"github.com/AliUnipal/chat/internal/service/msgsvc"
+	"github.com/AliUnipal/chat/internal/service/msgsvc/mocks"
+	"github.com/AliUnipal/chat/internal/service/msgsvc/repo"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestCreateMessage_SetExpiryInDisappearingChat(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	recipientID := uuid.New()
+	input := msgsvc.MessageInput{SenderID: uuid.New(), ChatID: uuid.New(), Content: []byte("gone tomorrow"), ContentType: message.TextContentType}
+
+	mockRepo := mocks.NewMessageRepository(t)
+	mockChatRepo := mocks.NewChatRepository(t)
+	mockUserService := mocks.NewUserService(t)
+	mockLimiter := mocks.NewRateLimiter(t)
+	mockModerator := mocks.NewModerator(t)
+	mockChatRepo.EXPECT().GetMember(mock.Anything, input.ChatID, input.SenderID).Return(chatrepo.Member{ChatID: input.ChatID, UserID: input.SenderID}, nil)
+	mockChatRepo.EXPECT().GetChat(mock.Anything, input.ChatID).Return(chatrepo.Chat{ID: input.ChatID, Participants: []chatrepo.User{{ID: input.SenderID}, {ID: recipientID}}, DisappearAfter: 24 * time.Hour}, nil)
+	mockChatRepo.EXPECT().GetMembers(mock.Anything, input.ChatID).Return(nil, nil)
+	mockUserService.EXPECT().CanMessage(mock.Anything, input.SenderID, recipientID).Return(nil)
+	mockLimiter.EXPECT().Allow(mock.Anything, input.SenderID, input.ChatID).Return(nil)
+	mockModerator.EXPECT().Moderate(mock.Anything, mock.Anything).Return(moderation.Decision{}, nil)
+	mockRepo.EXPECT().CreateMessage(mock.Anything, mock.MatchedBy(func(r repo.CreateMessageInput) bool {
+		return r.ExpiresAt.Equal(now.Add(24 * time.Hour))
+	})).Return(nil)
+
+	bus := events.NewBus()
+	var published []events.Event
+	bus.Subscribe(func(_ context.Context, e events.Event) { published = append(published, e) })
+	service := msgsvc.NewService(mockRepo, mockChatRepo, mockUserService, mockLimiter, mockModerator, bus, commands.NewRegistry(commands.NewMemoryStore()), mocks.NewScheduledRepository(t), func() time.Time { return now })
+	if _, err := service.CreateMessage(ctx, input); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(published) != 1 || !published[0].Message.ExpiresAt.Equal(now.Add(24*time.Hour)) {
+		t.Fatalf("expected the event to carry the expiry got %+v", published)
+	}
+}
+
+func TestDeleteExpiredMessages(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	senderID := uuid.New()
+	recipientID := uuid.New()
+	c := chatrepo.Chat{ID: uuid.New(), Participants: []chatrepo.User{{ID: senderID}, {ID: recipientID}}}
+	deletedChatID := uuid.New()
+	expired := []repo.Message{
+		{ID: uuid.New(), SenderID: senderID, ChatID: c.ID, ExpiresAt: now},
+		{ID: uuid.New(), SenderID: recipientID, ChatID: c.ID, ExpiresAt: now.Add(-time.Hour)},
+		{ID: uuid.New(), SenderID: senderID, ChatID: c.ID, ExpiresAt: now, Quarantined: true},
+		{ID: uuid.New(), SenderID: senderID, ChatID: deletedChatID, ExpiresAt: now},
+	}
+
+	mockRepo := mocks.NewMessageRepository(t)
+	mockChatRepo := mocks.NewChatRepository(t)
+	mockRepo.EXPECT().DeleteExpiredMessages(mock.Anything, now).Return(expired, nil)
+	mockChatRepo.EXPECT().GetChat(mock.Anything, c.ID).Return(c, nil).Once()
+	mockChatRepo.EXPECT().GetChat(mock.Anything, deletedChatID).Return(chatrepo.Chat{}, chatrepo.ErrChatNotFound)
+	mockModerator := mocks.NewModerator(t)
+	for _, m := range expired {
+		mockModerator.EXPECT().Redact(mock.Anything, m.ID).Return(nil).Once()
+	}
+
+	bus := events.NewBus()
+	var published []events.Event
+	bus.Subscribe(func(_ context.Context, e events.Event) { published = append(published, e) })
+	service := msgsvc.NewService(mockRepo, mockChatRepo, mocks.NewUserService(t), mocks.NewRateLimiter(t), mockModerator, bus, commands.NewRegistry(commands.NewMemoryStore()), mocks.NewScheduledRepository(t), func() time.Time { return now })
+	if err := service.DeleteExpiredMessages(ctx); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	if len(published) != 2 {
+		t.Fatalf("expected an event for each delivered message got %+v", published)
+	}
+	for i, e := range published {
+		if e.Type != events.MessageDeleted || e.Message.ID != expired[i].ID || !e.Message.ExpiresAt.Equal(expired[i].ExpiresAt) || len(e.Recipients) != 2 {
+			t.Fatalf("expected the deletion of %v announced to both participants got %+v", expired[i].ID, e)
+		}
+	}
+}
+
+func TestGetMessages_HideExpiredMessages(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	chatID := uuid.New()
+	userID := uuid.New()
+	kept := repo.Message{ID: uuid.New(), SenderID: userID, ChatID: chatID, Content: []byte("kept")}
+	pending := repo.Message{ID: uuid.New(), SenderID: userID, ChatID: chatID, Content: []byte("pending"), ExpiresAt: now.Add(time.Minute)}
+	expired := repo.Message{ID: uuid.New(), SenderID: userID, ChatID: chatID, Content: []byte("expired"), ExpiresAt: now}
+
+	mockRepo := mocks.NewMessageRepository(t)
+	mockChatRepo := mocks.NewChatRepository(t)
+	mockChatRepo.EXPECT().GetMember(mock.Anything, chatID, userID).Return(chatrepo.Member{ChatID: chatID, UserID: userID}, nil)
+	mockRepo.EXPECT().GetMessages(mock.Anything, chatID).Return([]repo.Message{kept, pending, expired}, nil)
+
+	service := msgsvc.NewService(mockRepo, mockChatRepo, mocks.NewUserService(t), mocks.NewRateLimiter(t), mocks.NewModerator(t), events.NewBus(), commands.NewRegistry(commands.NewMemoryStore()), mocks.NewScheduledRepository(t), func() time.Time { return now })
+	ms, err := service.GetMessages(ctx, chatID, userID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(ms) != 2 || ms[0].ID != kept.ID || ms[1].ID != pending.ID {
+		t.Fatalf("expected the expired message hidden got %+v", ms)
+	}
+}
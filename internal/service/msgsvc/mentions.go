@@ -0,0 +1,138 @@
+package msgsvc
+
+import (
+	"context"
+	"github.com/AliUnipal/chat/internal/models/message"
+	chatrepo "github.com/AliUnipal/chat/internal/service/chatsvc/repo"
+	"github.com/AliUnipal/chat/internal/service/msgsvc/repo"
+	"github.com/google/uuid"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxMentions bounds how many @usernames of a message are looked up.
+const maxMentions = 50
+
+// mentionPattern matches an @ followed by the characters usernames are made
+// of, including the "+" of phone numbers.
+var mentionPattern = regexp.MustCompile(`@[\p{L}\p{N}_.+-]+`)
+
+// GetMentions returns the messages that mention userID, directly or through
+// @all, across their chats, newest first.
+func (s *service) GetMentions(ctx context.Context, userID uuid.UUID) ([]message.Message, error) {
+	userChats, err := s.chatRepo.GetChatsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	since := make(map[uuid.UUID]time.Time, len(userChats))
+	for _, c := range userChats {
+		since[c.ID] = c.Member.ClearedAt
+	}
+	msgs, err := s.repo.GetMentions(ctx, userID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.clock()
+	r := make([]message.Message, 0, len(msgs))
+	for _, m := range msgs {
+		if !expired(m, now) {
+			r = append(r, toMessage(m))
+		}
+	}
+	return r, nil
+}
+
+// findMentions resolves the @usernames in text to participants of the chat.
+// @all mentions everyone, but only in chats with more than two
+// participants. Names that match nobody in the chat, or are formatted as
+// code, are plain text.
+func findMentions(c chatrepo.Chat, text string, entities []message.Entity) []message.Mention {
+	type target struct {
+		id uuid.UUID
+		ok bool
+	}
+	var mentions []message.Mention
+	resolved := make(map[string]target)
+	for _, loc := range mentionPattern.FindAllStringIndex(text, maxMentions) {
+		// An @ inside a word, as in an email address, is not a mention.
+		if r, _ := utf8.DecodeLastRuneInString(text[:loc[0]]); loc[0] > 0 && (r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			continue
+		}
+		if slices.ContainsFunc(entities, func(e message.Entity) bool {
+			return (e.Type == message.CodeEntity || e.Type == message.CodeBlockEntity) && loc[0] >= e.Offset && loc[0] < e.Offset+e.Length
+		}) {
+			continue
+		}
+		name := strings.TrimRight(text[loc[0]+1:loc[1]], ".-")
+		if name == "" {
+			continue
+		}
+
+		key := strings.ToLower(name)
+		t, seen := resolved[key]
+		if !seen {
+			t.id, t.ok = resolveMention(c, name)
+			resolved[key] = t
+		}
+		if t.ok {
+			mentions = append(mentions, message.Mention{UserID: t.id, Offset: loc[0], Length: 1 + len(name)})
+		}
+	}
+
+	return mentions
+}
+
+// resolveMention returns the participant with the given username, or
+// uuid.Nil for @all. It reports false when the name mentions nobody.
+func resolveMention(c chatrepo.Chat, name string) (uuid.UUID, bool) {
+	if strings.EqualFold(name, "all") {
+		return uuid.Nil, len(c.Participants) > 2
+	}
+	i := slices.IndexFunc(c.Participants, func(p chatrepo.User) bool {
+		return p.Username != "" && strings.EqualFold(p.Username, name)
+	})
+	if i < 0 {
+		return uuid.Nil, false
+	}
+
+	return c.Participants[i].ID, true
+}
+
+// silenced returns the members who muted the chat at the given time, except
+// those the mentions reach: mentions get through the mute.
+func (s *service) silenced(ctx context.Context, chatID uuid.UUID, mentions []message.Mention, now time.Time) ([]uuid.UUID, error) {
+	members, err := s.chatRepo.GetMembers(ctx, chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []uuid.UUID
+	for _, m := range members {
+		if now.Before(m.MutedUntil) && !message.Mentioned(mentions, m.UserID) {
+			ids = append(ids, m.UserID)
+		}
+	}
+	return ids, nil
+}
+
+func toMessage(m repo.Message) message.Message {
+	return message.Message{
+		ID:          m.ID,
+		SenderID:    m.SenderID,
+		ChatID:      m.ChatID,
+		Content:     m.Content,
+		ContentType: m.ContentType,
+		Timestamp:   m.Timestamp,
+		Quarantined: m.Quarantined,
+		Mentions:    m.Mentions,
+		Entities:    m.Entities,
+		Previews:    m.Previews,
+		ExpiresAt:   m.ExpiresAt,
+		Imported:    m.Imported,
+	}
+}
@@ -6,6 +6,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/AliUnipal/chat/internal/service/msgsvc/repo"
 	"github.com/google/uuid"
@@ -96,6 +97,274 @@ func (_c *MessageRepository_CreateMessage_Call) RunAndReturn(run func(ctx contex
 	return _c
 }
 
+// DeleteExpiredMessages provides a mock function for the type MessageRepository
+func (_mock *MessageRepository) DeleteExpiredMessages(ctx context.Context, now time.Time) ([]repo.Message, error) {
+	ret := _mock.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteExpiredMessages")
+	}
+
+	var r0 []repo.Message
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) ([]repo.Message, error)); ok {
+		return returnFunc(ctx, now)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) []repo.Message); ok {
+		r0 = returnFunc(ctx, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repo.Message)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MessageRepository_DeleteExpiredMessages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteExpiredMessages'
+type MessageRepository_DeleteExpiredMessages_Call struct {
+	*mock.Call
+}
+
+// DeleteExpiredMessages is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+func (_e *MessageRepository_Expecter) DeleteExpiredMessages(ctx interface{}, now interface{}) *MessageRepository_DeleteExpiredMessages_Call {
+	return &MessageRepository_DeleteExpiredMessages_Call{Call: _e.mock.On("DeleteExpiredMessages", ctx, now)}
+}
+
+func (_c *MessageRepository_DeleteExpiredMessages_Call) Run(run func(ctx context.Context, now time.Time)) *MessageRepository_DeleteExpiredMessages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MessageRepository_DeleteExpiredMessages_Call) Return(messages []repo.Message, err error) *MessageRepository_DeleteExpiredMessages_Call {
+	_c.Call.Return(messages, err)
+	return _c
+}
+
+func (_c *MessageRepository_DeleteExpiredMessages_Call) RunAndReturn(run func(ctx context.Context, now time.Time) ([]repo.Message, error)) *MessageRepository_DeleteExpiredMessages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteMessage provides a mock function for the type MessageRepository
+func (_mock *MessageRepository) DeleteMessage(ctx context.Context, chatID uuid.UUID, id uuid.UUID) error {
+	ret := _mock.Called(ctx, chatID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMessage")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, chatID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MessageRepository_DeleteMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMessage'
+type MessageRepository_DeleteMessage_Call struct {
+	*mock.Call
+}
+
+// DeleteMessage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID uuid.UUID
+//   - id uuid.UUID
+func (_e *MessageRepository_Expecter) DeleteMessage(ctx interface{}, chatID interface{}, id interface{}) *MessageRepository_DeleteMessage_Call {
+	return &MessageRepository_DeleteMessage_Call{Call: _e.mock.On("DeleteMessage", ctx, chatID, id)}
+}
+
+func (_c *MessageRepository_DeleteMessage_Call) Run(run func(ctx context.Context, chatID uuid.UUID, id uuid.UUID)) *MessageRepository_DeleteMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MessageRepository_DeleteMessage_Call) Return(err error) *MessageRepository_DeleteMessage_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MessageRepository_DeleteMessage_Call) RunAndReturn(run func(ctx context.Context, chatID uuid.UUID, id uuid.UUID) error) *MessageRepository_DeleteMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteMessagesBySender provides a mock function for the type MessageRepository
+func (_mock *MessageRepository) DeleteMessagesBySender(ctx context.Context, chatID uuid.UUID, senderID uuid.UUID) error {
+	ret := _mock.Called(ctx, chatID, senderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMessagesBySender")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, chatID, senderID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MessageRepository_DeleteMessagesBySender_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMessagesBySender'
+type MessageRepository_DeleteMessagesBySender_Call struct {
+	*mock.Call
+}
+
+// DeleteMessagesBySender is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID uuid.UUID
+//   - senderID uuid.UUID
+func (_e *MessageRepository_Expecter) DeleteMessagesBySender(ctx interface{}, chatID interface{}, senderID interface{}) *MessageRepository_DeleteMessagesBySender_Call {
+	return &MessageRepository_DeleteMessagesBySender_Call{Call: _e.mock.On("DeleteMessagesBySender", ctx, chatID, senderID)}
+}
+
+func (_c *MessageRepository_DeleteMessagesBySender_Call) Run(run func(ctx context.Context, chatID uuid.UUID, senderID uuid.UUID)) *MessageRepository_DeleteMessagesBySender_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MessageRepository_DeleteMessagesBySender_Call) Return(err error) *MessageRepository_DeleteMessagesBySender_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MessageRepository_DeleteMessagesBySender_Call) RunAndReturn(run func(ctx context.Context, chatID uuid.UUID, senderID uuid.UUID) error) *MessageRepository_DeleteMessagesBySender_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMentions provides a mock function for the type MessageRepository
+func (_mock *MessageRepository) GetMentions(ctx context.Context, userID uuid.UUID, since map[uuid.UUID]time.Time) ([]repo.Message, error) {
+	ret := _mock.Called(ctx, userID, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMentions")
+	}
+
+	var r0 []repo.Message
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, map[uuid.UUID]time.Time) ([]repo.Message, error)); ok {
+		return returnFunc(ctx, userID, since)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, map[uuid.UUID]time.Time) []repo.Message); ok {
+		r0 = returnFunc(ctx, userID, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repo.Message)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, map[uuid.UUID]time.Time) error); ok {
+		r1 = returnFunc(ctx, userID, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MessageRepository_GetMentions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMentions'
+type MessageRepository_GetMentions_Call struct {
+	*mock.Call
+}
+
+// GetMentions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - since map[uuid.UUID]time.Time
+func (_e *MessageRepository_Expecter) GetMentions(ctx interface{}, userID interface{}, since interface{}) *MessageRepository_GetMentions_Call {
+	return &MessageRepository_GetMentions_Call{Call: _e.mock.On("GetMentions", ctx, userID, since)}
+}
+
+func (_c *MessageRepository_GetMentions_Call) Run(run func(ctx context.Context, userID uuid.UUID, since map[uuid.UUID]time.Time)) *MessageRepository_GetMentions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 map[uuid.UUID]time.Time
+		if args[2] != nil {
+			arg2 = args[2].(map[uuid.UUID]time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MessageRepository_GetMentions_Call) Return(messages []repo.Message, err error) *MessageRepository_GetMentions_Call {
+	_c.Call.Return(messages, err)
+	return _c
+}
+
+func (_c *MessageRepository_GetMentions_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, since map[uuid.UUID]time.Time) ([]repo.Message, error)) *MessageRepository_GetMentions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetMessage provides a mock function for the type MessageRepository
 func (_mock *MessageRepository) GetMessage(ctx context.Context, id uuid.UUID, chatID uuid.UUID) (repo.Message, error) {
 	ret := _mock.Called(ctx, id, chatID)
@@ -235,3 +504,66 @@ func (_c *MessageRepository_GetMessages_Call) RunAndReturn(run func(ctx context.
 	_c.Call.Return(run)
 	return _c
 }
+
+// ReleaseMessage provides a mock function for the type MessageRepository
+func (_mock *MessageRepository) ReleaseMessage(ctx context.Context, chatID uuid.UUID, id uuid.UUID) error {
+	ret := _mock.Called(ctx, chatID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseMessage")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, chatID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MessageRepository_ReleaseMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseMessage'
+type MessageRepository_ReleaseMessage_Call struct {
+	*mock.Call
+}
+
+// ReleaseMessage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID uuid.UUID
+//   - id uuid.UUID
+func (_e *MessageRepository_Expecter) ReleaseMessage(ctx interface{}, chatID interface{}, id interface{}) *MessageRepository_ReleaseMessage_Call {
+	return &MessageRepository_ReleaseMessage_Call{Call: _e.mock.On("ReleaseMessage", ctx, chatID, id)}
+}
+
+func (_c *MessageRepository_ReleaseMessage_Call) Run(run func(ctx context.Context, chatID uuid.UUID, id uuid.UUID)) *MessageRepository_ReleaseMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MessageRepository_ReleaseMessage_Call) Return(err error) *MessageRepository_ReleaseMessage_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MessageRepository_ReleaseMessage_Call) RunAndReturn(run func(ctx context.Context, chatID uuid.UUID, id uuid.UUID) error) *MessageRepository_ReleaseMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
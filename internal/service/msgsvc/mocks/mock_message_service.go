@@ -6,7 +6,9 @@ package mocks
 
 import (
 	"context"
+	"time"
 
+	"github.com/AliUnipal/chat/internal/commands"
 	"github.com/AliUnipal/chat/internal/models/message"
 	"github.com/AliUnipal/chat/internal/service/msgsvc"
 	"github.com/google/uuid"
@@ -40,6 +42,126 @@ func (_m *MessageService) EXPECT() *MessageService_Expecter {
 	return &MessageService_Expecter{mock: &_m.Mock}
 }
 
+// CancelScheduledMessage provides a mock function for the type MessageService
+func (_mock *MessageService) CancelScheduledMessage(ctx context.Context, userID uuid.UUID, id uuid.UUID) error {
+	ret := _mock.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelScheduledMessage")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, userID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MessageService_CancelScheduledMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelScheduledMessage'
+type MessageService_CancelScheduledMessage_Call struct {
+	*mock.Call
+}
+
+// CancelScheduledMessage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - id uuid.UUID
+func (_e *MessageService_Expecter) CancelScheduledMessage(ctx interface{}, userID interface{}, id interface{}) *MessageService_CancelScheduledMessage_Call {
+	return &MessageService_CancelScheduledMessage_Call{Call: _e.mock.On("CancelScheduledMessage", ctx, userID, id)}
+}
+
+func (_c *MessageService_CancelScheduledMessage_Call) Run(run func(ctx context.Context, userID uuid.UUID, id uuid.UUID)) *MessageService_CancelScheduledMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MessageService_CancelScheduledMessage_Call) Return(err error) *MessageService_CancelScheduledMessage_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MessageService_CancelScheduledMessage_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, id uuid.UUID) error) *MessageService_CancelScheduledMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CancelScheduledMessages provides a mock function for the type MessageService
+func (_mock *MessageService) CancelScheduledMessages(ctx context.Context, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelScheduledMessages")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MessageService_CancelScheduledMessages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelScheduledMessages'
+type MessageService_CancelScheduledMessages_Call struct {
+	*mock.Call
+}
+
+// CancelScheduledMessages is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *MessageService_Expecter) CancelScheduledMessages(ctx interface{}, userID interface{}) *MessageService_CancelScheduledMessages_Call {
+	return &MessageService_CancelScheduledMessages_Call{Call: _e.mock.On("CancelScheduledMessages", ctx, userID)}
+}
+
+func (_c *MessageService_CancelScheduledMessages_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MessageService_CancelScheduledMessages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MessageService_CancelScheduledMessages_Call) Return(err error) *MessageService_CancelScheduledMessages_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MessageService_CancelScheduledMessages_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) error) *MessageService_CancelScheduledMessages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateMessage provides a mock function for the type MessageService
 func (_mock *MessageService) CreateMessage(ctx context.Context, in msgsvc.MessageInput) (uuid.UUID, error) {
 	ret := _mock.Called(ctx, in)
@@ -108,46 +230,233 @@ func (_c *MessageService_CreateMessage_Call) RunAndReturn(run func(ctx context.C
 	return _c
 }
 
-// GetMessage provides a mock function for the type MessageService
-func (_mock *MessageService) GetMessage(ctx context.Context, id uuid.UUID, chatID uuid.UUID) (message.Message, error) {
-	ret := _mock.Called(ctx, id, chatID)
+// DeleteExpiredMessages provides a mock function for the type MessageService
+func (_mock *MessageService) DeleteExpiredMessages(ctx context.Context) error {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetMessage")
+		panic("no return value specified for DeleteExpiredMessages")
 	}
 
-	var r0 message.Message
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (message.Message, error)); ok {
-		return returnFunc(ctx, id, chatID)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MessageService_DeleteExpiredMessages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteExpiredMessages'
+type MessageService_DeleteExpiredMessages_Call struct {
+	*mock.Call
+}
+
+// DeleteExpiredMessages is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MessageService_Expecter) DeleteExpiredMessages(ctx interface{}) *MessageService_DeleteExpiredMessages_Call {
+	return &MessageService_DeleteExpiredMessages_Call{Call: _e.mock.On("DeleteExpiredMessages", ctx)}
+}
+
+func (_c *MessageService_DeleteExpiredMessages_Call) Run(run func(ctx context.Context)) *MessageService_DeleteExpiredMessages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MessageService_DeleteExpiredMessages_Call) Return(err error) *MessageService_DeleteExpiredMessages_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MessageService_DeleteExpiredMessages_Call) RunAndReturn(run func(ctx context.Context) error) *MessageService_DeleteExpiredMessages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DisableCommand provides a mock function for the type MessageService
+func (_mock *MessageService) DisableCommand(ctx context.Context, chatID uuid.UUID, userID uuid.UUID, name string) error {
+	ret := _mock.Called(ctx, chatID, userID, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DisableCommand")
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) message.Message); ok {
-		r0 = returnFunc(ctx, id, chatID)
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, string) error); ok {
+		r0 = returnFunc(ctx, chatID, userID, name)
 	} else {
-		r0 = ret.Get(0).(message.Message)
+		r0 = ret.Error(0)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
-		r1 = returnFunc(ctx, id, chatID)
+	return r0
+}
+
+// MessageService_DisableCommand_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DisableCommand'
+type MessageService_DisableCommand_Call struct {
+	*mock.Call
+}
+
+// DisableCommand is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID uuid.UUID
+//   - userID uuid.UUID
+//   - name string
+func (_e *MessageService_Expecter) DisableCommand(ctx interface{}, chatID interface{}, userID interface{}, name interface{}) *MessageService_DisableCommand_Call {
+	return &MessageService_DisableCommand_Call{Call: _e.mock.On("DisableCommand", ctx, chatID, userID, name)}
+}
+
+func (_c *MessageService_DisableCommand_Call) Run(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID, name string)) *MessageService_DisableCommand_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MessageService_DisableCommand_Call) Return(err error) *MessageService_DisableCommand_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MessageService_DisableCommand_Call) RunAndReturn(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID, name string) error) *MessageService_DisableCommand_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EditScheduledMessage provides a mock function for the type MessageService
+func (_mock *MessageService) EditScheduledMessage(ctx context.Context, userID uuid.UUID, id uuid.UUID, content []byte, sendAt time.Time) error {
+	ret := _mock.Called(ctx, userID, id, content, sendAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EditScheduledMessage")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, []byte, time.Time) error); ok {
+		r0 = returnFunc(ctx, userID, id, content, sendAt)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MessageService_GetMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMessage'
-type MessageService_GetMessage_Call struct {
+// MessageService_EditScheduledMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EditScheduledMessage'
+type MessageService_EditScheduledMessage_Call struct {
 	*mock.Call
 }
 
-// GetMessage is a helper method to define mock.On call
+// EditScheduledMessage is a helper method to define mock.On call
 //   - ctx context.Context
+//   - userID uuid.UUID
 //   - id uuid.UUID
+//   - content []byte
+//   - sendAt time.Time
+func (_e *MessageService_Expecter) EditScheduledMessage(ctx interface{}, userID interface{}, id interface{}, content interface{}, sendAt interface{}) *MessageService_EditScheduledMessage_Call {
+	return &MessageService_EditScheduledMessage_Call{Call: _e.mock.On("EditScheduledMessage", ctx, userID, id, content, sendAt)}
+}
+
+func (_c *MessageService_EditScheduledMessage_Call) Run(run func(ctx context.Context, userID uuid.UUID, id uuid.UUID, content []byte, sendAt time.Time)) *MessageService_EditScheduledMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		var arg3 []byte
+		if args[3] != nil {
+			arg3 = args[3].([]byte)
+		}
+		var arg4 time.Time
+		if args[4] != nil {
+			arg4 = args[4].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MessageService_EditScheduledMessage_Call) Return(err error) *MessageService_EditScheduledMessage_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MessageService_EditScheduledMessage_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, id uuid.UUID, content []byte, sendAt time.Time) error) *MessageService_EditScheduledMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnableCommand provides a mock function for the type MessageService
+func (_mock *MessageService) EnableCommand(ctx context.Context, chatID uuid.UUID, userID uuid.UUID, name string) error {
+	ret := _mock.Called(ctx, chatID, userID, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnableCommand")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, string) error); ok {
+		r0 = returnFunc(ctx, chatID, userID, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MessageService_EnableCommand_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnableCommand'
+type MessageService_EnableCommand_Call struct {
+	*mock.Call
+}
+
+// EnableCommand is a helper method to define mock.On call
+//   - ctx context.Context
 //   - chatID uuid.UUID
-func (_e *MessageService_Expecter) GetMessage(ctx interface{}, id interface{}, chatID interface{}) *MessageService_GetMessage_Call {
-	return &MessageService_GetMessage_Call{Call: _e.mock.On("GetMessage", ctx, id, chatID)}
+//   - userID uuid.UUID
+//   - name string
+func (_e *MessageService_Expecter) EnableCommand(ctx interface{}, chatID interface{}, userID interface{}, name interface{}) *MessageService_EnableCommand_Call {
+	return &MessageService_EnableCommand_Call{Call: _e.mock.On("EnableCommand", ctx, chatID, userID, name)}
 }
 
-func (_c *MessageService_GetMessage_Call) Run(run func(ctx context.Context, id uuid.UUID, chatID uuid.UUID)) *MessageService_GetMessage_Call {
+func (_c *MessageService_EnableCommand_Call) Run(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID, name string)) *MessageService_EnableCommand_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -161,66 +470,72 @@ func (_c *MessageService_GetMessage_Call) Run(run func(ctx context.Context, id u
 		if args[2] != nil {
 			arg2 = args[2].(uuid.UUID)
 		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MessageService_GetMessage_Call) Return(message1 message.Message, err error) *MessageService_GetMessage_Call {
-	_c.Call.Return(message1, err)
+func (_c *MessageService_EnableCommand_Call) Return(err error) *MessageService_EnableCommand_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MessageService_GetMessage_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, chatID uuid.UUID) (message.Message, error)) *MessageService_GetMessage_Call {
+func (_c *MessageService_EnableCommand_Call) RunAndReturn(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID, name string) error) *MessageService_EnableCommand_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetMessages provides a mock function for the type MessageService
-func (_mock *MessageService) GetMessages(ctx context.Context, chatID uuid.UUID) ([]message.Message, error) {
-	ret := _mock.Called(ctx, chatID)
+// GetCommands provides a mock function for the type MessageService
+func (_mock *MessageService) GetCommands(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) ([]commands.Command, error) {
+	ret := _mock.Called(ctx, chatID, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetMessages")
+		panic("no return value specified for GetCommands")
 	}
 
-	var r0 []message.Message
+	var r0 []commands.Command
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]message.Message, error)); ok {
-		return returnFunc(ctx, chatID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) ([]commands.Command, error)); ok {
+		return returnFunc(ctx, chatID, userID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []message.Message); ok {
-		r0 = returnFunc(ctx, chatID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) []commands.Command); ok {
+		r0 = returnFunc(ctx, chatID, userID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]message.Message)
+			r0 = ret.Get(0).([]commands.Command)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = returnFunc(ctx, chatID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, chatID, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MessageService_GetMessages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMessages'
-type MessageService_GetMessages_Call struct {
+// MessageService_GetCommands_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCommands'
+type MessageService_GetCommands_Call struct {
 	*mock.Call
 }
 
-// GetMessages is a helper method to define mock.On call
+// GetCommands is a helper method to define mock.On call
 //   - ctx context.Context
 //   - chatID uuid.UUID
-func (_e *MessageService_Expecter) GetMessages(ctx interface{}, chatID interface{}) *MessageService_GetMessages_Call {
-	return &MessageService_GetMessages_Call{Call: _e.mock.On("GetMessages", ctx, chatID)}
+//   - userID uuid.UUID
+func (_e *MessageService_Expecter) GetCommands(ctx interface{}, chatID interface{}, userID interface{}) *MessageService_GetCommands_Call {
+	return &MessageService_GetCommands_Call{Call: _e.mock.On("GetCommands", ctx, chatID, userID)}
 }
 
-func (_c *MessageService_GetMessages_Call) Run(run func(ctx context.Context, chatID uuid.UUID)) *MessageService_GetMessages_Call {
+func (_c *MessageService_GetCommands_Call) Run(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID)) *MessageService_GetCommands_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -230,20 +545,619 @@ func (_c *MessageService_GetMessages_Call) Run(run func(ctx context.Context, cha
 		if args[1] != nil {
 			arg1 = args[1].(uuid.UUID)
 		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MessageService_GetMessages_Call) Return(messages []message.Message, err error) *MessageService_GetMessages_Call {
-	_c.Call.Return(messages, err)
+func (_c *MessageService_GetCommands_Call) Return(commands1 []commands.Command, err error) *MessageService_GetCommands_Call {
+	_c.Call.Return(commands1, err)
+	return _c
+}
+
+func (_c *MessageService_GetCommands_Call) RunAndReturn(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) ([]commands.Command, error)) *MessageService_GetCommands_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMentions provides a mock function for the type MessageService
+func (_mock *MessageService) GetMentions(ctx context.Context, userID uuid.UUID) ([]message.Message, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMentions")
+	}
+
+	var r0 []message.Message
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]message.Message, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []message.Message); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]message.Message)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MessageService_GetMentions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMentions'
+type MessageService_GetMentions_Call struct {
+	*mock.Call
+}
+
+// GetMentions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *MessageService_Expecter) GetMentions(ctx interface{}, userID interface{}) *MessageService_GetMentions_Call {
+	return &MessageService_GetMentions_Call{Call: _e.mock.On("GetMentions", ctx, userID)}
+}
+
+func (_c *MessageService_GetMentions_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MessageService_GetMentions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MessageService_GetMentions_Call) Return(messages []message.Message, err error) *MessageService_GetMentions_Call {
+	_c.Call.Return(messages, err)
+	return _c
+}
+
+func (_c *MessageService_GetMentions_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) ([]message.Message, error)) *MessageService_GetMentions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMessages provides a mock function for the type MessageService
+func (_mock *MessageService) GetMessages(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) ([]message.Message, error) {
+	ret := _mock.Called(ctx, chatID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMessages")
+	}
+
+	var r0 []message.Message
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) ([]message.Message, error)); ok {
+		return returnFunc(ctx, chatID, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) []message.Message); ok {
+		r0 = returnFunc(ctx, chatID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]message.Message)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, chatID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MessageService_GetMessages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMessages'
+type MessageService_GetMessages_Call struct {
+	*mock.Call
+}
+
+// GetMessages is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID uuid.UUID
+//   - userID uuid.UUID
+func (_e *MessageService_Expecter) GetMessages(ctx interface{}, chatID interface{}, userID interface{}) *MessageService_GetMessages_Call {
+	return &MessageService_GetMessages_Call{Call: _e.mock.On("GetMessages", ctx, chatID, userID)}
+}
+
+func (_c *MessageService_GetMessages_Call) Run(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID)) *MessageService_GetMessages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MessageService_GetMessages_Call) Return(messages []message.Message, err error) *MessageService_GetMessages_Call {
+	_c.Call.Return(messages, err)
+	return _c
+}
+
+func (_c *MessageService_GetMessages_Call) RunAndReturn(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) ([]message.Message, error)) *MessageService_GetMessages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetScheduledMessages provides a mock function for the type MessageService
+func (_mock *MessageService) GetScheduledMessages(ctx context.Context, userID uuid.UUID) ([]message.ScheduledMessage, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetScheduledMessages")
+	}
+
+	var r0 []message.ScheduledMessage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]message.ScheduledMessage, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []message.ScheduledMessage); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]message.ScheduledMessage)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MessageService_GetScheduledMessages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetScheduledMessages'
+type MessageService_GetScheduledMessages_Call struct {
+	*mock.Call
+}
+
+// GetScheduledMessages is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *MessageService_Expecter) GetScheduledMessages(ctx interface{}, userID interface{}) *MessageService_GetScheduledMessages_Call {
+	return &MessageService_GetScheduledMessages_Call{Call: _e.mock.On("GetScheduledMessages", ctx, userID)}
+}
+
+func (_c *MessageService_GetScheduledMessages_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MessageService_GetScheduledMessages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MessageService_GetScheduledMessages_Call) Return(scheduledMessages []message.ScheduledMessage, err error) *MessageService_GetScheduledMessages_Call {
+	_c.Call.Return(scheduledMessages, err)
+	return _c
+}
+
+func (_c *MessageService_GetScheduledMessages_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) ([]message.ScheduledMessage, error)) *MessageService_GetScheduledMessages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveMessagesBySender provides a mock function for the type MessageService
+func (_mock *MessageService) RemoveMessagesBySender(ctx context.Context, chatID uuid.UUID, senderID uuid.UUID) error {
+	ret := _mock.Called(ctx, chatID, senderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveMessagesBySender")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, chatID, senderID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MessageService_RemoveMessagesBySender_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveMessagesBySender'
+type MessageService_RemoveMessagesBySender_Call struct {
+	*mock.Call
+}
+
+// RemoveMessagesBySender is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID uuid.UUID
+//   - senderID uuid.UUID
+func (_e *MessageService_Expecter) RemoveMessagesBySender(ctx interface{}, chatID interface{}, senderID interface{}) *MessageService_RemoveMessagesBySender_Call {
+	return &MessageService_RemoveMessagesBySender_Call{Call: _e.mock.On("RemoveMessagesBySender", ctx, chatID, senderID)}
+}
+
+func (_c *MessageService_RemoveMessagesBySender_Call) Run(run func(ctx context.Context, chatID uuid.UUID, senderID uuid.UUID)) *MessageService_RemoveMessagesBySender_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MessageService_RemoveMessagesBySender_Call) Return(err error) *MessageService_RemoveMessagesBySender_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MessageService_RemoveMessagesBySender_Call) RunAndReturn(run func(ctx context.Context, chatID uuid.UUID, senderID uuid.UUID) error) *MessageService_RemoveMessagesBySender_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ScheduleMessage provides a mock function for the type MessageService
+func (_mock *MessageService) ScheduleMessage(ctx context.Context, in msgsvc.MessageInput, sendAt time.Time) (uuid.UUID, error) {
+	ret := _mock.Called(ctx, in, sendAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScheduleMessage")
+	}
+
+	var r0 uuid.UUID
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, msgsvc.MessageInput, time.Time) (uuid.UUID, error)); ok {
+		return returnFunc(ctx, in, sendAt)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, msgsvc.MessageInput, time.Time) uuid.UUID); ok {
+		r0 = returnFunc(ctx, in, sendAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(uuid.UUID)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, msgsvc.MessageInput, time.Time) error); ok {
+		r1 = returnFunc(ctx, in, sendAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MessageService_ScheduleMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ScheduleMessage'
+type MessageService_ScheduleMessage_Call struct {
+	*mock.Call
+}
+
+// ScheduleMessage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in msgsvc.MessageInput
+//   - sendAt time.Time
+func (_e *MessageService_Expecter) ScheduleMessage(ctx interface{}, in interface{}, sendAt interface{}) *MessageService_ScheduleMessage_Call {
+	return &MessageService_ScheduleMessage_Call{Call: _e.mock.On("ScheduleMessage", ctx, in, sendAt)}
+}
+
+func (_c *MessageService_ScheduleMessage_Call) Run(run func(ctx context.Context, in msgsvc.MessageInput, sendAt time.Time)) *MessageService_ScheduleMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 msgsvc.MessageInput
+		if args[1] != nil {
+			arg1 = args[1].(msgsvc.MessageInput)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MessageService_ScheduleMessage_Call) Return(uUID uuid.UUID, err error) *MessageService_ScheduleMessage_Call {
+	_c.Call.Return(uUID, err)
+	return _c
+}
+
+func (_c *MessageService_ScheduleMessage_Call) RunAndReturn(run func(ctx context.Context, in msgsvc.MessageInput, sendAt time.Time) (uuid.UUID, error)) *MessageService_ScheduleMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendScheduledMessages provides a mock function for the type MessageService
+func (_mock *MessageService) SendScheduledMessages(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendScheduledMessages")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MessageService_SendScheduledMessages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendScheduledMessages'
+type MessageService_SendScheduledMessages_Call struct {
+	*mock.Call
+}
+
+// SendScheduledMessages is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MessageService_Expecter) SendScheduledMessages(ctx interface{}) *MessageService_SendScheduledMessages_Call {
+	return &MessageService_SendScheduledMessages_Call{Call: _e.mock.On("SendScheduledMessages", ctx)}
+}
+
+func (_c *MessageService_SendScheduledMessages_Call) Run(run func(ctx context.Context)) *MessageService_SendScheduledMessages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MessageService_SendScheduledMessages_Call) Return(err error) *MessageService_SendScheduledMessages_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MessageService_SendScheduledMessages_Call) RunAndReturn(run func(ctx context.Context) error) *MessageService_SendScheduledMessages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StartTyping provides a mock function for the type MessageService
+func (_mock *MessageService) StartTyping(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, chatID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartTyping")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, chatID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MessageService_StartTyping_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartTyping'
+type MessageService_StartTyping_Call struct {
+	*mock.Call
+}
+
+// StartTyping is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID uuid.UUID
+//   - userID uuid.UUID
+func (_e *MessageService_Expecter) StartTyping(ctx interface{}, chatID interface{}, userID interface{}) *MessageService_StartTyping_Call {
+	return &MessageService_StartTyping_Call{Call: _e.mock.On("StartTyping", ctx, chatID, userID)}
+}
+
+func (_c *MessageService_StartTyping_Call) Run(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID)) *MessageService_StartTyping_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MessageService_StartTyping_Call) Return(err error) *MessageService_StartTyping_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MessageService_StartTyping_Call) RunAndReturn(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) error) *MessageService_StartTyping_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StopTyping provides a mock function for the type MessageService
+func (_mock *MessageService) StopTyping(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, chatID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StopTyping")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, chatID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MessageService_StopTyping_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StopTyping'
+type MessageService_StopTyping_Call struct {
+	*mock.Call
+}
+
+// StopTyping is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID uuid.UUID
+//   - userID uuid.UUID
+func (_e *MessageService_Expecter) StopTyping(ctx interface{}, chatID interface{}, userID interface{}) *MessageService_StopTyping_Call {
+	return &MessageService_StopTyping_Call{Call: _e.mock.On("StopTyping", ctx, chatID, userID)}
+}
+
+func (_c *MessageService_StopTyping_Call) Run(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID)) *MessageService_StopTyping_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MessageService_StopTyping_Call) Return(err error) *MessageService_StopTyping_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MessageService_StopTyping_Call) RunAndReturn(run func(ctx context.Context, chatID uuid.UUID, userID uuid.UUID) error) *MessageService_StopTyping_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SubscribeTyping provides a mock function for the type MessageService
+func (_mock *MessageService) SubscribeTyping(ctx context.Context, userID uuid.UUID) (<-chan message.TypingEvent, func()) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscribeTyping")
+	}
+
+	var r0 <-chan message.TypingEvent
+	var r1 func()
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (<-chan message.TypingEvent, func())); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) <-chan message.TypingEvent); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan message.TypingEvent)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) func()); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(func())
+		}
+	}
+	return r0, r1
+}
+
+// MessageService_SubscribeTyping_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SubscribeTyping'
+type MessageService_SubscribeTyping_Call struct {
+	*mock.Call
+}
+
+// SubscribeTyping is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *MessageService_Expecter) SubscribeTyping(ctx interface{}, userID interface{}) *MessageService_SubscribeTyping_Call {
+	return &MessageService_SubscribeTyping_Call{Call: _e.mock.On("SubscribeTyping", ctx, userID)}
+}
+
+func (_c *MessageService_SubscribeTyping_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MessageService_SubscribeTyping_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MessageService_SubscribeTyping_Call) Return(ch <-chan message.TypingEvent, fn func()) *MessageService_SubscribeTyping_Call {
+	_c.Call.Return(ch, fn)
 	return _c
 }
 
-func (_c *MessageService_GetMessages_Call) RunAndReturn(run func(ctx context.Context, chatID uuid.UUID) ([]message.Message, error)) *MessageService_GetMessages_Call {
+func (_c *MessageService_SubscribeTyping_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) (<-chan message.TypingEvent, func())) *MessageService_SubscribeTyping_Call {
 	_c.Call.Return(run)
 	return _c
 }
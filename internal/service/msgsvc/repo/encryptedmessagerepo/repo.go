@@ -0,0 +1,388 @@
+// Package encryptedmessagerepo encrypts message content at rest on top of
+// another message repository.
+//
+// It uses envelope encryption: every chat gets its own random data key that
+// seals the content of its messages, and only a copy of the data key wrapped
+// by a master key is stored. Rotating the master key therefore only
+// re-wraps the data keys; message bodies are never rewritten.
+package encryptedmessagerepo
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"github.com/AliUnipal/chat/internal/models/message"
+	"github.com/AliUnipal/chat/internal/service/msgsvc/repo"
+	"github.com/google/uuid"
+	"slices"
+	"sync"
+	"time"
+)
+
+// contentVersion prefixes sealed content so the format can evolve.
+const contentVersion = 1
+
+func New(messages messageRepository, dataKeys dataKeyRepository, masterKey masterKey) *repository {
+	return &repository{
+		messages:  messages,
+		dataKeys:  dataKeys,
+		masterKey: masterKey,
+		cache:     make(map[uuid.UUID]cipher.AEAD),
+	}
+}
+
+type messageRepository interface {
+	CreateMessage(ctx context.Context, in repo.CreateMessageInput) error
+	GetMessage(ctx context.Context, id, chatID uuid.UUID) (repo.Message, error)
+	GetMessages(ctx context.Context, chatID uuid.UUID) ([]repo.Message, error)
+	GetLastMessages(ctx context.Context, chatIDs []uuid.UUID, now time.Time) (map[uuid.UUID]repo.Message, error)
+	GetMentions(ctx context.Context, userID uuid.UUID, since map[uuid.UUID]time.Time) ([]repo.Message, error)
+	CountMentions(ctx context.Context, userID uuid.UUID, since map[uuid.UUID]time.Time, now time.Time) (map[uuid.UUID]repo.MentionCount, error)
+	SetPreviews(ctx context.Context, chatID, id uuid.UUID, previews []message.Preview) error
+	ReleaseMessage(ctx context.Context, chatID, id uuid.UUID) error
+	DeleteMessage(ctx context.Context, chatID, id uuid.UUID) error
+	DeleteMessagesBySender(ctx context.Context, chatID, senderID uuid.UUID) error
+	DeleteMessages(ctx context.Context, chatID uuid.UUID) error
+	DeleteMessagesBefore(ctx context.Context, chatID uuid.UUID, before time.Time) error
+	DeleteExpiredMessages(ctx context.Context, now time.Time) ([]repo.Message, error)
+}
+
+type dataKeyRepository interface {
+	GetDataKey(ctx context.Context, chatID uuid.UUID) (repo.DataKey, error)
+	GetDataKeys(ctx context.Context) ([]repo.DataKey, error)
+	SaveDataKey(ctx context.Context, key repo.DataKey) error
+	DeleteDataKey(ctx context.Context, chatID uuid.UUID) error
+}
+
+// masterKey wraps data keys, typically by calling out to a KMS.
+type masterKey interface {
+	CurrentKeyID() string
+	Encrypt(ctx context.Context, plaintext []byte) (keyID string, ciphertext []byte, err error)
+	Decrypt(ctx context.Context, keyID string, ciphertext []byte) ([]byte, error)
+}
+
+type repository struct {
+	messages  messageRepository
+	dataKeys  dataKeyRepository
+	masterKey masterKey
+
+	// cache holds unwrapped data keys so the master key is only asked once
+	// per chat. Re-wrapping does not change a data key, so entries only go
+	// when the chat's messages are deleted.
+	mu    sync.Mutex
+	cache map[uuid.UUID]cipher.AEAD
+}
+
+func (r *repository) CreateMessage(ctx context.Context, in repo.CreateMessageInput) error {
+	gcm, err := r.dataKey(ctx, in.ChatID, true)
+	if err != nil {
+		return err
+	}
+	ad := additionalData(in.ChatID, in.ID)
+	if in.Content, err = seal(gcm, in.Content, ad); err != nil {
+		return err
+	}
+	// Link targets are content too, so they are sealed the same way.
+	in.Entities = slices.Clone(in.Entities)
+	for i := range in.Entities {
+		if err := sealString(gcm, &in.Entities[i].URL, ad); err != nil {
+			return err
+		}
+	}
+
+	return r.messages.CreateMessage(ctx, in)
+}
+
+func (r *repository) GetMessage(ctx context.Context, id, chatID uuid.UUID) (repo.Message, error) {
+	m, err := r.messages.GetMessage(ctx, id, chatID)
+	if err != nil {
+		return repo.Message{}, err
+	}
+
+	return r.open(ctx, m)
+}
+
+func (r *repository) GetMessages(ctx context.Context, chatID uuid.UUID) ([]repo.Message, error) {
+	msgs, err := r.messages.GetMessages(ctx, chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	opened := make([]repo.Message, 0, len(msgs))
+	for _, m := range msgs {
+		m, err := r.open(ctx, m)
+		if err != nil {
+			return nil, err
+		}
+		opened = append(opened, m)
+	}
+
+	return opened, nil
+}
+
+func (r *repository) GetLastMessages(ctx context.Context, chatIDs []uuid.UUID, now time.Time) (map[uuid.UUID]repo.Message, error) {
+	last, err := r.messages.GetLastMessages(ctx, chatIDs, now)
+	if err != nil {
+		return nil, err
+	}
+
+	for id, m := range last {
+		if last[id], err = r.open(ctx, m); err != nil {
+			return nil, err
+		}
+	}
+
+	return last, nil
+}
+
+func (r *repository) GetMentions(ctx context.Context, userID uuid.UUID, since map[uuid.UUID]time.Time) ([]repo.Message, error) {
+	msgs, err := r.messages.GetMentions(ctx, userID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, m := range msgs {
+		if msgs[i], err = r.open(ctx, m); err != nil {
+			return nil, err
+		}
+	}
+
+	return msgs, nil
+}
+
+// CountMentions leaves content sealed, as counting does not need it.
+func (r *repository) CountMentions(ctx context.Context, userID uuid.UUID, since map[uuid.UUID]time.Time, now time.Time) (map[uuid.UUID]repo.MentionCount, error) {
+	return r.messages.CountMentions(ctx, userID, since, now)
+}
+
+// SetPreviews seals the previews, as they are made of what the message links
+// to.
+func (r *repository) SetPreviews(ctx context.Context, chatID, id uuid.UUID, previews []message.Preview) error {
+	gcm, err := r.dataKey(ctx, chatID, false)
+	if err != nil {
+		return err
+	}
+	previews = slices.Clone(previews)
+	for i := range previews {
+		if err := sealPreview(gcm, &previews[i], additionalData(chatID, id)); err != nil {
+			return err
+		}
+	}
+
+	return r.messages.SetPreviews(ctx, chatID, id, previews)
+}
+
+func (r *repository) ReleaseMessage(ctx context.Context, chatID, id uuid.UUID) error {
+	return r.messages.ReleaseMessage(ctx, chatID, id)
+}
+
+func (r *repository) DeleteMessage(ctx context.Context, chatID, id uuid.UUID) error {
+	return r.messages.DeleteMessage(ctx, chatID, id)
+}
+
+func (r *repository) DeleteMessagesBySender(ctx context.Context, chatID, senderID uuid.UUID) error {
+	return r.messages.DeleteMessagesBySender(ctx, chatID, senderID)
+}
+
+// DeleteMessages deletes the chat's messages along with its data key, so any
+// copy of the ciphertext left in backups can no longer be read.
+func (r *repository) DeleteMessages(ctx context.Context, chatID uuid.UUID) error {
+	if err := r.messages.DeleteMessages(ctx, chatID); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	delete(r.cache, chatID)
+	r.mu.Unlock()
+	return r.dataKeys.DeleteDataKey(ctx, chatID)
+}
+
+func (r *repository) DeleteMessagesBefore(ctx context.Context, chatID uuid.UUID, before time.Time) error {
+	return r.messages.DeleteMessagesBefore(ctx, chatID, before)
+}
+
+// DeleteExpiredMessages returns the purged messages with their content still
+// sealed, as they are gone and only who sent them where is of use.
+func (r *repository) DeleteExpiredMessages(ctx context.Context, now time.Time) ([]repo.Message, error) {
+	return r.messages.DeleteExpiredMessages(ctx, now)
+}
+
+// RewrapKeys re-wraps every data key that is not wrapped by the current
+// master key, returning how many it re-wrapped. Once it has run, retired
+// master keys are no longer needed.
+func (r *repository) RewrapKeys(ctx context.Context) (int, error) {
+	keys, err := r.dataKeys.GetDataKeys(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	current := r.masterKey.CurrentKeyID()
+	n := 0
+	for _, k := range keys {
+		if k.MasterKeyID == current {
+			continue
+		}
+		plaintext, err := r.masterKey.Decrypt(ctx, k.MasterKeyID, k.WrappedKey)
+		if err != nil {
+			return n, fmt.Errorf("unwrapping data key of chat %v: %w", k.ChatID, err)
+		}
+		if k.MasterKeyID, k.WrappedKey, err = r.masterKey.Encrypt(ctx, plaintext); err != nil {
+			return n, err
+		}
+		if err := r.dataKeys.SaveDataKey(ctx, k); err != nil {
+			return n, err
+		}
+		n++
+	}
+
+	return n, nil
+}
+
+func (r *repository) open(ctx context.Context, m repo.Message) (repo.Message, error) {
+	gcm, err := r.dataKey(ctx, m.ChatID, false)
+	if err != nil {
+		return repo.Message{}, err
+	}
+	ad := additionalData(m.ChatID, m.ID)
+	if m.Content, err = unseal(gcm, m.Content, ad); err != nil {
+		return repo.Message{}, fmt.Errorf("decrypting message %v: %w", m.ID, err)
+	}
+	m.Entities = slices.Clone(m.Entities)
+	for i := range m.Entities {
+		if err := openString(gcm, &m.Entities[i].URL, ad); err != nil {
+			return repo.Message{}, fmt.Errorf("decrypting message %v: %w", m.ID, err)
+		}
+	}
+	m.Previews = slices.Clone(m.Previews)
+	for i := range m.Previews {
+		if err := openPreview(gcm, &m.Previews[i], ad); err != nil {
+			return repo.Message{}, fmt.Errorf("decrypting message %v: %w", m.ID, err)
+		}
+	}
+
+	return m, nil
+}
+
+func sealPreview(gcm cipher.AEAD, p *message.Preview, additionalData []byte) error {
+	for _, s := range []*string{&p.URL, &p.Title, &p.Description, &p.ImageURL, &p.SiteName} {
+		if err := sealString(gcm, s, additionalData); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func openPreview(gcm cipher.AEAD, p *message.Preview, additionalData []byte) error {
+	for _, s := range []*string{&p.URL, &p.Title, &p.Description, &p.ImageURL, &p.SiteName} {
+		if err := openString(gcm, s, additionalData); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sealString seals a non-empty string in place, base64 encoded.
+func sealString(gcm cipher.AEAD, s *string, additionalData []byte) error {
+	if *s == "" {
+		return nil
+	}
+	sealed, err := seal(gcm, []byte(*s), additionalData)
+	if err != nil {
+		return err
+	}
+	*s = base64.RawStdEncoding.EncodeToString(sealed)
+	return nil
+}
+
+func openString(gcm cipher.AEAD, s *string, additionalData []byte) error {
+	if *s == "" {
+		return nil
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(*s)
+	if err != nil {
+		return err
+	}
+	plaintext, err := unseal(gcm, sealed, additionalData)
+	if err != nil {
+		return err
+	}
+	*s = string(plaintext)
+	return nil
+}
+
+// seal encrypts plaintext under a fresh nonce, prefixed by the format version
+// and the nonce.
+func seal(gcm cipher.AEAD, plaintext, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	sealed := append([]byte{contentVersion}, nonce...)
+	return gcm.Seal(sealed, nonce, plaintext, additionalData), nil
+}
+
+func unseal(gcm cipher.AEAD, sealed, additionalData []byte) ([]byte, error) {
+	if len(sealed) < 1+gcm.NonceSize() || sealed[0] != contentVersion {
+		return nil, errors.New("stored message content is malformed")
+	}
+
+	nonce := sealed[1 : 1+gcm.NonceSize()]
+	return gcm.Open(nil, nonce, sealed[1+gcm.NonceSize():], additionalData)
+}
+
+// dataKey returns the chat's data key, generating one first when create is
+// set and the chat has none yet.
+func (r *repository) dataKey(ctx context.Context, chatID uuid.UUID, create bool) (cipher.AEAD, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if gcm, ok := r.cache[chatID]; ok {
+		return gcm, nil
+	}
+
+	var key []byte
+	k, err := r.dataKeys.GetDataKey(ctx, chatID)
+	switch {
+	case err == nil:
+		if key, err = r.masterKey.Decrypt(ctx, k.MasterKeyID, k.WrappedKey); err != nil {
+			return nil, fmt.Errorf("unwrapping data key of chat %v: %w", chatID, err)
+		}
+	case errors.Is(err, repo.ErrDataKeyNotFound) && create:
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+		k = repo.DataKey{ChatID: chatID}
+		if k.MasterKeyID, k.WrappedKey, err = r.masterKey.Encrypt(ctx, key); err != nil {
+			return nil, err
+		}
+		if err := r.dataKeys.SaveDataKey(ctx, k); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	r.cache[chatID] = gcm
+
+	return gcm, nil
+}
+
+// additionalData binds sealed content to its message, so ciphertext cannot be
+// moved to another message or chat.
+func additionalData(chatID, messageID uuid.UUID) []byte {
+	return append(chatID[:], messageID[:]...)
+}
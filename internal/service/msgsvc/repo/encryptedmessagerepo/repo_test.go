@@ -0,0 +1,281 @@
+package encryptedmessagerepo_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/AliUnipal/chat/internal/kms"
+	"github.com/AliUnipal/chat/internal/models/message"
+	chatrepo "github.com/AliUnipal/chat/internal/service/chatsvc/repo"
+	"github.com/AliUnipal/chat/internal/service/chatsvc/repo/inmemchatrepo"
+	"github.com/AliUnipal/chat/internal/service/msgsvc/repo"
+	"github.com/AliUnipal/chat/internal/service/msgsvc/repo/encryptedmessagerepo"
+	"github.com/AliUnipal/chat/internal/service/msgsvc/repo/inmemdatakeyrepo"
+	"github.com/AliUnipal/chat/internal/service/msgsvc/repo/inmemmessagerepo"
+	userrepo "github.com/AliUnipal/chat/internal/service/usersvc/repo"
+	"github.com/AliUnipal/chat/internal/service/usersvc/repo/inmemuserrepo"
+	"github.com/google/uuid"
+	"testing"
+	"time"
+)
+
+func newKeyfile(t *testing.T, current string, ids ...string) *kms.Keyfile {
+	t.Helper()
+	keys := make(map[string][]byte, len(ids))
+	for _, id := range ids {
+		keys[id] = bytes.Repeat([]byte(id[len(id)-1:]), 32)
+	}
+	k, err := kms.NewKeyfile(current, keys)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	return k
+}
+
+// store is the in-memory repository the encrypted one writes through to.
+type store interface {
+	CreateMessage(ctx context.Context, in repo.CreateMessageInput) error
+	GetMessage(ctx context.Context, id, chatID uuid.UUID) (repo.Message, error)
+	GetMessages(ctx context.Context, chatID uuid.UUID) ([]repo.Message, error)
+	GetLastMessages(ctx context.Context, chatIDs []uuid.UUID, now time.Time) (map[uuid.UUID]repo.Message, error)
+	GetMentions(ctx context.Context, userID uuid.UUID, since map[uuid.UUID]time.Time) ([]repo.Message, error)
+	CountMentions(ctx context.Context, userID uuid.UUID, since map[uuid.UUID]time.Time, now time.Time) (map[uuid.UUID]repo.MentionCount, error)
+	SetPreviews(ctx context.Context, chatID, id uuid.UUID, previews []message.Preview) error
+	ReleaseMessage(ctx context.Context, chatID, id uuid.UUID) error
+	DeleteMessage(ctx context.Context, chatID, id uuid.UUID) error
+	DeleteMessagesBySender(ctx context.Context, chatID, senderID uuid.UUID) error
+	DeleteMessages(ctx context.Context, chatID uuid.UUID) error
+	DeleteMessagesBefore(ctx context.Context, chatID uuid.UUID, before time.Time) error
+	DeleteExpiredMessages(ctx context.Context, now time.Time) ([]repo.Message, error)
+}
+
+// keyStore is the in-memory repository the data keys are kept in.
+type keyStore interface {
+	GetDataKey(ctx context.Context, chatID uuid.UUID) (repo.DataKey, error)
+	GetDataKeys(ctx context.Context) ([]repo.DataKey, error)
+	SaveDataKey(ctx context.Context, key repo.DataKey) error
+	DeleteDataKey(ctx context.Context, chatID uuid.UUID) error
+}
+
+// newStore returns an empty message and key store along with a chat the
+// returned sender belongs to.
+func newStore(t *testing.T) (store, keyStore, uuid.UUID, uuid.UUID) {
+	t.Helper()
+	ctx := context.Background()
+	sender := userrepo.CreateUserInput{ID: uuid.New(), FirstName: "Alice"}
+	other := userrepo.CreateUserInput{ID: uuid.New(), FirstName: "Bob"}
+	chats := inmemchatrepo.New(inmemuserrepo.New(sender, other))
+	chatID := uuid.New()
+	if err := chats.CreateChat(ctx, chatrepo.CreateChatInput{ID: chatID, CurrentUserID: sender.ID, OtherUserID: other.ID}); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	return inmemmessagerepo.New(chats, nil), inmemdatakeyrepo.New(), chatID, sender.ID
+}
+
+type messageCreator interface {
+	CreateMessage(ctx context.Context, in repo.CreateMessageInput) error
+}
+
+func createMessage(t *testing.T, r messageCreator, chatID, senderID uuid.UUID, content string) uuid.UUID {
+	t.Helper()
+	id := uuid.New()
+	if err := r.CreateMessage(context.Background(), repo.CreateMessageInput{
+		ID:          id,
+		SenderID:    senderID,
+		ChatID:      chatID,
+		Content:     []byte(content),
+		ContentType: message.TextContentType,
+		Timestamp:   time.Now().UTC(),
+	}); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	return id
+}
+
+func TestRepository_EncryptAtRest(t *testing.T) {
+	ctx := context.Background()
+	inner, keys, chatID, senderID := newStore(t)
+	r := encryptedmessagerepo.New(inner, keys, newKeyfile(t, "k1", "k1"))
+
+	id := createMessage(t, r, chatID, senderID, "meet at noon")
+
+	raw, err := inner.GetMessages(ctx, chatID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(raw) != 1 || bytes.Contains(raw[0].Content, []byte("meet at noon")) {
+		t.Fatalf("expected stored content to be encrypted got %v", raw)
+	}
+	got, err := r.GetMessages(ctx, chatID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(got) != 1 || got[0].ID != id || string(got[0].Content) != "meet at noon" {
+		t.Fatalf("expected the message decrypted got %v", got)
+	}
+	last, err := r.GetLastMessages(ctx, []uuid.UUID{chatID}, time.Now())
+	if err != nil || string(last[chatID].Content) != "meet at noon" {
+		t.Fatalf("expected the last message decrypted got %v, %v", last, err)
+	}
+}
+
+func TestRepository_RewrapKeysOnRotation(t *testing.T) {
+	ctx := context.Background()
+	inner, keys, chatID, senderID := newStore(t)
+	createMessage(t, encryptedmessagerepo.New(inner, keys, newKeyfile(t, "k1", "k1")), chatID, senderID, "before rotation")
+	before, err := inner.GetMessages(ctx, chatID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	sealed := bytes.Clone(before[0].Content)
+
+	rotated := encryptedmessagerepo.New(inner, keys, newKeyfile(t, "k2", "k1", "k2"))
+	n, err := rotated.RewrapKeys(ctx)
+	if err != nil || n != 1 {
+		t.Fatalf("expected one data key re-wrapped got %d, %v", n, err)
+	}
+	if k, err := keys.GetDataKey(ctx, chatID); err != nil || k.MasterKeyID != "k2" {
+		t.Fatalf("expected the data key wrapped by k2 got %v, %v", k.MasterKeyID, err)
+	}
+	after, err := inner.GetMessages(ctx, chatID)
+	if err != nil || !bytes.Equal(after[0].Content, sealed) {
+		t.Fatalf("expected message bodies to be left as they were got %v", err)
+	}
+
+	retired := encryptedmessagerepo.New(inner, keys, newKeyfile(t, "k2", "k2"))
+	got, err := retired.GetMessages(ctx, chatID)
+	if err != nil || string(got[0].Content) != "before rotation" {
+		t.Fatalf("expected the message readable without the retired key got %v, %v", got, err)
+	}
+}
+
+func TestRepository_DeleteMessagesShredsDataKey(t *testing.T) {
+	ctx := context.Background()
+	inner, keys, chatID, senderID := newStore(t)
+	r := encryptedmessagerepo.New(inner, keys, newKeyfile(t, "k1", "k1"))
+	createMessage(t, r, chatID, senderID, "hello")
+
+	if err := r.DeleteMessages(ctx, chatID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if _, err := keys.GetDataKey(ctx, chatID); !errors.Is(err, repo.ErrDataKeyNotFound) {
+		t.Fatalf("expected %v got %v", repo.ErrDataKeyNotFound, err)
+	}
+}
+
+func TestRepository_EncryptLinkTargets(t *testing.T) {
+	ctx := context.Background()
+	inner, keys, chatID, senderID := newStore(t)
+	r := encryptedmessagerepo.New(inner, keys, newKeyfile(t, "k1", "k1"))
+	entities := []message.Entity{{Type: message.LinkEntity, Offset: 0, Length: 4, URL: "https://example.com/secret"}}
+	id := uuid.New()
+	if err := r.CreateMessage(ctx, repo.CreateMessageInput{
+		ID:          id,
+		SenderID:    senderID,
+		ChatID:      chatID,
+		Content:     []byte("docs"),
+		ContentType: message.RichTextContentType,
+		Timestamp:   time.Now().UTC(),
+		Entities:    entities,
+	}); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	raw, err := inner.GetMessage(ctx, id, chatID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(raw.Entities) != 1 || raw.Entities[0].URL == entities[0].URL {
+		t.Fatalf("expected the stored link target to be encrypted got %v", raw.Entities)
+	}
+	got, err := r.GetMessage(ctx, id, chatID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(got.Entities) != 1 || got.Entities[0] != entities[0] {
+		t.Fatalf("expected the link target decrypted got %v", got.Entities)
+	}
+}
+
+func TestRepository_DeleteExpiredMessages(t *testing.T) {
+	ctx := context.Background()
+	inner, keys, chatID, senderID := newStore(t)
+	r := encryptedmessagerepo.New(inner, keys, newKeyfile(t, "k1", "k1"))
+	now := time.Now().UTC()
+
+	kept := createMessage(t, r, chatID, senderID, "kept")
+	expiredID := uuid.New()
+	if err := r.CreateMessage(ctx, repo.CreateMessageInput{
+		ID:          expiredID,
+		SenderID:    senderID,
+		ChatID:      chatID,
+		Content:     []byte("gone"),
+		ContentType: message.TextContentType,
+		Timestamp:   now.Add(-time.Hour),
+		ExpiresAt:   now,
+	}); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	expired, err := r.DeleteExpiredMessages(ctx, now)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(expired) != 1 || expired[0].ID != expiredID || expired[0].SenderID != senderID {
+		t.Fatalf("expected the expired message deleted got %+v", expired)
+	}
+	left, err := r.GetMessages(ctx, chatID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(left) != 1 || left[0].ID != kept {
+		t.Fatalf("expected only the kept message left got %+v", left)
+	}
+}
+
+func TestRepository_SkipExpiredMessages(t *testing.T) {
+	ctx := context.Background()
+	inner, keys, chatID, senderID := newStore(t)
+	r := encryptedmessagerepo.New(inner, keys, newKeyfile(t, "k1", "k1"))
+	now := time.Now().UTC()
+	readerID := uuid.New()
+
+	for _, in := range []repo.CreateMessageInput{
+		{ID: uuid.New(), Content: []byte("kept"), Timestamp: now.Add(-2 * time.Hour)},
+		{ID: uuid.New(), Content: []byte("gone"), Timestamp: now.Add(-time.Hour), ExpiresAt: now, Mentions: []message.Mention{{UserID: readerID}}},
+	} {
+		in.SenderID, in.ChatID, in.ContentType = senderID, chatID, message.TextContentType
+		if err := r.CreateMessage(ctx, in); err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		last     string
+		mentions int
+	}{
+		{"before expiry", now.Add(-time.Minute), "gone", 1},
+		{"after expiry", now, "kept", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			last, err := r.GetLastMessages(ctx, []uuid.UUID{chatID}, tt.now)
+			if err != nil {
+				t.Fatalf("expected no error got %v", err)
+			}
+			if string(last[chatID].Content) != tt.last {
+				t.Fatalf("expected %q got %q", tt.last, last[chatID].Content)
+			}
+			counts, err := r.CountMentions(ctx, readerID, map[uuid.UUID]time.Time{chatID: {}}, tt.now)
+			if err != nil {
+				t.Fatalf("expected no error got %v", err)
+			}
+			if counts[chatID].Count != tt.mentions {
+				t.Fatalf("expected %v got %v", tt.mentions, counts[chatID].Count)
+			}
+		})
+	}
+}
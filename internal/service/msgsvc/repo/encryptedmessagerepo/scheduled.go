@@ -0,0 +1,123 @@
+package encryptedmessagerepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/AliUnipal/chat/internal/service/msgsvc/repo"
+	"github.com/google/uuid"
+	"time"
+)
+
+// NewScheduled encrypts the content of scheduled messages at rest on top of
+// scheduled, under the data key of the chat they are to be sent to.
+func NewScheduled(scheduled scheduledMessageRepository, messages *repository) *scheduledRepository {
+	return &scheduledRepository{scheduled: scheduled, messages: messages}
+}
+
+type scheduledMessageRepository interface {
+	CreateScheduledMessage(ctx context.Context, m repo.ScheduledMessage) error
+	GetScheduledMessage(ctx context.Context, id uuid.UUID) (repo.ScheduledMessage, error)
+	GetScheduledMessages(ctx context.Context, senderID uuid.UUID) ([]repo.ScheduledMessage, error)
+	GetDueScheduledMessages(ctx context.Context, now time.Time) ([]repo.ScheduledMessage, error)
+	UpdateScheduledMessage(ctx context.Context, m repo.ScheduledMessage) error
+	DeleteScheduledMessage(ctx context.Context, id uuid.UUID) error
+}
+
+type scheduledRepository struct {
+	scheduled scheduledMessageRepository
+	messages  *repository
+}
+
+func (r *scheduledRepository) CreateScheduledMessage(ctx context.Context, m repo.ScheduledMessage) error {
+	m, err := r.seal(ctx, m)
+	if err != nil {
+		return err
+	}
+
+	return r.scheduled.CreateScheduledMessage(ctx, m)
+}
+
+func (r *scheduledRepository) GetScheduledMessage(ctx context.Context, id uuid.UUID) (repo.ScheduledMessage, error) {
+	m, err := r.scheduled.GetScheduledMessage(ctx, id)
+	if err != nil {
+		return repo.ScheduledMessage{}, err
+	}
+
+	return r.open(ctx, m)
+}
+
+func (r *scheduledRepository) GetScheduledMessages(ctx context.Context, senderID uuid.UUID) ([]repo.ScheduledMessage, error) {
+	ms, err := r.scheduled.GetScheduledMessages(ctx, senderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.openAll(ctx, ms)
+}
+
+func (r *scheduledRepository) GetDueScheduledMessages(ctx context.Context, now time.Time) ([]repo.ScheduledMessage, error) {
+	ms, err := r.scheduled.GetDueScheduledMessages(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.openAll(ctx, ms)
+}
+
+func (r *scheduledRepository) UpdateScheduledMessage(ctx context.Context, m repo.ScheduledMessage) error {
+	m, err := r.seal(ctx, m)
+	if err != nil {
+		return err
+	}
+
+	return r.scheduled.UpdateScheduledMessage(ctx, m)
+}
+
+func (r *scheduledRepository) DeleteScheduledMessage(ctx context.Context, id uuid.UUID) error {
+	return r.scheduled.DeleteScheduledMessage(ctx, id)
+}
+
+func (r *scheduledRepository) seal(ctx context.Context, m repo.ScheduledMessage) (repo.ScheduledMessage, error) {
+	gcm, err := r.messages.dataKey(ctx, m.ChatID, true)
+	if err != nil {
+		return repo.ScheduledMessage{}, err
+	}
+	if m.Content, err = seal(gcm, m.Content, additionalData(m.ChatID, m.ID)); err != nil {
+		return repo.ScheduledMessage{}, err
+	}
+
+	return m, nil
+}
+
+// open decrypts a scheduled message. The data key of a chat goes when the
+// chat is deleted, so a message still scheduled for it is returned without
+// content: it can no longer be read, and there is nowhere left to send it.
+func (r *scheduledRepository) open(ctx context.Context, m repo.ScheduledMessage) (repo.ScheduledMessage, error) {
+	gcm, err := r.messages.dataKey(ctx, m.ChatID, false)
+	if errors.Is(err, repo.ErrDataKeyNotFound) {
+		m.Content = nil
+		return m, nil
+	}
+	if err != nil {
+		return repo.ScheduledMessage{}, err
+	}
+	if m.Content, err = unseal(gcm, m.Content, additionalData(m.ChatID, m.ID)); err != nil {
+		return repo.ScheduledMessage{}, fmt.Errorf("decrypting scheduled message %v: %w", m.ID, err)
+	}
+
+	return m, nil
+}
+
+func (r *scheduledRepository) openAll(ctx context.Context, ms []repo.ScheduledMessage) ([]repo.ScheduledMessage, error) {
+	opened := make([]repo.ScheduledMessage, 0, len(ms))
+	for _, m := range ms {
+		m, err := r.open(ctx, m)
+		if err != nil {
+			return nil, err
+		}
+		opened = append(opened, m)
+	}
+
+	return opened, nil
+}
@@ -0,0 +1,93 @@
+package encryptedmessagerepo_test
+
+import (
+	"bytes"
+	"context"
+	"github.com/AliUnipal/chat/internal/models/message"
+	"github.com/AliUnipal/chat/internal/service/msgsvc/repo"
+	"github.com/AliUnipal/chat/internal/service/msgsvc/repo/encryptedmessagerepo"
+	"github.com/AliUnipal/chat/internal/service/msgsvc/repo/filescheduledrepo"
+	"github.com/google/uuid"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestScheduledRepository_EncryptAtRest(t *testing.T) {
+	ctx := context.Background()
+	inner, keys, chatID, senderID := newStore(t)
+	path := filepath.Join(t.TempDir(), "scheduled.json")
+	file, err := filescheduledrepo.New(path)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	r := encryptedmessagerepo.NewScheduled(file, encryptedmessagerepo.New(inner, keys, newKeyfile(t, "k1", "k1")))
+
+	now := time.Now().UTC()
+	m := repo.ScheduledMessage{
+		ID:          uuid.New(),
+		SenderID:    senderID,
+		ChatID:      chatID,
+		Content:     []byte("surprise party at six"),
+		ContentType: message.TextContentType,
+		SendAt:      now.Add(time.Hour),
+		CreatedAt:   now,
+	}
+	if err := r.CreateScheduledMessage(ctx, m); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	m.Content = []byte("surprise party at seven")
+	if err := r.UpdateScheduledMessage(ctx, m); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if bytes.Contains(b, []byte("surprise")) {
+		t.Fatalf("expected the stored content to be encrypted got %s", b)
+	}
+	got, err := r.GetScheduledMessages(ctx, senderID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(got) != 1 || string(got[0].Content) != "surprise party at seven" {
+		t.Fatalf("expected the scheduled message decrypted got %+v", got)
+	}
+	due, err := r.GetDueScheduledMessages(ctx, m.SendAt)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(due) != 1 || string(due[0].Content) != "surprise party at seven" {
+		t.Fatalf("expected the due message decrypted got %+v", due)
+	}
+}
+
+func TestScheduledRepository_DropContentOfDeletedChat(t *testing.T) {
+	ctx := context.Background()
+	inner, keys, chatID, senderID := newStore(t)
+	file, err := filescheduledrepo.New(filepath.Join(t.TempDir(), "scheduled.json"))
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	messages := encryptedmessagerepo.New(inner, keys, newKeyfile(t, "k1", "k1"))
+	r := encryptedmessagerepo.NewScheduled(file, messages)
+
+	id := uuid.New()
+	if err := r.CreateScheduledMessage(ctx, repo.ScheduledMessage{ID: id, SenderID: senderID, ChatID: chatID, Content: []byte("hi"), SendAt: time.Now().Add(time.Hour)}); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if err := messages.DeleteMessages(ctx, chatID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	got, err := r.GetScheduledMessage(ctx, id)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if got.Content != nil {
+		t.Fatalf("expected no content once the chat's data key is gone got %q", got.Content)
+	}
+}
@@ -0,0 +1,184 @@
+// Package filescheduledrepo keeps scheduled messages in a JSON file, so they
+// survive restarts and are sent once the service is back.
+//
+// Every change rewrites the whole file, which suits the few messages users
+// have waiting at any time. The file is replaced atomically, so a crash
+// leaves either the old or the new schedule behind, never half of one.
+//
+// Content is written as given; wrap the repository with
+// encryptedmessagerepo.NewScheduled to keep it encrypted at rest.
+package filescheduledrepo
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/AliUnipal/chat/internal/models/message"
+	"github.com/AliUnipal/chat/internal/service/msgsvc/repo"
+	"github.com/google/uuid"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+// New opens the schedule stored at path, starting an empty one when the file
+// does not exist yet.
+func New(path string) (*repository, error) {
+	r := &repository{path: path, messages: make(map[uuid.UUID]repo.ScheduledMessage)}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return r, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var records []record
+	if err := json.Unmarshal(b, &records); err != nil {
+		return nil, fmt.Errorf("scheduled messages file is malformed: %w", err)
+	}
+	for _, rec := range records {
+		r.messages[rec.ID] = repo.ScheduledMessage(rec)
+	}
+
+	return r, nil
+}
+
+// record is how a scheduled message is stored in the file.
+type record struct {
+	ID          uuid.UUID           `json:"id"`
+	SenderID    uuid.UUID           `json:"sender_id"`
+	ChatID      uuid.UUID           `json:"chat_id"`
+	Content     []byte              `json:"content"`
+	ContentType message.ContentType `json:"content_type"`
+	SendAt      time.Time           `json:"send_at"`
+	CreatedAt   time.Time           `json:"created_at"`
+}
+
+// repository is safe for concurrent use, as messages are scheduled by users
+// while the scheduler sends those that are due.
+type repository struct {
+	path string
+
+	mu       sync.RWMutex
+	messages map[uuid.UUID]repo.ScheduledMessage
+}
+
+func (r *repository) CreateScheduledMessage(_ context.Context, m repo.ScheduledMessage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.save(func(ms map[uuid.UUID]repo.ScheduledMessage) { ms[m.ID] = m })
+}
+
+func (r *repository) GetScheduledMessage(_ context.Context, id uuid.UUID) (repo.ScheduledMessage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	m, ok := r.messages[id]
+	if !ok {
+		return repo.ScheduledMessage{}, repo.ErrScheduledMessageNotFound
+	}
+	return m, nil
+}
+
+// GetScheduledMessages returns what senderID has scheduled, soonest first.
+func (r *repository) GetScheduledMessages(_ context.Context, senderID uuid.UUID) ([]repo.ScheduledMessage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var ms []repo.ScheduledMessage
+	for _, m := range r.messages {
+		if m.SenderID == senderID {
+			ms = append(ms, m)
+		}
+	}
+	return sorted(ms), nil
+}
+
+// GetDueScheduledMessages returns the messages to be sent at or before now,
+// soonest first.
+func (r *repository) GetDueScheduledMessages(_ context.Context, now time.Time) ([]repo.ScheduledMessage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var ms []repo.ScheduledMessage
+	for _, m := range r.messages {
+		if !m.SendAt.After(now) {
+			ms = append(ms, m)
+		}
+	}
+	return sorted(ms), nil
+}
+
+func (r *repository) UpdateScheduledMessage(_ context.Context, m repo.ScheduledMessage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.messages[m.ID]; !ok {
+		return repo.ErrScheduledMessageNotFound
+	}
+	return r.save(func(ms map[uuid.UUID]repo.ScheduledMessage) { ms[m.ID] = m })
+}
+
+func (r *repository) DeleteScheduledMessage(_ context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.messages[id]; !ok {
+		return repo.ErrScheduledMessageNotFound
+	}
+	return r.save(func(ms map[uuid.UUID]repo.ScheduledMessage) { delete(ms, id) })
+}
+
+// save applies change to a copy of the messages and writes it out, only
+// keeping it once it is on disk. The caller holds the write lock.
+func (r *repository) save(change func(map[uuid.UUID]repo.ScheduledMessage)) error {
+	messages := maps.Clone(r.messages)
+	change(messages)
+
+	records := make([]record, 0, len(messages))
+	for _, m := range sorted(slices.Collect(maps.Values(messages))) {
+		records = append(records, record(m))
+	}
+	b, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(r.path), filepath.Base(r.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(f.Name(), r.path); err != nil {
+		return err
+	}
+
+	r.messages = messages
+	return nil
+}
+
+func sorted(ms []repo.ScheduledMessage) []repo.ScheduledMessage {
+	slices.SortFunc(ms, func(a, b repo.ScheduledMessage) int {
+		return cmp.Or(a.SendAt.Compare(b.SendAt), a.CreatedAt.Compare(b.CreatedAt))
+	})
+	return ms
+}
@@ -0,0 +1,89 @@
+package filescheduledrepo_test
+
+import (
+	"context"
+	"errors"
+	"github.com/AliUnipal/chat/internal/models/message"
+	"github.com/AliUnipal/chat/internal/service/msgsvc/repo"
+	"github.com/AliUnipal/chat/internal/service/msgsvc/repo/filescheduledrepo"
+	"github.com/google/uuid"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRepository_SurviveReopen(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "scheduled.json")
+	senderID := uuid.New()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	later := repo.ScheduledMessage{ID: uuid.New(), SenderID: senderID, ChatID: uuid.New(), Content: []byte("later"), ContentType: message.TextContentType, SendAt: now.Add(time.Hour), CreatedAt: now}
+	sooner := repo.ScheduledMessage{ID: uuid.New(), SenderID: senderID, ChatID: uuid.New(), Content: []byte("sooner"), ContentType: message.TextContentType, SendAt: now.Add(time.Minute), CreatedAt: now}
+	cancelled := repo.ScheduledMessage{ID: uuid.New(), SenderID: senderID, ChatID: uuid.New(), Content: []byte("cancelled"), ContentType: message.TextContentType, SendAt: now.Add(time.Minute), CreatedAt: now}
+
+	r, err := filescheduledrepo.New(path)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	for _, m := range []repo.ScheduledMessage{later, sooner, cancelled} {
+		if err := r.CreateScheduledMessage(ctx, m); err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+	}
+	if err := r.DeleteScheduledMessage(ctx, cancelled.ID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	reopened, err := filescheduledrepo.New(path)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	ms, err := reopened.GetScheduledMessages(ctx, senderID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(ms) != 2 || ms[0].ID != sooner.ID || ms[1].ID != later.ID {
+		t.Fatalf("expected the sooner then the later message got %+v", ms)
+	}
+	if string(ms[1].Content) != "later" || !ms[1].SendAt.Equal(later.SendAt) || ms[1].ChatID != later.ChatID {
+		t.Fatalf("expected %+v got %+v", later, ms[1])
+	}
+
+	due, err := reopened.GetDueScheduledMessages(ctx, now.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(due) != 1 || due[0].ID != sooner.ID {
+		t.Fatalf("expected only the sooner message to be due got %+v", due)
+	}
+}
+
+func TestRepository_ReturnErrorOnUnknownMessage(t *testing.T) {
+	ctx := context.Background()
+	r, err := filescheduledrepo.New(filepath.Join(t.TempDir(), "scheduled.json"))
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	if _, err := r.GetScheduledMessage(ctx, uuid.New()); !errors.Is(err, repo.ErrScheduledMessageNotFound) {
+		t.Fatalf("expected %v got %v", repo.ErrScheduledMessageNotFound, err)
+	}
+	if err := r.UpdateScheduledMessage(ctx, repo.ScheduledMessage{ID: uuid.New()}); !errors.Is(err, repo.ErrScheduledMessageNotFound) {
+		t.Fatalf("expected %v got %v", repo.ErrScheduledMessageNotFound, err)
+	}
+	if err := r.DeleteScheduledMessage(ctx, uuid.New()); !errors.Is(err, repo.ErrScheduledMessageNotFound) {
+		t.Fatalf("expected %v got %v", repo.ErrScheduledMessageNotFound, err)
+	}
+}
+
+func TestNew_ReturnErrorOnMalformedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scheduled.json")
+	if err := os.WriteFile(path, []byte("{"), 0o600); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	if _, err := filescheduledrepo.New(path); err == nil {
+		t.Fatalf("expected an error got nil")
+	}
+}
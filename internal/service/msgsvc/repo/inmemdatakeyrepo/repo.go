@@ -0,0 +1,59 @@
+// Package inmemdatakeyrepo keeps the wrapped data keys of
+// encryptedmessagerepo apart from the messages they seal, so the two can be
+// stored and backed up separately.
+package inmemdatakeyrepo
+
+import (
+	"context"
+	"github.com/AliUnipal/chat/internal/service/msgsvc/repo"
+	"github.com/google/uuid"
+	"maps"
+	"slices"
+	"sync"
+)
+
+func New() *repository {
+	return &repository{dataKeys: make(map[uuid.UUID]repo.DataKey)}
+}
+
+// repository is safe for concurrent use, as data keys are looked up by every
+// service reading messages.
+type repository struct {
+	mu       sync.RWMutex
+	dataKeys map[uuid.UUID]repo.DataKey
+}
+
+func (r *repository) GetDataKey(_ context.Context, chatID uuid.UUID) (repo.DataKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	k, ok := r.dataKeys[chatID]
+	if !ok {
+		return repo.DataKey{}, repo.ErrDataKeyNotFound
+	}
+
+	return k, nil
+}
+
+func (r *repository) GetDataKeys(_ context.Context) ([]repo.DataKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Collect(maps.Values(r.dataKeys)), nil
+}
+
+func (r *repository) SaveDataKey(_ context.Context, key repo.DataKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.dataKeys[key.ChatID] = key
+	return nil
+}
+
+func (r *repository) DeleteDataKey(_ context.Context, chatID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.dataKeys, chatID)
+	return nil
+}
@@ -2,13 +2,20 @@ package inmemmessagerepo
 
 import (
 	"context"
-	"errors"
+	"github.com/AliUnipal/chat/internal/models/message"
 	chatrepo "github.com/AliUnipal/chat/internal/service/chatsvc/repo"
 	"github.com/AliUnipal/chat/internal/service/msgsvc/repo"
 	"github.com/google/uuid"
+	"slices"
+	"sync"
+	"time"
 )
 
 func New(chatRepo chatRepository, msgs map[uuid.UUID][]repo.Message) *repository {
+	if msgs == nil {
+		msgs = make(map[uuid.UUID][]repo.Message)
+	}
+
 	return &repository{
 		messages: msgs,
 		chatRepo: chatRepo,
@@ -19,7 +26,10 @@ type chatRepository interface {
 	GetChat(ctx context.Context, id uuid.UUID) (chatrepo.Chat, error)
 }
 
+// repository is safe for concurrent use, as link previews are attached to
+// messages and expired messages deleted in the background.
 type repository struct {
+	mu       sync.RWMutex
 	messages map[uuid.UUID][]repo.Message
 	chatRepo chatRepository
 }
@@ -29,27 +39,248 @@ func (r *repository) CreateMessage(ctx context.Context, in repo.CreateMessageInp
 	if err != nil {
 		return err
 	}
-	if c.CurrentUser.ID != in.SenderID || c.OtherUser.ID != in.SenderID {
-		return errors.New("user does not belong to this chat")
+	if !slices.ContainsFunc(c.Participants, func(u chatrepo.User) bool { return u.ID == in.SenderID }) {
+		return chatrepo.ErrMemberNotFound
 	}
 
-	r.messages[in.ChatID] = append(r.messages[in.ChatID], repo.Message{
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// Messages are kept oldest first. New messages go last, while imported
+	// history is slotted in before whatever was sent after it.
+	msgs := r.messages[in.ChatID]
+	i := len(msgs)
+	for i > 0 && msgs[i-1].Timestamp.After(in.Timestamp) {
+		i--
+	}
+	r.messages[in.ChatID] = slices.Insert(msgs, i, repo.Message{
 		ID:          in.ID,
 		SenderID:    in.SenderID,
 		ChatID:      in.ChatID,
 		Content:     in.Content,
 		ContentType: in.ContentType,
 		Timestamp:   in.Timestamp,
+		Quarantined: in.Quarantined,
+		Mentions:    in.Mentions,
+		Entities:    in.Entities,
+		ExpiresAt:   in.ExpiresAt,
+		Imported:    in.Imported,
 	})
 
 	return nil
 }
 
-func (r *repository) GetMessages(_ context.Context, chatID uuid.UUID) ([]repo.Message, error) {
+func (r *repository) GetMessage(ctx context.Context, id, chatID uuid.UUID) (repo.Message, error) {
+	msgs, err := r.GetMessages(ctx, chatID)
+	if err != nil {
+		return repo.Message{}, err
+	}
+	for _, m := range msgs {
+		if m.ID == id {
+			return m, nil
+		}
+	}
+
+	return repo.Message{}, repo.ErrMessageNotFound
+}
+
+// GetMessages returns the chat's messages, oldest first.
+func (r *repository) GetMessages(ctx context.Context, chatID uuid.UUID) ([]repo.Message, error) {
+	r.mu.RLock()
 	msgs, ok := r.messages[chatID]
+	msgs = slices.Clone(msgs)
+	r.mu.RUnlock()
 	if !ok {
-		return nil, errors.New("chat does not exist")
+		if _, err := r.chatRepo.GetChat(ctx, chatID); err != nil {
+			return nil, err
+		}
 	}
 
 	return msgs, nil
 }
+
+// GetLastMessages returns the latest message of each of the given chats,
+// omitting chats that have none. Quarantined messages and those expired by
+// now are skipped.
+func (r *repository) GetLastMessages(_ context.Context, chatIDs []uuid.UUID, now time.Time) (map[uuid.UUID]repo.Message, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	last := make(map[uuid.UUID]repo.Message, len(chatIDs))
+	for _, id := range chatIDs {
+		msgs := r.messages[id]
+		for i := len(msgs) - 1; i >= 0; i-- {
+			if !msgs[i].Quarantined && !expired(msgs[i], now) {
+				last[id] = msgs[i]
+				break
+			}
+		}
+	}
+
+	return last, nil
+}
+
+// GetMentions returns the delivered messages mentioning userID in the given
+// chats that were sent after the chat's time in since, newest first. The
+// user's own messages are left out.
+func (r *repository) GetMentions(_ context.Context, userID uuid.UUID, since map[uuid.UUID]time.Time) ([]repo.Message, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var ms []repo.Message
+	for chatID, t := range since {
+		for _, m := range r.messages[chatID] {
+			if mentions(m, userID) && m.Timestamp.After(t) {
+				ms = append(ms, m)
+			}
+		}
+	}
+	slices.SortFunc(ms, func(a, b repo.Message) int { return b.Timestamp.Compare(a.Timestamp) })
+
+	return ms, nil
+}
+
+// CountMentions counts what GetMentions would return per chat, leaving out
+// messages expired by now and omitting chats without mentions.
+func (r *repository) CountMentions(_ context.Context, userID uuid.UUID, since map[uuid.UUID]time.Time, now time.Time) (map[uuid.UUID]repo.MentionCount, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	counts := make(map[uuid.UUID]repo.MentionCount)
+	for chatID, t := range since {
+		for _, m := range r.messages[chatID] {
+			if mentions(m, userID) && m.Timestamp.After(t) && !expired(m, now) {
+				c := counts[chatID]
+				c.Count++
+				counts[chatID] = c
+			}
+		}
+	}
+
+	return counts, nil
+}
+
+// SetPreviews replaces the link previews of a message.
+func (r *repository) SetPreviews(_ context.Context, chatID, id uuid.UUID, previews []message.Preview) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	msgs := r.messages[chatID]
+	i := slices.IndexFunc(msgs, func(m repo.Message) bool { return m.ID == id })
+	if i < 0 {
+		return repo.ErrMessageNotFound
+	}
+	msgs[i].Previews = previews
+
+	return nil
+}
+
+// ReleaseMessage delivers a quarantined message, returning
+// repo.ErrNotQuarantined when it is not held back.
+func (r *repository) ReleaseMessage(_ context.Context, chatID, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	msgs := r.messages[chatID]
+	i := slices.IndexFunc(msgs, func(m repo.Message) bool { return m.ID == id })
+	if i < 0 {
+		return repo.ErrMessageNotFound
+	}
+	if !msgs[i].Quarantined {
+		return repo.ErrNotQuarantined
+	}
+	msgs[i].Quarantined = false
+
+	return nil
+}
+
+func (r *repository) DeleteMessage(_ context.Context, chatID, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	msgs := r.messages[chatID]
+	i := slices.IndexFunc(msgs, func(m repo.Message) bool { return m.ID == id })
+	if i < 0 {
+		return repo.ErrMessageNotFound
+	}
+	r.messages[chatID] = slices.Delete(msgs, i, i+1)
+
+	return nil
+}
+
+// DeleteMessagesBySender purges everything senderID sent to the chat.
+func (r *repository) DeleteMessagesBySender(_ context.Context, chatID, senderID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	msgs := slices.DeleteFunc(r.messages[chatID], func(m repo.Message) bool {
+		return m.SenderID == senderID
+	})
+	if len(msgs) == 0 {
+		delete(r.messages, chatID)
+	} else {
+		r.messages[chatID] = msgs
+	}
+
+	return nil
+}
+
+func (r *repository) DeleteMessages(_ context.Context, chatID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.messages, chatID)
+	return nil
+}
+
+// DeleteMessagesBefore purges the messages of the chat sent up to and
+// including before.
+func (r *repository) DeleteMessagesBefore(_ context.Context, chatID uuid.UUID, before time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	msgs := slices.DeleteFunc(r.messages[chatID], func(m repo.Message) bool {
+		return !m.Timestamp.After(before)
+	})
+	if len(msgs) == 0 {
+		delete(r.messages, chatID)
+	} else {
+		r.messages[chatID] = msgs
+	}
+
+	return nil
+}
+
+// DeleteExpiredMessages purges the messages of every chat that expire at or
+// before now, returning what it purged.
+func (r *repository) DeleteExpiredMessages(_ context.Context, now time.Time) ([]repo.Message, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var purged []repo.Message
+	for chatID, msgs := range r.messages {
+		msgs = slices.DeleteFunc(msgs, func(m repo.Message) bool {
+			if !expired(m, now) {
+				return false
+			}
+			purged = append(purged, m)
+			return true
+		})
+		if len(msgs) == 0 {
+			delete(r.messages, chatID)
+		} else {
+			r.messages[chatID] = msgs
+		}
+	}
+
+	return purged, nil
+}
+
+// expired reports whether a disappearing message's time is up, even if it
+// has not been purged yet.
+func expired(m repo.Message, now time.Time) bool {
+	return !m.ExpiresAt.IsZero() && !m.ExpiresAt.After(now)
+}
+
+func mentions(m repo.Message, userID uuid.UUID) bool {
+	return m.SenderID != userID && !m.Quarantined && message.Mentioned(m.Mentions, userID)
+}
@@ -1,11 +1,19 @@
 package repo
 
 import (
+	"errors"
 	"github.com/AliUnipal/chat/internal/models/message"
 	"github.com/google/uuid"
 	"time"
 )
 
+var (
+	ErrMessageNotFound          = errors.New("message does not exist")
+	ErrNotQuarantined           = errors.New("message is not quarantined")
+	ErrDataKeyNotFound          = errors.New("chat has no data key")
+	ErrScheduledMessageNotFound = errors.New("scheduled message does not exist")
+)
+
 type Message struct {
 	ID          uuid.UUID
 	SenderID    uuid.UUID
@@ -13,6 +21,20 @@ type Message struct {
 	Content     []byte
 	ContentType message.ContentType
 	Timestamp   time.Time
+	// Quarantined messages are held back from everyone but their sender
+	// until a moderator reviews them.
+	Quarantined bool
+	Mentions    []message.Mention
+	Entities    []message.Entity
+	Previews    []message.Preview
+	// ExpiresAt is zero unless the message disappears.
+	ExpiresAt time.Time
+	Imported  bool
+}
+
+// MentionCount sums up the mentions of a user in a chat.
+type MentionCount struct {
+	Count int
 }
 
 type CreateMessageInput struct {
@@ -22,4 +44,28 @@ type CreateMessageInput struct {
 	Content     []byte
 	ContentType message.ContentType
 	Timestamp   time.Time
+	Quarantined bool
+	Mentions    []message.Mention
+	Entities    []message.Entity
+	ExpiresAt   time.Time
+	Imported    bool
+}
+
+// DataKey is a chat's message encryption key, wrapped by the master key
+// MasterKeyID.
+type DataKey struct {
+	ChatID      uuid.UUID
+	MasterKeyID string
+	WrappedKey  []byte
+}
+
+// ScheduledMessage is a message waiting to be sent at SendAt.
+type ScheduledMessage struct {
+	ID          uuid.UUID
+	SenderID    uuid.UUID
+	ChatID      uuid.UUID
+	Content     []byte
+	ContentType message.ContentType
+	SendAt      time.Time
+	CreatedAt   time.Time
 }
@@ -0,0 +1,216 @@
+package msgsvc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/AliUnipal/chat/internal/models/message"
+	"github.com/AliUnipal/chat/internal/ratelimit"
+	"github.com/AliUnipal/chat/internal/service/msgsvc/repo"
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	// maxScheduled bounds how many messages a user can have waiting.
+	maxScheduled = 100
+	// maxScheduleAhead is how far in the future a message can be scheduled.
+	maxScheduleAhead = 365 * 24 * time.Hour
+)
+
+var (
+	ErrInvalidSendAt    = errors.New("scheduled messages must be sent in the future, within a year")
+	ErrTooManyScheduled = errors.New("too many scheduled messages")
+)
+
+// ScheduleMessage saves a message to be sent to the chat at sendAt. The
+// sender must be able to message the chat now; everything else CreateMessage
+// checks is checked when the message is sent.
+func (s *service) ScheduleMessage(ctx context.Context, in MessageInput, sendAt time.Time) (uuid.UUID, error) {
+	if len(in.Content) == 0 {
+		return uuid.Nil, errors.New("content is empty")
+	}
+	if err := checkLength(in.Content, in.ContentType); err != nil {
+		return uuid.Nil, err
+	}
+	now := s.clock().UTC()
+	if err := checkSendAt(sendAt, now); err != nil {
+		return uuid.Nil, err
+	}
+	c, err := s.authorizeSender(ctx, in.ChatID, in.SenderID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if c.Encrypted != (in.ContentType == message.EncryptedContentType) {
+		return uuid.Nil, ErrEncryptionMismatch
+	}
+	scheduled, err := s.scheduled.GetScheduledMessages(ctx, in.SenderID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if len(scheduled) >= maxScheduled {
+		return uuid.Nil, ErrTooManyScheduled
+	}
+
+	id := uuid.New()
+	if err := s.scheduled.CreateScheduledMessage(ctx, repo.ScheduledMessage{
+		ID:          id,
+		SenderID:    in.SenderID,
+		ChatID:      in.ChatID,
+		Content:     in.Content,
+		ContentType: in.ContentType,
+		SendAt:      sendAt.UTC(),
+		CreatedAt:   now,
+	}); err != nil {
+		return uuid.Nil, err
+	}
+
+	return id, nil
+}
+
+// GetScheduledMessages returns the messages userID has waiting, soonest
+// first.
+func (s *service) GetScheduledMessages(ctx context.Context, userID uuid.UUID) ([]message.ScheduledMessage, error) {
+	ms, err := s.scheduled.GetScheduledMessages(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	r := make([]message.ScheduledMessage, len(ms))
+	for i, m := range ms {
+		r[i] = message.ScheduledMessage(m)
+	}
+	return r, nil
+}
+
+// EditScheduledMessage replaces the content and send time of one of the
+// user's scheduled messages.
+func (s *service) EditScheduledMessage(ctx context.Context, userID, id uuid.UUID, content []byte, sendAt time.Time) error {
+	if len(content) == 0 {
+		return errors.New("content is empty")
+	}
+	if err := checkSendAt(sendAt, s.clock().UTC()); err != nil {
+		return err
+	}
+	m, err := s.getScheduledMessage(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if err := checkLength(content, m.ContentType); err != nil {
+		return err
+	}
+
+	m.Content = content
+	m.SendAt = sendAt.UTC()
+	return s.scheduled.UpdateScheduledMessage(ctx, m)
+}
+
+// CancelScheduledMessage drops one of the user's scheduled messages.
+func (s *service) CancelScheduledMessage(ctx context.Context, userID, id uuid.UUID) error {
+	if _, err := s.getScheduledMessage(ctx, userID, id); err != nil {
+		return err
+	}
+
+	return s.scheduled.DeleteScheduledMessage(ctx, id)
+}
+
+// CancelScheduledMessages drops every message userID has waiting, such as
+// when their account is deleted.
+func (s *service) CancelScheduledMessages(ctx context.Context, userID uuid.UUID) error {
+	ms, err := s.scheduled.GetScheduledMessages(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, m := range ms {
+		// One the scheduler has just sent is gone already.
+		if err := s.scheduled.DeleteScheduledMessage(ctx, m.ID); err != nil && !errors.Is(err, repo.ErrScheduledMessageNotFound) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SendScheduledMessages sends the scheduled messages that are due through
+// CreateMessage, so they are authorized, moderated and announced like any
+// other message. A message held up by a rate limit is retried once the limit
+// allows; one that cannot be sent at all is dropped, and its sender told why.
+// Each message is taken off the schedule before it is sent, so a failure can
+// drop it but never send it twice.
+func (s *service) SendScheduledMessages(ctx context.Context) error {
+	s.sending.Lock()
+	defer s.sending.Unlock()
+
+	now := s.clock().UTC()
+	due, err := s.scheduled.GetDueScheduledMessages(ctx, now)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, m := range due {
+		errs = append(errs, s.sendScheduled(ctx, m, now))
+	}
+
+	return errors.Join(errs...)
+}
+
+// RunScheduler sends the scheduled messages that are due every interval until
+// ctx is done.
+func (s *service) RunScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = s.SendScheduledMessages(ctx)
+		}
+	}
+}
+
+func (s *service) sendScheduled(ctx context.Context, m repo.ScheduledMessage, now time.Time) error {
+	// One cancelled since it was found due is not sent.
+	if err := s.scheduled.DeleteScheduledMessage(ctx, m.ID); errors.Is(err, repo.ErrScheduledMessageNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	_, err := s.CreateMessage(ctx, MessageInput{
+		SenderID:    m.SenderID,
+		ChatID:      m.ChatID,
+		Content:     m.Content,
+		ContentType: m.ContentType,
+	})
+	if limited := (*ratelimit.Error)(nil); errors.As(err, &limited) {
+		m.SendAt = now.Add(limited.RetryAfter)
+		return s.scheduled.CreateScheduledMessage(ctx, m)
+	}
+	if err != nil {
+		s.reply(ctx, m.ChatID, m.SenderID, fmt.Sprintf("Your scheduled message could not be sent: %v.", err))
+	}
+
+	return nil
+}
+
+// getScheduledMessage returns the scheduled message, as long as userID
+// scheduled it.
+func (s *service) getScheduledMessage(ctx context.Context, userID, id uuid.UUID) (repo.ScheduledMessage, error) {
+	m, err := s.scheduled.GetScheduledMessage(ctx, id)
+	if err != nil {
+		return repo.ScheduledMessage{}, err
+	}
+	if m.SenderID != userID {
+		return repo.ScheduledMessage{}, repo.ErrScheduledMessageNotFound
+	}
+
+	return m, nil
+}
+
+func checkSendAt(sendAt, now time.Time) error {
+	if !sendAt.After(now) || sendAt.Sub(now) > maxScheduleAhead {
+		return ErrInvalidSendAt
+	}
+	return nil
+}
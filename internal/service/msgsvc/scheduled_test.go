@@ -0,0 +1,192 @@
+package msgsvc_test
+
+import (
+	"context"
+	"errors"
+	"github.com/AliUnipal/chat/internal/commands"
+	"github.com/AliUnipal/chat/internal/events"
+	"github.com/AliUnipal/chat/internal/models/message"
+	"github.com/AliUnipal/chat/internal/moderation"
+	"github.com/AliUnipal/chat/internal/ratelimit"
+	chatrepo "github.com/AliUnipal/chat/internal/service/chatsvc/repo"
+	"github.com/AliUnipal/chat/internal/service/msgsvc"
+	"github.com/AliUnipal/chat/internal/service/msgsvc/mocks"
+	"github.com/AliUnipal/chat/internal/service/msgsvc/repo"
+	"github.com/AliUnipal/chat/internal/service/usersvc"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestScheduleMessage_ReturnErrorOnInvalidSendAt(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		sendAt time.Time
+	}{
+		{name: "now", sendAt: now},
+		{name: "past", sendAt: now.Add(-time.Minute)},
+		{name: "too far ahead", sendAt: now.AddDate(1, 0, 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := msgsvc.NewService(mocks.NewMessageRepository(t), mocks.NewChatRepository(t), mocks.NewUserService(t), mocks.NewRateLimiter(t), mocks.NewModerator(t), events.NewBus(), commands.NewRegistry(commands.NewMemoryStore()), mocks.NewScheduledRepository(t), func() time.Time { return now })
+			_, err := service.ScheduleMessage(context.Background(), msgsvc.MessageInput{SenderID: uuid.New(), ChatID: uuid.New(), Content: []byte("hi")}, tt.sendAt)
+			if !errors.Is(err, msgsvc.ErrInvalidSendAt) {
+				t.Fatalf("expected %v got %v", msgsvc.ErrInvalidSendAt, err)
+			}
+		})
+	}
+}
+
+func TestScheduleMessage_ReturnErrorWhenBlocked(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	recipientID := uuid.New()
+	input := msgsvc.MessageInput{SenderID: uuid.New(), ChatID: uuid.New(), Content: []byte("hi")}
+
+	mockChatRepo := mocks.NewChatRepository(t)
+	mockUserService := mocks.NewUserService(t)
+	mockChatRepo.EXPECT().GetMember(mock.Anything, input.ChatID, input.SenderID).Return(chatrepo.Member{ChatID: input.ChatID, UserID: input.SenderID}, nil)
+	mockChatRepo.EXPECT().GetChat(mock.Anything, input.ChatID).Return(chatrepo.Chat{ID: input.ChatID, Participants: []chatrepo.User{{ID: input.SenderID}, {ID: recipientID}}}, nil)
+	mockUserService.EXPECT().CanMessage(mock.Anything, input.SenderID, recipientID).Return(usersvc.ErrBlocked)
+
+	service := msgsvc.NewService(mocks.NewMessageRepository(t), mockChatRepo, mockUserService, mocks.NewRateLimiter(t), mocks.NewModerator(t), events.NewBus(), commands.NewRegistry(commands.NewMemoryStore()), mocks.NewScheduledRepository(t), func() time.Time { return now })
+	if _, err := service.ScheduleMessage(ctx, input, now.Add(time.Hour)); !errors.Is(err, usersvc.ErrBlocked) {
+		t.Fatalf("expected %v got %v", usersvc.ErrBlocked, err)
+	}
+}
+
+func TestEditScheduledMessage_ReturnErrorOnOthersMessage(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	scheduled := repo.ScheduledMessage{ID: uuid.New(), SenderID: uuid.New(), ChatID: uuid.New(), Content: []byte("hi"), SendAt: now.Add(time.Hour)}
+
+	mockScheduled := mocks.NewScheduledRepository(t)
+	mockScheduled.EXPECT().GetScheduledMessage(mock.Anything, scheduled.ID).Return(scheduled, nil)
+
+	service := msgsvc.NewService(mocks.NewMessageRepository(t), mocks.NewChatRepository(t), mocks.NewUserService(t), mocks.NewRateLimiter(t), mocks.NewModerator(t), events.NewBus(), commands.NewRegistry(commands.NewMemoryStore()), mockScheduled, func() time.Time { return now })
+	if err := service.EditScheduledMessage(ctx, uuid.New(), scheduled.ID, []byte("changed"), now.Add(2*time.Hour)); !errors.Is(err, repo.ErrScheduledMessageNotFound) {
+		t.Fatalf("expected %v got %v", repo.ErrScheduledMessageNotFound, err)
+	}
+	if err := service.CancelScheduledMessage(ctx, uuid.New(), scheduled.ID); !errors.Is(err, repo.ErrScheduledMessageNotFound) {
+		t.Fatalf("expected %v got %v", repo.ErrScheduledMessageNotFound, err)
+	}
+}
+
+func TestSendScheduledMessages(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	recipientID := uuid.New()
+	scheduled := repo.ScheduledMessage{
+		ID:          uuid.New(),
+		SenderID:    uuid.New(),
+		ChatID:      uuid.New(),
+		Content:     []byte("good morning"),
+		ContentType: message.TextContentType,
+		SendAt:      now.Add(-time.Second),
+		CreatedAt:   now.Add(-time.Hour),
+	}
+
+	tests := []struct {
+		name     string
+		allowErr error
+		expect   func(r *mocks.MessageRepository, s *mocks.ScheduledRepository, m *mocks.Moderator)
+		replied  bool
+	}{
+		{
+			name: "sent",
+			expect: func(r *mocks.MessageRepository, s *mocks.ScheduledRepository, m *mocks.Moderator) {
+				m.EXPECT().Moderate(mock.Anything, mock.Anything).Return(moderation.Decision{}, nil)
+				claimed := s.EXPECT().DeleteScheduledMessage(mock.Anything, scheduled.ID).Return(nil).Call
+				r.EXPECT().CreateMessage(mock.Anything, mock.MatchedBy(func(in repo.CreateMessageInput) bool {
+					return in.SenderID == scheduled.SenderID && string(in.Content) == "good morning" && in.Timestamp.Equal(now)
+				})).Return(nil).NotBefore(claimed)
+			},
+		},
+		{
+			name:     "rate limited",
+			allowErr: &ratelimit.Error{Scope: "sender", RetryAfter: time.Minute},
+			expect: func(_ *mocks.MessageRepository, s *mocks.ScheduledRepository, _ *mocks.Moderator) {
+				claimed := s.EXPECT().DeleteScheduledMessage(mock.Anything, scheduled.ID).Return(nil).Call
+				s.EXPECT().CreateScheduledMessage(mock.Anything, mock.MatchedBy(func(m repo.ScheduledMessage) bool {
+					return m.ID == scheduled.ID && m.SendAt.Equal(now.Add(time.Minute))
+				})).Return(nil).NotBefore(claimed)
+			},
+		},
+		{
+			name:     "rejected",
+			allowErr: errors.New("no"),
+			expect: func(_ *mocks.MessageRepository, s *mocks.ScheduledRepository, _ *mocks.Moderator) {
+				s.EXPECT().DeleteScheduledMessage(mock.Anything, scheduled.ID).Return(nil)
+			},
+			replied: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMessageRepository(t)
+			mockChatRepo := mocks.NewChatRepository(t)
+			mockUserService := mocks.NewUserService(t)
+			mockLimiter := mocks.NewRateLimiter(t)
+			mockModerator := mocks.NewModerator(t)
+			mockScheduled := mocks.NewScheduledRepository(t)
+			mockScheduled.EXPECT().GetDueScheduledMessages(mock.Anything, now).Return([]repo.ScheduledMessage{scheduled}, nil)
+			mockChatRepo.EXPECT().GetMember(mock.Anything, scheduled.ChatID, scheduled.SenderID).Return(chatrepo.Member{ChatID: scheduled.ChatID, UserID: scheduled.SenderID}, nil)
+			mockChatRepo.EXPECT().GetChat(mock.Anything, scheduled.ChatID).Return(chatrepo.Chat{ID: scheduled.ChatID, Participants: []chatrepo.User{{ID: scheduled.SenderID}, {ID: recipientID}}}, nil)
+			// Only sent messages look up who muted the chat.
+			mockChatRepo.EXPECT().GetMembers(mock.Anything, scheduled.ChatID).Return(nil, nil).Maybe()
+			mockUserService.EXPECT().CanMessage(mock.Anything, scheduled.SenderID, recipientID).Return(nil)
+			mockLimiter.EXPECT().Allow(mock.Anything, scheduled.SenderID, scheduled.ChatID).Return(tt.allowErr)
+			tt.expect(mockRepo, mockScheduled, mockModerator)
+
+			bus := events.NewBus()
+			var replies []events.Event
+			bus.Subscribe(func(_ context.Context, e events.Event) {
+				if e.Type == events.EphemeralMessage {
+					replies = append(replies, e)
+				}
+			})
+			service := msgsvc.NewService(mockRepo, mockChatRepo, mockUserService, mockLimiter, mockModerator, bus, commands.NewRegistry(commands.NewMemoryStore()), mockScheduled, func() time.Time { return now })
+			if err := service.SendScheduledMessages(ctx); err != nil {
+				t.Fatalf("expected no error got %v", err)
+			}
+			if (len(replies) == 1) != tt.replied {
+				t.Fatalf("expected a reply to the sender: %v got %+v", tt.replied, replies)
+			}
+			if tt.replied && replies[0].Recipients[0] != scheduled.SenderID {
+				t.Fatalf("expected the reply to go to %v got %+v", scheduled.SenderID, replies[0])
+			}
+		})
+	}
+}
+
+func TestSendScheduledMessages_SendOnlyOnceClaimed(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	scheduled := repo.ScheduledMessage{ID: uuid.New(), SenderID: uuid.New(), ChatID: uuid.New(), Content: []byte("good morning"), SendAt: now.Add(-time.Second)}
+	unavailable := errors.New("unavailable")
+
+	tests := []struct {
+		name      string
+		deleteErr error
+		err       error
+	}{
+		{name: "cancelled meanwhile", deleteErr: repo.ErrScheduledMessageNotFound},
+		{name: "claim failed", deleteErr: unavailable, err: unavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockScheduled := mocks.NewScheduledRepository(t)
+			mockScheduled.EXPECT().GetDueScheduledMessages(mock.Anything, now).Return([]repo.ScheduledMessage{scheduled}, nil)
+			mockScheduled.EXPECT().DeleteScheduledMessage(mock.Anything, scheduled.ID).Return(tt.deleteErr)
+
+			// Nothing else is expected, so the message is not sent.
+			service := msgsvc.NewService(mocks.NewMessageRepository(t), mocks.NewChatRepository(t), mocks.NewUserService(t), mocks.NewRateLimiter(t), mocks.NewModerator(t), events.NewBus(), commands.NewRegistry(commands.NewMemoryStore()), mockScheduled, func() time.Time { return now })
+			if err := service.SendScheduledMessages(ctx); !errors.Is(err, tt.err) {
+				t.Fatalf("expected %v got %v", tt.err, err)
+			}
+		})
+	}
+}
@@ -3,12 +3,33 @@ package msgsvc
 import (
 	"context"
 	"errors"
+	"fmt"
+	"github.com/AliUnipal/chat/internal/commands"
+	"github.com/AliUnipal/chat/internal/events"
 	"github.com/AliUnipal/chat/internal/models/message"
+	"github.com/AliUnipal/chat/internal/moderation"
+	"github.com/AliUnipal/chat/internal/richtext"
+	chatrepo "github.com/AliUnipal/chat/internal/service/chatsvc/repo"
 	"github.com/AliUnipal/chat/internal/service/msgsvc/repo"
 	"github.com/google/uuid"
+	"sync"
 	"time"
 )
 
+// MaxTextLength bounds the text messages are written in, in bytes, as text is
+// parsed and scanned on every send. Attachments are not text, so they are not
+// held to it.
+const MaxTextLength = 64 << 10
+
+var (
+	ErrContentTooLong = errors.New("message is too long")
+	// ErrEncryptionMismatch is returned for plaintext sent to an encrypted
+	// chat, or an encrypted envelope sent to a plaintext one.
+	ErrEncryptionMismatch = errors.New("message encryption does not match the chat")
+	ErrMessageRejected    = errors.New("message rejected by moderation")
+	ErrNotQuarantined     = repo.ErrNotQuarantined
+)
+
 type MessageInput struct {
 	SenderID    uuid.UUID
 	ChatID      uuid.UUID
@@ -19,29 +40,145 @@ type MessageInput struct {
 // TODO: Ask about how the middleware/authorization for creating and getting message. And if it change the structure of the methods
 type messageService interface {
 	CreateMessage(ctx context.Context, in MessageInput) (uuid.UUID, error)
-	GetMessages(ctx context.Context, chatID uuid.UUID) ([]message.Message, error)
+	GetMessages(ctx context.Context, chatID, userID uuid.UUID) ([]message.Message, error)
+	GetMentions(ctx context.Context, userID uuid.UUID) ([]message.Message, error)
+	RemoveMessagesBySender(ctx context.Context, chatID, senderID uuid.UUID) error
+	StartTyping(ctx context.Context, chatID, userID uuid.UUID) error
+	StopTyping(ctx context.Context, chatID, userID uuid.UUID) error
+	SubscribeTyping(ctx context.Context, userID uuid.UUID) (<-chan message.TypingEvent, func())
+	GetCommands(ctx context.Context, chatID, userID uuid.UUID) ([]commands.Command, error)
+	EnableCommand(ctx context.Context, chatID, userID uuid.UUID, name string) error
+	DisableCommand(ctx context.Context, chatID, userID uuid.UUID, name string) error
+	ScheduleMessage(ctx context.Context, in MessageInput, sendAt time.Time) (uuid.UUID, error)
+	GetScheduledMessages(ctx context.Context, userID uuid.UUID) ([]message.ScheduledMessage, error)
+	EditScheduledMessage(ctx context.Context, userID, id uuid.UUID, content []byte, sendAt time.Time) error
+	CancelScheduledMessage(ctx context.Context, userID, id uuid.UUID) error
+	CancelScheduledMessages(ctx context.Context, userID uuid.UUID) error
+	SendScheduledMessages(ctx context.Context) error
+	DeleteExpiredMessages(ctx context.Context) error
+}
+
+// moderationService holds the methods that act on messages on a moderator's
+// behalf. They are kept off messageService and are only reached through the
+// report service, which checks the moderator first.
+type moderationService interface {
+	RemoveMessage(ctx context.Context, chatID, id uuid.UUID) error
+	ReviewMessage(ctx context.Context, chatID, id, reviewerID uuid.UUID, release bool) error
 }
 
 type messageRepository interface {
 	CreateMessage(ctx context.Context, in repo.CreateMessageInput) error
 	GetMessage(ctx context.Context, id, chatID uuid.UUID) (repo.Message, error)
 	GetMessages(ctx context.Context, chatID uuid.UUID) ([]repo.Message, error)
+	GetMentions(ctx context.Context, userID uuid.UUID, since map[uuid.UUID]time.Time) ([]repo.Message, error)
+	ReleaseMessage(ctx context.Context, chatID, id uuid.UUID) error
+	DeleteMessage(ctx context.Context, chatID, id uuid.UUID) error
+	DeleteMessagesBySender(ctx context.Context, chatID, senderID uuid.UUID) error
+	DeleteExpiredMessages(ctx context.Context, now time.Time) ([]repo.Message, error)
+}
+
+type chatRepository interface {
+	GetChat(ctx context.Context, id uuid.UUID) (chatrepo.Chat, error)
+	GetMember(ctx context.Context, chatID, userID uuid.UUID) (chatrepo.Member, error)
+	GetMembers(ctx context.Context, chatID uuid.UUID) ([]chatrepo.Member, error)
+	GetChatsByUser(ctx context.Context, userID uuid.UUID) ([]chatrepo.UserChat, error)
+	UseRequestMessage(ctx context.Context, chatID, senderID uuid.UUID) error
+}
+
+type userService interface {
+	CanMessage(ctx context.Context, senderID, recipientID uuid.UUID) error
+}
+
+// rateLimiter returns a *ratelimit.Error when the sender, the chat or the
+// service as a whole is sending too fast.
+type rateLimiter interface {
+	Allow(ctx context.Context, senderID, chatID uuid.UUID) error
+}
+
+// moderator decides whether a message is delivered, held back for review or
+// refused.
+type moderator interface {
+	Moderate(ctx context.Context, in moderation.Input) (moderation.Decision, error)
+	Review(ctx context.Context, messageID, reviewerID uuid.UUID, status moderation.ReviewStatus) error
+	Redact(ctx context.Context, messageID uuid.UUID) error
+}
+
+// publisher announces new and deleted messages.
+type publisher interface {
+	Publish(ctx context.Context, e events.Event)
+}
+
+// commandRegistry runs the slash commands text messages start with.
+type commandRegistry interface {
+	Run(ctx context.Context, chatID, userID uuid.UUID, text string, now time.Time) (commands.Result, bool, error)
+	Commands(ctx context.Context, chatID uuid.UUID) ([]commands.Command, error)
+	Enable(ctx context.Context, chatID uuid.UUID, name string) error
+	Disable(ctx context.Context, chatID uuid.UUID, name string) error
+}
+
+// scheduledRepository keeps scheduled messages until they are sent. It
+// should be durable, so they survive restarts.
+type scheduledRepository interface {
+	CreateScheduledMessage(ctx context.Context, m repo.ScheduledMessage) error
+	GetScheduledMessage(ctx context.Context, id uuid.UUID) (repo.ScheduledMessage, error)
+	GetScheduledMessages(ctx context.Context, senderID uuid.UUID) ([]repo.ScheduledMessage, error)
+	GetDueScheduledMessages(ctx context.Context, now time.Time) ([]repo.ScheduledMessage, error)
+	UpdateScheduledMessage(ctx context.Context, m repo.ScheduledMessage) error
+	DeleteScheduledMessage(ctx context.Context, id uuid.UUID) error
 }
 
 type service struct {
-	repo messageRepository
+	repo      messageRepository
+	chatRepo  chatRepository
+	users     userService
+	limiter   rateLimiter
+	moderator moderator
+	events    publisher
+	commands  commandRegistry
+	scheduled scheduledRepository
+	// clock tells the time messages are sent at, and when scheduled ones
+	// are due. It is time.Now outside of tests.
+	clock func() time.Time
+
+	// sending keeps concurrent SendScheduledMessages calls from sending the
+	// same message twice.
+	sending sync.Mutex
+
+	mu                sync.Mutex
+	typing            map[typingKey]*typingState
+	typingSubscribers map[uuid.UUID]map[chan message.TypingEvent]struct{}
 }
 
 var _ (messageService) = (*service)(nil)
+var _ (moderationService) = (*service)(nil)
 
-func NewService(repo messageRepository) *service {
-	return &service{repo: repo}
+func NewService(repo messageRepository, chatRepo chatRepository, users userService, limiter rateLimiter, moderator moderator, events publisher, commands commandRegistry, scheduled scheduledRepository, clock func() time.Time) *service {
+	return &service{
+		repo:              repo,
+		chatRepo:          chatRepo,
+		users:             users,
+		limiter:           limiter,
+		moderator:         moderator,
+		events:            events,
+		commands:          commands,
+		scheduled:         scheduled,
+		clock:             clock,
+		typing:            make(map[typingKey]*typingState),
+		typingSubscribers: make(map[uuid.UUID]map[chan message.TypingEvent]struct{}),
+	}
 }
 
+// CreateMessage sends a message to the chat. A text message starting with a
+// slash command runs it, and what the command sends, if anything, is sent in
+// its place; the returned ID is uuid.Nil when nothing was sent. Rich text is
+// sent as markdown and stored as its plain text and entities.
 func (s *service) CreateMessage(ctx context.Context, in MessageInput) (uuid.UUID, error) {
 	if in.Content == nil || len(in.Content) == 0 {
 		return uuid.Nil, errors.New("content is empty")
 	}
+	if err := checkLength(in.Content, in.ContentType); err != nil {
+		return uuid.Nil, err
+	}
 	if in.ChatID == uuid.Nil {
 		return uuid.Nil, errors.New("chatID is empty")
 	}
@@ -49,7 +186,75 @@ func (s *service) CreateMessage(ctx context.Context, in MessageInput) (uuid.UUID
 		return uuid.Nil, errors.New("senderID is empty")
 	}
 
+	c, err := s.authorizeSender(ctx, in.ChatID, in.SenderID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if c.Encrypted != (in.ContentType == message.EncryptedContentType) {
+		return uuid.Nil, ErrEncryptionMismatch
+	}
+	if err := s.limiter.Allow(ctx, in.SenderID, in.ChatID); err != nil {
+		return uuid.Nil, err
+	}
+	if in.ContentType.IsText() {
+		res, ok, err := s.commands.Run(ctx, in.ChatID, in.SenderID, string(in.Content), s.clock())
+		if err != nil {
+			return uuid.Nil, err
+		}
+		if ok {
+			if res.Reply != "" {
+				s.reply(ctx, in.ChatID, in.SenderID, res.Reply)
+			}
+			if res.Message == "" {
+				return uuid.Nil, nil
+			}
+			// What commands send is plain text, while escaped text keeps
+			// its formatting.
+			in.Content = []byte(res.Message)
+			if !res.Escaped {
+				in.ContentType = message.TextContentType
+			}
+		}
+	}
+	var entities []message.Entity
+	if in.ContentType == message.RichTextContentType {
+		var text string
+		if text, entities = richtext.Parse(string(in.Content)); text == "" {
+			return uuid.Nil, errors.New("content is empty")
+		}
+		in.Content = []byte(text)
+	}
+	var mentions []message.Mention
+	if in.ContentType.IsText() {
+		mentions = findMentions(c, string(in.Content), entities)
+	}
+
 	id := uuid.New()
+	now := s.clock().UTC()
+	var expiresAt time.Time
+	if c.DisappearAfter > 0 {
+		expiresAt = now.Add(c.DisappearAfter)
+	}
+	d, err := s.moderator.Moderate(ctx, moderation.Input{
+		MessageID:   id,
+		SenderID:    in.SenderID,
+		ChatID:      in.ChatID,
+		Content:     in.Content,
+		ContentType: in.ContentType,
+		Entities:    entities,
+		SentAt:      now,
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if d.Action == moderation.Reject {
+		return uuid.Nil, fmt.Errorf("%w: %s", ErrMessageRejected, d.Reason)
+	}
+	if c.RequestStatus != chatrepo.NoRequest {
+		if err := s.chatRepo.UseRequestMessage(ctx, in.ChatID, in.SenderID); err != nil {
+			return uuid.Nil, err
+		}
+	}
 
 	if err := s.repo.CreateMessage(ctx, repo.CreateMessageInput{
 		ID:          id,
@@ -57,30 +262,203 @@ func (s *service) CreateMessage(ctx context.Context, in MessageInput) (uuid.UUID
 		ChatID:      in.ChatID,
 		Content:     in.Content,
 		ContentType: in.ContentType,
-		Timestamp:   time.Now().UTC(),
+		Timestamp:   now,
+		Quarantined: d.Action == moderation.Quarantine,
+		Mentions:    mentions,
+		Entities:    entities,
+		ExpiresAt:   expiresAt,
 	}); err != nil {
 		return uuid.Nil, err
 	}
+	s.stopTyping(in.ChatID, in.SenderID)
+	// Quarantined messages stay private to their sender.
+	if d.Action != moderation.Quarantine {
+		if err := s.publishCreated(ctx, c, message.Message{
+			ID:          id,
+			SenderID:    in.SenderID,
+			ChatID:      in.ChatID,
+			Content:     in.Content,
+			ContentType: in.ContentType,
+			Timestamp:   now,
+			Mentions:    mentions,
+			Entities:    entities,
+			ExpiresAt:   expiresAt,
+		}, now); err != nil {
+			return uuid.Nil, err
+		}
+	}
 
 	return id, nil
 }
 
-func (s *service) GetMessages(ctx context.Context, chatID uuid.UUID) ([]message.Message, error) {
+// publishCreated announces a message delivered to the chat at now.
+func (s *service) publishCreated(ctx context.Context, c chatrepo.Chat, m message.Message, now time.Time) error {
+	silent, err := s.silenced(ctx, c.ID, m.Mentions, now)
+	if err != nil {
+		return err
+	}
+	s.events.Publish(ctx, events.Event{
+		Type:       events.MessageCreated,
+		ChatID:     c.ID,
+		UserID:     m.SenderID,
+		Recipients: participantIDs(c),
+		Silent:     silent,
+		Message:    &m,
+		Timestamp:  now,
+	})
+
+	return nil
+}
+
+// GetMessages returns the messages of the chat visible to userID, leaving out
+// the history they deleted, other people's quarantined messages and expired
+// messages not deleted yet.
+func (s *service) GetMessages(ctx context.Context, chatID, userID uuid.UUID) ([]message.Message, error) {
+	member, err := s.chatRepo.GetMember(ctx, chatID, userID)
+	if err != nil {
+		return nil, err
+	}
 	msgs, err := s.repo.GetMessages(ctx, chatID)
 	if err != nil {
 		return nil, err
 	}
-	r := make([]message.Message, len(msgs))
-	for i, m := range msgs {
-		r[i] = message.Message{
-			ID:          m.ID,
-			SenderID:    m.SenderID,
-			ChatID:      m.ChatID,
-			Content:     m.Content,
-			ContentType: m.ContentType,
-			Timestamp:   m.Timestamp,
+	now := s.clock()
+	r := make([]message.Message, 0, len(msgs))
+	for _, m := range msgs {
+		if expired(m, now) {
+			continue
+		}
+		if !member.ClearedAt.IsZero() && !m.Timestamp.After(member.ClearedAt) {
+			continue
 		}
+		if m.Quarantined && m.SenderID != userID {
+			continue
+		}
+		r = append(r, toMessage(m))
 	}
 
 	return r, nil
 }
+
+// RemoveMessage deletes a message for every participant. It is part of
+// moderationService, so callers must have authorized the moderator.
+// Removing a message that was delivered publishes a MessageDeleted event.
+func (s *service) RemoveMessage(ctx context.Context, chatID, id uuid.UUID) error {
+	m, err := s.repo.GetMessage(ctx, id, chatID)
+	if err != nil {
+		return err
+	}
+	c, err := s.chatRepo.GetChat(ctx, chatID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteMessage(ctx, chatID, id); err != nil {
+		return err
+	}
+	if m.Quarantined {
+		return nil
+	}
+
+	s.events.Publish(ctx, events.Event{
+		Type:       events.MessageDeleted,
+		ChatID:     chatID,
+		UserID:     m.SenderID,
+		Recipients: participantIDs(c),
+		Message:    &message.Message{ID: id, SenderID: m.SenderID, ChatID: chatID},
+	})
+	return nil
+}
+
+// ReviewMessage settles a quarantined message: released, it is delivered
+// and the MessageCreated event held back when it was sent is published;
+// otherwise it is deleted without anyone else having seen it. The decision
+// is recorded under reviewerID, whom callers are expected to have
+// authorized as a moderator. It returns ErrNotQuarantined for messages that
+// are not held back.
+func (s *service) ReviewMessage(ctx context.Context, chatID, id, reviewerID uuid.UUID, release bool) error {
+	m, err := s.repo.GetMessage(ctx, id, chatID)
+	if err != nil {
+		return err
+	}
+	if !m.Quarantined {
+		return ErrNotQuarantined
+	}
+	if !release {
+		if err := s.repo.DeleteMessage(ctx, chatID, id); err != nil {
+			return err
+		}
+		if err := s.moderator.Review(ctx, id, reviewerID, moderation.Removed); err != nil {
+			return err
+		}
+		return s.moderator.Redact(ctx, id)
+	}
+
+	c, err := s.chatRepo.GetChat(ctx, chatID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.ReleaseMessage(ctx, chatID, id); err != nil {
+		return err
+	}
+	if err := s.moderator.Review(ctx, id, reviewerID, moderation.Released); err != nil {
+		return err
+	}
+	m.Quarantined = false
+
+	return s.publishCreated(ctx, c, toMessage(m), s.clock().UTC())
+}
+
+// RemoveMessagesBySender deletes everything senderID sent to the chat, for
+// example when their account is deleted. Callers authorize the removal.
+func (s *service) RemoveMessagesBySender(ctx context.Context, chatID, senderID uuid.UUID) error {
+	return s.repo.DeleteMessagesBySender(ctx, chatID, senderID)
+}
+
+// authorizeSender checks that the sender belongs to the chat and that no
+// block stands between them and the other participants. Until a message
+// request is accepted only the requester may write, and only once:
+// CreateMessage marks the chat once that message is sent.
+func (s *service) authorizeSender(ctx context.Context, chatID, senderID uuid.UUID) (chatrepo.Chat, error) {
+	if _, err := s.chatRepo.GetMember(ctx, chatID, senderID); err != nil {
+		return chatrepo.Chat{}, err
+	}
+	c, err := s.chatRepo.GetChat(ctx, chatID)
+	if err != nil {
+		return chatrepo.Chat{}, err
+	}
+	for _, p := range c.Participants {
+		if p.ID == senderID {
+			continue
+		}
+		if err := s.users.CanMessage(ctx, senderID, p.ID); err != nil {
+			return chatrepo.Chat{}, err
+		}
+	}
+	if c.RequestStatus == chatrepo.NoRequest {
+		return c, nil
+	}
+	if c.RequesterID != senderID || c.RequestMessageSent {
+		return chatrepo.Chat{}, chatrepo.ErrRequestPending
+	}
+
+	return c, nil
+}
+
+// participantIDs returns who is told about the chat: every participant but
+// those removed from it.
+func participantIDs(c chatrepo.Chat) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(c.Participants))
+	for _, p := range c.Participants {
+		if !p.Removed {
+			ids = append(ids, p.ID)
+		}
+	}
+	return ids
+}
+
+func checkLength(content []byte, t message.ContentType) error {
+	if t.IsText() && len(content) > MaxTextLength {
+		return ErrContentTooLong
+	}
+	return nil
+}
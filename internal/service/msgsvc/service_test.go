@@ -4,18 +4,25 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"github.com/AliUnipal/chat/internal/commands"
+	"github.com/AliUnipal/chat/internal/events"
 	"github.com/AliUnipal/chat/internal/models/message"
+	"github.com/AliUnipal/chat/internal/moderation"
+	"github.com/AliUnipal/chat/internal/ratelimit"
+	chatrepo "github.com/AliUnipal/chat/internal/service/chatsvc/repo"
 	"github.com/AliUnipal/chat/internal/service/msgsvc"
 	"github.com/AliUnipal/chat/internal/service/msgsvc/mocks"
 	"github.com/AliUnipal/chat/internal/service/msgsvc/repo"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
+	"slices"
 	"testing"
 	"time"
 )
 
 func TestCreateMessage_ReturnID(t *testing.T) {
 	ctx := context.Background()
+	recipientID := uuid.New()
 	input := msgsvc.MessageInput{
 		SenderID:    uuid.New(),
 		ChatID:      uuid.New(),
@@ -24,6 +31,16 @@ func TestCreateMessage_ReturnID(t *testing.T) {
 	}
 
 	mockRepo := mocks.NewMessageRepository(t)
+	mockChatRepo := mocks.NewChatRepository(t)
+	mockUserService := mocks.NewUserService(t)
+	mockLimiter := mocks.NewRateLimiter(t)
+	mockModerator := mocks.NewModerator(t)
+	mockChatRepo.EXPECT().GetMember(mock.Anything, input.ChatID, input.SenderID).Return(chatrepo.Member{ChatID: input.ChatID, UserID: input.SenderID}, nil)
+	mockChatRepo.EXPECT().GetChat(mock.Anything, input.ChatID).Return(chatrepo.Chat{ID: input.ChatID, Participants: []chatrepo.User{{ID: input.SenderID}, {ID: recipientID}}}, nil)
+	mockChatRepo.EXPECT().GetMembers(mock.Anything, input.ChatID).Return(nil, nil)
+	mockUserService.EXPECT().CanMessage(mock.Anything, input.SenderID, recipientID).Return(nil)
+	mockLimiter.EXPECT().Allow(mock.Anything, input.SenderID, input.ChatID).Return(nil)
+	mockModerator.EXPECT().Moderate(mock.Anything, mock.Anything).Return(moderation.Decision{}, nil)
 	mockRepo.EXPECT().CreateMessage(mock.Anything, mock.MatchedBy(func(r repo.CreateMessageInput) bool {
 		return r.ID != uuid.Nil &&
 			r.SenderID == input.SenderID &&
@@ -32,7 +49,7 @@ func TestCreateMessage_ReturnID(t *testing.T) {
 			r.ContentType == input.ContentType
 	})).Return(nil)
 
-	service := msgsvc.NewService(mockRepo)
+	service := msgsvc.NewService(mockRepo, mockChatRepo, mockUserService, mockLimiter, mockModerator, events.NewBus(), commands.NewRegistry(commands.NewMemoryStore()), mocks.NewScheduledRepository(t), time.Now)
 
 	id, err := service.CreateMessage(ctx, input)
 	if err != nil {
@@ -53,13 +70,34 @@ func TestCreateMessage_ReturnErrorOnEmptyContent(t *testing.T) {
 	}
 
 	mockRepo := mocks.NewMessageRepository(t)
+	mockChatRepo := mocks.NewChatRepository(t)
+	mockUserService := mocks.NewUserService(t)
+	mockLimiter := mocks.NewRateLimiter(t)
+	mockModerator := mocks.NewModerator(t)
 
-	service := msgsvc.NewService(mockRepo)
+	service := msgsvc.NewService(mockRepo, mockChatRepo, mockUserService, mockLimiter, mockModerator, events.NewBus(), commands.NewRegistry(commands.NewMemoryStore()), mocks.NewScheduledRepository(t), time.Now)
 	if _, err := service.CreateMessage(ctx, input); err == nil {
 		t.Fatalf("expected error got %v", err)
 	}
 }
 
+func TestCreateMessage_ReturnErrorOnTooLongText(t *testing.T) {
+	ctx := context.Background()
+	for _, typ := range []message.ContentType{message.TextContentType, message.RichTextContentType} {
+		input := msgsvc.MessageInput{
+			SenderID:    uuid.New(),
+			ChatID:      uuid.New(),
+			Content:     bytes.Repeat([]byte("*a "), 25000),
+			ContentType: typ,
+		}
+
+		service := msgsvc.NewService(mocks.NewMessageRepository(t), mocks.NewChatRepository(t), mocks.NewUserService(t), mocks.NewRateLimiter(t), mocks.NewModerator(t), events.NewBus(), commands.NewRegistry(commands.NewMemoryStore()), mocks.NewScheduledRepository(t), time.Now)
+		if _, err := service.CreateMessage(ctx, input); !errors.Is(err, msgsvc.ErrContentTooLong) {
+			t.Fatalf("expected %v got %v", msgsvc.ErrContentTooLong, err)
+		}
+	}
+}
+
 func TestCreateMessage_ReturnErrorOnNilSenderID(t *testing.T) {
 	ctx := context.Background()
 	input := msgsvc.MessageInput{
@@ -70,8 +108,12 @@ func TestCreateMessage_ReturnErrorOnNilSenderID(t *testing.T) {
 	}
 
 	mockRepo := mocks.NewMessageRepository(t)
+	mockChatRepo := mocks.NewChatRepository(t)
+	mockUserService := mocks.NewUserService(t)
+	mockLimiter := mocks.NewRateLimiter(t)
+	mockModerator := mocks.NewModerator(t)
 
-	service := msgsvc.NewService(mockRepo)
+	service := msgsvc.NewService(mockRepo, mockChatRepo, mockUserService, mockLimiter, mockModerator, events.NewBus(), commands.NewRegistry(commands.NewMemoryStore()), mocks.NewScheduledRepository(t), time.Now)
 	if _, err := service.CreateMessage(ctx, input); err == nil {
 		t.Fatalf("expected error got %v", err)
 	}
@@ -87,8 +129,12 @@ func TestCreateMessage_ReturnErrorOnNilChatID(t *testing.T) {
 	}
 
 	mockRepo := mocks.NewMessageRepository(t)
+	mockChatRepo := mocks.NewChatRepository(t)
+	mockUserService := mocks.NewUserService(t)
+	mockLimiter := mocks.NewRateLimiter(t)
+	mockModerator := mocks.NewModerator(t)
 
-	service := msgsvc.NewService(mockRepo)
+	service := msgsvc.NewService(mockRepo, mockChatRepo, mockUserService, mockLimiter, mockModerator, events.NewBus(), commands.NewRegistry(commands.NewMemoryStore()), mocks.NewScheduledRepository(t), time.Now)
 	if _, err := service.CreateMessage(ctx, input); err == nil {
 		t.Fatalf("expected error got %v", err)
 	}
@@ -96,6 +142,7 @@ func TestCreateMessage_ReturnErrorOnNilChatID(t *testing.T) {
 
 func TestCreateMessage_ReturnError(t *testing.T) {
 	ctx := context.Background()
+	recipientID := uuid.New()
 	input := msgsvc.MessageInput{
 		SenderID:    uuid.New(),
 		ChatID:      uuid.New(),
@@ -104,6 +151,15 @@ func TestCreateMessage_ReturnError(t *testing.T) {
 	}
 
 	mockRepo := mocks.NewMessageRepository(t)
+	mockChatRepo := mocks.NewChatRepository(t)
+	mockUserService := mocks.NewUserService(t)
+	mockLimiter := mocks.NewRateLimiter(t)
+	mockModerator := mocks.NewModerator(t)
+	mockChatRepo.EXPECT().GetMember(mock.Anything, input.ChatID, input.SenderID).Return(chatrepo.Member{ChatID: input.ChatID, UserID: input.SenderID}, nil)
+	mockChatRepo.EXPECT().GetChat(mock.Anything, input.ChatID).Return(chatrepo.Chat{ID: input.ChatID, Participants: []chatrepo.User{{ID: input.SenderID}, {ID: recipientID}}}, nil)
+	mockUserService.EXPECT().CanMessage(mock.Anything, input.SenderID, recipientID).Return(nil)
+	mockLimiter.EXPECT().Allow(mock.Anything, input.SenderID, input.ChatID).Return(nil)
+	mockModerator.EXPECT().Moderate(mock.Anything, mock.Anything).Return(moderation.Decision{}, nil)
 	mockRepo.EXPECT().CreateMessage(mock.Anything, mock.MatchedBy(func(r repo.CreateMessageInput) bool {
 		return r.ID != uuid.Nil &&
 			r.SenderID == input.SenderID &&
@@ -112,7 +168,7 @@ func TestCreateMessage_ReturnError(t *testing.T) {
 			r.ContentType == input.ContentType
 	})).Return(errors.New("error"))
 
-	service := msgsvc.NewService(mockRepo)
+	service := msgsvc.NewService(mockRepo, mockChatRepo, mockUserService, mockLimiter, mockModerator, events.NewBus(), commands.NewRegistry(commands.NewMemoryStore()), mocks.NewScheduledRepository(t), time.Now)
 	if _, err := service.CreateMessage(ctx, input); err == nil {
 		t.Fatalf("expected error got %v", err)
 	}
@@ -121,6 +177,7 @@ func TestCreateMessage_ReturnError(t *testing.T) {
 func TestGetMessages_ReturnMessages(t *testing.T) {
 	ctx := context.Background()
 	chatID := uuid.New()
+	userID := uuid.New()
 	expectedMessages := []message.Message{
 		{
 			ID:          uuid.New(),
@@ -160,10 +217,15 @@ func TestGetMessages_ReturnMessages(t *testing.T) {
 	}
 
 	mockRepo := mocks.NewMessageRepository(t)
+	mockChatRepo := mocks.NewChatRepository(t)
+	mockUserService := mocks.NewUserService(t)
+	mockLimiter := mocks.NewRateLimiter(t)
+	mockModerator := mocks.NewModerator(t)
+	mockChatRepo.EXPECT().GetMember(mock.Anything, chatID, userID).Return(chatrepo.Member{ChatID: chatID, UserID: userID}, nil)
 	mockRepo.EXPECT().GetMessages(mock.Anything, chatID).Return(repoExpectedMessage, nil)
 
-	service := msgsvc.NewService(mockRepo)
-	msgs, err := service.GetMessages(ctx, chatID)
+	service := msgsvc.NewService(mockRepo, mockChatRepo, mockUserService, mockLimiter, mockModerator, events.NewBus(), commands.NewRegistry(commands.NewMemoryStore()), mocks.NewScheduledRepository(t), time.Now)
+	msgs, err := service.GetMessages(ctx, chatID, userID)
 	if err != nil {
 		t.Fatalf("expected no error got %v", err)
 	}
@@ -187,12 +249,602 @@ func TestGetMessages_ReturnMessages(t *testing.T) {
 func TestGetMessages_ReturnError(t *testing.T) {
 	ctx := context.Background()
 	chatID := uuid.New()
+	userID := uuid.New()
 
 	mockRepo := mocks.NewMessageRepository(t)
+	mockChatRepo := mocks.NewChatRepository(t)
+	mockUserService := mocks.NewUserService(t)
+	mockLimiter := mocks.NewRateLimiter(t)
+	mockModerator := mocks.NewModerator(t)
+	mockChatRepo.EXPECT().GetMember(mock.Anything, chatID, userID).Return(chatrepo.Member{ChatID: chatID, UserID: userID}, nil)
 	mockRepo.EXPECT().GetMessages(mock.Anything, chatID).Return(nil, errors.New("error"))
 
-	service := msgsvc.NewService(mockRepo)
-	if _, err := service.GetMessages(ctx, chatID); err == nil {
+	service := msgsvc.NewService(mockRepo, mockChatRepo, mockUserService, mockLimiter, mockModerator, events.NewBus(), commands.NewRegistry(commands.NewMemoryStore()), mocks.NewScheduledRepository(t), time.Now)
+	if _, err := service.GetMessages(ctx, chatID, userID); err == nil {
 		t.Fatalf("expected error got %v", err)
 	}
 }
+
+func TestCreateMessage_ReturnErrorOnNonMember(t *testing.T) {
+	ctx := context.Background()
+	input := msgsvc.MessageInput{
+		SenderID:    uuid.New(),
+		ChatID:      uuid.New(),
+		Content:     []byte("Hello Hello"),
+		ContentType: message.TextContentType,
+	}
+
+	mockRepo := mocks.NewMessageRepository(t)
+	mockChatRepo := mocks.NewChatRepository(t)
+	mockUserService := mocks.NewUserService(t)
+	mockLimiter := mocks.NewRateLimiter(t)
+	mockModerator := mocks.NewModerator(t)
+	mockChatRepo.EXPECT().GetMember(mock.Anything, input.ChatID, input.SenderID).Return(chatrepo.Member{}, chatrepo.ErrMemberNotFound)
+
+	service := msgsvc.NewService(mockRepo, mockChatRepo, mockUserService, mockLimiter, mockModerator, events.NewBus(), commands.NewRegistry(commands.NewMemoryStore()), mocks.NewScheduledRepository(t), time.Now)
+	if _, err := service.CreateMessage(ctx, input); !errors.Is(err, chatrepo.ErrMemberNotFound) {
+		t.Fatalf("expected %v got %v", chatrepo.ErrMemberNotFound, err)
+	}
+}
+
+func TestGetMessages_HideClearedHistory(t *testing.T) {
+	ctx := context.Background()
+	chatID := uuid.New()
+	userID := uuid.New()
+	clearedAt := time.Date(2009, time.November, 12, 0, 0, 0, 0, time.UTC)
+	before := repo.Message{ID: uuid.New(), ChatID: chatID, Content: []byte("old"), Timestamp: clearedAt.Add(-time.Hour)}
+	after := repo.Message{ID: uuid.New(), ChatID: chatID, Content: []byte("new"), Timestamp: clearedAt.Add(time.Hour)}
+
+	mockRepo := mocks.NewMessageRepository(t)
+	mockChatRepo := mocks.NewChatRepository(t)
+	mockUserService := mocks.NewUserService(t)
+	mockLimiter := mocks.NewRateLimiter(t)
+	mockModerator := mocks.NewModerator(t)
+	mockChatRepo.EXPECT().GetMember(mock.Anything, chatID, userID).Return(chatrepo.Member{ChatID: chatID, UserID: userID, ClearedAt: clearedAt}, nil)
+	mockRepo.EXPECT().GetMessages(mock.Anything, chatID).Return([]repo.Message{before, after}, nil)
+
+	service := msgsvc.NewService(mockRepo, mockChatRepo, mockUserService, mockLimiter, mockModerator, events.NewBus(), commands.NewRegistry(commands.NewMemoryStore()), mocks.NewScheduledRepository(t), time.Now)
+	msgs, err := service.GetMessages(ctx, chatID, userID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(msgs) != 1 || msgs[0].ID != after.ID {
+		t.Fatalf("expected only message %v, got %v", after.ID, msgs)
+	}
+}
+
+func TestGetMessages_ReturnErrorOnNonMember(t *testing.T) {
+	ctx := context.Background()
+	chatID := uuid.New()
+	userID := uuid.New()
+
+	mockRepo := mocks.NewMessageRepository(t)
+	mockChatRepo := mocks.NewChatRepository(t)
+	mockUserService := mocks.NewUserService(t)
+	mockLimiter := mocks.NewRateLimiter(t)
+	mockModerator := mocks.NewModerator(t)
+	mockChatRepo.EXPECT().GetMember(mock.Anything, chatID, userID).Return(chatrepo.Member{}, chatrepo.ErrMemberNotFound)
+
+	service := msgsvc.NewService(mockRepo, mockChatRepo, mockUserService, mockLimiter, mockModerator, events.NewBus(), commands.NewRegistry(commands.NewMemoryStore()), mocks.NewScheduledRepository(t), time.Now)
+	if _, err := service.GetMessages(ctx, chatID, userID); !errors.Is(err, chatrepo.ErrMemberNotFound) {
+		t.Fatalf("expected %v got %v", chatrepo.ErrMemberNotFound, err)
+	}
+}
+
+func TestCreateMessage_ReturnErrorWhenBlocked(t *testing.T) {
+	ctx := context.Background()
+	recipientID := uuid.New()
+	input := msgsvc.MessageInput{
+		SenderID:    uuid.New(),
+		ChatID:      uuid.New(),
+		Content:     []byte("Hello Hello"),
+		ContentType: message.TextContentType,
+	}
+
+	mockRepo := mocks.NewMessageRepository(t)
+	mockChatRepo := mocks.NewChatRepository(t)
+	mockUserService := mocks.NewUserService(t)
+	mockLimiter := mocks.NewRateLimiter(t)
+	mockModerator := mocks.NewModerator(t)
+	mockChatRepo.EXPECT().GetMember(mock.Anything, input.ChatID, input.SenderID).Return(chatrepo.Member{ChatID: input.ChatID, UserID: input.SenderID}, nil)
+	mockChatRepo.EXPECT().GetChat(mock.Anything, input.ChatID).Return(chatrepo.Chat{ID: input.ChatID, Participants: []chatrepo.User{{ID: input.SenderID}, {ID: recipientID}}}, nil)
+	mockUserService.EXPECT().CanMessage(mock.Anything, input.SenderID, recipientID).Return(errors.New("user is blocked"))
+
+	service := msgsvc.NewService(mockRepo, mockChatRepo, mockUserService, mockLimiter, mockModerator, events.NewBus(), commands.NewRegistry(commands.NewMemoryStore()), mocks.NewScheduledRepository(t), time.Now)
+	if _, err := service.CreateMessage(ctx, input); err == nil {
+		t.Fatalf("expected error got %v", err)
+	}
+}
+
+func TestCreateMessage_LimitPendingRequestToOneMessage(t *testing.T) {
+	ctx := context.Background()
+	requesterID := uuid.New()
+	recipientID := uuid.New()
+	chatID := uuid.New()
+
+	tests := []struct {
+		name     string
+		senderID uuid.UUID
+		sent     bool
+		useErr   error
+		expected error
+	}{
+		{name: "first message from requester", senderID: requesterID},
+		// The request stays used even once its message is deleted.
+		{name: "second message from requester", senderID: requesterID, sent: true, expected: chatrepo.ErrRequestPending},
+		{name: "second message sent meanwhile", senderID: requesterID, useErr: chatrepo.ErrRequestPending, expected: chatrepo.ErrRequestPending},
+		{name: "reply from recipient", senderID: recipientID, expected: chatrepo.ErrRequestPending},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := chatrepo.Chat{
+				ID:                 chatID,
+				Participants:       []chatrepo.User{{ID: requesterID}, {ID: recipientID}},
+				RequesterID:        requesterID,
+				RequestStatus:      chatrepo.RequestPending,
+				RequestMessageSent: tt.sent,
+			}
+			mockRepo := mocks.NewMessageRepository(t)
+			mockChatRepo := mocks.NewChatRepository(t)
+			mockUserService := mocks.NewUserService(t)
+			mockLimiter := mocks.NewRateLimiter(t)
+			mockModerator := mocks.NewModerator(t)
+			mockChatRepo.EXPECT().GetMember(mock.Anything, chatID, tt.senderID).Return(chatrepo.Member{ChatID: chatID, UserID: tt.senderID}, nil)
+			mockChatRepo.EXPECT().GetChat(mock.Anything, chatID).Return(c, nil)
+			mockUserService.EXPECT().CanMessage(mock.Anything, tt.senderID, mock.Anything).Return(nil)
+			if tt.senderID == requesterID && !tt.sent {
+				mockLimiter.EXPECT().Allow(mock.Anything, tt.senderID, chatID).Return(nil)
+				mockModerator.EXPECT().Moderate(mock.Anything, mock.Anything).Return(moderation.Decision{}, nil)
+				mockChatRepo.EXPECT().UseRequestMessage(mock.Anything, chatID, tt.senderID).Return(tt.useErr)
+			}
+			if tt.expected == nil {
+				mockChatRepo.EXPECT().GetMembers(mock.Anything, chatID).Return(nil, nil)
+				mockRepo.EXPECT().CreateMessage(mock.Anything, mock.Anything).Return(nil)
+			}
+
+			service := msgsvc.NewService(mockRepo, mockChatRepo, mockUserService, mockLimiter, mockModerator, events.NewBus(), commands.NewRegistry(commands.NewMemoryStore()), mocks.NewScheduledRepository(t), time.Now)
+			if _, err := service.CreateMessage(ctx, msgsvc.MessageInput{
+				SenderID:    tt.senderID,
+				ChatID:      chatID,
+				Content:     []byte("Hello"),
+				ContentType: message.TextContentType,
+			}); !errors.Is(err, tt.expected) {
+				t.Fatalf("expected %v got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestCreateMessage_ReturnErrorOnEncryptionMismatch(t *testing.T) {
+	ctx := context.Background()
+	senderID := uuid.New()
+	recipientID := uuid.New()
+
+	tests := []struct {
+		name        string
+		encrypted   bool
+		contentType message.ContentType
+	}{
+		{"plaintext in encrypted chat", true, message.TextContentType},
+		{"envelope in plaintext chat", false, message.EncryptedContentType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chatID := uuid.New()
+			mockRepo := mocks.NewMessageRepository(t)
+			mockChatRepo := mocks.NewChatRepository(t)
+			mockUserService := mocks.NewUserService(t)
+			mockLimiter := mocks.NewRateLimiter(t)
+			mockModerator := mocks.NewModerator(t)
+			mockChatRepo.EXPECT().GetMember(mock.Anything, chatID, senderID).Return(chatrepo.Member{ChatID: chatID, UserID: senderID}, nil)
+			mockChatRepo.EXPECT().GetChat(mock.Anything, chatID).Return(chatrepo.Chat{
+				ID:           chatID,
+				Participants: []chatrepo.User{{ID: senderID}, {ID: recipientID}},
+				Encrypted:    tt.encrypted,
+			}, nil)
+			mockUserService.EXPECT().CanMessage(mock.Anything, senderID, recipientID).Return(nil)
+
+			service := msgsvc.NewService(mockRepo, mockChatRepo, mockUserService, mockLimiter, mockModerator, events.NewBus(), commands.NewRegistry(commands.NewMemoryStore()), mocks.NewScheduledRepository(t), time.Now)
+			if _, err := service.CreateMessage(ctx, msgsvc.MessageInput{
+				SenderID:    senderID,
+				ChatID:      chatID,
+				Content:     []byte("Hello"),
+				ContentType: tt.contentType,
+			}); !errors.Is(err, msgsvc.ErrEncryptionMismatch) {
+				t.Fatalf("expected %v got %v", msgsvc.ErrEncryptionMismatch, err)
+			}
+		})
+	}
+}
+
+func TestCreateMessage_ReturnErrorWhenRateLimited(t *testing.T) {
+	ctx := context.Background()
+	recipientID := uuid.New()
+	input := msgsvc.MessageInput{
+		SenderID:    uuid.New(),
+		ChatID:      uuid.New(),
+		Content:     []byte("Hello"),
+		ContentType: message.TextContentType,
+	}
+	limited := &ratelimit.Error{Scope: "sender", RetryAfter: time.Second}
+
+	mockRepo := mocks.NewMessageRepository(t)
+	mockChatRepo := mocks.NewChatRepository(t)
+	mockUserService := mocks.NewUserService(t)
+	mockLimiter := mocks.NewRateLimiter(t)
+	mockModerator := mocks.NewModerator(t)
+	mockChatRepo.EXPECT().GetMember(mock.Anything, input.ChatID, input.SenderID).Return(chatrepo.Member{ChatID: input.ChatID, UserID: input.SenderID}, nil)
+	mockChatRepo.EXPECT().GetChat(mock.Anything, input.ChatID).Return(chatrepo.Chat{ID: input.ChatID, Participants: []chatrepo.User{{ID: input.SenderID}, {ID: recipientID}}}, nil)
+	mockUserService.EXPECT().CanMessage(mock.Anything, input.SenderID, recipientID).Return(nil)
+	mockLimiter.EXPECT().Allow(mock.Anything, input.SenderID, input.ChatID).Return(limited)
+
+	service := msgsvc.NewService(mockRepo, mockChatRepo, mockUserService, mockLimiter, mockModerator, events.NewBus(), commands.NewRegistry(commands.NewMemoryStore()), mocks.NewScheduledRepository(t), time.Now)
+	_, err := service.CreateMessage(ctx, input)
+	var rateErr *ratelimit.Error
+	if !errors.As(err, &rateErr) || rateErr.RetryAfter != time.Second {
+		t.Fatalf("expected %v got %v", limited, err)
+	}
+}
+
+func TestCreateMessage_ModerationDecision(t *testing.T) {
+	tests := []struct {
+		name            string
+		action          moderation.Action
+		wantErr         error
+		wantQuarantined bool
+	}{
+		{name: "rejected", action: moderation.Reject, wantErr: msgsvc.ErrMessageRejected},
+		{name: "quarantined", action: moderation.Quarantine, wantQuarantined: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			recipientID := uuid.New()
+			input := msgsvc.MessageInput{
+				SenderID:    uuid.New(),
+				ChatID:      uuid.New(),
+				Content:     []byte("buy now at spam.example"),
+				ContentType: message.TextContentType,
+			}
+
+			mockRepo := mocks.NewMessageRepository(t)
+			mockChatRepo := mocks.NewChatRepository(t)
+			mockUserService := mocks.NewUserService(t)
+			mockLimiter := mocks.NewRateLimiter(t)
+			mockModerator := mocks.NewModerator(t)
+			mockChatRepo.EXPECT().GetMember(mock.Anything, input.ChatID, input.SenderID).Return(chatrepo.Member{ChatID: input.ChatID, UserID: input.SenderID}, nil)
+			mockChatRepo.EXPECT().GetChat(mock.Anything, input.ChatID).Return(chatrepo.Chat{ID: input.ChatID, Participants: []chatrepo.User{{ID: input.SenderID}, {ID: recipientID}}}, nil)
+			mockUserService.EXPECT().CanMessage(mock.Anything, input.SenderID, recipientID).Return(nil)
+			mockLimiter.EXPECT().Allow(mock.Anything, input.SenderID, input.ChatID).Return(nil)
+			mockModerator.EXPECT().Moderate(mock.Anything, mock.MatchedBy(func(in moderation.Input) bool {
+				return in.MessageID != uuid.Nil &&
+					in.SenderID == input.SenderID &&
+					in.ChatID == input.ChatID &&
+					bytes.Equal(in.Content, input.Content)
+			})).Return(moderation.Decision{Action: tt.action, Filter: "url-blocklist"}, nil)
+			if tt.wantErr == nil {
+				mockRepo.EXPECT().CreateMessage(mock.Anything, mock.MatchedBy(func(r repo.CreateMessageInput) bool {
+					return r.Quarantined == tt.wantQuarantined
+				})).Return(nil)
+			}
+
+			service := msgsvc.NewService(mockRepo, mockChatRepo, mockUserService, mockLimiter, mockModerator, events.NewBus(), commands.NewRegistry(commands.NewMemoryStore()), mocks.NewScheduledRepository(t), time.Now)
+			if _, err := service.CreateMessage(ctx, input); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestGetMessages_HideOthersQuarantinedMessages(t *testing.T) {
+	ctx := context.Background()
+	chatID := uuid.New()
+	senderID := uuid.New()
+	recipientID := uuid.New()
+	msgs := []repo.Message{
+		{ID: uuid.New(), SenderID: senderID, ChatID: chatID, Content: []byte("hi"), Timestamp: time.Now()},
+		{ID: uuid.New(), SenderID: senderID, ChatID: chatID, Content: []byte("spam"), Timestamp: time.Now(), Quarantined: true},
+	}
+
+	mockRepo := mocks.NewMessageRepository(t)
+	mockChatRepo := mocks.NewChatRepository(t)
+	mockUserService := mocks.NewUserService(t)
+	mockLimiter := mocks.NewRateLimiter(t)
+	mockModerator := mocks.NewModerator(t)
+	mockChatRepo.EXPECT().GetMember(mock.Anything, chatID, mock.Anything).Return(chatrepo.Member{ChatID: chatID}, nil)
+	mockRepo.EXPECT().GetMessages(mock.Anything, chatID).Return(msgs, nil)
+
+	service := msgsvc.NewService(mockRepo, mockChatRepo, mockUserService, mockLimiter, mockModerator, events.NewBus(), commands.NewRegistry(commands.NewMemoryStore()), mocks.NewScheduledRepository(t), time.Now)
+	got, err := service.GetMessages(ctx, chatID, recipientID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(got) != 1 || got[0].ID != msgs[0].ID {
+		t.Fatalf("expected only %v got %v", msgs[0].ID, got)
+	}
+	got, err = service.GetMessages(ctx, chatID, senderID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(got) != 2 || !got[1].Quarantined {
+		t.Fatalf("expected sender to see their quarantined message got %v", got)
+	}
+}
+
+func TestCreateMessage_RunCommand(t *testing.T) {
+	ctx := context.Background()
+	senderID := uuid.New()
+	recipientID := uuid.New()
+	chatID := uuid.New()
+	registry := commands.NewRegistry(commands.NewMemoryStore())
+	if err := registry.Register(commands.Command{
+		Name:    "roll",
+		MaxArgs: 0,
+		Handler: func(_ context.Context, _ commands.Invocation) (commands.Result, error) {
+			return commands.Result{Message: "rolled a 4", Reply: "only you can see this"}, nil
+		},
+	}); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if err := registry.Register(commands.Command{
+		Name: "quiet",
+		Handler: func(_ context.Context, _ commands.Invocation) (commands.Result, error) {
+			return commands.Result{Reply: "done"}, nil
+		},
+	}); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		text         string
+		contentType  message.ContentType
+		expected     string
+		expectedType message.ContentType
+		err          error
+	}{
+		{name: "send in place of command", text: "/roll", expected: "rolled a 4"},
+		{name: "rich text command", text: "/roll", contentType: message.RichTextContentType, expected: "rolled a 4", expectedType: message.TextContentType},
+		{name: "reply only", text: "/quiet"},
+		{name: "unknown command", text: "/etc is full", expected: "/etc is full"},
+		{name: "escaped", text: "//roll", expected: "/roll"},
+		{name: "escaped rich text", text: "//roll **now**", contentType: message.RichTextContentType, expected: "/roll now", expectedType: message.RichTextContentType},
+		{name: "plain text", text: "/usr/bin", expected: "/usr/bin"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMessageRepository(t)
+			mockChatRepo := mocks.NewChatRepository(t)
+			mockUserService := mocks.NewUserService(t)
+			mockLimiter := mocks.NewRateLimiter(t)
+			mockModerator := mocks.NewModerator(t)
+			mockChatRepo.EXPECT().GetMember(mock.Anything, chatID, senderID).Return(chatrepo.Member{ChatID: chatID, UserID: senderID}, nil)
+			mockChatRepo.EXPECT().GetChat(mock.Anything, chatID).Return(chatrepo.Chat{ID: chatID, Participants: []chatrepo.User{{ID: senderID}, {ID: recipientID}}}, nil)
+			mockUserService.EXPECT().CanMessage(mock.Anything, senderID, recipientID).Return(nil)
+			mockLimiter.EXPECT().Allow(mock.Anything, senderID, chatID).Return(nil)
+			if tt.expected != "" {
+				mockChatRepo.EXPECT().GetMembers(mock.Anything, chatID).Return(nil, nil)
+				mockModerator.EXPECT().Moderate(mock.Anything, mock.Anything).Return(moderation.Decision{}, nil)
+				mockRepo.EXPECT().CreateMessage(mock.Anything, mock.MatchedBy(func(r repo.CreateMessageInput) bool {
+					return string(r.Content) == tt.expected && r.ContentType == tt.expectedType
+				})).Return(nil)
+			}
+
+			bus := events.NewBus()
+			var published []events.Event
+			bus.Subscribe(func(_ context.Context, e events.Event) { published = append(published, e) })
+			service := msgsvc.NewService(mockRepo, mockChatRepo, mockUserService, mockLimiter, mockModerator, bus, registry, mocks.NewScheduledRepository(t), time.Now)
+			id, err := service.CreateMessage(ctx, msgsvc.MessageInput{SenderID: senderID, ChatID: chatID, Content: []byte(tt.text), ContentType: tt.contentType})
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected %v got %v", tt.err, err)
+			}
+			if (id != uuid.Nil) != (tt.expected != "") {
+				t.Fatalf("expected an ID only when a message is sent got %v", id)
+			}
+			for _, e := range published {
+				if e.Type == events.EphemeralMessage && (len(e.Recipients) != 1 || e.Recipients[0] != senderID) {
+					t.Fatalf("expected the reply to go to the sender only got %+v", e)
+				}
+			}
+		})
+	}
+}
+
+func TestCreateMessage_FindMentions(t *testing.T) {
+	ctx := context.Background()
+	senderID := uuid.New()
+	bobID := uuid.New()
+	carolID := uuid.New()
+	text := "hi @Bob, ask @carol or x@bob.com. @nobody @outsider @all."
+
+	tests := []struct {
+		name         string
+		participants []chatrepo.User
+		expected     []message.Mention
+	}{
+		{
+			name:         "direct chat",
+			participants: []chatrepo.User{{ID: senderID, Username: "alice"}, {ID: bobID, Username: "bob"}},
+			expected:     []message.Mention{{UserID: bobID, Offset: 3, Length: 4}},
+		},
+		{
+			name:         "group chat",
+			participants: []chatrepo.User{{ID: senderID, Username: "alice"}, {ID: bobID, Username: "bob"}, {ID: carolID, Username: "Carol"}},
+			expected: []message.Mention{
+				{UserID: bobID, Offset: 3, Length: 4},
+				{UserID: carolID, Offset: 13, Length: 6},
+				{UserID: uuid.Nil, Offset: 52, Length: 4},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chatID := uuid.New()
+			mockRepo := mocks.NewMessageRepository(t)
+			mockChatRepo := mocks.NewChatRepository(t)
+			mockUserService := mocks.NewUserService(t)
+			mockLimiter := mocks.NewRateLimiter(t)
+			mockModerator := mocks.NewModerator(t)
+			mockChatRepo.EXPECT().GetMember(mock.Anything, chatID, senderID).Return(chatrepo.Member{ChatID: chatID, UserID: senderID}, nil)
+			mockChatRepo.EXPECT().GetChat(mock.Anything, chatID).Return(chatrepo.Chat{ID: chatID, Participants: tt.participants}, nil)
+			mockChatRepo.EXPECT().GetMembers(mock.Anything, chatID).Return(nil, nil)
+			mockUserService.EXPECT().CanMessage(mock.Anything, senderID, mock.Anything).Return(nil)
+			mockLimiter.EXPECT().Allow(mock.Anything, senderID, chatID).Return(nil)
+			mockModerator.EXPECT().Moderate(mock.Anything, mock.Anything).Return(moderation.Decision{}, nil)
+			mockRepo.EXPECT().CreateMessage(mock.Anything, mock.MatchedBy(func(r repo.CreateMessageInput) bool {
+				return slices.Equal(r.Mentions, tt.expected)
+			})).Return(nil)
+
+			service := msgsvc.NewService(mockRepo, mockChatRepo, mockUserService, mockLimiter, mockModerator, events.NewBus(), commands.NewRegistry(commands.NewMemoryStore()), mocks.NewScheduledRepository(t), time.Now)
+			if _, err := service.CreateMessage(ctx, msgsvc.MessageInput{SenderID: senderID, ChatID: chatID, Content: []byte(text)}); err != nil {
+				t.Fatalf("expected no error got %v", err)
+			}
+		})
+	}
+}
+
+func TestCreateMessage_SilenceMutedRecipients(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	senderID, bobID, carolID, daveID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	chatID := uuid.New()
+	mockRepo := mocks.NewMessageRepository(t)
+	mockChatRepo := mocks.NewChatRepository(t)
+	mockUserService := mocks.NewUserService(t)
+	mockLimiter := mocks.NewRateLimiter(t)
+	mockModerator := mocks.NewModerator(t)
+	mockChatRepo.EXPECT().GetMember(mock.Anything, chatID, senderID).Return(chatrepo.Member{ChatID: chatID, UserID: senderID}, nil)
+	mockChatRepo.EXPECT().GetChat(mock.Anything, chatID).Return(chatrepo.Chat{ID: chatID, Participants: []chatrepo.User{
+		{ID: senderID, Username: "alice"}, {ID: bobID, Username: "bob"}, {ID: carolID, Username: "carol"}, {ID: daveID, Username: "dave"},
+	}}, nil)
+	mockChatRepo.EXPECT().GetMembers(mock.Anything, chatID).Return([]chatrepo.Member{
+		{ChatID: chatID, UserID: senderID},
+		{ChatID: chatID, UserID: bobID, MutedUntil: now.Add(time.Hour)},
+		{ChatID: chatID, UserID: carolID, MutedUntil: now.Add(time.Hour)},
+		{ChatID: chatID, UserID: daveID, MutedUntil: now.Add(-time.Hour)},
+	}, nil)
+	mockUserService.EXPECT().CanMessage(mock.Anything, senderID, mock.Anything).Return(nil)
+	mockLimiter.EXPECT().Allow(mock.Anything, senderID, chatID).Return(nil)
+	mockModerator.EXPECT().Moderate(mock.Anything, mock.Anything).Return(moderation.Decision{}, nil)
+	mockRepo.EXPECT().CreateMessage(mock.Anything, mock.Anything).Return(nil)
+
+	bus := events.NewBus()
+	var published []events.Event
+	bus.Subscribe(func(_ context.Context, e events.Event) { published = append(published, e) })
+	service := msgsvc.NewService(mockRepo, mockChatRepo, mockUserService, mockLimiter, mockModerator, bus, commands.NewRegistry(commands.NewMemoryStore()), mocks.NewScheduledRepository(t), func() time.Time { return now })
+	if _, err := service.CreateMessage(ctx, msgsvc.MessageInput{SenderID: senderID, ChatID: chatID, Content: []byte("hi @bob")}); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	if len(published) != 1 || !slices.Equal(published[0].Silent, []uuid.UUID{carolID}) {
+		t.Fatalf("expected only carol to be silenced got %+v", published)
+	}
+}
+
+func TestCreateMessage_ParseRichText(t *testing.T) {
+	ctx := context.Background()
+	senderID := uuid.New()
+	chatID := uuid.New()
+	entities := []message.Entity{
+		{Type: message.BoldEntity, Offset: 0, Length: 2},
+		{Type: message.CodeEntity, Offset: 3, Length: 4},
+	}
+
+	mockRepo := mocks.NewMessageRepository(t)
+	mockChatRepo := mocks.NewChatRepository(t)
+	mockUserService := mocks.NewUserService(t)
+	mockLimiter := mocks.NewRateLimiter(t)
+	mockModerator := mocks.NewModerator(t)
+	mockChatRepo.EXPECT().GetMember(mock.Anything, chatID, senderID).Return(chatrepo.Member{ChatID: chatID, UserID: senderID}, nil)
+	mockChatRepo.EXPECT().GetChat(mock.Anything, chatID).Return(chatrepo.Chat{ID: chatID, Participants: []chatrepo.User{{ID: senderID}, {ID: uuid.New()}}}, nil)
+	mockChatRepo.EXPECT().GetMembers(mock.Anything, chatID).Return(nil, nil)
+	mockUserService.EXPECT().CanMessage(mock.Anything, senderID, mock.Anything).Return(nil)
+	mockLimiter.EXPECT().Allow(mock.Anything, senderID, chatID).Return(nil)
+	mockModerator.EXPECT().Moderate(mock.Anything, mock.MatchedBy(func(in moderation.Input) bool {
+		return string(in.Content) == "hi @Bob" && slices.Equal(in.Entities, entities)
+	})).Return(moderation.Decision{}, nil)
+	mockRepo.EXPECT().CreateMessage(mock.Anything, mock.MatchedBy(func(r repo.CreateMessageInput) bool {
+		return string(r.Content) == "hi @Bob" &&
+			r.ContentType == message.RichTextContentType &&
+			slices.Equal(r.Entities, entities) &&
+			len(r.Mentions) == 0
+	})).Return(nil)
+
+	service := msgsvc.NewService(mockRepo, mockChatRepo, mockUserService, mockLimiter, mockModerator, events.NewBus(), commands.NewRegistry(commands.NewMemoryStore()), mocks.NewScheduledRepository(t), time.Now)
+	if _, err := service.CreateMessage(ctx, msgsvc.MessageInput{
+		SenderID:    senderID,
+		ChatID:      chatID,
+		Content:     []byte("**hi** `@Bob`"),
+		ContentType: message.RichTextContentType,
+	}); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+}
+
+func TestReviewMessage(t *testing.T) {
+	ctx := context.Background()
+	senderID, recipientID, reviewerID := uuid.New(), uuid.New(), uuid.New()
+	chatID, id := uuid.New(), uuid.New()
+	quarantined := repo.Message{ID: id, SenderID: senderID, ChatID: chatID, Content: []byte("hi"), ContentType: message.TextContentType, Quarantined: true}
+
+	tests := []struct {
+		name     string
+		stored   repo.Message
+		release  bool
+		setup    func(r *mocks.MessageRepository, c *mocks.ChatRepository, m *mocks.Moderator)
+		expected error
+		events   int
+	}{
+		{
+			name:    "release",
+			stored:  quarantined,
+			release: true,
+			setup: func(r *mocks.MessageRepository, c *mocks.ChatRepository, m *mocks.Moderator) {
+				c.EXPECT().GetChat(mock.Anything, chatID).Return(chatrepo.Chat{ID: chatID, Participants: []chatrepo.User{{ID: senderID}, {ID: recipientID}}}, nil)
+				r.EXPECT().ReleaseMessage(mock.Anything, chatID, id).Return(nil)
+				m.EXPECT().Review(mock.Anything, id, reviewerID, moderation.Released).Return(nil)
+				c.EXPECT().GetMembers(mock.Anything, chatID).Return(nil, nil)
+			},
+			events: 1,
+		},
+		{
+			name:   "remove",
+			stored: quarantined,
+			setup: func(r *mocks.MessageRepository, c *mocks.ChatRepository, m *mocks.Moderator) {
+				r.EXPECT().DeleteMessage(mock.Anything, chatID, id).Return(nil)
+				m.EXPECT().Review(mock.Anything, id, reviewerID, moderation.Removed).Return(nil)
+				m.EXPECT().Redact(mock.Anything, id).Return(nil)
+			},
+		},
+		{
+			name:     "delivered message",
+			stored:   repo.Message{ID: id, SenderID: senderID, ChatID: chatID},
+			release:  true,
+			setup:    func(*mocks.MessageRepository, *mocks.ChatRepository, *mocks.Moderator) {},
+			expected: msgsvc.ErrNotQuarantined,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMessageRepository(t)
+			mockChatRepo := mocks.NewChatRepository(t)
+			mockModerator := mocks.NewModerator(t)
+			mockRepo.EXPECT().GetMessage(mock.Anything, id, chatID).Return(tt.stored, nil)
+			tt.setup(mockRepo, mockChatRepo, mockModerator)
+
+			bus := events.NewBus()
+			var published []events.Event
+			bus.Subscribe(func(_ context.Context, e events.Event) { published = append(published, e) })
+			service := msgsvc.NewService(mockRepo, mockChatRepo, mocks.NewUserService(t), mocks.NewRateLimiter(t), mockModerator, bus, commands.NewRegistry(commands.NewMemoryStore()), mocks.NewScheduledRepository(t), time.Now)
+			if err := service.ReviewMessage(ctx, chatID, id, reviewerID, tt.release); !errors.Is(err, tt.expected) {
+				t.Fatalf("expected %v got %v", tt.expected, err)
+			}
+			if len(published) != tt.events {
+				t.Fatalf("expected %d events got %+v", tt.events, published)
+			}
+			if tt.events > 0 && (published[0].Type != events.MessageCreated || published[0].Message.Quarantined || !slices.Contains(published[0].Recipients, recipientID)) {
+				t.Fatalf("expected the released message delivered got %+v", published[0])
+			}
+		})
+	}
+}
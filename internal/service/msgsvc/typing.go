@@ -0,0 +1,158 @@
+package msgsvc
+
+import (
+	"context"
+	"github.com/AliUnipal/chat/internal/models/message"
+	"github.com/google/uuid"
+	"sync"
+	"time"
+)
+
+const (
+	// typingTimeout is how long a typing signal lasts unless renewed.
+	typingTimeout = 6 * time.Second
+	// typingRenewInterval is how often renewals are announced at most. It
+	// leaves the last announced expiry well ahead of the next announcement.
+	typingRenewInterval = typingTimeout / 2
+	// typingEventBuffer is how many events a subscriber can fall behind
+	// before further events to it are dropped.
+	typingEventBuffer = 16
+)
+
+type typingKey struct {
+	chatID uuid.UUID
+	userID uuid.UUID
+}
+
+type typingState struct {
+	timer     *time.Timer
+	expiresAt time.Time
+	// announcedAt is when the signal was last published, to throttle the
+	// announcement of renewals.
+	announcedAt time.Time
+	// recipients are the participants told about the signal, so the stop
+	// event reaches the same users even when it fires on expiry.
+	recipients []uuid.UUID
+}
+
+// StartTyping tells the other participants of the chat that userID is typing.
+// The signal stops on its own after a few seconds; calling StartTyping again
+// before then renews it, and the new expiry is announced every few seconds
+// while renewals keep coming. Typing signals are never stored.
+func (s *service) StartTyping(ctx context.Context, chatID, userID uuid.UUID) error {
+	c, err := s.authorizeSender(ctx, chatID, userID)
+	if err != nil {
+		return err
+	}
+	recipients := make([]uuid.UUID, 0, len(c.Participants))
+	for _, p := range c.Participants {
+		if p.ID != userID && !p.Removed {
+			recipients = append(recipients, p.ID)
+		}
+	}
+
+	key := typingKey{chatID, userID}
+	now := s.clock().UTC()
+	expiresAt := now.Add(typingTimeout)
+	s.mu.Lock()
+	if st, ok := s.typing[key]; ok {
+		st.expiresAt = expiresAt
+		st.timer.Reset(typingTimeout)
+		if now.Sub(st.announcedAt) < typingRenewInterval {
+			s.mu.Unlock()
+			return nil
+		}
+		st.announcedAt = now
+		recipients = st.recipients
+		s.mu.Unlock()
+
+		s.publishTyping(recipients, message.TypingEvent{ChatID: chatID, UserID: userID, Typing: true, ExpiresAt: expiresAt})
+		return nil
+	}
+	st := &typingState{expiresAt: expiresAt, announcedAt: now, recipients: recipients}
+	st.timer = time.AfterFunc(typingTimeout, func() { s.expireTyping(key, st) })
+	s.typing[key] = st
+	s.mu.Unlock()
+
+	s.publishTyping(recipients, message.TypingEvent{ChatID: chatID, UserID: userID, Typing: true, ExpiresAt: expiresAt})
+	return nil
+}
+
+// StopTyping ends userID's typing signal in the chat, if there is one.
+func (s *service) StopTyping(ctx context.Context, chatID, userID uuid.UUID) error {
+	if _, err := s.chatRepo.GetMember(ctx, chatID, userID); err != nil {
+		return err
+	}
+	s.stopTyping(chatID, userID)
+
+	return nil
+}
+
+// SubscribeTyping returns a channel receiving the typing signals in the chats
+// userID belongs to. The returned func stops the subscription and closes the
+// channel. Events are dropped for subscribers that fall behind.
+func (s *service) SubscribeTyping(_ context.Context, userID uuid.UUID) (<-chan message.TypingEvent, func()) {
+	ch := make(chan message.TypingEvent, typingEventBuffer)
+	s.mu.Lock()
+	if s.typingSubscribers[userID] == nil {
+		s.typingSubscribers[userID] = make(map[chan message.TypingEvent]struct{})
+	}
+	s.typingSubscribers[userID][ch] = struct{}{}
+	s.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			delete(s.typingSubscribers[userID], ch)
+			if len(s.typingSubscribers[userID]) == 0 {
+				delete(s.typingSubscribers, userID)
+			}
+			close(ch)
+		})
+	}
+}
+
+func (s *service) stopTyping(chatID, userID uuid.UUID) {
+	key := typingKey{chatID, userID}
+	s.mu.Lock()
+	st, ok := s.typing[key]
+	if !ok {
+		s.mu.Unlock()
+		return
+	}
+	st.timer.Stop()
+	delete(s.typing, key)
+	s.mu.Unlock()
+
+	s.publishTyping(st.recipients, message.TypingEvent{ChatID: chatID, UserID: userID})
+}
+
+// expireTyping ends the signal st once its timer fires, unless it was
+// stopped, replaced or renewed in the meantime.
+func (s *service) expireTyping(key typingKey, st *typingState) {
+	s.mu.Lock()
+	if s.typing[key] != st || s.clock().UTC().Before(st.expiresAt) {
+		s.mu.Unlock()
+		return
+	}
+	delete(s.typing, key)
+	s.mu.Unlock()
+
+	s.publishTyping(st.recipients, message.TypingEvent{ChatID: key.chatID, UserID: key.userID})
+}
+
+func (s *service) publishTyping(recipients []uuid.UUID, e message.TypingEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, userID := range recipients {
+		for ch := range s.typingSubscribers[userID] {
+			select {
+			case ch <- e:
+			default:
+			}
+		}
+	}
+}
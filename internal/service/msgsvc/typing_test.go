@@ -0,0 +1,184 @@
+package msgsvc_test
+
+import (
+	"context"
+	"errors"
+	"github.com/AliUnipal/chat/internal/commands"
+	"github.com/AliUnipal/chat/internal/events"
+	"github.com/AliUnipal/chat/internal/models/message"
+	"github.com/AliUnipal/chat/internal/moderation"
+	chatrepo "github.com/AliUnipal/chat/internal/service/chatsvc/repo"
+	"github.com/AliUnipal/chat/internal/service/msgsvc"
+	"github.com/AliUnipal/chat/internal/service/msgsvc/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func receiveTyping(t *testing.T, events <-chan message.TypingEvent) message.TypingEvent {
+	t.Helper()
+	select {
+	case e := <-events:
+		return e
+	default:
+		t.Fatal("expected a typing event, got none")
+		return message.TypingEvent{}
+	}
+}
+
+func TestStartTyping_NotifyOtherParticipants(t *testing.T) {
+	ctx := context.Background()
+	chatID := uuid.New()
+	typistID := uuid.New()
+	recipientID := uuid.New()
+
+	mockRepo := mocks.NewMessageRepository(t)
+	mockChatRepo := mocks.NewChatRepository(t)
+	mockUserService := mocks.NewUserService(t)
+	mockLimiter := mocks.NewRateLimiter(t)
+	mockModerator := mocks.NewModerator(t)
+	mockChatRepo.EXPECT().GetMember(mock.Anything, chatID, typistID).Return(chatrepo.Member{ChatID: chatID, UserID: typistID}, nil)
+	mockChatRepo.EXPECT().GetChat(mock.Anything, chatID).Return(chatrepo.Chat{ID: chatID, Participants: []chatrepo.User{{ID: typistID}, {ID: recipientID}}}, nil)
+	mockUserService.EXPECT().CanMessage(mock.Anything, typistID, recipientID).Return(nil)
+
+	service := msgsvc.NewService(mockRepo, mockChatRepo, mockUserService, mockLimiter, mockModerator, events.NewBus(), commands.NewRegistry(commands.NewMemoryStore()), mocks.NewScheduledRepository(t), time.Now)
+	recipientEvents, stopRecipient := service.SubscribeTyping(ctx, recipientID)
+	defer stopRecipient()
+	typistEvents, stopTypist := service.SubscribeTyping(ctx, typistID)
+	defer stopTypist()
+
+	for range 2 {
+		if err := service.StartTyping(ctx, chatID, typistID); err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+	}
+	e := receiveTyping(t, recipientEvents)
+	if e.ChatID != chatID || e.UserID != typistID || !e.Typing || !e.ExpiresAt.After(time.Now()) {
+		t.Fatalf("expected a typing event from %v got %v", typistID, e)
+	}
+	select {
+	case e := <-recipientEvents:
+		t.Fatalf("expected a renewal not to be published again got %v", e)
+	case e := <-typistEvents:
+		t.Fatalf("expected the typist not to be notified got %v", e)
+	default:
+	}
+
+	if err := service.StopTyping(ctx, chatID, typistID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if e := receiveTyping(t, recipientEvents); e.Typing {
+		t.Fatalf("expected a stop event got %v", e)
+	}
+}
+
+func TestStartTyping_AnnounceRenewals(t *testing.T) {
+	ctx := context.Background()
+	chatID := uuid.New()
+	typistID := uuid.New()
+	recipientID := uuid.New()
+	now := time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	mockChatRepo := mocks.NewChatRepository(t)
+	mockUserService := mocks.NewUserService(t)
+	mockChatRepo.EXPECT().GetMember(mock.Anything, chatID, typistID).Return(chatrepo.Member{ChatID: chatID, UserID: typistID}, nil)
+	mockChatRepo.EXPECT().GetChat(mock.Anything, chatID).Return(chatrepo.Chat{ID: chatID, Participants: []chatrepo.User{{ID: typistID}, {ID: recipientID}}}, nil)
+	mockUserService.EXPECT().CanMessage(mock.Anything, typistID, recipientID).Return(nil)
+
+	clock := now
+	service := msgsvc.NewService(mocks.NewMessageRepository(t), mockChatRepo, mockUserService, mocks.NewRateLimiter(t), mocks.NewModerator(t), events.NewBus(), commands.NewRegistry(commands.NewMemoryStore()), mocks.NewScheduledRepository(t), func() time.Time { return clock })
+	recipientEvents, stop := service.SubscribeTyping(ctx, recipientID)
+	defer stop()
+	defer service.StopTyping(ctx, chatID, typistID)
+
+	tests := []struct {
+		after     time.Duration
+		announced bool
+	}{
+		{0, true},
+		{time.Second, false},
+		{4 * time.Second, true},
+		{5 * time.Second, false},
+		{7 * time.Second, true},
+	}
+	for _, tt := range tests {
+		clock = now.Add(tt.after)
+		if err := service.StartTyping(ctx, chatID, typistID); err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+		select {
+		case e := <-recipientEvents:
+			if !tt.announced {
+				t.Fatalf("after %v: expected the renewal throttled got %v", tt.after, e)
+			}
+			if expected := clock.Add(6 * time.Second); !e.Typing || !e.ExpiresAt.Equal(expected) {
+				t.Fatalf("after %v: expected typing until %v got %v", tt.after, expected, e)
+			}
+		default:
+			if tt.announced {
+				t.Fatalf("after %v: expected a typing event got none", tt.after)
+			}
+		}
+	}
+}
+
+func TestStartTyping_ReturnErrorOnNonMember(t *testing.T) {
+	ctx := context.Background()
+	chatID := uuid.New()
+	userID := uuid.New()
+
+	mockRepo := mocks.NewMessageRepository(t)
+	mockChatRepo := mocks.NewChatRepository(t)
+	mockUserService := mocks.NewUserService(t)
+	mockLimiter := mocks.NewRateLimiter(t)
+	mockModerator := mocks.NewModerator(t)
+	mockChatRepo.EXPECT().GetMember(mock.Anything, chatID, userID).Return(chatrepo.Member{}, chatrepo.ErrMemberNotFound)
+
+	service := msgsvc.NewService(mockRepo, mockChatRepo, mockUserService, mockLimiter, mockModerator, events.NewBus(), commands.NewRegistry(commands.NewMemoryStore()), mocks.NewScheduledRepository(t), time.Now)
+	if err := service.StartTyping(ctx, chatID, userID); !errors.Is(err, chatrepo.ErrMemberNotFound) {
+		t.Fatalf("expected %v got %v", chatrepo.ErrMemberNotFound, err)
+	}
+}
+
+func TestCreateMessage_StopTyping(t *testing.T) {
+	ctx := context.Background()
+	chatID := uuid.New()
+	senderID := uuid.New()
+	recipientID := uuid.New()
+
+	mockRepo := mocks.NewMessageRepository(t)
+	mockChatRepo := mocks.NewChatRepository(t)
+	mockUserService := mocks.NewUserService(t)
+	mockLimiter := mocks.NewRateLimiter(t)
+	mockModerator := mocks.NewModerator(t)
+	mockChatRepo.EXPECT().GetMember(mock.Anything, chatID, senderID).Return(chatrepo.Member{ChatID: chatID, UserID: senderID}, nil)
+	mockChatRepo.EXPECT().GetChat(mock.Anything, chatID).Return(chatrepo.Chat{ID: chatID, Participants: []chatrepo.User{{ID: senderID}, {ID: recipientID}}}, nil)
+	mockChatRepo.EXPECT().GetMembers(mock.Anything, chatID).Return(nil, nil)
+	mockUserService.EXPECT().CanMessage(mock.Anything, senderID, recipientID).Return(nil)
+	mockLimiter.EXPECT().Allow(mock.Anything, senderID, chatID).Return(nil)
+	mockModerator.EXPECT().Moderate(mock.Anything, mock.Anything).Return(moderation.Decision{}, nil)
+	mockRepo.EXPECT().CreateMessage(mock.Anything, mock.Anything).Return(nil)
+
+	service := msgsvc.NewService(mockRepo, mockChatRepo, mockUserService, mockLimiter, mockModerator, events.NewBus(), commands.NewRegistry(commands.NewMemoryStore()), mocks.NewScheduledRepository(t), time.Now)
+	events, stop := service.SubscribeTyping(ctx, recipientID)
+	defer stop()
+
+	if err := service.StartTyping(ctx, chatID, senderID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if _, err := service.CreateMessage(ctx, msgsvc.MessageInput{
+		SenderID:    senderID,
+		ChatID:      chatID,
+		Content:     []byte("Hello"),
+		ContentType: message.TextContentType,
+	}); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if e := receiveTyping(t, events); !e.Typing {
+		t.Fatalf("expected a typing event got %v", e)
+	}
+	if e := receiveTyping(t, events); e.Typing {
+		t.Fatalf("expected sending to stop typing got %v", e)
+	}
+}
@@ -0,0 +1,34 @@
+package inmempresencerepo
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"sync"
+	"time"
+)
+
+func New() *repository {
+	return &repository{lastSeen: make(map[uuid.UUID]time.Time)}
+}
+
+type repository struct {
+	mu       sync.RWMutex
+	lastSeen map[uuid.UUID]time.Time
+}
+
+func (r *repository) GetLastSeen(_ context.Context, userID uuid.UUID) (time.Time, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.lastSeen[userID], nil
+}
+
+func (r *repository) SetLastSeen(_ context.Context, userID uuid.UUID, t time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if t.After(r.lastSeen[userID]) {
+		r.lastSeen[userID] = t
+	}
+	return nil
+}
@@ -0,0 +1,322 @@
+package presencesvc
+
+import (
+	"context"
+	"errors"
+	"github.com/AliUnipal/chat/internal/models/user"
+	chatrepo "github.com/AliUnipal/chat/internal/service/chatsvc/repo"
+	"github.com/google/uuid"
+	"sync"
+	"time"
+)
+
+const (
+	// awayAfter is how long a connection can go without a heartbeat before
+	// its user shows as away.
+	awayAfter = time.Minute
+	// connectionTimeout is how long a connection can go without a heartbeat
+	// before it is dropped.
+	connectionTimeout = 5 * time.Minute
+	// eventBuffer is how many events a subscriber can fall behind before
+	// further events to it are dropped.
+	eventBuffer = 16
+)
+
+var ErrConnectionNotFound = errors.New("connection does not exist")
+
+type presenceService interface {
+	Connect(ctx context.Context, userID uuid.UUID) (uuid.UUID, error)
+	Heartbeat(ctx context.Context, connID uuid.UUID) error
+	Disconnect(ctx context.Context, connID uuid.UUID) error
+	GetPresence(ctx context.Context, viewerID, userID uuid.UUID) (user.Presence, error)
+	Subscribe(ctx context.Context, userID uuid.UUID) (<-chan user.Presence, func())
+	Sweep(ctx context.Context, now time.Time) error
+	Run(ctx context.Context, interval time.Duration)
+}
+
+type presenceRepository interface {
+	GetLastSeen(ctx context.Context, userID uuid.UUID) (time.Time, error)
+	SetLastSeen(ctx context.Context, userID uuid.UUID, t time.Time) error
+}
+
+type chatRepository interface {
+	GetChatsByUser(ctx context.Context, userID uuid.UUID) ([]chatrepo.UserChat, error)
+}
+
+type userService interface {
+	CanSeeLastSeen(ctx context.Context, viewerID, ownerID uuid.UUID) (bool, error)
+}
+
+type service struct {
+	repo     presenceRepository
+	chatRepo chatRepository
+	users    userService
+
+	mu sync.Mutex
+	// conns maps a connection to its user, and heartbeats holds the last
+	// heartbeat of every connection per user.
+	conns       map[uuid.UUID]uuid.UUID
+	heartbeats  map[uuid.UUID]map[uuid.UUID]time.Time
+	statuses    map[uuid.UUID]user.Status
+	subscribers map[uuid.UUID]map[chan user.Presence]struct{}
+}
+
+func NewService(repo presenceRepository, chatRepo chatRepository, users userService) *service {
+	return &service{
+		repo:        repo,
+		chatRepo:    chatRepo,
+		users:       users,
+		conns:       make(map[uuid.UUID]uuid.UUID),
+		heartbeats:  make(map[uuid.UUID]map[uuid.UUID]time.Time),
+		statuses:    make(map[uuid.UUID]user.Status),
+		subscribers: make(map[uuid.UUID]map[chan user.Presence]struct{}),
+	}
+}
+
+var _ presenceService = (*service)(nil)
+
+// Connect registers a new connection for userID and returns its ID, which the
+// client uses for heartbeats and to disconnect.
+func (s *service) Connect(ctx context.Context, userID uuid.UUID) (uuid.UUID, error) {
+	if userID == uuid.Nil {
+		return uuid.Nil, errors.New("user id is required")
+	}
+
+	now := time.Now().UTC()
+	connID := uuid.New()
+	s.mu.Lock()
+	s.conns[connID] = userID
+	if s.heartbeats[userID] == nil {
+		s.heartbeats[userID] = make(map[uuid.UUID]time.Time)
+	}
+	s.heartbeats[userID][connID] = now
+	changed := s.refresh(userID, now)
+	s.mu.Unlock()
+
+	return connID, s.touch(ctx, userID, now, changed)
+}
+
+// Heartbeat keeps the connection alive and marks its user as active.
+func (s *service) Heartbeat(ctx context.Context, connID uuid.UUID) error {
+	now := time.Now().UTC()
+	s.mu.Lock()
+	userID, ok := s.conns[connID]
+	if !ok {
+		s.mu.Unlock()
+		return ErrConnectionNotFound
+	}
+	s.heartbeats[userID][connID] = now
+	changed := s.refresh(userID, now)
+	s.mu.Unlock()
+
+	return s.touch(ctx, userID, now, changed)
+}
+
+// Disconnect drops the connection. Its user goes offline once they have no
+// connections left.
+func (s *service) Disconnect(ctx context.Context, connID uuid.UUID) error {
+	now := time.Now().UTC()
+	s.mu.Lock()
+	userID, ok := s.conns[connID]
+	if !ok {
+		s.mu.Unlock()
+		return ErrConnectionNotFound
+	}
+	s.drop(userID, connID)
+	changed := s.refresh(userID, now)
+	s.mu.Unlock()
+
+	return s.touch(ctx, userID, now, changed)
+}
+
+// GetPresence returns userID's presence as viewerID is allowed to see it.
+func (s *service) GetPresence(ctx context.Context, viewerID, userID uuid.UUID) (user.Presence, error) {
+	s.mu.Lock()
+	status := s.statuses[userID]
+	s.mu.Unlock()
+
+	lastSeen, err := s.repo.GetLastSeen(ctx, userID)
+	if err != nil {
+		return user.Presence{}, err
+	}
+
+	return s.view(ctx, viewerID, user.Presence{UserID: userID, Status: status, LastSeen: lastSeen})
+}
+
+// Subscribe returns a channel receiving the presence changes of the users who
+// share a chat with userID. The returned func stops the subscription and
+// closes the channel. Events are dropped for subscribers that fall behind.
+func (s *service) Subscribe(_ context.Context, userID uuid.UUID) (<-chan user.Presence, func()) {
+	ch := make(chan user.Presence, eventBuffer)
+	s.mu.Lock()
+	if s.subscribers[userID] == nil {
+		s.subscribers[userID] = make(map[chan user.Presence]struct{})
+	}
+	s.subscribers[userID][ch] = struct{}{}
+	s.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			delete(s.subscribers[userID], ch)
+			if len(s.subscribers[userID]) == 0 {
+				delete(s.subscribers, userID)
+			}
+			close(ch)
+		})
+	}
+}
+
+// Sweep drops the connections that timed out and moves users who stopped
+// sending heartbeats to away or offline, as of now.
+func (s *service) Sweep(ctx context.Context, now time.Time) error {
+	s.mu.Lock()
+	for connID, userID := range s.conns {
+		if now.Sub(s.heartbeats[userID][connID]) >= connectionTimeout {
+			s.drop(userID, connID)
+		}
+	}
+	var changed []user.Presence
+	for userID := range s.statuses {
+		if s.refresh(userID, now) {
+			changed = append(changed, user.Presence{UserID: userID, Status: s.statuses[userID]})
+		}
+	}
+	s.mu.Unlock()
+
+	var errs []error
+	for _, p := range changed {
+		lastSeen, err := s.repo.GetLastSeen(ctx, p.UserID)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		p.LastSeen = lastSeen
+		errs = append(errs, s.publish(ctx, p))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Run sweeps every interval until ctx is done.
+func (s *service) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			_ = s.Sweep(ctx, now.UTC())
+		}
+	}
+}
+
+// refresh recomputes userID's status from their connections and reports
+// whether it changed. It must be called with s.mu held.
+func (s *service) refresh(userID uuid.UUID, now time.Time) bool {
+	status := user.Offline
+	for _, hb := range s.heartbeats[userID] {
+		if now.Sub(hb) < awayAfter {
+			status = user.Online
+			break
+		}
+		status = user.Away
+	}
+
+	old := s.statuses[userID]
+	if status == user.Offline {
+		delete(s.statuses, userID)
+	} else {
+		s.statuses[userID] = status
+	}
+	return old != status
+}
+
+// drop removes the connection. It must be called with s.mu held.
+func (s *service) drop(userID, connID uuid.UUID) {
+	delete(s.conns, connID)
+	delete(s.heartbeats[userID], connID)
+	if len(s.heartbeats[userID]) == 0 {
+		delete(s.heartbeats, userID)
+	}
+}
+
+// touch records activity by userID at now and, when their status changed,
+// tells the users they share a chat with.
+func (s *service) touch(ctx context.Context, userID uuid.UUID, now time.Time, changed bool) error {
+	if err := s.repo.SetLastSeen(ctx, userID, now); err != nil {
+		return err
+	}
+	if !changed {
+		return nil
+	}
+
+	s.mu.Lock()
+	status := s.statuses[userID]
+	s.mu.Unlock()
+	return s.publish(ctx, user.Presence{UserID: userID, Status: status, LastSeen: now})
+}
+
+// publish delivers p to the subscribers of every user who shares a chat with
+// p.UserID, hiding LastSeen from those the user's privacy settings exclude.
+func (s *service) publish(ctx context.Context, p user.Presence) error {
+	userChats, err := s.chatRepo.GetChatsByUser(ctx, p.UserID)
+	if err != nil {
+		return err
+	}
+
+	seen := map[uuid.UUID]bool{p.UserID: true}
+	for _, c := range userChats {
+		for _, participant := range c.Participants {
+			if seen[participant.ID] {
+				continue
+			}
+			seen[participant.ID] = true
+			if !s.subscribed(participant.ID) {
+				continue
+			}
+			v, err := s.view(ctx, participant.ID, p)
+			if err != nil {
+				return err
+			}
+			s.send(participant.ID, v)
+		}
+	}
+
+	return nil
+}
+
+func (s *service) subscribed(userID uuid.UUID) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.subscribers[userID]) > 0
+}
+
+func (s *service) send(userID uuid.UUID, p user.Presence) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for ch := range s.subscribers[userID] {
+		select {
+		case ch <- p:
+		default:
+		}
+	}
+}
+
+// view returns p as viewerID is allowed to see it.
+func (s *service) view(ctx context.Context, viewerID uuid.UUID, p user.Presence) (user.Presence, error) {
+	visible, err := s.users.CanSeeLastSeen(ctx, viewerID, p.UserID)
+	if err != nil {
+		return user.Presence{}, err
+	}
+	if !visible {
+		p.LastSeen = time.Time{}
+	}
+
+	return p, nil
+}
@@ -0,0 +1,146 @@
+package presencesvc_test
+
+import (
+	"context"
+	"errors"
+	"github.com/AliUnipal/chat/internal/models/user"
+	chatrepo "github.com/AliUnipal/chat/internal/service/chatsvc/repo"
+	"github.com/AliUnipal/chat/internal/service/presencesvc"
+	"github.com/AliUnipal/chat/internal/service/presencesvc/mocks"
+	"github.com/AliUnipal/chat/internal/service/presencesvc/repo/inmempresencerepo"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func sharedChat(userA, userB uuid.UUID) []chatrepo.UserChat {
+	return []chatrepo.UserChat{{
+		Chat: chatrepo.Chat{
+			ID:           uuid.New(),
+			Participants: []chatrepo.User{{ID: userA}, {ID: userB}},
+		},
+	}}
+}
+
+func receive(t *testing.T, events <-chan user.Presence) user.Presence {
+	t.Helper()
+	select {
+	case p := <-events:
+		return p
+	default:
+		t.Fatal("expected a presence event, got none")
+		return user.Presence{}
+	}
+}
+
+func TestConnect_PublishOnlineToChatPartners(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	partnerID := uuid.New()
+
+	mockChatRepo := mocks.NewChatRepository(t)
+	mockUserService := mocks.NewUserService(t)
+	mockChatRepo.EXPECT().GetChatsByUser(ctx, userID).Return(sharedChat(userID, partnerID), nil)
+	mockUserService.EXPECT().CanSeeLastSeen(ctx, partnerID, userID).Return(false, nil)
+
+	service := presencesvc.NewService(inmempresencerepo.New(), mockChatRepo, mockUserService)
+	events, stop := service.Subscribe(ctx, partnerID)
+	defer stop()
+
+	if _, err := service.Connect(ctx, userID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	p := receive(t, events)
+	if p.UserID != userID || p.Status != user.Online {
+		t.Fatalf("expected %v to be online got %v", userID, p)
+	}
+	if !p.LastSeen.IsZero() {
+		t.Fatalf("expected last seen to be hidden got %v", p.LastSeen)
+	}
+}
+
+func TestSweep_MoveIdleUsersToAwayThenOffline(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockChatRepo := mocks.NewChatRepository(t)
+	mockUserService := mocks.NewUserService(t)
+	mockChatRepo.EXPECT().GetChatsByUser(ctx, userID).Return(nil, nil)
+	mockUserService.EXPECT().CanSeeLastSeen(ctx, mock.Anything, userID).Return(true, nil)
+
+	service := presencesvc.NewService(inmempresencerepo.New(), mockChatRepo, mockUserService)
+	if _, err := service.Connect(ctx, userID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		after time.Duration
+		want  user.Status
+	}{
+		{"recent heartbeat", 10 * time.Second, user.Online},
+		{"idle", 2 * time.Minute, user.Away},
+		{"timed out", 10 * time.Minute, user.Offline},
+	}
+	for _, tt := range tests {
+		if err := service.Sweep(ctx, time.Now().Add(tt.after)); err != nil {
+			t.Fatalf("%s: expected no error got %v", tt.name, err)
+		}
+		p, err := service.GetPresence(ctx, uuid.New(), userID)
+		if err != nil {
+			t.Fatalf("%s: expected no error got %v", tt.name, err)
+		}
+		if p.Status != tt.want {
+			t.Fatalf("%s: expected status %v got %v", tt.name, tt.want, p.Status)
+		}
+		if p.LastSeen.IsZero() {
+			t.Fatalf("%s: expected last seen to be set", tt.name)
+		}
+	}
+}
+
+func TestDisconnect_StayOnlineWithOtherConnections(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockChatRepo := mocks.NewChatRepository(t)
+	mockUserService := mocks.NewUserService(t)
+	mockChatRepo.EXPECT().GetChatsByUser(ctx, userID).Return(nil, nil)
+	mockUserService.EXPECT().CanSeeLastSeen(ctx, userID, userID).Return(true, nil)
+
+	service := presencesvc.NewService(inmempresencerepo.New(), mockChatRepo, mockUserService)
+	phone, err := service.Connect(ctx, userID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	laptop, err := service.Connect(ctx, userID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	for _, step := range []struct {
+		connID uuid.UUID
+		want   user.Status
+	}{{phone, user.Online}, {laptop, user.Offline}} {
+		if err := service.Disconnect(ctx, step.connID); err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+		p, err := service.GetPresence(ctx, userID, userID)
+		if err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+		if p.Status != step.want {
+			t.Fatalf("expected status %v got %v", step.want, p.Status)
+		}
+	}
+}
+
+func TestHeartbeat_ReturnErrorOnUnknownConnection(t *testing.T) {
+	ctx := context.Background()
+
+	service := presencesvc.NewService(inmempresencerepo.New(), mocks.NewChatRepository(t), mocks.NewUserService(t))
+	if err := service.Heartbeat(ctx, uuid.New()); !errors.Is(err, presencesvc.ErrConnectionNotFound) {
+		t.Fatalf("expected %v got %v", presencesvc.ErrConnectionNotFound, err)
+	}
+}
@@ -0,0 +1,50 @@
+package inmempreviewrepo
+
+import (
+	"context"
+	"github.com/AliUnipal/chat/internal/service/previewsvc/repo"
+	"sync"
+)
+
+// maxPreviews is how many links are cached. Past it, the link fetched the
+// longest ago is dropped.
+const maxPreviews = 10000
+
+func New() *repository {
+	return &repository{previews: make(map[string]repo.Preview)}
+}
+
+// repository is safe for concurrent use, as previews are fetched by several
+// workers at once.
+type repository struct {
+	mu       sync.RWMutex
+	previews map[string]repo.Preview
+}
+
+func (r *repository) GetPreview(_ context.Context, url string) (repo.Preview, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	p, ok := r.previews[url]
+	if !ok {
+		return repo.Preview{}, repo.ErrPreviewNotFound
+	}
+	return p, nil
+}
+
+func (r *repository) SavePreview(_ context.Context, p repo.Preview) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.previews[p.URL]; !ok && len(r.previews) >= maxPreviews {
+		var oldest repo.Preview
+		for _, c := range r.previews {
+			if oldest.URL == "" || c.FetchedAt.Before(oldest.FetchedAt) {
+				oldest = c
+			}
+		}
+		delete(r.previews, oldest.URL)
+	}
+	r.previews[p.URL] = p
+	return nil
+}
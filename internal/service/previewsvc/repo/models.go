@@ -0,0 +1,18 @@
+package repo
+
+import (
+	"errors"
+	"github.com/AliUnipal/chat/internal/models/message"
+	"time"
+)
+
+var ErrPreviewNotFound = errors.New("link preview is not cached")
+
+// Preview is the cached preview of a link. Links that could not be previewed
+// are cached as well, so they are not fetched over and over.
+type Preview struct {
+	URL       string
+	Preview   message.Preview
+	Failed    bool
+	FetchedAt time.Time
+}
@@ -0,0 +1,247 @@
+// Package previewsvc attaches previews of the links in text messages to them,
+// in the background, once they have been sent.
+package previewsvc
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"github.com/AliUnipal/chat/internal/events"
+	"github.com/AliUnipal/chat/internal/models/message"
+	msgrepo "github.com/AliUnipal/chat/internal/service/msgsvc/repo"
+	"github.com/AliUnipal/chat/internal/service/previewsvc/repo"
+	"github.com/google/uuid"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrQueueFull = errors.New("link preview queue is full")
+
+type Config struct {
+	// MaxLinks is how many links of a message get a preview.
+	MaxLinks int
+	// CacheTTL is how long a preview is reused before its link is fetched
+	// again. FailureTTL is the same for links that could not be previewed.
+	CacheTTL   time.Duration
+	FailureTTL time.Duration
+	// QueueSize is how many messages can wait for their previews. Messages
+	// sent while the queue is full get none.
+	QueueSize int
+}
+
+var DefaultConfig = Config{
+	MaxLinks:   3,
+	CacheTTL:   24 * time.Hour,
+	FailureTTL: time.Hour,
+	QueueSize:  1000,
+}
+
+// linkPattern matches the bare http and https URLs of a text.
+var linkPattern = regexp.MustCompile(`(?i)\bhttps?://[^\s<>"']+`)
+
+type previewService interface {
+	HandleEvent(ctx context.Context, e events.Event) error
+	Unfurl(ctx context.Context, e events.Event, now time.Time) error
+	Run(ctx context.Context, workers int)
+}
+
+type previewRepository interface {
+	GetPreview(ctx context.Context, url string) (repo.Preview, error)
+	SavePreview(ctx context.Context, p repo.Preview) error
+}
+
+type messageRepository interface {
+	SetPreviews(ctx context.Context, chatID, id uuid.UUID, previews []message.Preview) error
+}
+
+// fetcher builds the preview of the page a link points to.
+type fetcher interface {
+	Fetch(ctx context.Context, url string) (message.Preview, error)
+}
+
+// publisher announces messages that gained previews.
+type publisher interface {
+	Publish(ctx context.Context, e events.Event)
+}
+
+type service struct {
+	repo    previewRepository
+	msgs    messageRepository
+	fetcher fetcher
+	events  publisher
+	config  Config
+
+	queue chan events.Event
+}
+
+var _ previewService = (*service)(nil)
+
+func NewService(repo previewRepository, msgs messageRepository, fetcher fetcher, bus publisher, config Config) *service {
+	return &service{
+		repo:    repo,
+		msgs:    msgs,
+		fetcher: fetcher,
+		events:  bus,
+		config:  config,
+		queue:   make(chan events.Event, config.QueueSize),
+	}
+}
+
+// HandleEvent queues new text messages with links for Run to preview. It
+// never blocks: when the queue is full the message is dropped and
+// ErrQueueFull is returned.
+func (s *service) HandleEvent(_ context.Context, e events.Event) error {
+	if e.Type != events.MessageCreated || e.Message == nil || len(s.links(*e.Message)) == 0 {
+		return nil
+	}
+
+	select {
+	case s.queue <- e:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Run previews queued messages with the given number of workers until ctx is
+// done.
+func (s *service) Run(ctx context.Context, workers int) {
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case e := <-s.queue:
+					_ = s.Unfurl(ctx, e, time.Now().UTC())
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// Unfurl previews the links of the message of a MessageCreated event,
+// reusing the previews cached as of now, and publishes a MessageUpdated event
+// when any could be built. Links that cannot be previewed are skipped.
+//
+// What a disappearing message links to is never cached, so that it goes with
+// the message.
+func (s *service) Unfurl(ctx context.Context, e events.Event, now time.Time) error {
+	if e.Message == nil {
+		return nil
+	}
+	m := *e.Message
+
+	var previews []message.Preview
+	for _, link := range s.links(m) {
+		p, ok, err := s.preview(ctx, link, now, m.ExpiresAt.IsZero())
+		if err != nil {
+			return err
+		}
+		if ok {
+			previews = append(previews, p)
+		}
+	}
+	if len(previews) == 0 {
+		return nil
+	}
+
+	err := s.msgs.SetPreviews(ctx, m.ChatID, m.ID, previews)
+	if errors.Is(err, msgrepo.ErrMessageNotFound) {
+		// Deleted while its links were fetched.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	m.Previews = previews
+	s.events.Publish(ctx, events.Event{
+		Type:       events.MessageUpdated,
+		ChatID:     m.ChatID,
+		UserID:     m.SenderID,
+		Recipients: e.Recipients,
+		Message:    &m,
+		Timestamp:  now,
+	})
+
+	return nil
+}
+
+// preview returns the preview of a link, from the cache when it is fresh as of
+// now, caching what it fetches when cache is set. It reports false when the
+// link cannot be previewed.
+func (s *service) preview(ctx context.Context, link string, now time.Time, cache bool) (message.Preview, bool, error) {
+	cached, err := s.repo.GetPreview(ctx, link)
+	switch {
+	case err == nil:
+		ttl := s.config.CacheTTL
+		if cached.Failed {
+			ttl = s.config.FailureTTL
+		}
+		if now.Before(cached.FetchedAt.Add(ttl)) {
+			return cached.Preview, !cached.Failed, nil
+		}
+	case !errors.Is(err, repo.ErrPreviewNotFound):
+		return message.Preview{}, false, err
+	}
+
+	p, err := s.fetcher.Fetch(ctx, link)
+	if ctx.Err() != nil {
+		// Shutting down says nothing about the link.
+		return message.Preview{}, false, ctx.Err()
+	}
+	if cache {
+		if err := s.repo.SavePreview(ctx, repo.Preview{URL: link, Preview: p, Failed: err != nil, FetchedAt: now}); err != nil {
+			return message.Preview{}, false, err
+		}
+	}
+
+	return p, err == nil, nil
+}
+
+// links returns the distinct links of a text message in the order they
+// appear, both bare and behind the labels of rich text, up to MaxLinks.
+func (s *service) links(m message.Message) []string {
+	if !m.ContentType.IsText() {
+		return nil
+	}
+
+	type link struct {
+		offset int
+		url    string
+	}
+	var found []link
+	for _, e := range m.Entities {
+		if e.URL != "" && !strings.HasPrefix(e.URL, "mailto:") {
+			found = append(found, link{e.Offset, e.URL})
+		}
+	}
+	for _, loc := range linkPattern.FindAllIndex(m.Content, -1) {
+		u := string(m.Content[loc[0]:loc[1]])
+		u = strings.TrimRight(u, ".,:;!?")
+		if strings.HasSuffix(u, ")") && !strings.Contains(u, "(") {
+			u = strings.TrimRight(u, ")")
+		}
+		found = append(found, link{loc[0], u})
+	}
+	slices.SortStableFunc(found, func(a, b link) int { return cmp.Compare(a.offset, b.offset) })
+
+	var links []string
+	for _, l := range found {
+		if len(links) == s.config.MaxLinks {
+			break
+		}
+		if !slices.Contains(links, l.url) {
+			links = append(links, l.url)
+		}
+	}
+
+	return links
+}
@@ -0,0 +1,160 @@
+package previewsvc_test
+
+import (
+	"context"
+	"errors"
+	"github.com/AliUnipal/chat/internal/events"
+	"github.com/AliUnipal/chat/internal/linkpreview"
+	"github.com/AliUnipal/chat/internal/models/message"
+	msgrepo "github.com/AliUnipal/chat/internal/service/msgsvc/repo"
+	"github.com/AliUnipal/chat/internal/service/previewsvc"
+	"github.com/AliUnipal/chat/internal/service/previewsvc/mocks"
+	"github.com/AliUnipal/chat/internal/service/previewsvc/repo"
+	"github.com/AliUnipal/chat/internal/service/previewsvc/repo/inmempreviewrepo"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"slices"
+	"testing"
+	"time"
+)
+
+func messageEvent(content string, contentType message.ContentType, entities ...message.Entity) events.Event {
+	m := &message.Message{
+		ID:          uuid.New(),
+		SenderID:    uuid.New(),
+		ChatID:      uuid.New(),
+		Content:     []byte(content),
+		ContentType: contentType,
+		Entities:    entities,
+	}
+	return events.Event{Type: events.MessageCreated, ChatID: m.ChatID, UserID: m.SenderID, Recipients: []uuid.UUID{m.SenderID}, Message: m}
+}
+
+func TestUnfurl_AttachesPreviews(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	e := messageEvent(
+		"docs (https://a.example/x), see https://b.example. again https://a.example/x and https://c.example https://d.example",
+		message.RichTextContentType,
+		message.Entity{Type: message.LinkEntity, Offset: 0, Length: 4, URL: "https://docs.example"},
+	)
+	expected := []message.Preview{
+		{URL: "https://docs.example", Title: "Docs"},
+		{URL: "https://a.example/x", Title: "A"},
+		{URL: "https://c.example", Title: "C"},
+	}
+
+	mockFetcher := mocks.NewFetcher(t)
+	mockFetcher.EXPECT().Fetch(mock.Anything, "https://docs.example").Return(expected[0], nil).Once()
+	mockFetcher.EXPECT().Fetch(mock.Anything, "https://a.example/x").Return(expected[1], nil).Once()
+	mockFetcher.EXPECT().Fetch(mock.Anything, "https://b.example").Return(message.Preview{}, linkpreview.ErrNoPreview).Once()
+	mockFetcher.EXPECT().Fetch(mock.Anything, "https://c.example").Return(expected[2], nil).Once()
+	mockMsgs := mocks.NewMessageRepository(t)
+	mockMsgs.EXPECT().SetPreviews(mock.Anything, e.ChatID, e.Message.ID, expected).Return(nil)
+	bus := events.NewBus()
+	var updates []events.Event
+	bus.Subscribe(func(_ context.Context, e events.Event) { updates = append(updates, e) })
+
+	config := previewsvc.DefaultConfig
+	config.MaxLinks = 4
+	service := previewsvc.NewService(inmempreviewrepo.New(), mockMsgs, mockFetcher, bus, config)
+	if err := service.Unfurl(ctx, e, now); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(updates) != 1 || updates[0].Type != events.MessageUpdated || !slices.Equal(updates[0].Message.Previews, expected) {
+		t.Fatalf("expected a message.updated event with the previews got %+v", updates)
+	}
+
+	// Previews and failures are cached, so a second message fetches nothing.
+	second := messageEvent("https://b.example and https://a.example/x", message.TextContentType)
+	mockMsgs.EXPECT().SetPreviews(mock.Anything, second.ChatID, second.Message.ID, expected[1:2]).Return(nil)
+	if err := service.Unfurl(ctx, second, now.Add(time.Minute)); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+}
+
+func TestUnfurl_CacheExpires(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	config := previewsvc.DefaultConfig
+
+	mockFetcher := mocks.NewFetcher(t)
+	mockFetcher.EXPECT().Fetch(mock.Anything, "https://a.example").Return(message.Preview{}, errors.New("connection refused")).Twice()
+	mockMsgs := mocks.NewMessageRepository(t)
+	service := previewsvc.NewService(inmempreviewrepo.New(), mockMsgs, mockFetcher, events.NewBus(), config)
+
+	for _, at := range []time.Time{now, now.Add(config.FailureTTL - time.Second), now.Add(config.FailureTTL)} {
+		if err := service.Unfurl(ctx, messageEvent("https://a.example", message.TextContentType), at); err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+	}
+}
+
+func TestUnfurl_DisappearingMessagesAreNotCached(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	preview := message.Preview{URL: "https://a.example", Title: "A"}
+
+	mockFetcher := mocks.NewFetcher(t)
+	mockFetcher.EXPECT().Fetch(mock.Anything, "https://a.example").Return(preview, nil).Twice()
+	mockMsgs := mocks.NewMessageRepository(t)
+	mockMsgs.EXPECT().SetPreviews(mock.Anything, mock.Anything, mock.Anything, []message.Preview{preview}).Return(nil)
+	cache := inmempreviewrepo.New()
+	service := previewsvc.NewService(cache, mockMsgs, mockFetcher, events.NewBus(), previewsvc.DefaultConfig)
+
+	disappearing := messageEvent("https://a.example", message.TextContentType)
+	disappearing.Message.ExpiresAt = now.Add(time.Hour)
+	if err := service.Unfurl(ctx, disappearing, now); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if _, err := cache.GetPreview(ctx, "https://a.example"); !errors.Is(err, repo.ErrPreviewNotFound) {
+		t.Fatalf("expected %v got %v", repo.ErrPreviewNotFound, err)
+	}
+
+	// A message that stays fetches the link again, and caches it.
+	if err := service.Unfurl(ctx, messageEvent("https://a.example", message.TextContentType), now); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if _, err := cache.GetPreview(ctx, "https://a.example"); err != nil {
+		t.Fatalf("expected the preview cached got %v", err)
+	}
+}
+
+func TestUnfurl_IgnoreDeletedMessage(t *testing.T) {
+	ctx := context.Background()
+
+	mockFetcher := mocks.NewFetcher(t)
+	mockFetcher.EXPECT().Fetch(mock.Anything, "https://a.example").Return(message.Preview{URL: "https://a.example", Title: "A"}, nil)
+	mockMsgs := mocks.NewMessageRepository(t)
+	mockMsgs.EXPECT().SetPreviews(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(msgrepo.ErrMessageNotFound)
+	mockPublisher := mocks.NewPublisher(t)
+	service := previewsvc.NewService(inmempreviewrepo.New(), mockMsgs, mockFetcher, mockPublisher, previewsvc.DefaultConfig)
+
+	if err := service.Unfurl(ctx, messageEvent("https://a.example", message.TextContentType), time.Now().UTC()); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+}
+
+func TestHandleEvent(t *testing.T) {
+	ctx := context.Background()
+	config := previewsvc.DefaultConfig
+	config.QueueSize = 1
+	service := previewsvc.NewService(inmempreviewrepo.New(), mocks.NewMessageRepository(t), mocks.NewFetcher(t), events.NewBus(), config)
+
+	skipped := []events.Event{
+		messageEvent("no links here", message.TextContentType),
+		messageEvent("https://a.example", message.EncryptedContentType),
+		{Type: events.MessageDeleted, Message: &message.Message{ID: uuid.New()}},
+	}
+	for _, e := range skipped {
+		if err := service.HandleEvent(ctx, e); err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+	}
+	if err := service.HandleEvent(ctx, messageEvent("https://a.example", message.TextContentType)); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if err := service.HandleEvent(ctx, messageEvent("https://b.example", message.TextContentType)); !errors.Is(err, previewsvc.ErrQueueFull) {
+		t.Fatalf("expected %v got %v", previewsvc.ErrQueueFull, err)
+	}
+}
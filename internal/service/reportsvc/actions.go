@@ -0,0 +1,140 @@
+package reportsvc
+
+import (
+	"context"
+	"errors"
+	"github.com/AliUnipal/chat/internal/models/report"
+	"github.com/AliUnipal/chat/internal/service/reportsvc/repo"
+	"github.com/google/uuid"
+	"time"
+)
+
+// DeleteMessage removes the reported message and resolves the report.
+func (s *service) DeleteMessage(ctx context.Context, moderatorID, reportID uuid.UUID, note string) error {
+	rep, err := s.take(ctx, moderatorID, reportID)
+	if err != nil {
+		return err
+	}
+	if rep.Target.MessageID == uuid.Nil {
+		return errors.New("report is not about a message")
+	}
+	if err := s.msgs.RemoveMessage(ctx, rep.Target.ChatID, rep.Target.MessageID); err != nil {
+		return err
+	}
+
+	return s.close(ctx, moderatorID, rep, report.Resolved, report.MessageDeleted, note)
+}
+
+// SuspendUser suspends the reported user until until, or for good when it
+// is zero, and resolves the report.
+func (s *service) SuspendUser(ctx context.Context, moderatorID, reportID uuid.UUID, until time.Time, note string) error {
+	if note == "" {
+		return errors.New("note is required")
+	}
+	rep, err := s.take(ctx, moderatorID, reportID)
+	if err != nil {
+		return err
+	}
+	if err := s.users.SuspendUser(ctx, rep.Target.UserID, note, until); err != nil {
+		return err
+	}
+
+	return s.close(ctx, moderatorID, rep, report.Resolved, report.UserSuspended, note)
+}
+
+// DismissReport closes the report without acting on it.
+func (s *service) DismissReport(ctx context.Context, moderatorID, reportID uuid.UUID, note string) error {
+	rep, err := s.take(ctx, moderatorID, reportID)
+	if err != nil {
+		return err
+	}
+
+	return s.close(ctx, moderatorID, rep, report.Dismissed, report.ReportDismissed, note)
+}
+
+// ReviewQuarantined releases a message moderation held back, delivering it
+// to the chat, or removes it unseen. The action is audited without a report.
+func (s *service) ReviewQuarantined(ctx context.Context, moderatorID, chatID, messageID uuid.UUID, release bool, note string) error {
+	if err := s.requireModerator(ctx, moderatorID); err != nil {
+		return err
+	}
+	if err := s.msgs.ReviewMessage(ctx, chatID, messageID, moderatorID, release); err != nil {
+		return err
+	}
+
+	action := report.MessageDeleted
+	if release {
+		action = report.MessageReleased
+	}
+	return s.audit(ctx, moderatorID, repo.Report{Target: report.Target{ChatID: chatID, MessageID: messageID}}, action, note)
+}
+
+// GetAuditLog returns every moderator action, oldest first.
+func (s *service) GetAuditLog(ctx context.Context, moderatorID uuid.UUID) ([]report.AuditEntry, error) {
+	if err := s.requireModerator(ctx, moderatorID); err != nil {
+		return nil, err
+	}
+	entries, err := s.repo.GetAuditLog(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r := make([]report.AuditEntry, 0, len(entries))
+	for _, e := range entries {
+		r = append(r, report.AuditEntry{
+			ID:          e.ID,
+			ModeratorID: e.ModeratorID,
+			ReportID:    e.ReportID,
+			Action:      e.Action,
+			Target:      e.Target,
+			Note:        e.Note,
+			CreatedAt:   e.CreatedAt,
+		})
+	}
+
+	return r, nil
+}
+
+// take returns the report moderatorID is about to act on. Open reports can
+// be acted on by any moderator, reports in review only by their assignee.
+func (s *service) take(ctx context.Context, moderatorID, reportID uuid.UUID) (repo.Report, error) {
+	if err := s.requireModerator(ctx, moderatorID); err != nil {
+		return repo.Report{}, err
+	}
+	rep, err := s.repo.GetReport(ctx, reportID)
+	if err != nil {
+		return repo.Report{}, err
+	}
+	if closed(rep.Status) {
+		return repo.Report{}, ErrReportClosed
+	}
+	if rep.AssigneeID != uuid.Nil && rep.AssigneeID != moderatorID {
+		return repo.Report{}, ErrNotAssignee
+	}
+
+	return rep, nil
+}
+
+func (s *service) close(ctx context.Context, moderatorID uuid.UUID, rep repo.Report, status report.Status, action report.AuditAction, note string) error {
+	rep.AssigneeID = moderatorID
+	rep.Status = status
+	rep.Resolution = note
+	rep.UpdatedAt = time.Now().UTC()
+	if err := s.repo.UpdateReport(ctx, rep); err != nil {
+		return err
+	}
+
+	return s.audit(ctx, moderatorID, rep, action, note)
+}
+
+func (s *service) audit(ctx context.Context, moderatorID uuid.UUID, rep repo.Report, action report.AuditAction, note string) error {
+	return s.repo.AddAuditEntry(ctx, repo.AuditEntry{
+		ID:          uuid.New(),
+		ModeratorID: moderatorID,
+		ReportID:    rep.ID,
+		Action:      action,
+		Target:      rep.Target,
+		Note:        note,
+		CreatedAt:   time.Now().UTC(),
+	})
+}
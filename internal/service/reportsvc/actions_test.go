@@ -0,0 +1,134 @@
+package reportsvc_test
+
+import (
+	"context"
+	"errors"
+	"github.com/AliUnipal/chat/internal/models/report"
+	"github.com/AliUnipal/chat/internal/service/reportsvc"
+	"github.com/AliUnipal/chat/internal/service/reportsvc/mocks"
+	"github.com/AliUnipal/chat/internal/service/reportsvc/repo"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestDeleteMessage_RemoveAndResolve(t *testing.T) {
+	ctx := context.Background()
+	moderatorID := uuid.New()
+	rep := repo.Report{
+		ID:     uuid.New(),
+		Target: report.Target{UserID: uuid.New(), ChatID: uuid.New(), MessageID: uuid.New()},
+		Status: report.Open,
+	}
+
+	mockRepo := mocks.NewReportRepository(t)
+	mockMsgs := mocks.NewMessageService(t)
+	mockRepo.EXPECT().IsModerator(ctx, moderatorID).Return(true, nil)
+	mockRepo.EXPECT().GetReport(ctx, rep.ID).Return(rep, nil)
+	mockMsgs.EXPECT().RemoveMessage(ctx, rep.Target.ChatID, rep.Target.MessageID).Return(nil)
+	mockRepo.EXPECT().UpdateReport(ctx, mock.MatchedBy(func(r repo.Report) bool {
+		return r.Status == report.Resolved && r.AssigneeID == moderatorID && r.Resolution == "abusive"
+	})).Return(nil)
+	mockRepo.EXPECT().AddAuditEntry(ctx, mock.MatchedBy(func(e repo.AuditEntry) bool {
+		return e.ModeratorID == moderatorID && e.Action == report.MessageDeleted && e.Target == rep.Target && e.Note == "abusive"
+	})).Return(nil)
+
+	service := reportsvc.NewService(mockRepo, mockMsgs, mocks.NewUserService(t))
+	if err := service.DeleteMessage(ctx, moderatorID, rep.ID, "abusive"); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+}
+
+func TestSuspendUser_SuspendAndResolve(t *testing.T) {
+	ctx := context.Background()
+	moderatorID := uuid.New()
+	until := time.Now().Add(24 * time.Hour)
+	rep := repo.Report{ID: uuid.New(), Target: report.Target{UserID: uuid.New()}, Status: report.InReview, AssigneeID: moderatorID}
+
+	mockRepo := mocks.NewReportRepository(t)
+	mockUsers := mocks.NewUserService(t)
+	mockRepo.EXPECT().IsModerator(ctx, moderatorID).Return(true, nil)
+	mockRepo.EXPECT().GetReport(ctx, rep.ID).Return(rep, nil)
+	mockUsers.EXPECT().SuspendUser(ctx, rep.Target.UserID, "spam", until).Return(nil)
+	mockRepo.EXPECT().UpdateReport(ctx, mock.MatchedBy(func(r repo.Report) bool {
+		return r.Status == report.Resolved
+	})).Return(nil)
+	mockRepo.EXPECT().AddAuditEntry(ctx, mock.MatchedBy(func(e repo.AuditEntry) bool {
+		return e.Action == report.UserSuspended
+	})).Return(nil)
+
+	service := reportsvc.NewService(mockRepo, mocks.NewMessageService(t), mockUsers)
+	if err := service.SuspendUser(ctx, moderatorID, rep.ID, until, "spam"); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+}
+
+func TestDismissReport_ReturnError(t *testing.T) {
+	ctx := context.Background()
+	moderatorID := uuid.New()
+
+	tests := []struct {
+		name     string
+		report   repo.Report
+		expected error
+	}{
+		{name: "assigned to another moderator", report: repo.Report{Status: report.InReview, AssigneeID: uuid.New()}, expected: reportsvc.ErrNotAssignee},
+		{name: "already resolved", report: repo.Report{Status: report.Resolved}, expected: reportsvc.ErrReportClosed},
+		{name: "already dismissed", report: repo.Report{Status: report.Dismissed}, expected: reportsvc.ErrReportClosed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.report.ID = uuid.New()
+			mockRepo := mocks.NewReportRepository(t)
+			mockRepo.EXPECT().IsModerator(ctx, moderatorID).Return(true, nil)
+			mockRepo.EXPECT().GetReport(ctx, tt.report.ID).Return(tt.report, nil)
+
+			service := reportsvc.NewService(mockRepo, mocks.NewMessageService(t), mocks.NewUserService(t))
+			if err := service.DismissReport(ctx, moderatorID, tt.report.ID, ""); !errors.Is(err, tt.expected) {
+				t.Fatalf("expected %v got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestReviewQuarantined(t *testing.T) {
+	ctx := context.Background()
+	moderatorID, chatID, messageID := uuid.New(), uuid.New(), uuid.New()
+
+	tests := []struct {
+		name     string
+		release  bool
+		expected report.AuditAction
+	}{
+		{name: "release", release: true, expected: report.MessageReleased},
+		{name: "remove", release: false, expected: report.MessageDeleted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewReportRepository(t)
+			mockMsgs := mocks.NewMessageService(t)
+			mockRepo.EXPECT().IsModerator(ctx, moderatorID).Return(true, nil)
+			mockMsgs.EXPECT().ReviewMessage(ctx, chatID, messageID, moderatorID, tt.release).Return(nil)
+			mockRepo.EXPECT().AddAuditEntry(ctx, mock.MatchedBy(func(e repo.AuditEntry) bool {
+				return e.ModeratorID == moderatorID && e.ReportID == uuid.Nil && e.Action == tt.expected &&
+					e.Target == report.Target{ChatID: chatID, MessageID: messageID} && e.Note == "checked"
+			})).Return(nil)
+
+			service := reportsvc.NewService(mockRepo, mockMsgs, mocks.NewUserService(t))
+			if err := service.ReviewQuarantined(ctx, moderatorID, chatID, messageID, tt.release, "checked"); err != nil {
+				t.Fatalf("expected no error got %v", err)
+			}
+		})
+	}
+
+	t.Run("not a moderator", func(t *testing.T) {
+		mockRepo := mocks.NewReportRepository(t)
+		mockRepo.EXPECT().IsModerator(ctx, moderatorID).Return(false, nil)
+
+		service := reportsvc.NewService(mockRepo, mocks.NewMessageService(t), mocks.NewUserService(t))
+		if err := service.ReviewQuarantined(ctx, moderatorID, chatID, messageID, true, ""); !errors.Is(err, reportsvc.ErrNotModerator) {
+			t.Fatalf("expected %v got %v", reportsvc.ErrNotModerator, err)
+		}
+	})
+}
@@ -0,0 +1,140 @@
+package inmemreportrepo
+
+import (
+	"context"
+	"errors"
+	"github.com/AliUnipal/chat/internal/models/message"
+	"github.com/AliUnipal/chat/internal/service/reportsvc/repo"
+	"github.com/google/uuid"
+	"maps"
+	"slices"
+	"sync"
+)
+
+func New(moderatorIDs ...uuid.UUID) *repository {
+	moderators := make(map[uuid.UUID]struct{})
+	for _, id := range moderatorIDs {
+		moderators[id] = struct{}{}
+	}
+
+	return &repository{
+		reports:    make(map[uuid.UUID]repo.Report),
+		moderators: moderators,
+	}
+}
+
+// repository is safe for concurrent use, as disappeared messages are
+// redacted from snapshots in the background.
+type repository struct {
+	mu         sync.RWMutex
+	reports    map[uuid.UUID]repo.Report
+	moderators map[uuid.UUID]struct{}
+	audit      []repo.AuditEntry
+}
+
+func (r *repository) CreateReport(_ context.Context, in repo.Report) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.reports[in.ID]; ok {
+		return errors.New("report already exists")
+	}
+
+	r.reports[in.ID] = in
+	return nil
+}
+
+func (r *repository) GetReport(_ context.Context, id uuid.UUID) (repo.Report, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rep, ok := r.reports[id]
+	if !ok {
+		return repo.Report{}, repo.ErrReportNotFound
+	}
+
+	return rep, nil
+}
+
+func (r *repository) GetReports(_ context.Context) ([]repo.Report, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Collect(maps.Values(r.reports)), nil
+}
+
+// UpdateReport saves the review of a report. It keeps the stored snapshot,
+// which is taken when the report is filed and only ever redacted.
+func (r *repository) UpdateReport(_ context.Context, in repo.Report) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.reports[in.ID]
+	if !ok {
+		return repo.ErrReportNotFound
+	}
+
+	in.Snapshot = stored.Snapshot
+	r.reports[in.ID] = in
+	return nil
+}
+
+// RedactMessage strips the message down to its IDs in every snapshot it is
+// part of.
+func (r *repository) RedactMessage(_ context.Context, messageID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, rep := range r.reports {
+		i := slices.IndexFunc(rep.Snapshot, func(m message.Message) bool { return m.ID == messageID })
+		if i < 0 {
+			continue
+		}
+		m := rep.Snapshot[i]
+		// Snapshots are handed out by GetReport, so the redacted one is a
+		// copy.
+		rep.Snapshot = slices.Clone(rep.Snapshot)
+		rep.Snapshot[i] = message.Message{ID: m.ID, SenderID: m.SenderID, ChatID: m.ChatID, Timestamp: m.Timestamp, ExpiresAt: m.ExpiresAt}
+		r.reports[id] = rep
+	}
+	return nil
+}
+
+func (r *repository) AddModerator(_ context.Context, userID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.moderators[userID] = struct{}{}
+	return nil
+}
+
+func (r *repository) RemoveModerator(_ context.Context, userID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.moderators, userID)
+	return nil
+}
+
+func (r *repository) IsModerator(_ context.Context, userID uuid.UUID) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.moderators[userID]
+	return ok, nil
+}
+
+func (r *repository) AddAuditEntry(_ context.Context, e repo.AuditEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.audit = append(r.audit, e)
+	return nil
+}
+
+func (r *repository) GetAuditLog(_ context.Context) ([]repo.AuditEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Clone(r.audit), nil
+}
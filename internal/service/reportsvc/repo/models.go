@@ -0,0 +1,36 @@
+package repo
+
+import (
+	"errors"
+	"github.com/AliUnipal/chat/internal/models/message"
+	"github.com/AliUnipal/chat/internal/models/report"
+	"github.com/AliUnipal/chat/internal/models/user"
+	"github.com/google/uuid"
+	"time"
+)
+
+var ErrReportNotFound = errors.New("report does not exist")
+
+type Report struct {
+	ID           uuid.UUID
+	ReporterID   uuid.UUID
+	Target       report.Target
+	Reason       report.Reason
+	Status       report.Status
+	AssigneeID   uuid.UUID
+	ReportedUser user.User
+	Snapshot     []message.Message
+	Resolution   string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type AuditEntry struct {
+	ID          uuid.UUID
+	ModeratorID uuid.UUID
+	ReportID    uuid.UUID
+	Action      report.AuditAction
+	Target      report.Target
+	Note        string
+	CreatedAt   time.Time
+}
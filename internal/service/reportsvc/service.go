@@ -0,0 +1,261 @@
+package reportsvc
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"github.com/AliUnipal/chat/internal/events"
+	"github.com/AliUnipal/chat/internal/models/message"
+	"github.com/AliUnipal/chat/internal/models/report"
+	"github.com/AliUnipal/chat/internal/models/user"
+	"github.com/AliUnipal/chat/internal/service/reportsvc/repo"
+	"github.com/google/uuid"
+	"slices"
+	"time"
+)
+
+// contextSize is how many messages around the reported one, or of the
+// reported user's latest, go into a report's snapshot.
+const contextSize = 5
+
+var (
+	ErrAlreadyReported = errors.New("target already reported and awaiting review")
+	ErrTargetNotFound  = errors.New("reported message does not exist")
+	ErrNotModerator    = errors.New("user is not a moderator")
+	ErrNotAssignee     = errors.New("report is assigned to another moderator")
+	ErrReportClosed    = errors.New("report is already closed")
+)
+
+type reportService interface {
+	Report(ctx context.Context, reporterID uuid.UUID, target report.Target, reason report.Reason) (uuid.UUID, error)
+	GetQueue(ctx context.Context, moderatorID uuid.UUID, status report.Status) ([]report.Report, error)
+	GetReport(ctx context.Context, moderatorID, reportID uuid.UUID) (report.Report, error)
+	AssignReport(ctx context.Context, moderatorID, reportID, assigneeID uuid.UUID) error
+	DeleteMessage(ctx context.Context, moderatorID, reportID uuid.UUID, note string) error
+	SuspendUser(ctx context.Context, moderatorID, reportID uuid.UUID, until time.Time, note string) error
+	DismissReport(ctx context.Context, moderatorID, reportID uuid.UUID, note string) error
+	ReviewQuarantined(ctx context.Context, moderatorID, chatID, messageID uuid.UUID, release bool, note string) error
+	GetAuditLog(ctx context.Context, moderatorID uuid.UUID) ([]report.AuditEntry, error)
+	HandleEvent(ctx context.Context, e events.Event) error
+}
+
+type reportRepository interface {
+	CreateReport(ctx context.Context, in repo.Report) error
+	GetReport(ctx context.Context, id uuid.UUID) (repo.Report, error)
+	GetReports(ctx context.Context) ([]repo.Report, error)
+	UpdateReport(ctx context.Context, in repo.Report) error
+	RedactMessage(ctx context.Context, messageID uuid.UUID) error
+	IsModerator(ctx context.Context, userID uuid.UUID) (bool, error)
+	AddAuditEntry(ctx context.Context, e repo.AuditEntry) error
+	GetAuditLog(ctx context.Context) ([]repo.AuditEntry, error)
+}
+
+type messageService interface {
+	GetMessages(ctx context.Context, chatID, userID uuid.UUID) ([]message.Message, error)
+	RemoveMessage(ctx context.Context, chatID, id uuid.UUID) error
+	ReviewMessage(ctx context.Context, chatID, id, reviewerID uuid.UUID, release bool) error
+}
+
+type userService interface {
+	GetUser(ctx context.Context, id uuid.UUID) (user.User, error)
+	SuspendUser(ctx context.Context, userID uuid.UUID, reason string, until time.Time) error
+}
+
+type service struct {
+	repo  reportRepository
+	msgs  messageService
+	users userService
+}
+
+var _ reportService = (*service)(nil)
+
+func NewService(repo reportRepository, msgs messageService, users userService) *service {
+	return &service{repo: repo, msgs: msgs, users: users}
+}
+
+// Report files a report against a message or a user. The snapshot is taken
+// from what the reporter can see, so they can only report chats they belong
+// to. Messages of end-to-end encrypted chats are captured as ciphertext.
+func (s *service) Report(ctx context.Context, reporterID uuid.UUID, target report.Target, reason report.Reason) (uuid.UUID, error) {
+	if reason < report.Spam || reason > report.Other {
+		return uuid.Nil, errors.New("report reason is invalid")
+	}
+
+	var snapshot []message.Message
+	switch {
+	case target.MessageID != uuid.Nil:
+		if target.ChatID == uuid.Nil {
+			return uuid.Nil, errors.New("chatID is empty")
+		}
+		msgs, err := s.msgs.GetMessages(ctx, target.ChatID, reporterID)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		i := slices.IndexFunc(msgs, func(m message.Message) bool { return m.ID == target.MessageID })
+		if i < 0 {
+			return uuid.Nil, ErrTargetNotFound
+		}
+		target.UserID = msgs[i].SenderID
+		snapshot = slices.Clone(msgs[max(0, i-contextSize):min(len(msgs), i+contextSize+1)])
+	case target.UserID != uuid.Nil:
+		if target.ChatID != uuid.Nil {
+			msgs, err := s.msgs.GetMessages(ctx, target.ChatID, reporterID)
+			if err != nil {
+				return uuid.Nil, err
+			}
+			for _, m := range slices.Backward(msgs) {
+				if len(snapshot) == contextSize {
+					break
+				}
+				if m.SenderID == target.UserID {
+					snapshot = append(snapshot, m)
+				}
+			}
+			slices.Reverse(snapshot)
+		}
+	default:
+		return uuid.Nil, errors.New("report target is empty")
+	}
+	if target.UserID == reporterID {
+		return uuid.Nil, errors.New("users cannot report themselves")
+	}
+
+	reports, err := s.repo.GetReports(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if slices.ContainsFunc(reports, func(r repo.Report) bool {
+		return r.ReporterID == reporterID && r.Target == target && !closed(r.Status)
+	}) {
+		return uuid.Nil, ErrAlreadyReported
+	}
+
+	reported, err := s.users.GetUser(ctx, target.UserID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	id := uuid.New()
+	now := time.Now().UTC()
+	if err := s.repo.CreateReport(ctx, repo.Report{
+		ID:           id,
+		ReporterID:   reporterID,
+		Target:       target,
+		Reason:       reason,
+		Status:       report.Open,
+		ReportedUser: reported,
+		Snapshot:     snapshot,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}); err != nil {
+		return uuid.Nil, err
+	}
+
+	return id, nil
+}
+
+// HandleEvent redacts disappeared messages from report snapshots, as their
+// content must not outlive them. Messages deleted otherwise stay, so deleting
+// a reported message does not destroy the evidence. It is meant to be
+// subscribed to the event bus.
+func (s *service) HandleEvent(ctx context.Context, e events.Event) error {
+	if e.Type != events.MessageDeleted || e.Message == nil || e.Message.ExpiresAt.IsZero() {
+		return nil
+	}
+
+	return s.repo.RedactMessage(ctx, e.Message.ID)
+}
+
+// GetQueue returns the reports with the given status, oldest first.
+func (s *service) GetQueue(ctx context.Context, moderatorID uuid.UUID, status report.Status) ([]report.Report, error) {
+	if err := s.requireModerator(ctx, moderatorID); err != nil {
+		return nil, err
+	}
+	reports, err := s.repo.GetReports(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r := make([]report.Report, 0, len(reports))
+	for _, rep := range reports {
+		if rep.Status == status {
+			r = append(r, toReport(rep))
+		}
+	}
+	slices.SortFunc(r, func(a, b report.Report) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), cmp.Compare(a.ID.String(), b.ID.String()))
+	})
+
+	return r, nil
+}
+
+func (s *service) GetReport(ctx context.Context, moderatorID, reportID uuid.UUID) (report.Report, error) {
+	if err := s.requireModerator(ctx, moderatorID); err != nil {
+		return report.Report{}, err
+	}
+	rep, err := s.repo.GetReport(ctx, reportID)
+	if err != nil {
+		return report.Report{}, err
+	}
+
+	return toReport(rep), nil
+}
+
+// AssignReport hands an open report to assigneeID, who must be a moderator,
+// and puts it in review.
+func (s *service) AssignReport(ctx context.Context, moderatorID, reportID, assigneeID uuid.UUID) error {
+	if err := s.requireModerator(ctx, moderatorID); err != nil {
+		return err
+	}
+	if err := s.requireModerator(ctx, assigneeID); err != nil {
+		return err
+	}
+	rep, err := s.repo.GetReport(ctx, reportID)
+	if err != nil {
+		return err
+	}
+	if closed(rep.Status) {
+		return ErrReportClosed
+	}
+
+	rep.AssigneeID = assigneeID
+	rep.Status = report.InReview
+	rep.UpdatedAt = time.Now().UTC()
+	if err := s.repo.UpdateReport(ctx, rep); err != nil {
+		return err
+	}
+
+	return s.audit(ctx, moderatorID, rep, report.ReportAssigned, assigneeID.String())
+}
+
+func (s *service) requireModerator(ctx context.Context, userID uuid.UUID) error {
+	ok, err := s.repo.IsModerator(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrNotModerator
+	}
+
+	return nil
+}
+
+func closed(status report.Status) bool {
+	return status == report.Resolved || status == report.Dismissed
+}
+
+func toReport(r repo.Report) report.Report {
+	return report.Report{
+		ID:           r.ID,
+		ReporterID:   r.ReporterID,
+		Target:       r.Target,
+		Reason:       r.Reason,
+		Status:       r.Status,
+		AssigneeID:   r.AssigneeID,
+		ReportedUser: r.ReportedUser,
+		Snapshot:     slices.Clone(r.Snapshot),
+		Resolution:   r.Resolution,
+		CreatedAt:    r.CreatedAt,
+		UpdatedAt:    r.UpdatedAt,
+	}
+}
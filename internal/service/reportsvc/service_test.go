@@ -0,0 +1,209 @@
+package reportsvc_test
+
+import (
+	"context"
+	"errors"
+	"github.com/AliUnipal/chat/internal/events"
+	"github.com/AliUnipal/chat/internal/models/message"
+	"github.com/AliUnipal/chat/internal/models/report"
+	"github.com/AliUnipal/chat/internal/models/user"
+	"github.com/AliUnipal/chat/internal/service/reportsvc"
+	"github.com/AliUnipal/chat/internal/service/reportsvc/mocks"
+	"github.com/AliUnipal/chat/internal/service/reportsvc/repo"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func chatHistory(chatID uuid.UUID, senders ...uuid.UUID) []message.Message {
+	start := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	msgs := make([]message.Message, 0, len(senders))
+	for i, senderID := range senders {
+		msgs = append(msgs, message.Message{
+			ID:        uuid.New(),
+			SenderID:  senderID,
+			ChatID:    chatID,
+			Content:   []byte{byte('a' + i)},
+			Timestamp: start.Add(time.Duration(i) * time.Minute),
+		})
+	}
+	return msgs
+}
+
+func TestReport_SnapshotMessageWithContext(t *testing.T) {
+	ctx := context.Background()
+	chatID := uuid.New()
+	reporterID := uuid.New()
+	offenderID := uuid.New()
+	senders := make([]uuid.UUID, 20)
+	for i := range senders {
+		senders[i] = []uuid.UUID{reporterID, offenderID}[i%2]
+	}
+	msgs := chatHistory(chatID, senders...)
+	reported := msgs[9]
+
+	mockRepo := mocks.NewReportRepository(t)
+	mockMsgs := mocks.NewMessageService(t)
+	mockUsers := mocks.NewUserService(t)
+	mockMsgs.EXPECT().GetMessages(ctx, chatID, reporterID).Return(msgs, nil)
+	mockRepo.EXPECT().GetReports(ctx).Return(nil, nil)
+	mockUsers.EXPECT().GetUser(ctx, offenderID).Return(user.User{ID: offenderID, Username: "offender"}, nil)
+	mockRepo.EXPECT().CreateReport(ctx, mock.MatchedBy(func(r repo.Report) bool {
+		return r.ReporterID == reporterID &&
+			r.Target == report.Target{UserID: offenderID, ChatID: chatID, MessageID: reported.ID} &&
+			r.Status == report.Open &&
+			r.ReportedUser.Username == "offender" &&
+			len(r.Snapshot) == 11 && r.Snapshot[0].ID == msgs[4].ID && r.Snapshot[10].ID == msgs[14].ID
+	})).Return(nil)
+
+	service := reportsvc.NewService(mockRepo, mockMsgs, mockUsers)
+	id, err := service.Report(ctx, reporterID, report.Target{ChatID: chatID, MessageID: reported.ID}, report.Harassment)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if id == uuid.Nil {
+		t.Fatalf("expected id got %v", id)
+	}
+}
+
+func TestReport_SnapshotUsersLatestMessages(t *testing.T) {
+	ctx := context.Background()
+	chatID := uuid.New()
+	reporterID := uuid.New()
+	offenderID := uuid.New()
+	msgs := chatHistory(chatID, offenderID, offenderID, reporterID, offenderID, offenderID, offenderID, offenderID, reporterID, offenderID)
+
+	mockRepo := mocks.NewReportRepository(t)
+	mockMsgs := mocks.NewMessageService(t)
+	mockUsers := mocks.NewUserService(t)
+	mockMsgs.EXPECT().GetMessages(ctx, chatID, reporterID).Return(msgs, nil)
+	mockRepo.EXPECT().GetReports(ctx).Return(nil, nil)
+	mockUsers.EXPECT().GetUser(ctx, offenderID).Return(user.User{ID: offenderID}, nil)
+	mockRepo.EXPECT().CreateReport(ctx, mock.MatchedBy(func(r repo.Report) bool {
+		return len(r.Snapshot) == 5 && r.Snapshot[0].ID == msgs[3].ID && r.Snapshot[4].ID == msgs[8].ID
+	})).Return(nil)
+
+	service := reportsvc.NewService(mockRepo, mockMsgs, mockUsers)
+	if _, err := service.Report(ctx, reporterID, report.Target{UserID: offenderID, ChatID: chatID}, report.Spam); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+}
+
+func TestReport_ReturnError(t *testing.T) {
+	ctx := context.Background()
+	chatID := uuid.New()
+	reporterID := uuid.New()
+	offenderID := uuid.New()
+	msgs := chatHistory(chatID, reporterID, offenderID)
+
+	tests := []struct {
+		name     string
+		target   report.Target
+		existing []repo.Report
+		expected error
+	}{
+		{name: "unknown message", target: report.Target{ChatID: chatID, MessageID: uuid.New()}, expected: reportsvc.ErrTargetNotFound},
+		{name: "own message", target: report.Target{ChatID: chatID, MessageID: msgs[0].ID}},
+		{name: "empty target"},
+		{
+			name:   "already reported",
+			target: report.Target{ChatID: chatID, MessageID: msgs[1].ID},
+			existing: []repo.Report{{
+				ReporterID: reporterID,
+				Target:     report.Target{UserID: offenderID, ChatID: chatID, MessageID: msgs[1].ID},
+				Status:     report.InReview,
+			}},
+			expected: reportsvc.ErrAlreadyReported,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewReportRepository(t)
+			mockMsgs := mocks.NewMessageService(t)
+			mockUsers := mocks.NewUserService(t)
+			mockMsgs.EXPECT().GetMessages(ctx, chatID, reporterID).Return(msgs, nil).Maybe()
+			mockRepo.EXPECT().GetReports(ctx).Return(tt.existing, nil).Maybe()
+
+			service := reportsvc.NewService(mockRepo, mockMsgs, mockUsers)
+			_, err := service.Report(ctx, reporterID, tt.target, report.Spam)
+			if err == nil || tt.expected != nil && !errors.Is(err, tt.expected) {
+				t.Fatalf("expected %v got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestHandleEvent_RedactDisappearedMessages(t *testing.T) {
+	ctx := context.Background()
+	disappeared := message.Message{ID: uuid.New(), SenderID: uuid.New(), ChatID: uuid.New(), ExpiresAt: time.Now()}
+	deleted := message.Message{ID: uuid.New(), SenderID: uuid.New(), ChatID: uuid.New()}
+
+	mockRepo := mocks.NewReportRepository(t)
+	mockRepo.EXPECT().RedactMessage(ctx, disappeared.ID).Return(nil).Once()
+
+	service := reportsvc.NewService(mockRepo, mocks.NewMessageService(t), mocks.NewUserService(t))
+	for _, m := range []message.Message{disappeared, deleted} {
+		if err := service.HandleEvent(ctx, events.Event{Type: events.MessageDeleted, ChatID: m.ChatID, Message: &m}); err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+	}
+}
+
+func TestGetQueue_OldestFirst(t *testing.T) {
+	ctx := context.Background()
+	moderatorID := uuid.New()
+	now := time.Now()
+	newer := repo.Report{ID: uuid.New(), Status: report.Open, CreatedAt: now}
+	older := repo.Report{ID: uuid.New(), Status: report.Open, CreatedAt: now.Add(-time.Hour)}
+	closed := repo.Report{ID: uuid.New(), Status: report.Resolved, CreatedAt: now.Add(-2 * time.Hour)}
+
+	mockRepo := mocks.NewReportRepository(t)
+	mockRepo.EXPECT().IsModerator(ctx, moderatorID).Return(true, nil)
+	mockRepo.EXPECT().GetReports(ctx).Return([]repo.Report{newer, closed, older}, nil)
+
+	service := reportsvc.NewService(mockRepo, mocks.NewMessageService(t), mocks.NewUserService(t))
+	queue, err := service.GetQueue(ctx, moderatorID, report.Open)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(queue) != 2 || queue[0].ID != older.ID || queue[1].ID != newer.ID {
+		t.Fatalf("expected [%v %v] got %v", older.ID, newer.ID, queue)
+	}
+}
+
+func TestGetQueue_ReturnErrorForNonModerator(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockRepo := mocks.NewReportRepository(t)
+	mockRepo.EXPECT().IsModerator(ctx, userID).Return(false, nil)
+
+	service := reportsvc.NewService(mockRepo, mocks.NewMessageService(t), mocks.NewUserService(t))
+	if _, err := service.GetQueue(ctx, userID, report.Open); !errors.Is(err, reportsvc.ErrNotModerator) {
+		t.Fatalf("expected %v got %v", reportsvc.ErrNotModerator, err)
+	}
+}
+
+func TestAssignReport(t *testing.T) {
+	ctx := context.Background()
+	moderatorID := uuid.New()
+	assigneeID := uuid.New()
+	rep := repo.Report{ID: uuid.New(), Status: report.Open}
+
+	mockRepo := mocks.NewReportRepository(t)
+	mockRepo.EXPECT().IsModerator(ctx, moderatorID).Return(true, nil)
+	mockRepo.EXPECT().IsModerator(ctx, assigneeID).Return(true, nil)
+	mockRepo.EXPECT().GetReport(ctx, rep.ID).Return(rep, nil)
+	mockRepo.EXPECT().UpdateReport(ctx, mock.MatchedBy(func(r repo.Report) bool {
+		return r.AssigneeID == assigneeID && r.Status == report.InReview
+	})).Return(nil)
+	mockRepo.EXPECT().AddAuditEntry(ctx, mock.MatchedBy(func(e repo.AuditEntry) bool {
+		return e.ModeratorID == moderatorID && e.ReportID == rep.ID && e.Action == report.ReportAssigned
+	})).Return(nil)
+
+	service := reportsvc.NewService(mockRepo, mocks.NewMessageService(t), mocks.NewUserService(t))
+	if err := service.AssignReport(ctx, moderatorID, rep.ID, assigneeID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+}
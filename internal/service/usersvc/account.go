@@ -0,0 +1,147 @@
+package usersvc
+
+import (
+	"context"
+	"errors"
+	"github.com/AliUnipal/chat/internal/models/user"
+	"github.com/AliUnipal/chat/internal/service/usersvc/repo"
+	"github.com/google/uuid"
+	"time"
+)
+
+var (
+	ErrSuspended      = errors.New("user is suspended")
+	ErrAccountClosed  = errors.New("account is deactivated or deleted")
+	ErrAccountDeleted = errors.New("account is deleted")
+)
+
+// GetAccountState returns the user's account status. Suspensions end on their
+// own once their time is up.
+func (s *service) GetAccountState(ctx context.Context, userID uuid.UUID) (user.AccountState, error) {
+	u, err := s.repo.GetUser(ctx, userID)
+	if err != nil {
+		return user.AccountState{}, err
+	}
+
+	return accountState(u, time.Now()), nil
+}
+
+// SuspendUser stops userID from sending messages until until, or for good
+// when until is zero.
+func (s *service) SuspendUser(ctx context.Context, userID uuid.UUID, reason string, until time.Time) error {
+	if reason == "" {
+		return errors.New("suspension reason is required")
+	}
+
+	return s.setAccountState(ctx, userID, func(user.AccountState) (user.AccountState, error) {
+		return user.AccountState{Status: user.Suspended, Reason: reason, Until: until}, nil
+	})
+}
+
+func (s *service) UnsuspendUser(ctx context.Context, userID uuid.UUID) error {
+	return s.setAccountState(ctx, userID, func(a user.AccountState) (user.AccountState, error) {
+		if a.Status != user.Suspended {
+			return a, nil
+		}
+		return user.AccountState{Status: user.Active}, nil
+	})
+}
+
+// DeactivateUser closes the user's own account. Nobody can message them
+// until they reactivate it. Suspended users cannot deactivate, so they cannot
+// wait out a suspension that way.
+func (s *service) DeactivateUser(ctx context.Context, userID uuid.UUID) error {
+	return s.setAccountState(ctx, userID, func(a user.AccountState) (user.AccountState, error) {
+		if a.Status == user.Suspended {
+			return a, ErrSuspended
+		}
+		return user.AccountState{Status: user.Deactivated}, nil
+	})
+}
+
+func (s *service) ReactivateUser(ctx context.Context, userID uuid.UUID) error {
+	return s.setAccountState(ctx, userID, func(a user.AccountState) (user.AccountState, error) {
+		if a.Status != user.Deactivated {
+			return a, errors.New("account is not deactivated")
+		}
+		return user.AccountState{Status: user.Active}, nil
+	})
+}
+
+// DeleteUser anonymizes the user's profile and forgets their contacts,
+// blocks, privacy settings and keys. A bot also loses its API token but
+// keeps its owner, so the owner's deletion still finds it when retried.
+// Deleting an already deleted user does nothing, so a failed deletion can be
+// retried.
+func (s *service) DeleteUser(ctx context.Context, userID uuid.UUID) error {
+	u, err := s.repo.GetUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if u.Status != user.Deleted {
+		if err := s.repo.UpdateUser(ctx, repo.CreateUserInput{
+			ID:        u.ID,
+			FirstName: "Deleted",
+			LastName:  "Account",
+			CreatedAt: u.CreatedAt,
+			Status:    user.Deleted,
+			Type:      u.Type,
+			OwnerID:   u.OwnerID,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return s.repo.DeleteUserData(ctx, userID)
+}
+
+// setAccountState moves the user to the state change returns. Deleted
+// accounts never change state.
+func (s *service) setAccountState(ctx context.Context, userID uuid.UUID, change func(user.AccountState) (user.AccountState, error)) error {
+	u, err := s.repo.GetUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if u.Status == user.Deleted {
+		return ErrAccountDeleted
+	}
+	a, err := change(accountState(u, time.Now()))
+	if err != nil {
+		return err
+	}
+
+	u.Status, u.StatusReason, u.StatusUntil = a.Status, a.Reason, a.Until
+	return s.repo.UpdateUser(ctx, u)
+}
+
+// checkCanSend returns ErrSuspended or ErrAccountClosed when senderID may not
+// send to recipientID, whose account must not be closed either.
+func (s *service) checkCanSend(ctx context.Context, senderID, recipientID uuid.UUID) error {
+	sender, err := s.repo.GetUser(ctx, senderID)
+	if err != nil {
+		return err
+	}
+	switch accountState(sender, time.Now()).Status {
+	case user.Suspended:
+		return ErrSuspended
+	case user.Deactivated, user.Deleted:
+		return ErrAccountClosed
+	}
+	recipient, err := s.repo.GetUser(ctx, recipientID)
+	if err != nil {
+		return err
+	}
+	if recipient.Status == user.Deactivated || recipient.Status == user.Deleted {
+		return ErrAccountClosed
+	}
+
+	return nil
+}
+
+func accountState(u repo.CreateUserInput, now time.Time) user.AccountState {
+	if u.Status == user.Suspended && !u.StatusUntil.IsZero() && !now.Before(u.StatusUntil) {
+		return user.AccountState{Status: user.Active}
+	}
+
+	return user.AccountState{Status: u.Status, Reason: u.StatusReason, Until: u.StatusUntil}
+}
@@ -0,0 +1,115 @@
+package usersvc_test
+
+import (
+	"context"
+	"errors"
+	"github.com/AliUnipal/chat/internal/models/user"
+	"github.com/AliUnipal/chat/internal/service/usersvc"
+	"github.com/AliUnipal/chat/internal/service/usersvc/mocks"
+	"github.com/AliUnipal/chat/internal/service/usersvc/repo"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestCanMessage_AccountState(t *testing.T) {
+	ctx := context.Background()
+	senderID := uuid.New()
+	recipientID := uuid.New()
+
+	tests := []struct {
+		name      string
+		sender    repo.CreateUserInput
+		recipient repo.CreateUserInput
+		expected  error
+	}{
+		{name: "suspended indefinitely", sender: repo.CreateUserInput{Status: user.Suspended}, expected: usersvc.ErrSuspended},
+		{name: "suspension in effect", sender: repo.CreateUserInput{Status: user.Suspended, StatusUntil: time.Now().Add(time.Hour)}, expected: usersvc.ErrSuspended},
+		{name: "suspension expired", sender: repo.CreateUserInput{Status: user.Suspended, StatusUntil: time.Now().Add(-time.Hour)}},
+		{name: "sender deactivated", sender: repo.CreateUserInput{Status: user.Deactivated}, expected: usersvc.ErrAccountClosed},
+		{name: "recipient deactivated", recipient: repo.CreateUserInput{Status: user.Deactivated}, expected: usersvc.ErrAccountClosed},
+		{name: "recipient deleted", recipient: repo.CreateUserInput{Status: user.Deleted}, expected: usersvc.ErrAccountClosed},
+		{name: "suspended recipient", recipient: repo.CreateUserInput{Status: user.Suspended}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.sender.ID, tt.recipient.ID = senderID, recipientID
+			mockRepo := mocks.NewUserRepository(t)
+			mockRepo.EXPECT().GetUser(ctx, senderID).Return(tt.sender, nil)
+			mockRepo.EXPECT().GetUser(ctx, recipientID).Return(tt.recipient, nil).Maybe()
+			if tt.expected == nil {
+				mockRepo.EXPECT().IsBlocked(ctx, recipientID, senderID).Return(false, nil)
+				mockRepo.EXPECT().IsBlocked(ctx, senderID, recipientID).Return(false, nil)
+			}
+
+			service := usersvc.NewService(mockRepo)
+			if err := service.CanMessage(ctx, senderID, recipientID); !errors.Is(err, tt.expected) {
+				t.Fatalf("Expected %v got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestSuspendUser_RequireReason(t *testing.T) {
+	service := usersvc.NewService(mocks.NewUserRepository(t))
+	if err := service.SuspendUser(context.Background(), uuid.New(), "", time.Time{}); err == nil {
+		t.Fatalf("Expected error got %v", err)
+	}
+}
+
+func TestDeactivateUser_ReturnErrorWhileSuspended(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockRepo := mocks.NewUserRepository(t)
+	mockRepo.EXPECT().GetUser(ctx, userID).Return(repo.CreateUserInput{ID: userID, Status: user.Suspended}, nil)
+
+	service := usersvc.NewService(mockRepo)
+	if err := service.DeactivateUser(ctx, userID); !errors.Is(err, usersvc.ErrSuspended) {
+		t.Fatalf("Expected %v got %v", usersvc.ErrSuspended, err)
+	}
+}
+
+func TestReactivateUser_ReturnErrorWhenDeleted(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockRepo := mocks.NewUserRepository(t)
+	mockRepo.EXPECT().GetUser(ctx, userID).Return(repo.CreateUserInput{ID: userID, Status: user.Deleted}, nil)
+
+	service := usersvc.NewService(mockRepo)
+	if err := service.ReactivateUser(ctx, userID); !errors.Is(err, usersvc.ErrAccountDeleted) {
+		t.Fatalf("Expected %v got %v", usersvc.ErrAccountDeleted, err)
+	}
+}
+
+func TestDeleteUser_AnonymizeProfile(t *testing.T) {
+	ctx := context.Background()
+	createdAt := time.Now().Add(-time.Hour)
+	u := repo.CreateUserInput{
+		ID:        uuid.New(),
+		ImageURL:  "https://alice.png",
+		FirstName: "Alice",
+		LastName:  "Smith",
+		Username:  "+97311111111",
+		CreatedAt: createdAt,
+	}
+
+	mockRepo := mocks.NewUserRepository(t)
+	mockRepo.EXPECT().GetUser(ctx, u.ID).Return(u, nil)
+	mockRepo.EXPECT().UpdateUser(ctx, mock.MatchedBy(func(in repo.CreateUserInput) bool {
+		return in.ID == u.ID &&
+			in.Status == user.Deleted &&
+			in.ImageURL == "" &&
+			in.FirstName == "Deleted" &&
+			in.Username == "" &&
+			in.CreatedAt.Equal(createdAt)
+	})).Return(nil)
+	mockRepo.EXPECT().DeleteUserData(ctx, u.ID).Return(nil)
+
+	service := usersvc.NewService(mockRepo)
+	if err := service.DeleteUser(ctx, u.ID); err != nil {
+		t.Fatalf("Expected no error got %v", err)
+	}
+}
@@ -0,0 +1,163 @@
+package usersvc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"github.com/AliUnipal/chat/internal/models/user"
+	"github.com/AliUnipal/chat/internal/service/usersvc/repo"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNotABot       = errors.New("user is not a bot")
+	ErrNotBotOwner   = errors.New("user does not own the bot")
+	ErrInvalidToken  = errors.New("bot token is invalid")
+	ErrBotNotInvited = errors.New("bots cannot start chats with people")
+)
+
+// CreateBot creates a bot owned by ownerID and returns its ID and API token.
+// The token cannot be read back later; RotateBotToken replaces a lost one.
+func (s *service) CreateBot(ctx context.Context, ownerID uuid.UUID, in CreateUserInput) (uuid.UUID, string, error) {
+	if err := validateProfile(in); err != nil {
+		return uuid.Nil, "", err
+	}
+	owner, err := s.repo.GetUser(ctx, ownerID)
+	if err != nil {
+		return uuid.Nil, "", err
+	}
+	if owner.Type == user.Bot {
+		return uuid.Nil, "", errors.New("bots cannot create bots")
+	}
+	if owner.Status == user.Deleted {
+		return uuid.Nil, "", ErrAccountDeleted
+	}
+
+	botID := uuid.New()
+	token, hash, err := newBotToken(botID)
+	if err != nil {
+		return uuid.Nil, "", err
+	}
+	if err := s.repo.CreateUser(ctx, repo.CreateUserInput{
+		ID:        botID,
+		ImageURL:  in.ImageURL,
+		FirstName: in.FirstName,
+		LastName:  in.LastName,
+		Username:  in.Username,
+		CreatedAt: time.Now().UTC(),
+		Type:      user.Bot,
+		OwnerID:   ownerID,
+		TokenHash: hash,
+	}); err != nil {
+		return uuid.Nil, "", err
+	}
+
+	return botID, token, nil
+}
+
+// GetBots returns the bots ownerID created, deleted ones included, oldest
+// first.
+func (s *service) GetBots(ctx context.Context, ownerID uuid.UUID) ([]user.User, error) {
+	users, err := s.repo.GetUsersByOwner(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var bots []user.User
+	for _, u := range users {
+		if u.Type == user.Bot {
+			bots = append(bots, toUser(u))
+		}
+	}
+	return bots, nil
+}
+
+// RotateBotToken replaces the bot's API token, so the old one stops working.
+func (s *service) RotateBotToken(ctx context.Context, ownerID, botID uuid.UUID) (string, error) {
+	bot, err := s.repo.GetUser(ctx, botID)
+	if err != nil {
+		return "", err
+	}
+	if bot.Type != user.Bot {
+		return "", ErrNotABot
+	}
+	if bot.OwnerID != ownerID {
+		return "", ErrNotBotOwner
+	}
+	if bot.Status == user.Deleted {
+		return "", ErrAccountDeleted
+	}
+
+	token, hash, err := newBotToken(botID)
+	if err != nil {
+		return "", err
+	}
+	bot.TokenHash = hash
+	if err := s.repo.UpdateUser(ctx, bot); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// AuthenticateBot returns the ID of the bot the API token belongs to.
+func (s *service) AuthenticateBot(ctx context.Context, token string) (uuid.UUID, error) {
+	id, secret, ok := strings.Cut(token, ":")
+	if !ok {
+		return uuid.Nil, ErrInvalidToken
+	}
+	botID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, ErrInvalidToken
+	}
+	bot, err := s.repo.GetUser(ctx, botID)
+	if err != nil || bot.Type != user.Bot || bot.TokenHash == nil {
+		return uuid.Nil, ErrInvalidToken
+	}
+	hash := sha256.Sum256([]byte(secret))
+	if subtle.ConstantTimeCompare(hash[:], bot.TokenHash) != 1 {
+		return uuid.Nil, ErrInvalidToken
+	}
+
+	return botID, nil
+}
+
+// checkBotInvited returns ErrBotNotInvited when a bot tries to start a chat
+// with a person. People invite bots by starting chats with them or adding
+// them to chats.
+func (s *service) checkBotInvited(ctx context.Context, senderID, recipientID uuid.UUID) error {
+	sender, err := s.repo.GetUser(ctx, senderID)
+	if err != nil {
+		return err
+	}
+	if sender.Type != user.Bot {
+		return nil
+	}
+	recipient, err := s.repo.GetUser(ctx, recipientID)
+	if err != nil {
+		return err
+	}
+	if recipient.Type != user.Bot {
+		return ErrBotNotInvited
+	}
+
+	return nil
+}
+
+// newBotToken returns a token of the form "<bot ID>:<secret>" along with the
+// hash of its secret.
+func newBotToken(botID uuid.UUID) (string, []byte, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", nil, err
+	}
+	secret := base64.RawURLEncoding.EncodeToString(b)
+	hash := sha256.Sum256([]byte(secret))
+
+	return botID.String() + ":" + secret, hash[:], nil
+}
@@ -0,0 +1,151 @@
+package usersvc_test
+
+import (
+	"context"
+	"errors"
+	"github.com/AliUnipal/chat/internal/models/user"
+	"github.com/AliUnipal/chat/internal/service/usersvc"
+	"github.com/AliUnipal/chat/internal/service/usersvc/repo/inmemuserrepo"
+	"github.com/google/uuid"
+	"testing"
+)
+
+func createBot(t *testing.T, service interface {
+	CreateBot(ctx context.Context, ownerID uuid.UUID, in usersvc.CreateUserInput) (uuid.UUID, string, error)
+}, ownerID uuid.UUID) (uuid.UUID, string) {
+	t.Helper()
+	botID, token, err := service.CreateBot(context.Background(), ownerID, usersvc.CreateUserInput{
+		ImageURL:  "https://bot.png",
+		FirstName: "Weather",
+		LastName:  "Bot",
+		Username:  "weather_bot",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error got %v", err)
+	}
+
+	return botID, token
+}
+
+func TestBot_Token(t *testing.T) {
+	ctx := context.Background()
+	service := usersvc.NewService(inmemuserrepo.New())
+	ownerID, err := service.CreateUser(ctx, usersvc.CreateUserInput{ImageURL: "https://alice.png", FirstName: "Alice", Username: "+97311111111"})
+	if err != nil {
+		t.Fatalf("Expected no error got %v", err)
+	}
+
+	botID, token := createBot(t, service, ownerID)
+	bot, err := service.GetUser(ctx, botID)
+	if err != nil {
+		t.Fatalf("Expected no error got %v", err)
+	}
+	if bot.Type != user.Bot {
+		t.Fatalf("Expected a bot got %+v", bot)
+	}
+	if id, err := service.AuthenticateBot(ctx, token); err != nil || id != botID {
+		t.Fatalf("Expected the bot's ID got %v, %v", id, err)
+	}
+
+	for _, bad := range []string{"", "not-a-token", ownerID.String() + ":secret", botID.String() + ":wrong"} {
+		if _, err := service.AuthenticateBot(ctx, bad); !errors.Is(err, usersvc.ErrInvalidToken) {
+			t.Fatalf("Expected ErrInvalidToken for %q got %v", bad, err)
+		}
+	}
+
+	if _, err := service.RotateBotToken(ctx, uuid.New(), botID); !errors.Is(err, usersvc.ErrNotBotOwner) {
+		t.Fatalf("Expected ErrNotBotOwner got %v", err)
+	}
+	if _, err := service.RotateBotToken(ctx, ownerID, ownerID); !errors.Is(err, usersvc.ErrNotABot) {
+		t.Fatalf("Expected ErrNotABot got %v", err)
+	}
+	rotated, err := service.RotateBotToken(ctx, ownerID, botID)
+	if err != nil {
+		t.Fatalf("Expected no error got %v", err)
+	}
+	if _, err := service.AuthenticateBot(ctx, token); !errors.Is(err, usersvc.ErrInvalidToken) {
+		t.Fatalf("Expected the old token to stop working got %v", err)
+	}
+	if id, err := service.AuthenticateBot(ctx, rotated); err != nil || id != botID {
+		t.Fatalf("Expected the new token to work got %v, %v", id, err)
+	}
+
+	if err := service.DeleteUser(ctx, botID); err != nil {
+		t.Fatalf("Expected no error got %v", err)
+	}
+	if _, err := service.AuthenticateBot(ctx, rotated); !errors.Is(err, usersvc.ErrInvalidToken) {
+		t.Fatalf("Expected a deleted bot's token to stop working got %v", err)
+	}
+	if _, err := service.RotateBotToken(ctx, ownerID, botID); !errors.Is(err, usersvc.ErrAccountDeleted) {
+		t.Fatalf("Expected ErrAccountDeleted got %v", err)
+	}
+}
+
+func TestBot_GetBots(t *testing.T) {
+	ctx := context.Background()
+	service := usersvc.NewService(inmemuserrepo.New())
+	ownerID, err := service.CreateUser(ctx, usersvc.CreateUserInput{ImageURL: "https://alice.png", FirstName: "Alice", Username: "+97311111111"})
+	if err != nil {
+		t.Fatalf("Expected no error got %v", err)
+	}
+	botID, _ := createBot(t, service, ownerID)
+	if _, err := service.CreateImportedUser(ctx, ownerID, usersvc.CreateUserInput{ImageURL: "https://bob.png", FirstName: "Bob", Username: "bob"}); err != nil {
+		t.Fatalf("Expected no error got %v", err)
+	}
+
+	// A deleted bot is still listed, so deleting its owner can be retried.
+	if err := service.DeleteUser(ctx, botID); err != nil {
+		t.Fatalf("Expected no error got %v", err)
+	}
+	bots, err := service.GetBots(ctx, ownerID)
+	if err != nil {
+		t.Fatalf("Expected no error got %v", err)
+	}
+	if len(bots) != 1 || bots[0].ID != botID || bots[0].Status != user.Deleted {
+		t.Fatalf("Expected the owner's deleted bot got %+v", bots)
+	}
+
+	if err := service.DeleteUser(ctx, ownerID); err != nil {
+		t.Fatalf("Expected no error got %v", err)
+	}
+	if _, _, err := service.CreateBot(ctx, ownerID, usersvc.CreateUserInput{ImageURL: "https://bot.png", FirstName: "Late", Username: "late_bot"}); !errors.Is(err, usersvc.ErrAccountDeleted) {
+		t.Fatalf("Expected ErrAccountDeleted got %v", err)
+	}
+}
+
+func TestBot_CannotCreateBots(t *testing.T) {
+	service := usersvc.NewService(inmemuserrepo.New())
+	ownerID, err := service.CreateUser(context.Background(), usersvc.CreateUserInput{ImageURL: "https://alice.png", FirstName: "Alice", Username: "+97311111111"})
+	if err != nil {
+		t.Fatalf("Expected no error got %v", err)
+	}
+	botID, _ := createBot(t, service, ownerID)
+
+	if _, _, err := service.CreateBot(context.Background(), botID, usersvc.CreateUserInput{ImageURL: "https://bot.png", FirstName: "Other", Username: "other_bot"}); err == nil {
+		t.Fatalf("Expected error got %v", err)
+	}
+}
+
+func TestBot_CanStartChat(t *testing.T) {
+	ctx := context.Background()
+	service := usersvc.NewService(inmemuserrepo.New())
+	aliceID, err := service.CreateUser(ctx, usersvc.CreateUserInput{ImageURL: "https://alice.png", FirstName: "Alice", Username: "+97311111111"})
+	if err != nil {
+		t.Fatalf("Expected no error got %v", err)
+	}
+	botID, _ := createBot(t, service, aliceID)
+	otherBotID, _ := createBot(t, service, aliceID)
+
+	if err := service.CanStartChat(ctx, botID, aliceID); !errors.Is(err, usersvc.ErrBotNotInvited) {
+		t.Fatalf("Expected ErrBotNotInvited got %v", err)
+	}
+	if err := service.CanStartChat(ctx, aliceID, botID); err != nil {
+		t.Fatalf("Expected people to start chats with bots got %v", err)
+	}
+	if err := service.CanStartChat(ctx, botID, otherBotID); err != nil {
+		t.Fatalf("Expected bots to start chats with bots got %v", err)
+	}
+	if err := service.CanMessage(ctx, botID, aliceID); err != nil {
+		t.Fatalf("Expected bots to message people got %v", err)
+	}
+}
@@ -0,0 +1,41 @@
+package usersvc
+
+import (
+	"context"
+	"errors"
+	"github.com/AliUnipal/chat/internal/models/user"
+	"github.com/AliUnipal/chat/internal/service/usersvc/repo"
+	"github.com/google/uuid"
+	"time"
+)
+
+// CreateImportedUser creates a user standing in for someone in a chat history
+// ownerID imports, and returns its ID.
+func (s *service) CreateImportedUser(ctx context.Context, ownerID uuid.UUID, in CreateUserInput) (uuid.UUID, error) {
+	if err := validateProfile(in); err != nil {
+		return uuid.Nil, err
+	}
+	owner, err := s.repo.GetUser(ctx, ownerID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if owner.Type != user.Human {
+		return uuid.Nil, errors.New("only people can import chats")
+	}
+
+	id := uuid.New()
+	if err := s.repo.CreateUser(ctx, repo.CreateUserInput{
+		ID:        id,
+		ImageURL:  in.ImageURL,
+		FirstName: in.FirstName,
+		LastName:  in.LastName,
+		Username:  in.Username,
+		CreatedAt: time.Now().UTC(),
+		Type:      user.Imported,
+		OwnerID:   ownerID,
+	}); err != nil {
+		return uuid.Nil, err
+	}
+
+	return id, nil
+}
@@ -0,0 +1,96 @@
+package usersvc
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"github.com/AliUnipal/chat/internal/models/user"
+	"github.com/AliUnipal/chat/internal/service/usersvc/repo"
+	"github.com/google/uuid"
+)
+
+// keySize is the size of an X25519 public key.
+const keySize = 32
+
+var ErrKeysNotFound = repo.ErrKeysNotFound
+
+// PublishKeys stores userID's identity keys in the key directory after
+// checking that SigningKey vouches for the other keys. The server only ever
+// holds public keys.
+func (s *service) PublishKeys(ctx context.Context, userID uuid.UUID, keys user.IdentityKeys) error {
+	if len(keys.SigningKey) != ed25519.PublicKeySize ||
+		len(keys.IdentityKey) != keySize ||
+		len(keys.SignedPreKey) != keySize {
+		return errors.New("identity keys are malformed")
+	}
+	signed := append(append([]byte{}, keys.IdentityKey...), keys.SignedPreKey...)
+	if !ed25519.Verify(keys.SigningKey, signed, keys.Signature) {
+		return errors.New("signed prekey signature is invalid")
+	}
+
+	return s.repo.SaveIdentityKeys(ctx, userID, repo.IdentityKeys{
+		SigningKey:   keys.SigningKey,
+		IdentityKey:  keys.IdentityKey,
+		SignedPreKey: keys.SignedPreKey,
+		Signature:    keys.Signature,
+	})
+}
+
+// AddOneTimePreKeys tops up userID's one-time prekeys. Clients should call it
+// whenever CountOneTimePreKeys runs low.
+func (s *service) AddOneTimePreKeys(ctx context.Context, userID uuid.UUID, keys []user.OneTimePreKey) error {
+	r := make([]repo.OneTimePreKey, 0, len(keys))
+	for _, k := range keys {
+		if k.ID == uuid.Nil || len(k.Key) != keySize {
+			return errors.New("one-time prekey is malformed")
+		}
+		r = append(r, repo.OneTimePreKey{ID: k.ID, Key: k.Key})
+	}
+
+	return s.repo.AddOneTimePreKeys(ctx, userID, r)
+}
+
+func (s *service) CountOneTimePreKeys(ctx context.Context, userID uuid.UUID) (int, error) {
+	return s.repo.CountOneTimePreKeys(ctx, userID)
+}
+
+// HasKeys reports whether userID has published identity keys.
+func (s *service) HasKeys(ctx context.Context, userID uuid.UUID) (bool, error) {
+	_, err := s.repo.GetIdentityKeys(ctx, userID)
+	if errors.Is(err, repo.ErrKeysNotFound) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// GetPreKeyBundle returns the keys requesterID needs to start an encrypted
+// session with userID, handing out one of userID's one-time prekeys.
+func (s *service) GetPreKeyBundle(ctx context.Context, requesterID, userID uuid.UUID) (user.PreKeyBundle, error) {
+	if err := s.CanMessage(ctx, requesterID, userID); err != nil {
+		return user.PreKeyBundle{}, err
+	}
+	keys, err := s.repo.GetIdentityKeys(ctx, userID)
+	if err != nil {
+		return user.PreKeyBundle{}, err
+	}
+
+	bundle := user.PreKeyBundle{
+		UserID: userID,
+		IdentityKeys: user.IdentityKeys{
+			SigningKey:   keys.SigningKey,
+			IdentityKey:  keys.IdentityKey,
+			SignedPreKey: keys.SignedPreKey,
+			Signature:    keys.Signature,
+		},
+	}
+	preKey, ok, err := s.repo.TakeOneTimePreKey(ctx, userID)
+	if err != nil {
+		return user.PreKeyBundle{}, err
+	}
+	if ok {
+		bundle.OneTimePreKey = &user.OneTimePreKey{ID: preKey.ID, Key: preKey.Key}
+	}
+
+	return bundle, nil
+}
@@ -0,0 +1,107 @@
+package usersvc_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"github.com/AliUnipal/chat/internal/models/user"
+	"github.com/AliUnipal/chat/internal/service/usersvc"
+	"github.com/AliUnipal/chat/internal/service/usersvc/mocks"
+	"github.com/AliUnipal/chat/internal/service/usersvc/repo"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func signedIdentityKeys(t *testing.T) user.IdentityKeys {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Expected no error got %v", err)
+	}
+	keys := user.IdentityKeys{
+		SigningKey:   pub,
+		IdentityKey:  make([]byte, 32),
+		SignedPreKey: make([]byte, 32),
+	}
+	rand.Read(keys.IdentityKey)
+	rand.Read(keys.SignedPreKey)
+	keys.Signature = ed25519.Sign(priv, append(append([]byte{}, keys.IdentityKey...), keys.SignedPreKey...))
+
+	return keys
+}
+
+func TestPublishKeys_SaveVerifiedKeys(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	keys := signedIdentityKeys(t)
+
+	mockRepo := mocks.NewUserRepository(t)
+	mockRepo.EXPECT().SaveIdentityKeys(ctx, userID, repo.IdentityKeys{
+		SigningKey:   keys.SigningKey,
+		IdentityKey:  keys.IdentityKey,
+		SignedPreKey: keys.SignedPreKey,
+		Signature:    keys.Signature,
+	}).Return(nil)
+	service := usersvc.NewService(mockRepo)
+
+	if err := service.PublishKeys(ctx, userID, keys); err != nil {
+		t.Fatalf("Expected no error got %v", err)
+	}
+}
+
+func TestPublishKeys_ReturnErrorOnBadSignature(t *testing.T) {
+	ctx := context.Background()
+	keys := signedIdentityKeys(t)
+	keys.SignedPreKey = signedIdentityKeys(t).SignedPreKey
+
+	mockRepo := mocks.NewUserRepository(t)
+	service := usersvc.NewService(mockRepo)
+
+	if err := service.PublishKeys(ctx, uuid.New(), keys); err == nil {
+		t.Fatalf("Expected error got %v", err)
+	}
+}
+
+func TestGetPreKeyBundle_HandOutOneTimePreKey(t *testing.T) {
+	ctx := context.Background()
+	requesterID := uuid.New()
+	userID := uuid.New()
+	keys := signedIdentityKeys(t)
+	preKey := repo.OneTimePreKey{ID: uuid.New(), Key: make([]byte, 32)}
+
+	mockRepo := mocks.NewUserRepository(t)
+	mockRepo.EXPECT().GetUser(ctx, requesterID).Return(repo.CreateUserInput{ID: requesterID}, nil)
+	mockRepo.EXPECT().GetUser(ctx, userID).Return(repo.CreateUserInput{ID: userID}, nil)
+	mockRepo.EXPECT().IsBlocked(ctx, mock.Anything, mock.Anything).Return(false, nil)
+	mockRepo.EXPECT().GetIdentityKeys(ctx, userID).Return(repo.IdentityKeys{
+		SigningKey:   keys.SigningKey,
+		IdentityKey:  keys.IdentityKey,
+		SignedPreKey: keys.SignedPreKey,
+		Signature:    keys.Signature,
+	}, nil)
+	mockRepo.EXPECT().TakeOneTimePreKey(ctx, userID).Return(preKey, true, nil)
+	service := usersvc.NewService(mockRepo)
+
+	bundle, err := service.GetPreKeyBundle(ctx, requesterID, userID)
+	if err != nil {
+		t.Fatalf("Expected no error got %v", err)
+	}
+	if bundle.UserID != userID || bundle.OneTimePreKey == nil || bundle.OneTimePreKey.ID != preKey.ID {
+		t.Fatalf("Expected a bundle with one-time prekey %v got %v", preKey.ID, bundle)
+	}
+}
+
+func TestHasKeys_ReturnFalseWhenNotPublished(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockRepo := mocks.NewUserRepository(t)
+	mockRepo.EXPECT().GetIdentityKeys(ctx, userID).Return(repo.IdentityKeys{}, repo.ErrKeysNotFound)
+	service := usersvc.NewService(mockRepo)
+
+	ok, err := service.HasKeys(ctx, userID)
+	if err != nil || ok {
+		t.Fatalf("Expected false got %v, %v", ok, err)
+	}
+}
@@ -39,6 +39,261 @@ func (_m *UserRepository) EXPECT() *UserRepository_Expecter {
 	return &UserRepository_Expecter{mock: &_m.Mock}
 }
 
+// AddContact provides a mock function for the type UserRepository
+func (_mock *UserRepository) AddContact(ctx context.Context, userID uuid.UUID, contactID uuid.UUID) error {
+	ret := _mock.Called(ctx, userID, contactID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddContact")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, userID, contactID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// UserRepository_AddContact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddContact'
+type UserRepository_AddContact_Call struct {
+	*mock.Call
+}
+
+// AddContact is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - contactID uuid.UUID
+func (_e *UserRepository_Expecter) AddContact(ctx interface{}, userID interface{}, contactID interface{}) *UserRepository_AddContact_Call {
+	return &UserRepository_AddContact_Call{Call: _e.mock.On("AddContact", ctx, userID, contactID)}
+}
+
+func (_c *UserRepository_AddContact_Call) Run(run func(ctx context.Context, userID uuid.UUID, contactID uuid.UUID)) *UserRepository_AddContact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *UserRepository_AddContact_Call) Return(err error) *UserRepository_AddContact_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *UserRepository_AddContact_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, contactID uuid.UUID) error) *UserRepository_AddContact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddOneTimePreKeys provides a mock function for the type UserRepository
+func (_mock *UserRepository) AddOneTimePreKeys(ctx context.Context, userID uuid.UUID, keys []repo.OneTimePreKey) error {
+	ret := _mock.Called(ctx, userID, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddOneTimePreKeys")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, []repo.OneTimePreKey) error); ok {
+		r0 = returnFunc(ctx, userID, keys)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// UserRepository_AddOneTimePreKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddOneTimePreKeys'
+type UserRepository_AddOneTimePreKeys_Call struct {
+	*mock.Call
+}
+
+// AddOneTimePreKeys is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - keys []repo.OneTimePreKey
+func (_e *UserRepository_Expecter) AddOneTimePreKeys(ctx interface{}, userID interface{}, keys interface{}) *UserRepository_AddOneTimePreKeys_Call {
+	return &UserRepository_AddOneTimePreKeys_Call{Call: _e.mock.On("AddOneTimePreKeys", ctx, userID, keys)}
+}
+
+func (_c *UserRepository_AddOneTimePreKeys_Call) Run(run func(ctx context.Context, userID uuid.UUID, keys []repo.OneTimePreKey)) *UserRepository_AddOneTimePreKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 []repo.OneTimePreKey
+		if args[2] != nil {
+			arg2 = args[2].([]repo.OneTimePreKey)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *UserRepository_AddOneTimePreKeys_Call) Return(err error) *UserRepository_AddOneTimePreKeys_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *UserRepository_AddOneTimePreKeys_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, keys []repo.OneTimePreKey) error) *UserRepository_AddOneTimePreKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BlockUser provides a mock function for the type UserRepository
+func (_mock *UserRepository) BlockUser(ctx context.Context, userID uuid.UUID, blockedID uuid.UUID) error {
+	ret := _mock.Called(ctx, userID, blockedID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BlockUser")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, userID, blockedID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// UserRepository_BlockUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BlockUser'
+type UserRepository_BlockUser_Call struct {
+	*mock.Call
+}
+
+// BlockUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - blockedID uuid.UUID
+func (_e *UserRepository_Expecter) BlockUser(ctx interface{}, userID interface{}, blockedID interface{}) *UserRepository_BlockUser_Call {
+	return &UserRepository_BlockUser_Call{Call: _e.mock.On("BlockUser", ctx, userID, blockedID)}
+}
+
+func (_c *UserRepository_BlockUser_Call) Run(run func(ctx context.Context, userID uuid.UUID, blockedID uuid.UUID)) *UserRepository_BlockUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *UserRepository_BlockUser_Call) Return(err error) *UserRepository_BlockUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *UserRepository_BlockUser_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, blockedID uuid.UUID) error) *UserRepository_BlockUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountOneTimePreKeys provides a mock function for the type UserRepository
+func (_mock *UserRepository) CountOneTimePreKeys(ctx context.Context, userID uuid.UUID) (int, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountOneTimePreKeys")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (int, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) int); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// UserRepository_CountOneTimePreKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountOneTimePreKeys'
+type UserRepository_CountOneTimePreKeys_Call struct {
+	*mock.Call
+}
+
+// CountOneTimePreKeys is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *UserRepository_Expecter) CountOneTimePreKeys(ctx interface{}, userID interface{}) *UserRepository_CountOneTimePreKeys_Call {
+	return &UserRepository_CountOneTimePreKeys_Call{Call: _e.mock.On("CountOneTimePreKeys", ctx, userID)}
+}
+
+func (_c *UserRepository_CountOneTimePreKeys_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *UserRepository_CountOneTimePreKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *UserRepository_CountOneTimePreKeys_Call) Return(n int, err error) *UserRepository_CountOneTimePreKeys_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *UserRepository_CountOneTimePreKeys_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) (int, error)) *UserRepository_CountOneTimePreKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateUser provides a mock function for the type UserRepository
 func (_mock *UserRepository) CreateUser(ctx context.Context, in repo.CreateUserInput) error {
 	ret := _mock.Called(ctx, in)
@@ -96,45 +351,104 @@ func (_c *UserRepository_CreateUser_Call) RunAndReturn(run func(ctx context.Cont
 	return _c
 }
 
-// GetUser provides a mock function for the type UserRepository
-func (_mock *UserRepository) GetUser(ctx context.Context, id uuid.UUID) (repo.CreateUserInput, error) {
-	ret := _mock.Called(ctx, id)
+// DeleteUserData provides a mock function for the type UserRepository
+func (_mock *UserRepository) DeleteUserData(ctx context.Context, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUser")
+		panic("no return value specified for DeleteUserData")
 	}
 
-	var r0 repo.CreateUserInput
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// UserRepository_DeleteUserData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUserData'
+type UserRepository_DeleteUserData_Call struct {
+	*mock.Call
+}
+
+// DeleteUserData is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *UserRepository_Expecter) DeleteUserData(ctx interface{}, userID interface{}) *UserRepository_DeleteUserData_Call {
+	return &UserRepository_DeleteUserData_Call{Call: _e.mock.On("DeleteUserData", ctx, userID)}
+}
+
+func (_c *UserRepository_DeleteUserData_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *UserRepository_DeleteUserData_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *UserRepository_DeleteUserData_Call) Return(err error) *UserRepository_DeleteUserData_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *UserRepository_DeleteUserData_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) error) *UserRepository_DeleteUserData_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBlockedUsers provides a mock function for the type UserRepository
+func (_mock *UserRepository) GetBlockedUsers(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlockedUsers")
+	}
+
+	var r0 []uuid.UUID
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (repo.CreateUserInput, error)); ok {
-		return returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]uuid.UUID, error)); ok {
+		return returnFunc(ctx, userID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) repo.CreateUserInput); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []uuid.UUID); ok {
+		r0 = returnFunc(ctx, userID)
 	} else {
-		r0 = ret.Get(0).(repo.CreateUserInput)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uuid.UUID)
+		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = returnFunc(ctx, id)
+		r1 = returnFunc(ctx, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// UserRepository_GetUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUser'
-type UserRepository_GetUser_Call struct {
+// UserRepository_GetBlockedUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBlockedUsers'
+type UserRepository_GetBlockedUsers_Call struct {
 	*mock.Call
 }
 
-// GetUser is a helper method to define mock.On call
+// GetBlockedUsers is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id uuid.UUID
-func (_e *UserRepository_Expecter) GetUser(ctx interface{}, id interface{}) *UserRepository_GetUser_Call {
-	return &UserRepository_GetUser_Call{Call: _e.mock.On("GetUser", ctx, id)}
+//   - userID uuid.UUID
+func (_e *UserRepository_Expecter) GetBlockedUsers(ctx interface{}, userID interface{}) *UserRepository_GetBlockedUsers_Call {
+	return &UserRepository_GetBlockedUsers_Call{Call: _e.mock.On("GetBlockedUsers", ctx, userID)}
 }
 
-func (_c *UserRepository_GetUser_Call) Run(run func(ctx context.Context, id uuid.UUID)) *UserRepository_GetUser_Call {
+func (_c *UserRepository_GetBlockedUsers_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *UserRepository_GetBlockedUsers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -152,12 +466,937 @@ func (_c *UserRepository_GetUser_Call) Run(run func(ctx context.Context, id uuid
 	return _c
 }
 
-func (_c *UserRepository_GetUser_Call) Return(createUserInput repo.CreateUserInput, err error) *UserRepository_GetUser_Call {
-	_c.Call.Return(createUserInput, err)
+func (_c *UserRepository_GetBlockedUsers_Call) Return(uUIDs []uuid.UUID, err error) *UserRepository_GetBlockedUsers_Call {
+	_c.Call.Return(uUIDs, err)
 	return _c
 }
 
-func (_c *UserRepository_GetUser_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (repo.CreateUserInput, error)) *UserRepository_GetUser_Call {
+func (_c *UserRepository_GetBlockedUsers_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)) *UserRepository_GetBlockedUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetContacts provides a mock function for the type UserRepository
+func (_mock *UserRepository) GetContacts(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetContacts")
+	}
+
+	var r0 []uuid.UUID
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]uuid.UUID, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []uuid.UUID); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uuid.UUID)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// UserRepository_GetContacts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetContacts'
+type UserRepository_GetContacts_Call struct {
+	*mock.Call
+}
+
+// GetContacts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *UserRepository_Expecter) GetContacts(ctx interface{}, userID interface{}) *UserRepository_GetContacts_Call {
+	return &UserRepository_GetContacts_Call{Call: _e.mock.On("GetContacts", ctx, userID)}
+}
+
+func (_c *UserRepository_GetContacts_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *UserRepository_GetContacts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *UserRepository_GetContacts_Call) Return(uUIDs []uuid.UUID, err error) *UserRepository_GetContacts_Call {
+	_c.Call.Return(uUIDs, err)
+	return _c
+}
+
+func (_c *UserRepository_GetContacts_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)) *UserRepository_GetContacts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetIdentityKeys provides a mock function for the type UserRepository
+func (_mock *UserRepository) GetIdentityKeys(ctx context.Context, userID uuid.UUID) (repo.IdentityKeys, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIdentityKeys")
+	}
+
+	var r0 repo.IdentityKeys
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (repo.IdentityKeys, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) repo.IdentityKeys); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(repo.IdentityKeys)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// UserRepository_GetIdentityKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetIdentityKeys'
+type UserRepository_GetIdentityKeys_Call struct {
+	*mock.Call
+}
+
+// GetIdentityKeys is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *UserRepository_Expecter) GetIdentityKeys(ctx interface{}, userID interface{}) *UserRepository_GetIdentityKeys_Call {
+	return &UserRepository_GetIdentityKeys_Call{Call: _e.mock.On("GetIdentityKeys", ctx, userID)}
+}
+
+func (_c *UserRepository_GetIdentityKeys_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *UserRepository_GetIdentityKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *UserRepository_GetIdentityKeys_Call) Return(identityKeys repo.IdentityKeys, err error) *UserRepository_GetIdentityKeys_Call {
+	_c.Call.Return(identityKeys, err)
+	return _c
+}
+
+func (_c *UserRepository_GetIdentityKeys_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) (repo.IdentityKeys, error)) *UserRepository_GetIdentityKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPrivacySettings provides a mock function for the type UserRepository
+func (_mock *UserRepository) GetPrivacySettings(ctx context.Context, userID uuid.UUID) (repo.PrivacySettings, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPrivacySettings")
+	}
+
+	var r0 repo.PrivacySettings
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (repo.PrivacySettings, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) repo.PrivacySettings); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(repo.PrivacySettings)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// UserRepository_GetPrivacySettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPrivacySettings'
+type UserRepository_GetPrivacySettings_Call struct {
+	*mock.Call
+}
+
+// GetPrivacySettings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *UserRepository_Expecter) GetPrivacySettings(ctx interface{}, userID interface{}) *UserRepository_GetPrivacySettings_Call {
+	return &UserRepository_GetPrivacySettings_Call{Call: _e.mock.On("GetPrivacySettings", ctx, userID)}
+}
+
+func (_c *UserRepository_GetPrivacySettings_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *UserRepository_GetPrivacySettings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *UserRepository_GetPrivacySettings_Call) Return(privacySettings repo.PrivacySettings, err error) *UserRepository_GetPrivacySettings_Call {
+	_c.Call.Return(privacySettings, err)
+	return _c
+}
+
+func (_c *UserRepository_GetPrivacySettings_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) (repo.PrivacySettings, error)) *UserRepository_GetPrivacySettings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUser provides a mock function for the type UserRepository
+func (_mock *UserRepository) GetUser(ctx context.Context, id uuid.UUID) (repo.CreateUserInput, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUser")
+	}
+
+	var r0 repo.CreateUserInput
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (repo.CreateUserInput, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) repo.CreateUserInput); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(repo.CreateUserInput)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// UserRepository_GetUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUser'
+type UserRepository_GetUser_Call struct {
+	*mock.Call
+}
+
+// GetUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *UserRepository_Expecter) GetUser(ctx interface{}, id interface{}) *UserRepository_GetUser_Call {
+	return &UserRepository_GetUser_Call{Call: _e.mock.On("GetUser", ctx, id)}
+}
+
+func (_c *UserRepository_GetUser_Call) Run(run func(ctx context.Context, id uuid.UUID)) *UserRepository_GetUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *UserRepository_GetUser_Call) Return(createUserInput repo.CreateUserInput, err error) *UserRepository_GetUser_Call {
+	_c.Call.Return(createUserInput, err)
+	return _c
+}
+
+func (_c *UserRepository_GetUser_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (repo.CreateUserInput, error)) *UserRepository_GetUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserByUsername provides a mock function for the type UserRepository
+func (_mock *UserRepository) GetUserByUsername(ctx context.Context, username string) (repo.CreateUserInput, error) {
+	ret := _mock.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByUsername")
+	}
+
+	var r0 repo.CreateUserInput
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (repo.CreateUserInput, error)); ok {
+		return returnFunc(ctx, username)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) repo.CreateUserInput); ok {
+		r0 = returnFunc(ctx, username)
+	} else {
+		r0 = ret.Get(0).(repo.CreateUserInput)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// UserRepository_GetUserByUsername_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByUsername'
+type UserRepository_GetUserByUsername_Call struct {
+	*mock.Call
+}
+
+// GetUserByUsername is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+func (_e *UserRepository_Expecter) GetUserByUsername(ctx interface{}, username interface{}) *UserRepository_GetUserByUsername_Call {
+	return &UserRepository_GetUserByUsername_Call{Call: _e.mock.On("GetUserByUsername", ctx, username)}
+}
+
+func (_c *UserRepository_GetUserByUsername_Call) Run(run func(ctx context.Context, username string)) *UserRepository_GetUserByUsername_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *UserRepository_GetUserByUsername_Call) Return(createUserInput repo.CreateUserInput, err error) *UserRepository_GetUserByUsername_Call {
+	_c.Call.Return(createUserInput, err)
+	return _c
+}
+
+func (_c *UserRepository_GetUserByUsername_Call) RunAndReturn(run func(ctx context.Context, username string) (repo.CreateUserInput, error)) *UserRepository_GetUserByUsername_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUsersByOwner provides a mock function for the type UserRepository
+func (_mock *UserRepository) GetUsersByOwner(ctx context.Context, ownerID uuid.UUID) ([]repo.CreateUserInput, error) {
+	ret := _mock.Called(ctx, ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUsersByOwner")
+	}
+
+	var r0 []repo.CreateUserInput
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]repo.CreateUserInput, error)); ok {
+		return returnFunc(ctx, ownerID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []repo.CreateUserInput); ok {
+		r0 = returnFunc(ctx, ownerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repo.CreateUserInput)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, ownerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// UserRepository_GetUsersByOwner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUsersByOwner'
+type UserRepository_GetUsersByOwner_Call struct {
+	*mock.Call
+}
+
+// GetUsersByOwner is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ownerID uuid.UUID
+func (_e *UserRepository_Expecter) GetUsersByOwner(ctx interface{}, ownerID interface{}) *UserRepository_GetUsersByOwner_Call {
+	return &UserRepository_GetUsersByOwner_Call{Call: _e.mock.On("GetUsersByOwner", ctx, ownerID)}
+}
+
+func (_c *UserRepository_GetUsersByOwner_Call) Run(run func(ctx context.Context, ownerID uuid.UUID)) *UserRepository_GetUsersByOwner_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *UserRepository_GetUsersByOwner_Call) Return(createUserInputs []repo.CreateUserInput, err error) *UserRepository_GetUsersByOwner_Call {
+	_c.Call.Return(createUserInputs, err)
+	return _c
+}
+
+func (_c *UserRepository_GetUsersByOwner_Call) RunAndReturn(run func(ctx context.Context, ownerID uuid.UUID) ([]repo.CreateUserInput, error)) *UserRepository_GetUsersByOwner_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsBlocked provides a mock function for the type UserRepository
+func (_mock *UserRepository) IsBlocked(ctx context.Context, userID uuid.UUID, otherID uuid.UUID) (bool, error) {
+	ret := _mock.Called(ctx, userID, otherID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsBlocked")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (bool, error)); ok {
+		return returnFunc(ctx, userID, otherID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) bool); ok {
+		r0 = returnFunc(ctx, userID, otherID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID, otherID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// UserRepository_IsBlocked_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsBlocked'
+type UserRepository_IsBlocked_Call struct {
+	*mock.Call
+}
+
+// IsBlocked is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - otherID uuid.UUID
+func (_e *UserRepository_Expecter) IsBlocked(ctx interface{}, userID interface{}, otherID interface{}) *UserRepository_IsBlocked_Call {
+	return &UserRepository_IsBlocked_Call{Call: _e.mock.On("IsBlocked", ctx, userID, otherID)}
+}
+
+func (_c *UserRepository_IsBlocked_Call) Run(run func(ctx context.Context, userID uuid.UUID, otherID uuid.UUID)) *UserRepository_IsBlocked_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *UserRepository_IsBlocked_Call) Return(b bool, err error) *UserRepository_IsBlocked_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *UserRepository_IsBlocked_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, otherID uuid.UUID) (bool, error)) *UserRepository_IsBlocked_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsContact provides a mock function for the type UserRepository
+func (_mock *UserRepository) IsContact(ctx context.Context, userID uuid.UUID, contactID uuid.UUID) (bool, error) {
+	ret := _mock.Called(ctx, userID, contactID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsContact")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (bool, error)); ok {
+		return returnFunc(ctx, userID, contactID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) bool); ok {
+		r0 = returnFunc(ctx, userID, contactID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID, contactID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// UserRepository_IsContact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsContact'
+type UserRepository_IsContact_Call struct {
+	*mock.Call
+}
+
+// IsContact is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - contactID uuid.UUID
+func (_e *UserRepository_Expecter) IsContact(ctx interface{}, userID interface{}, contactID interface{}) *UserRepository_IsContact_Call {
+	return &UserRepository_IsContact_Call{Call: _e.mock.On("IsContact", ctx, userID, contactID)}
+}
+
+func (_c *UserRepository_IsContact_Call) Run(run func(ctx context.Context, userID uuid.UUID, contactID uuid.UUID)) *UserRepository_IsContact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *UserRepository_IsContact_Call) Return(b bool, err error) *UserRepository_IsContact_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *UserRepository_IsContact_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, contactID uuid.UUID) (bool, error)) *UserRepository_IsContact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveContact provides a mock function for the type UserRepository
+func (_mock *UserRepository) RemoveContact(ctx context.Context, userID uuid.UUID, contactID uuid.UUID) error {
+	ret := _mock.Called(ctx, userID, contactID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveContact")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, userID, contactID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// UserRepository_RemoveContact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveContact'
+type UserRepository_RemoveContact_Call struct {
+	*mock.Call
+}
+
+// RemoveContact is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - contactID uuid.UUID
+func (_e *UserRepository_Expecter) RemoveContact(ctx interface{}, userID interface{}, contactID interface{}) *UserRepository_RemoveContact_Call {
+	return &UserRepository_RemoveContact_Call{Call: _e.mock.On("RemoveContact", ctx, userID, contactID)}
+}
+
+func (_c *UserRepository_RemoveContact_Call) Run(run func(ctx context.Context, userID uuid.UUID, contactID uuid.UUID)) *UserRepository_RemoveContact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *UserRepository_RemoveContact_Call) Return(err error) *UserRepository_RemoveContact_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *UserRepository_RemoveContact_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, contactID uuid.UUID) error) *UserRepository_RemoveContact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveIdentityKeys provides a mock function for the type UserRepository
+func (_mock *UserRepository) SaveIdentityKeys(ctx context.Context, userID uuid.UUID, keys repo.IdentityKeys) error {
+	ret := _mock.Called(ctx, userID, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveIdentityKeys")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, repo.IdentityKeys) error); ok {
+		r0 = returnFunc(ctx, userID, keys)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// UserRepository_SaveIdentityKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveIdentityKeys'
+type UserRepository_SaveIdentityKeys_Call struct {
+	*mock.Call
+}
+
+// SaveIdentityKeys is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - keys repo.IdentityKeys
+func (_e *UserRepository_Expecter) SaveIdentityKeys(ctx interface{}, userID interface{}, keys interface{}) *UserRepository_SaveIdentityKeys_Call {
+	return &UserRepository_SaveIdentityKeys_Call{Call: _e.mock.On("SaveIdentityKeys", ctx, userID, keys)}
+}
+
+func (_c *UserRepository_SaveIdentityKeys_Call) Run(run func(ctx context.Context, userID uuid.UUID, keys repo.IdentityKeys)) *UserRepository_SaveIdentityKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 repo.IdentityKeys
+		if args[2] != nil {
+			arg2 = args[2].(repo.IdentityKeys)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *UserRepository_SaveIdentityKeys_Call) Return(err error) *UserRepository_SaveIdentityKeys_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *UserRepository_SaveIdentityKeys_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, keys repo.IdentityKeys) error) *UserRepository_SaveIdentityKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TakeOneTimePreKey provides a mock function for the type UserRepository
+func (_mock *UserRepository) TakeOneTimePreKey(ctx context.Context, userID uuid.UUID) (repo.OneTimePreKey, bool, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TakeOneTimePreKey")
+	}
+
+	var r0 repo.OneTimePreKey
+	var r1 bool
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (repo.OneTimePreKey, bool, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) repo.OneTimePreKey); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(repo.OneTimePreKey)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) bool); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, uuid.UUID) error); ok {
+		r2 = returnFunc(ctx, userID)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// UserRepository_TakeOneTimePreKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TakeOneTimePreKey'
+type UserRepository_TakeOneTimePreKey_Call struct {
+	*mock.Call
+}
+
+// TakeOneTimePreKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *UserRepository_Expecter) TakeOneTimePreKey(ctx interface{}, userID interface{}) *UserRepository_TakeOneTimePreKey_Call {
+	return &UserRepository_TakeOneTimePreKey_Call{Call: _e.mock.On("TakeOneTimePreKey", ctx, userID)}
+}
+
+func (_c *UserRepository_TakeOneTimePreKey_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *UserRepository_TakeOneTimePreKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *UserRepository_TakeOneTimePreKey_Call) Return(oneTimePreKey repo.OneTimePreKey, b bool, err error) *UserRepository_TakeOneTimePreKey_Call {
+	_c.Call.Return(oneTimePreKey, b, err)
+	return _c
+}
+
+func (_c *UserRepository_TakeOneTimePreKey_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) (repo.OneTimePreKey, bool, error)) *UserRepository_TakeOneTimePreKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnblockUser provides a mock function for the type UserRepository
+func (_mock *UserRepository) UnblockUser(ctx context.Context, userID uuid.UUID, blockedID uuid.UUID) error {
+	ret := _mock.Called(ctx, userID, blockedID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnblockUser")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, userID, blockedID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// UserRepository_UnblockUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnblockUser'
+type UserRepository_UnblockUser_Call struct {
+	*mock.Call
+}
+
+// UnblockUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - blockedID uuid.UUID
+func (_e *UserRepository_Expecter) UnblockUser(ctx interface{}, userID interface{}, blockedID interface{}) *UserRepository_UnblockUser_Call {
+	return &UserRepository_UnblockUser_Call{Call: _e.mock.On("UnblockUser", ctx, userID, blockedID)}
+}
+
+func (_c *UserRepository_UnblockUser_Call) Run(run func(ctx context.Context, userID uuid.UUID, blockedID uuid.UUID)) *UserRepository_UnblockUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *UserRepository_UnblockUser_Call) Return(err error) *UserRepository_UnblockUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *UserRepository_UnblockUser_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, blockedID uuid.UUID) error) *UserRepository_UnblockUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePrivacySettings provides a mock function for the type UserRepository
+func (_mock *UserRepository) UpdatePrivacySettings(ctx context.Context, userID uuid.UUID, settings repo.PrivacySettings) error {
+	ret := _mock.Called(ctx, userID, settings)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePrivacySettings")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, repo.PrivacySettings) error); ok {
+		r0 = returnFunc(ctx, userID, settings)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// UserRepository_UpdatePrivacySettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePrivacySettings'
+type UserRepository_UpdatePrivacySettings_Call struct {
+	*mock.Call
+}
+
+// UpdatePrivacySettings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - settings repo.PrivacySettings
+func (_e *UserRepository_Expecter) UpdatePrivacySettings(ctx interface{}, userID interface{}, settings interface{}) *UserRepository_UpdatePrivacySettings_Call {
+	return &UserRepository_UpdatePrivacySettings_Call{Call: _e.mock.On("UpdatePrivacySettings", ctx, userID, settings)}
+}
+
+func (_c *UserRepository_UpdatePrivacySettings_Call) Run(run func(ctx context.Context, userID uuid.UUID, settings repo.PrivacySettings)) *UserRepository_UpdatePrivacySettings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 repo.PrivacySettings
+		if args[2] != nil {
+			arg2 = args[2].(repo.PrivacySettings)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *UserRepository_UpdatePrivacySettings_Call) Return(err error) *UserRepository_UpdatePrivacySettings_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *UserRepository_UpdatePrivacySettings_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, settings repo.PrivacySettings) error) *UserRepository_UpdatePrivacySettings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateUser provides a mock function for the type UserRepository
+func (_mock *UserRepository) UpdateUser(ctx context.Context, in repo.CreateUserInput) error {
+	ret := _mock.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUser")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repo.CreateUserInput) error); ok {
+		r0 = returnFunc(ctx, in)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// UserRepository_UpdateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUser'
+type UserRepository_UpdateUser_Call struct {
+	*mock.Call
+}
+
+// UpdateUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in repo.CreateUserInput
+func (_e *UserRepository_Expecter) UpdateUser(ctx interface{}, in interface{}) *UserRepository_UpdateUser_Call {
+	return &UserRepository_UpdateUser_Call{Call: _e.mock.On("UpdateUser", ctx, in)}
+}
+
+func (_c *UserRepository_UpdateUser_Call) Run(run func(ctx context.Context, in repo.CreateUserInput)) *UserRepository_UpdateUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 repo.CreateUserInput
+		if args[1] != nil {
+			arg1 = args[1].(repo.CreateUserInput)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *UserRepository_UpdateUser_Call) Return(err error) *UserRepository_UpdateUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *UserRepository_UpdateUser_Call) RunAndReturn(run func(ctx context.Context, in repo.CreateUserInput) error) *UserRepository_UpdateUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
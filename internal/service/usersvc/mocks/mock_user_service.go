@@ -6,6 +6,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/AliUnipal/chat/internal/models/user"
 	"github.com/AliUnipal/chat/internal/service/usersvc"
@@ -40,55 +41,181 @@ func (_m *UserService) EXPECT() *UserService_Expecter {
 	return &UserService_Expecter{mock: &_m.Mock}
 }
 
-// CreateUser provides a mock function for the type UserService
-func (_mock *UserService) CreateUser(ctx context.Context, in usersvc.CreateUserInput) (uuid.UUID, error) {
-	ret := _mock.Called(ctx, in)
+// AddContact provides a mock function for the type UserService
+func (_mock *UserService) AddContact(ctx context.Context, userID uuid.UUID, contactID uuid.UUID) error {
+	ret := _mock.Called(ctx, userID, contactID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateUser")
+		panic("no return value specified for AddContact")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, userID, contactID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// UserService_AddContact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddContact'
+type UserService_AddContact_Call struct {
+	*mock.Call
+}
+
+// AddContact is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - contactID uuid.UUID
+func (_e *UserService_Expecter) AddContact(ctx interface{}, userID interface{}, contactID interface{}) *UserService_AddContact_Call {
+	return &UserService_AddContact_Call{Call: _e.mock.On("AddContact", ctx, userID, contactID)}
+}
+
+func (_c *UserService_AddContact_Call) Run(run func(ctx context.Context, userID uuid.UUID, contactID uuid.UUID)) *UserService_AddContact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *UserService_AddContact_Call) Return(err error) *UserService_AddContact_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *UserService_AddContact_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, contactID uuid.UUID) error) *UserService_AddContact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddOneTimePreKeys provides a mock function for the type UserService
+func (_mock *UserService) AddOneTimePreKeys(ctx context.Context, userID uuid.UUID, keys []user.OneTimePreKey) error {
+	ret := _mock.Called(ctx, userID, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddOneTimePreKeys")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, []user.OneTimePreKey) error); ok {
+		r0 = returnFunc(ctx, userID, keys)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// UserService_AddOneTimePreKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddOneTimePreKeys'
+type UserService_AddOneTimePreKeys_Call struct {
+	*mock.Call
+}
+
+// AddOneTimePreKeys is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - keys []user.OneTimePreKey
+func (_e *UserService_Expecter) AddOneTimePreKeys(ctx interface{}, userID interface{}, keys interface{}) *UserService_AddOneTimePreKeys_Call {
+	return &UserService_AddOneTimePreKeys_Call{Call: _e.mock.On("AddOneTimePreKeys", ctx, userID, keys)}
+}
+
+func (_c *UserService_AddOneTimePreKeys_Call) Run(run func(ctx context.Context, userID uuid.UUID, keys []user.OneTimePreKey)) *UserService_AddOneTimePreKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 []user.OneTimePreKey
+		if args[2] != nil {
+			arg2 = args[2].([]user.OneTimePreKey)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *UserService_AddOneTimePreKeys_Call) Return(err error) *UserService_AddOneTimePreKeys_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *UserService_AddOneTimePreKeys_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, keys []user.OneTimePreKey) error) *UserService_AddOneTimePreKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AuthenticateBot provides a mock function for the type UserService
+func (_mock *UserService) AuthenticateBot(ctx context.Context, token string) (uuid.UUID, error) {
+	ret := _mock.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuthenticateBot")
 	}
 
 	var r0 uuid.UUID
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, usersvc.CreateUserInput) (uuid.UUID, error)); ok {
-		return returnFunc(ctx, in)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (uuid.UUID, error)); ok {
+		return returnFunc(ctx, token)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, usersvc.CreateUserInput) uuid.UUID); ok {
-		r0 = returnFunc(ctx, in)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) uuid.UUID); ok {
+		r0 = returnFunc(ctx, token)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(uuid.UUID)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, usersvc.CreateUserInput) error); ok {
-		r1 = returnFunc(ctx, in)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, token)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// UserService_CreateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUser'
-type UserService_CreateUser_Call struct {
+// UserService_AuthenticateBot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AuthenticateBot'
+type UserService_AuthenticateBot_Call struct {
 	*mock.Call
 }
 
-// CreateUser is a helper method to define mock.On call
+// AuthenticateBot is a helper method to define mock.On call
 //   - ctx context.Context
-//   - in usersvc.CreateUserInput
-func (_e *UserService_Expecter) CreateUser(ctx interface{}, in interface{}) *UserService_CreateUser_Call {
-	return &UserService_CreateUser_Call{Call: _e.mock.On("CreateUser", ctx, in)}
+//   - token string
+func (_e *UserService_Expecter) AuthenticateBot(ctx interface{}, token interface{}) *UserService_AuthenticateBot_Call {
+	return &UserService_AuthenticateBot_Call{Call: _e.mock.On("AuthenticateBot", ctx, token)}
 }
 
-func (_c *UserService_CreateUser_Call) Run(run func(ctx context.Context, in usersvc.CreateUserInput)) *UserService_CreateUser_Call {
+func (_c *UserService_AuthenticateBot_Call) Run(run func(ctx context.Context, token string)) *UserService_AuthenticateBot_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 usersvc.CreateUserInput
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(usersvc.CreateUserInput)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
@@ -98,55 +225,182 @@ func (_c *UserService_CreateUser_Call) Run(run func(ctx context.Context, in user
 	return _c
 }
 
-func (_c *UserService_CreateUser_Call) Return(uUID uuid.UUID, err error) *UserService_CreateUser_Call {
+func (_c *UserService_AuthenticateBot_Call) Return(uUID uuid.UUID, err error) *UserService_AuthenticateBot_Call {
 	_c.Call.Return(uUID, err)
 	return _c
 }
 
-func (_c *UserService_CreateUser_Call) RunAndReturn(run func(ctx context.Context, in usersvc.CreateUserInput) (uuid.UUID, error)) *UserService_CreateUser_Call {
+func (_c *UserService_AuthenticateBot_Call) RunAndReturn(run func(ctx context.Context, token string) (uuid.UUID, error)) *UserService_AuthenticateBot_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUser provides a mock function for the type UserService
-func (_mock *UserService) GetUser(ctx context.Context, id uuid.UUID) (user.User, error) {
-	ret := _mock.Called(ctx, id)
+// BlockUser provides a mock function for the type UserService
+func (_mock *UserService) BlockUser(ctx context.Context, userID uuid.UUID, blockedID uuid.UUID) error {
+	ret := _mock.Called(ctx, userID, blockedID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUser")
+		panic("no return value specified for BlockUser")
 	}
 
-	var r0 user.User
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, userID, blockedID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// UserService_BlockUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BlockUser'
+type UserService_BlockUser_Call struct {
+	*mock.Call
+}
+
+// BlockUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - blockedID uuid.UUID
+func (_e *UserService_Expecter) BlockUser(ctx interface{}, userID interface{}, blockedID interface{}) *UserService_BlockUser_Call {
+	return &UserService_BlockUser_Call{Call: _e.mock.On("BlockUser", ctx, userID, blockedID)}
+}
+
+func (_c *UserService_BlockUser_Call) Run(run func(ctx context.Context, userID uuid.UUID, blockedID uuid.UUID)) *UserService_BlockUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *UserService_BlockUser_Call) Return(err error) *UserService_BlockUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *UserService_BlockUser_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, blockedID uuid.UUID) error) *UserService_BlockUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CanMessage provides a mock function for the type UserService
+func (_mock *UserService) CanMessage(ctx context.Context, senderID uuid.UUID, recipientID uuid.UUID) error {
+	ret := _mock.Called(ctx, senderID, recipientID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CanMessage")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, senderID, recipientID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// UserService_CanMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CanMessage'
+type UserService_CanMessage_Call struct {
+	*mock.Call
+}
+
+// CanMessage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - senderID uuid.UUID
+//   - recipientID uuid.UUID
+func (_e *UserService_Expecter) CanMessage(ctx interface{}, senderID interface{}, recipientID interface{}) *UserService_CanMessage_Call {
+	return &UserService_CanMessage_Call{Call: _e.mock.On("CanMessage", ctx, senderID, recipientID)}
+}
+
+func (_c *UserService_CanMessage_Call) Run(run func(ctx context.Context, senderID uuid.UUID, recipientID uuid.UUID)) *UserService_CanMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *UserService_CanMessage_Call) Return(err error) *UserService_CanMessage_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *UserService_CanMessage_Call) RunAndReturn(run func(ctx context.Context, senderID uuid.UUID, recipientID uuid.UUID) error) *UserService_CanMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CanSeeLastSeen provides a mock function for the type UserService
+func (_mock *UserService) CanSeeLastSeen(ctx context.Context, viewerID uuid.UUID, ownerID uuid.UUID) (bool, error) {
+	ret := _mock.Called(ctx, viewerID, ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CanSeeLastSeen")
+	}
+
+	var r0 bool
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (user.User, error)); ok {
-		return returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (bool, error)); ok {
+		return returnFunc(ctx, viewerID, ownerID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) user.User); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) bool); ok {
+		r0 = returnFunc(ctx, viewerID, ownerID)
 	} else {
-		r0 = ret.Get(0).(user.User)
+		r0 = ret.Get(0).(bool)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, viewerID, ownerID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// UserService_GetUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUser'
-type UserService_GetUser_Call struct {
+// UserService_CanSeeLastSeen_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CanSeeLastSeen'
+type UserService_CanSeeLastSeen_Call struct {
 	*mock.Call
 }
 
-// GetUser is a helper method to define mock.On call
+// CanSeeLastSeen is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id uuid.UUID
-func (_e *UserService_Expecter) GetUser(ctx interface{}, id interface{}) *UserService_GetUser_Call {
-	return &UserService_GetUser_Call{Call: _e.mock.On("GetUser", ctx, id)}
+//   - viewerID uuid.UUID
+//   - ownerID uuid.UUID
+func (_e *UserService_Expecter) CanSeeLastSeen(ctx interface{}, viewerID interface{}, ownerID interface{}) *UserService_CanSeeLastSeen_Call {
+	return &UserService_CanSeeLastSeen_Call{Call: _e.mock.On("CanSeeLastSeen", ctx, viewerID, ownerID)}
 }
 
-func (_c *UserService_GetUser_Call) Run(run func(ctx context.Context, id uuid.UUID)) *UserService_GetUser_Call {
+func (_c *UserService_CanSeeLastSeen_Call) Run(run func(ctx context.Context, viewerID uuid.UUID, ownerID uuid.UUID)) *UserService_CanSeeLastSeen_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -156,20 +410,1747 @@ func (_c *UserService_GetUser_Call) Run(run func(ctx context.Context, id uuid.UU
 		if args[1] != nil {
 			arg1 = args[1].(uuid.UUID)
 		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *UserService_GetUser_Call) Return(user1 user.User, err error) *UserService_GetUser_Call {
-	_c.Call.Return(user1, err)
+func (_c *UserService_CanSeeLastSeen_Call) Return(b bool, err error) *UserService_CanSeeLastSeen_Call {
+	_c.Call.Return(b, err)
 	return _c
 }
 
-func (_c *UserService_GetUser_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (user.User, error)) *UserService_GetUser_Call {
+func (_c *UserService_CanSeeLastSeen_Call) RunAndReturn(run func(ctx context.Context, viewerID uuid.UUID, ownerID uuid.UUID) (bool, error)) *UserService_CanSeeLastSeen_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CanSeeProfileImage provides a mock function for the type UserService
+func (_mock *UserService) CanSeeProfileImage(ctx context.Context, viewerID uuid.UUID, ownerID uuid.UUID) (bool, error) {
+	ret := _mock.Called(ctx, viewerID, ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CanSeeProfileImage")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (bool, error)); ok {
+		return returnFunc(ctx, viewerID, ownerID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) bool); ok {
+		r0 = returnFunc(ctx, viewerID, ownerID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, viewerID, ownerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// UserService_CanSeeProfileImage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CanSeeProfileImage'
+type UserService_CanSeeProfileImage_Call struct {
+	*mock.Call
+}
+
+// CanSeeProfileImage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - viewerID uuid.UUID
+//   - ownerID uuid.UUID
+func (_e *UserService_Expecter) CanSeeProfileImage(ctx interface{}, viewerID interface{}, ownerID interface{}) *UserService_CanSeeProfileImage_Call {
+	return &UserService_CanSeeProfileImage_Call{Call: _e.mock.On("CanSeeProfileImage", ctx, viewerID, ownerID)}
+}
+
+func (_c *UserService_CanSeeProfileImage_Call) Run(run func(ctx context.Context, viewerID uuid.UUID, ownerID uuid.UUID)) *UserService_CanSeeProfileImage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *UserService_CanSeeProfileImage_Call) Return(b bool, err error) *UserService_CanSeeProfileImage_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *UserService_CanSeeProfileImage_Call) RunAndReturn(run func(ctx context.Context, viewerID uuid.UUID, ownerID uuid.UUID) (bool, error)) *UserService_CanSeeProfileImage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CanStartChat provides a mock function for the type UserService
+func (_mock *UserService) CanStartChat(ctx context.Context, senderID uuid.UUID, recipientID uuid.UUID) error {
+	ret := _mock.Called(ctx, senderID, recipientID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CanStartChat")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, senderID, recipientID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// UserService_CanStartChat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CanStartChat'
+type UserService_CanStartChat_Call struct {
+	*mock.Call
+}
+
+// CanStartChat is a helper method to define mock.On call
+//   - ctx context.Context
+//   - senderID uuid.UUID
+//   - recipientID uuid.UUID
+func (_e *UserService_Expecter) CanStartChat(ctx interface{}, senderID interface{}, recipientID interface{}) *UserService_CanStartChat_Call {
+	return &UserService_CanStartChat_Call{Call: _e.mock.On("CanStartChat", ctx, senderID, recipientID)}
+}
+
+func (_c *UserService_CanStartChat_Call) Run(run func(ctx context.Context, senderID uuid.UUID, recipientID uuid.UUID)) *UserService_CanStartChat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *UserService_CanStartChat_Call) Return(err error) *UserService_CanStartChat_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *UserService_CanStartChat_Call) RunAndReturn(run func(ctx context.Context, senderID uuid.UUID, recipientID uuid.UUID) error) *UserService_CanStartChat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountOneTimePreKeys provides a mock function for the type UserService
+func (_mock *UserService) CountOneTimePreKeys(ctx context.Context, userID uuid.UUID) (int, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountOneTimePreKeys")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (int, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) int); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// UserService_CountOneTimePreKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountOneTimePreKeys'
+type UserService_CountOneTimePreKeys_Call struct {
+	*mock.Call
+}
+
+// CountOneTimePreKeys is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *UserService_Expecter) CountOneTimePreKeys(ctx interface{}, userID interface{}) *UserService_CountOneTimePreKeys_Call {
+	return &UserService_CountOneTimePreKeys_Call{Call: _e.mock.On("CountOneTimePreKeys", ctx, userID)}
+}
+
+func (_c *UserService_CountOneTimePreKeys_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *UserService_CountOneTimePreKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *UserService_CountOneTimePreKeys_Call) Return(n int, err error) *UserService_CountOneTimePreKeys_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *UserService_CountOneTimePreKeys_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) (int, error)) *UserService_CountOneTimePreKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateBot provides a mock function for the type UserService
+func (_mock *UserService) CreateBot(ctx context.Context, ownerID uuid.UUID, in usersvc.CreateUserInput) (uuid.UUID, string, error) {
+	ret := _mock.Called(ctx, ownerID, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBot")
+	}
+
+	var r0 uuid.UUID
+	var r1 string
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, usersvc.CreateUserInput) (uuid.UUID, string, error)); ok {
+		return returnFunc(ctx, ownerID, in)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, usersvc.CreateUserInput) uuid.UUID); ok {
+		r0 = returnFunc(ctx, ownerID, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(uuid.UUID)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, usersvc.CreateUserInput) string); ok {
+		r1 = returnFunc(ctx, ownerID, in)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, uuid.UUID, usersvc.CreateUserInput) error); ok {
+		r2 = returnFunc(ctx, ownerID, in)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// UserService_CreateBot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBot'
+type UserService_CreateBot_Call struct {
+	*mock.Call
+}
+
+// CreateBot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ownerID uuid.UUID
+//   - in usersvc.CreateUserInput
+func (_e *UserService_Expecter) CreateBot(ctx interface{}, ownerID interface{}, in interface{}) *UserService_CreateBot_Call {
+	return &UserService_CreateBot_Call{Call: _e.mock.On("CreateBot", ctx, ownerID, in)}
+}
+
+func (_c *UserService_CreateBot_Call) Run(run func(ctx context.Context, ownerID uuid.UUID, in usersvc.CreateUserInput)) *UserService_CreateBot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 usersvc.CreateUserInput
+		if args[2] != nil {
+			arg2 = args[2].(usersvc.CreateUserInput)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *UserService_CreateBot_Call) Return(uUID uuid.UUID, s string, err error) *UserService_CreateBot_Call {
+	_c.Call.Return(uUID, s, err)
+	return _c
+}
+
+func (_c *UserService_CreateBot_Call) RunAndReturn(run func(ctx context.Context, ownerID uuid.UUID, in usersvc.CreateUserInput) (uuid.UUID, string, error)) *UserService_CreateBot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateImportedUser provides a mock function for the type UserService
+func (_mock *UserService) CreateImportedUser(ctx context.Context, ownerID uuid.UUID, in usersvc.CreateUserInput) (uuid.UUID, error) {
+	ret := _mock.Called(ctx, ownerID, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateImportedUser")
+	}
+
+	var r0 uuid.UUID
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, usersvc.CreateUserInput) (uuid.UUID, error)); ok {
+		return returnFunc(ctx, ownerID, in)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, usersvc.CreateUserInput) uuid.UUID); ok {
+		r0 = returnFunc(ctx, ownerID, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(uuid.UUID)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, usersvc.CreateUserInput) error); ok {
+		r1 = returnFunc(ctx, ownerID, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// UserService_CreateImportedUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateImportedUser'
+type UserService_CreateImportedUser_Call struct {
+	*mock.Call
+}
+
+// CreateImportedUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ownerID uuid.UUID
+//   - in usersvc.CreateUserInput
+func (_e *UserService_Expecter) CreateImportedUser(ctx interface{}, ownerID interface{}, in interface{}) *UserService_CreateImportedUser_Call {
+	return &UserService_CreateImportedUser_Call{Call: _e.mock.On("CreateImportedUser", ctx, ownerID, in)}
+}
+
+func (_c *UserService_CreateImportedUser_Call) Run(run func(ctx context.Context, ownerID uuid.UUID, in usersvc.CreateUserInput)) *UserService_CreateImportedUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 usersvc.CreateUserInput
+		if args[2] != nil {
+			arg2 = args[2].(usersvc.CreateUserInput)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *UserService_CreateImportedUser_Call) Return(uUID uuid.UUID, err error) *UserService_CreateImportedUser_Call {
+	_c.Call.Return(uUID, err)
+	return _c
+}
+
+func (_c *UserService_CreateImportedUser_Call) RunAndReturn(run func(ctx context.Context, ownerID uuid.UUID, in usersvc.CreateUserInput) (uuid.UUID, error)) *UserService_CreateImportedUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateUser provides a mock function for the type UserService
+func (_mock *UserService) CreateUser(ctx context.Context, in usersvc.CreateUserInput) (uuid.UUID, error) {
+	ret := _mock.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUser")
+	}
+
+	var r0 uuid.UUID
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, usersvc.CreateUserInput) (uuid.UUID, error)); ok {
+		return returnFunc(ctx, in)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, usersvc.CreateUserInput) uuid.UUID); ok {
+		r0 = returnFunc(ctx, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(uuid.UUID)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, usersvc.CreateUserInput) error); ok {
+		r1 = returnFunc(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// UserService_CreateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUser'
+type UserService_CreateUser_Call struct {
+	*mock.Call
+}
+
+// CreateUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in usersvc.CreateUserInput
+func (_e *UserService_Expecter) CreateUser(ctx interface{}, in interface{}) *UserService_CreateUser_Call {
+	return &UserService_CreateUser_Call{Call: _e.mock.On("CreateUser", ctx, in)}
+}
+
+func (_c *UserService_CreateUser_Call) Run(run func(ctx context.Context, in usersvc.CreateUserInput)) *UserService_CreateUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 usersvc.CreateUserInput
+		if args[1] != nil {
+			arg1 = args[1].(usersvc.CreateUserInput)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *UserService_CreateUser_Call) Return(uUID uuid.UUID, err error) *UserService_CreateUser_Call {
+	_c.Call.Return(uUID, err)
+	return _c
+}
+
+func (_c *UserService_CreateUser_Call) RunAndReturn(run func(ctx context.Context, in usersvc.CreateUserInput) (uuid.UUID, error)) *UserService_CreateUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeactivateUser provides a mock function for the type UserService
+func (_mock *UserService) DeactivateUser(ctx context.Context, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeactivateUser")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// UserService_DeactivateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeactivateUser'
+type UserService_DeactivateUser_Call struct {
+	*mock.Call
+}
+
+// DeactivateUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *UserService_Expecter) DeactivateUser(ctx interface{}, userID interface{}) *UserService_DeactivateUser_Call {
+	return &UserService_DeactivateUser_Call{Call: _e.mock.On("DeactivateUser", ctx, userID)}
+}
+
+func (_c *UserService_DeactivateUser_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *UserService_DeactivateUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *UserService_DeactivateUser_Call) Return(err error) *UserService_DeactivateUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *UserService_DeactivateUser_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) error) *UserService_DeactivateUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteUser provides a mock function for the type UserService
+func (_mock *UserService) DeleteUser(ctx context.Context, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUser")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// UserService_DeleteUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUser'
+type UserService_DeleteUser_Call struct {
+	*mock.Call
+}
+
+// DeleteUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *UserService_Expecter) DeleteUser(ctx interface{}, userID interface{}) *UserService_DeleteUser_Call {
+	return &UserService_DeleteUser_Call{Call: _e.mock.On("DeleteUser", ctx, userID)}
+}
+
+func (_c *UserService_DeleteUser_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *UserService_DeleteUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *UserService_DeleteUser_Call) Return(err error) *UserService_DeleteUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *UserService_DeleteUser_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) error) *UserService_DeleteUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAccountState provides a mock function for the type UserService
+func (_mock *UserService) GetAccountState(ctx context.Context, userID uuid.UUID) (user.AccountState, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountState")
+	}
+
+	var r0 user.AccountState
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (user.AccountState, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) user.AccountState); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(user.AccountState)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// UserService_GetAccountState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAccountState'
+type UserService_GetAccountState_Call struct {
+	*mock.Call
+}
+
+// GetAccountState is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *UserService_Expecter) GetAccountState(ctx interface{}, userID interface{}) *UserService_GetAccountState_Call {
+	return &UserService_GetAccountState_Call{Call: _e.mock.On("GetAccountState", ctx, userID)}
+}
+
+func (_c *UserService_GetAccountState_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *UserService_GetAccountState_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *UserService_GetAccountState_Call) Return(accountState user.AccountState, err error) *UserService_GetAccountState_Call {
+	_c.Call.Return(accountState, err)
+	return _c
+}
+
+func (_c *UserService_GetAccountState_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) (user.AccountState, error)) *UserService_GetAccountState_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBlockedUsers provides a mock function for the type UserService
+func (_mock *UserService) GetBlockedUsers(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlockedUsers")
+	}
+
+	var r0 []uuid.UUID
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]uuid.UUID, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []uuid.UUID); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uuid.UUID)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// UserService_GetBlockedUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBlockedUsers'
+type UserService_GetBlockedUsers_Call struct {
+	*mock.Call
+}
+
+// GetBlockedUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *UserService_Expecter) GetBlockedUsers(ctx interface{}, userID interface{}) *UserService_GetBlockedUsers_Call {
+	return &UserService_GetBlockedUsers_Call{Call: _e.mock.On("GetBlockedUsers", ctx, userID)}
+}
+
+func (_c *UserService_GetBlockedUsers_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *UserService_GetBlockedUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *UserService_GetBlockedUsers_Call) Return(uUIDs []uuid.UUID, err error) *UserService_GetBlockedUsers_Call {
+	_c.Call.Return(uUIDs, err)
+	return _c
+}
+
+func (_c *UserService_GetBlockedUsers_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)) *UserService_GetBlockedUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBots provides a mock function for the type UserService
+func (_mock *UserService) GetBots(ctx context.Context, ownerID uuid.UUID) ([]user.User, error) {
+	ret := _mock.Called(ctx, ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBots")
+	}
+
+	var r0 []user.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]user.User, error)); ok {
+		return returnFunc(ctx, ownerID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []user.User); ok {
+		r0 = returnFunc(ctx, ownerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]user.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, ownerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// UserService_GetBots_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBots'
+type UserService_GetBots_Call struct {
+	*mock.Call
+}
+
+// GetBots is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ownerID uuid.UUID
+func (_e *UserService_Expecter) GetBots(ctx interface{}, ownerID interface{}) *UserService_GetBots_Call {
+	return &UserService_GetBots_Call{Call: _e.mock.On("GetBots", ctx, ownerID)}
+}
+
+func (_c *UserService_GetBots_Call) Run(run func(ctx context.Context, ownerID uuid.UUID)) *UserService_GetBots_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *UserService_GetBots_Call) Return(users []user.User, err error) *UserService_GetBots_Call {
+	_c.Call.Return(users, err)
+	return _c
+}
+
+func (_c *UserService_GetBots_Call) RunAndReturn(run func(ctx context.Context, ownerID uuid.UUID) ([]user.User, error)) *UserService_GetBots_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetContacts provides a mock function for the type UserService
+func (_mock *UserService) GetContacts(ctx context.Context, userID uuid.UUID) ([]user.User, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetContacts")
+	}
+
+	var r0 []user.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]user.User, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []user.User); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]user.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// UserService_GetContacts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetContacts'
+type UserService_GetContacts_Call struct {
+	*mock.Call
+}
+
+// GetContacts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *UserService_Expecter) GetContacts(ctx interface{}, userID interface{}) *UserService_GetContacts_Call {
+	return &UserService_GetContacts_Call{Call: _e.mock.On("GetContacts", ctx, userID)}
+}
+
+func (_c *UserService_GetContacts_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *UserService_GetContacts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *UserService_GetContacts_Call) Return(users []user.User, err error) *UserService_GetContacts_Call {
+	_c.Call.Return(users, err)
+	return _c
+}
+
+func (_c *UserService_GetContacts_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) ([]user.User, error)) *UserService_GetContacts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPreKeyBundle provides a mock function for the type UserService
+func (_mock *UserService) GetPreKeyBundle(ctx context.Context, requesterID uuid.UUID, userID uuid.UUID) (user.PreKeyBundle, error) {
+	ret := _mock.Called(ctx, requesterID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPreKeyBundle")
+	}
+
+	var r0 user.PreKeyBundle
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (user.PreKeyBundle, error)); ok {
+		return returnFunc(ctx, requesterID, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) user.PreKeyBundle); ok {
+		r0 = returnFunc(ctx, requesterID, userID)
+	} else {
+		r0 = ret.Get(0).(user.PreKeyBundle)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, requesterID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// UserService_GetPreKeyBundle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPreKeyBundle'
+type UserService_GetPreKeyBundle_Call struct {
+	*mock.Call
+}
+
+// GetPreKeyBundle is a helper method to define mock.On call
+//   - ctx context.Context
+//   - requesterID uuid.UUID
+//   - userID uuid.UUID
+func (_e *UserService_Expecter) GetPreKeyBundle(ctx interface{}, requesterID interface{}, userID interface{}) *UserService_GetPreKeyBundle_Call {
+	return &UserService_GetPreKeyBundle_Call{Call: _e.mock.On("GetPreKeyBundle", ctx, requesterID, userID)}
+}
+
+func (_c *UserService_GetPreKeyBundle_Call) Run(run func(ctx context.Context, requesterID uuid.UUID, userID uuid.UUID)) *UserService_GetPreKeyBundle_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *UserService_GetPreKeyBundle_Call) Return(preKeyBundle user.PreKeyBundle, err error) *UserService_GetPreKeyBundle_Call {
+	_c.Call.Return(preKeyBundle, err)
+	return _c
+}
+
+func (_c *UserService_GetPreKeyBundle_Call) RunAndReturn(run func(ctx context.Context, requesterID uuid.UUID, userID uuid.UUID) (user.PreKeyBundle, error)) *UserService_GetPreKeyBundle_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPrivacySettings provides a mock function for the type UserService
+func (_mock *UserService) GetPrivacySettings(ctx context.Context, userID uuid.UUID) (user.PrivacySettings, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPrivacySettings")
+	}
+
+	var r0 user.PrivacySettings
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (user.PrivacySettings, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) user.PrivacySettings); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(user.PrivacySettings)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// UserService_GetPrivacySettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPrivacySettings'
+type UserService_GetPrivacySettings_Call struct {
+	*mock.Call
+}
+
+// GetPrivacySettings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *UserService_Expecter) GetPrivacySettings(ctx interface{}, userID interface{}) *UserService_GetPrivacySettings_Call {
+	return &UserService_GetPrivacySettings_Call{Call: _e.mock.On("GetPrivacySettings", ctx, userID)}
+}
+
+func (_c *UserService_GetPrivacySettings_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *UserService_GetPrivacySettings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *UserService_GetPrivacySettings_Call) Return(privacySettings user.PrivacySettings, err error) *UserService_GetPrivacySettings_Call {
+	_c.Call.Return(privacySettings, err)
+	return _c
+}
+
+func (_c *UserService_GetPrivacySettings_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) (user.PrivacySettings, error)) *UserService_GetPrivacySettings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUser provides a mock function for the type UserService
+func (_mock *UserService) GetUser(ctx context.Context, id uuid.UUID) (user.User, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUser")
+	}
+
+	var r0 user.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (user.User, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) user.User); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(user.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// UserService_GetUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUser'
+type UserService_GetUser_Call struct {
+	*mock.Call
+}
+
+// GetUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *UserService_Expecter) GetUser(ctx interface{}, id interface{}) *UserService_GetUser_Call {
+	return &UserService_GetUser_Call{Call: _e.mock.On("GetUser", ctx, id)}
+}
+
+func (_c *UserService_GetUser_Call) Run(run func(ctx context.Context, id uuid.UUID)) *UserService_GetUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *UserService_GetUser_Call) Return(user1 user.User, err error) *UserService_GetUser_Call {
+	_c.Call.Return(user1, err)
+	return _c
+}
+
+func (_c *UserService_GetUser_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (user.User, error)) *UserService_GetUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserByUsername provides a mock function for the type UserService
+func (_mock *UserService) GetUserByUsername(ctx context.Context, username string) (user.User, error) {
+	ret := _mock.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByUsername")
+	}
+
+	var r0 user.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (user.User, error)); ok {
+		return returnFunc(ctx, username)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) user.User); ok {
+		r0 = returnFunc(ctx, username)
+	} else {
+		r0 = ret.Get(0).(user.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// UserService_GetUserByUsername_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByUsername'
+type UserService_GetUserByUsername_Call struct {
+	*mock.Call
+}
+
+// GetUserByUsername is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+func (_e *UserService_Expecter) GetUserByUsername(ctx interface{}, username interface{}) *UserService_GetUserByUsername_Call {
+	return &UserService_GetUserByUsername_Call{Call: _e.mock.On("GetUserByUsername", ctx, username)}
+}
+
+func (_c *UserService_GetUserByUsername_Call) Run(run func(ctx context.Context, username string)) *UserService_GetUserByUsername_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *UserService_GetUserByUsername_Call) Return(user1 user.User, err error) *UserService_GetUserByUsername_Call {
+	_c.Call.Return(user1, err)
+	return _c
+}
+
+func (_c *UserService_GetUserByUsername_Call) RunAndReturn(run func(ctx context.Context, username string) (user.User, error)) *UserService_GetUserByUsername_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HasKeys provides a mock function for the type UserService
+func (_mock *UserService) HasKeys(ctx context.Context, userID uuid.UUID) (bool, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasKeys")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (bool, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) bool); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// UserService_HasKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasKeys'
+type UserService_HasKeys_Call struct {
+	*mock.Call
+}
+
+// HasKeys is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *UserService_Expecter) HasKeys(ctx interface{}, userID interface{}) *UserService_HasKeys_Call {
+	return &UserService_HasKeys_Call{Call: _e.mock.On("HasKeys", ctx, userID)}
+}
+
+func (_c *UserService_HasKeys_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *UserService_HasKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *UserService_HasKeys_Call) Return(b bool, err error) *UserService_HasKeys_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *UserService_HasKeys_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) (bool, error)) *UserService_HasKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsContact provides a mock function for the type UserService
+func (_mock *UserService) IsContact(ctx context.Context, userID uuid.UUID, contactID uuid.UUID) (bool, error) {
+	ret := _mock.Called(ctx, userID, contactID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsContact")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (bool, error)); ok {
+		return returnFunc(ctx, userID, contactID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) bool); ok {
+		r0 = returnFunc(ctx, userID, contactID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID, contactID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// UserService_IsContact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsContact'
+type UserService_IsContact_Call struct {
+	*mock.Call
+}
+
+// IsContact is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - contactID uuid.UUID
+func (_e *UserService_Expecter) IsContact(ctx interface{}, userID interface{}, contactID interface{}) *UserService_IsContact_Call {
+	return &UserService_IsContact_Call{Call: _e.mock.On("IsContact", ctx, userID, contactID)}
+}
+
+func (_c *UserService_IsContact_Call) Run(run func(ctx context.Context, userID uuid.UUID, contactID uuid.UUID)) *UserService_IsContact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *UserService_IsContact_Call) Return(b bool, err error) *UserService_IsContact_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *UserService_IsContact_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, contactID uuid.UUID) (bool, error)) *UserService_IsContact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PublishKeys provides a mock function for the type UserService
+func (_mock *UserService) PublishKeys(ctx context.Context, userID uuid.UUID, keys user.IdentityKeys) error {
+	ret := _mock.Called(ctx, userID, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishKeys")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, user.IdentityKeys) error); ok {
+		r0 = returnFunc(ctx, userID, keys)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// UserService_PublishKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublishKeys'
+type UserService_PublishKeys_Call struct {
+	*mock.Call
+}
+
+// PublishKeys is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - keys user.IdentityKeys
+func (_e *UserService_Expecter) PublishKeys(ctx interface{}, userID interface{}, keys interface{}) *UserService_PublishKeys_Call {
+	return &UserService_PublishKeys_Call{Call: _e.mock.On("PublishKeys", ctx, userID, keys)}
+}
+
+func (_c *UserService_PublishKeys_Call) Run(run func(ctx context.Context, userID uuid.UUID, keys user.IdentityKeys)) *UserService_PublishKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 user.IdentityKeys
+		if args[2] != nil {
+			arg2 = args[2].(user.IdentityKeys)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *UserService_PublishKeys_Call) Return(err error) *UserService_PublishKeys_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *UserService_PublishKeys_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, keys user.IdentityKeys) error) *UserService_PublishKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReactivateUser provides a mock function for the type UserService
+func (_mock *UserService) ReactivateUser(ctx context.Context, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReactivateUser")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// UserService_ReactivateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReactivateUser'
+type UserService_ReactivateUser_Call struct {
+	*mock.Call
+}
+
+// ReactivateUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *UserService_Expecter) ReactivateUser(ctx interface{}, userID interface{}) *UserService_ReactivateUser_Call {
+	return &UserService_ReactivateUser_Call{Call: _e.mock.On("ReactivateUser", ctx, userID)}
+}
+
+func (_c *UserService_ReactivateUser_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *UserService_ReactivateUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *UserService_ReactivateUser_Call) Return(err error) *UserService_ReactivateUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *UserService_ReactivateUser_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) error) *UserService_ReactivateUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveContact provides a mock function for the type UserService
+func (_mock *UserService) RemoveContact(ctx context.Context, userID uuid.UUID, contactID uuid.UUID) error {
+	ret := _mock.Called(ctx, userID, contactID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveContact")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, userID, contactID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// UserService_RemoveContact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveContact'
+type UserService_RemoveContact_Call struct {
+	*mock.Call
+}
+
+// RemoveContact is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - contactID uuid.UUID
+func (_e *UserService_Expecter) RemoveContact(ctx interface{}, userID interface{}, contactID interface{}) *UserService_RemoveContact_Call {
+	return &UserService_RemoveContact_Call{Call: _e.mock.On("RemoveContact", ctx, userID, contactID)}
+}
+
+func (_c *UserService_RemoveContact_Call) Run(run func(ctx context.Context, userID uuid.UUID, contactID uuid.UUID)) *UserService_RemoveContact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *UserService_RemoveContact_Call) Return(err error) *UserService_RemoveContact_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *UserService_RemoveContact_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, contactID uuid.UUID) error) *UserService_RemoveContact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RotateBotToken provides a mock function for the type UserService
+func (_mock *UserService) RotateBotToken(ctx context.Context, ownerID uuid.UUID, botID uuid.UUID) (string, error) {
+	ret := _mock.Called(ctx, ownerID, botID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RotateBotToken")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (string, error)); ok {
+		return returnFunc(ctx, ownerID, botID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) string); ok {
+		r0 = returnFunc(ctx, ownerID, botID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, ownerID, botID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// UserService_RotateBotToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RotateBotToken'
+type UserService_RotateBotToken_Call struct {
+	*mock.Call
+}
+
+// RotateBotToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ownerID uuid.UUID
+//   - botID uuid.UUID
+func (_e *UserService_Expecter) RotateBotToken(ctx interface{}, ownerID interface{}, botID interface{}) *UserService_RotateBotToken_Call {
+	return &UserService_RotateBotToken_Call{Call: _e.mock.On("RotateBotToken", ctx, ownerID, botID)}
+}
+
+func (_c *UserService_RotateBotToken_Call) Run(run func(ctx context.Context, ownerID uuid.UUID, botID uuid.UUID)) *UserService_RotateBotToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *UserService_RotateBotToken_Call) Return(s string, err error) *UserService_RotateBotToken_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *UserService_RotateBotToken_Call) RunAndReturn(run func(ctx context.Context, ownerID uuid.UUID, botID uuid.UUID) (string, error)) *UserService_RotateBotToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SuspendUser provides a mock function for the type UserService
+func (_mock *UserService) SuspendUser(ctx context.Context, userID uuid.UUID, reason string, until time.Time) error {
+	ret := _mock.Called(ctx, userID, reason, until)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SuspendUser")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, time.Time) error); ok {
+		r0 = returnFunc(ctx, userID, reason, until)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// UserService_SuspendUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SuspendUser'
+type UserService_SuspendUser_Call struct {
+	*mock.Call
+}
+
+// SuspendUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - reason string
+//   - until time.Time
+func (_e *UserService_Expecter) SuspendUser(ctx interface{}, userID interface{}, reason interface{}, until interface{}) *UserService_SuspendUser_Call {
+	return &UserService_SuspendUser_Call{Call: _e.mock.On("SuspendUser", ctx, userID, reason, until)}
+}
+
+func (_c *UserService_SuspendUser_Call) Run(run func(ctx context.Context, userID uuid.UUID, reason string, until time.Time)) *UserService_SuspendUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *UserService_SuspendUser_Call) Return(err error) *UserService_SuspendUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *UserService_SuspendUser_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, reason string, until time.Time) error) *UserService_SuspendUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnblockUser provides a mock function for the type UserService
+func (_mock *UserService) UnblockUser(ctx context.Context, userID uuid.UUID, blockedID uuid.UUID) error {
+	ret := _mock.Called(ctx, userID, blockedID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnblockUser")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, userID, blockedID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// UserService_UnblockUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnblockUser'
+type UserService_UnblockUser_Call struct {
+	*mock.Call
+}
+
+// UnblockUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - blockedID uuid.UUID
+func (_e *UserService_Expecter) UnblockUser(ctx interface{}, userID interface{}, blockedID interface{}) *UserService_UnblockUser_Call {
+	return &UserService_UnblockUser_Call{Call: _e.mock.On("UnblockUser", ctx, userID, blockedID)}
+}
+
+func (_c *UserService_UnblockUser_Call) Run(run func(ctx context.Context, userID uuid.UUID, blockedID uuid.UUID)) *UserService_UnblockUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 uuid.UUID
+		if args[2] != nil {
+			arg2 = args[2].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *UserService_UnblockUser_Call) Return(err error) *UserService_UnblockUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *UserService_UnblockUser_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, blockedID uuid.UUID) error) *UserService_UnblockUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnsuspendUser provides a mock function for the type UserService
+func (_mock *UserService) UnsuspendUser(ctx context.Context, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnsuspendUser")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// UserService_UnsuspendUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnsuspendUser'
+type UserService_UnsuspendUser_Call struct {
+	*mock.Call
+}
+
+// UnsuspendUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *UserService_Expecter) UnsuspendUser(ctx interface{}, userID interface{}) *UserService_UnsuspendUser_Call {
+	return &UserService_UnsuspendUser_Call{Call: _e.mock.On("UnsuspendUser", ctx, userID)}
+}
+
+func (_c *UserService_UnsuspendUser_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *UserService_UnsuspendUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *UserService_UnsuspendUser_Call) Return(err error) *UserService_UnsuspendUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *UserService_UnsuspendUser_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) error) *UserService_UnsuspendUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePrivacySettings provides a mock function for the type UserService
+func (_mock *UserService) UpdatePrivacySettings(ctx context.Context, userID uuid.UUID, settings user.PrivacySettings) error {
+	ret := _mock.Called(ctx, userID, settings)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePrivacySettings")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, user.PrivacySettings) error); ok {
+		r0 = returnFunc(ctx, userID, settings)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// UserService_UpdatePrivacySettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePrivacySettings'
+type UserService_UpdatePrivacySettings_Call struct {
+	*mock.Call
+}
+
+// UpdatePrivacySettings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+//   - settings user.PrivacySettings
+func (_e *UserService_Expecter) UpdatePrivacySettings(ctx interface{}, userID interface{}, settings interface{}) *UserService_UpdatePrivacySettings_Call {
+	return &UserService_UpdatePrivacySettings_Call{Call: _e.mock.On("UpdatePrivacySettings", ctx, userID, settings)}
+}
+
+func (_c *UserService_UpdatePrivacySettings_Call) Run(run func(ctx context.Context, userID uuid.UUID, settings user.PrivacySettings)) *UserService_UpdatePrivacySettings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 user.PrivacySettings
+		if args[2] != nil {
+			arg2 = args[2].(user.PrivacySettings)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *UserService_UpdatePrivacySettings_Call) Return(err error) *UserService_UpdatePrivacySettings_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *UserService_UpdatePrivacySettings_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, settings user.PrivacySettings) error) *UserService_UpdatePrivacySettings_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,202 @@
+package usersvc
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"github.com/AliUnipal/chat/internal/models/user"
+	"github.com/AliUnipal/chat/internal/service/usersvc/repo"
+	"github.com/google/uuid"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrBlocked        = errors.New("user is blocked")
+	ErrChatNotAllowed = errors.New("user does not accept chats from this user")
+)
+
+// BlockUser stops blockedID from starting chats with or messaging userID.
+func (s *service) BlockUser(ctx context.Context, userID, blockedID uuid.UUID) error {
+	if userID == blockedID {
+		return errors.New("users cannot block themselves")
+	}
+
+	return s.repo.BlockUser(ctx, userID, blockedID)
+}
+
+func (s *service) UnblockUser(ctx context.Context, userID, blockedID uuid.UUID) error {
+	return s.repo.UnblockUser(ctx, userID, blockedID)
+}
+
+func (s *service) GetBlockedUsers(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	return s.repo.GetBlockedUsers(ctx, userID)
+}
+
+func (s *service) AddContact(ctx context.Context, userID, contactID uuid.UUID) error {
+	if userID == contactID {
+		return errors.New("users cannot add themselves as a contact")
+	}
+
+	return s.repo.AddContact(ctx, userID, contactID)
+}
+
+func (s *service) RemoveContact(ctx context.Context, userID, contactID uuid.UUID) error {
+	return s.repo.RemoveContact(ctx, userID, contactID)
+}
+
+// GetContacts returns the users in userID's contact list, sorted by name.
+// A contact's profile image is left out unless their privacy settings let
+// userID see it.
+func (s *service) GetContacts(ctx context.Context, userID uuid.UUID) ([]user.User, error) {
+	ids, err := s.repo.GetContacts(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	contacts := make([]user.User, 0, len(ids))
+	for _, id := range ids {
+		u, err := s.GetUser(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		visible, err := s.CanSeeProfileImage(ctx, userID, id)
+		if err != nil {
+			return nil, err
+		}
+		if !visible {
+			u.ImageURL = ""
+		}
+		contacts = append(contacts, u)
+	}
+	slices.SortFunc(contacts, func(a, b user.User) int {
+		return cmp.Or(
+			strings.Compare(a.FirstName, b.FirstName),
+			strings.Compare(a.LastName, b.LastName),
+			strings.Compare(a.Username, b.Username),
+		)
+	})
+
+	return contacts, nil
+}
+
+// IsContact reports whether contactID is in userID's contact list.
+func (s *service) IsContact(ctx context.Context, userID, contactID uuid.UUID) (bool, error) {
+	return s.repo.IsContact(ctx, userID, contactID)
+}
+
+func (s *service) GetPrivacySettings(ctx context.Context, userID uuid.UUID) (user.PrivacySettings, error) {
+	p, err := s.repo.GetPrivacySettings(ctx, userID)
+	if err != nil {
+		return user.PrivacySettings{}, err
+	}
+
+	return user.PrivacySettings{
+		StartChat:    p.StartChat,
+		ProfileImage: p.ProfileImage,
+		LastSeen:     p.LastSeen,
+	}, nil
+}
+
+func (s *service) UpdatePrivacySettings(ctx context.Context, userID uuid.UUID, settings user.PrivacySettings) error {
+	if !validAudience(settings.StartChat) || !validAudience(settings.ProfileImage) || !validAudience(settings.LastSeen) {
+		return errors.New("privacy audience is invalid")
+	}
+
+	return s.repo.UpdatePrivacySettings(ctx, userID, repo.PrivacySettings{
+		StartChat:    settings.StartChat,
+		ProfileImage: settings.ProfileImage,
+		LastSeen:     settings.LastSeen,
+	})
+}
+
+// CanStartChat reports whether senderID may start a direct chat with
+// recipientID, returning ErrBlocked, ErrChatNotAllowed or ErrBotNotInvited
+// when they may not.
+func (s *service) CanStartChat(ctx context.Context, senderID, recipientID uuid.UUID) error {
+	if err := s.CanMessage(ctx, senderID, recipientID); err != nil {
+		return err
+	}
+	if err := s.checkBotInvited(ctx, senderID, recipientID); err != nil {
+		return err
+	}
+	p, err := s.repo.GetPrivacySettings(ctx, recipientID)
+	if err != nil {
+		return err
+	}
+	ok, err := s.inAudience(ctx, p.StartChat, recipientID, senderID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrChatNotAllowed
+	}
+
+	return nil
+}
+
+// CanMessage returns ErrSuspended when the sender is suspended,
+// ErrAccountClosed when either account is deactivated or deleted, and
+// ErrBlocked when either user has blocked the other.
+func (s *service) CanMessage(ctx context.Context, senderID, recipientID uuid.UUID) error {
+	if err := s.checkCanSend(ctx, senderID, recipientID); err != nil {
+		return err
+	}
+	for _, pair := range [][2]uuid.UUID{{recipientID, senderID}, {senderID, recipientID}} {
+		blocked, err := s.repo.IsBlocked(ctx, pair[0], pair[1])
+		if err != nil {
+			return err
+		}
+		if blocked {
+			return ErrBlocked
+		}
+	}
+
+	return nil
+}
+
+func (s *service) CanSeeProfileImage(ctx context.Context, viewerID, ownerID uuid.UUID) (bool, error) {
+	return s.canSee(ctx, viewerID, ownerID, func(p repo.PrivacySettings) user.Audience {
+		return p.ProfileImage
+	})
+}
+
+func (s *service) CanSeeLastSeen(ctx context.Context, viewerID, ownerID uuid.UUID) (bool, error) {
+	return s.canSee(ctx, viewerID, ownerID, func(p repo.PrivacySettings) user.Audience {
+		return p.LastSeen
+	})
+}
+
+// canSee reports whether viewerID may see the part of ownerID's profile whose
+// audience setting is returned by audience. Blocked viewers never can.
+func (s *service) canSee(ctx context.Context, viewerID, ownerID uuid.UUID, audience func(repo.PrivacySettings) user.Audience) (bool, error) {
+	if viewerID == ownerID {
+		return true, nil
+	}
+	blocked, err := s.repo.IsBlocked(ctx, ownerID, viewerID)
+	if err != nil || blocked {
+		return false, err
+	}
+	p, err := s.repo.GetPrivacySettings(ctx, ownerID)
+	if err != nil {
+		return false, err
+	}
+
+	return s.inAudience(ctx, audience(p), ownerID, viewerID)
+}
+
+// inAudience reports whether otherID falls within the audience ownerID chose.
+func (s *service) inAudience(ctx context.Context, audience user.Audience, ownerID, otherID uuid.UUID) (bool, error) {
+	switch audience {
+	case user.Everyone:
+		return true, nil
+	case user.ContactsOnly:
+		return s.repo.IsContact(ctx, ownerID, otherID)
+	default:
+		return false, nil
+	}
+}
+
+func validAudience(a user.Audience) bool {
+	return a >= user.Everyone && a <= user.Nobody
+}
@@ -0,0 +1,251 @@
+package usersvc_test
+
+import (
+	"context"
+	"errors"
+	"github.com/AliUnipal/chat/internal/models/user"
+	"github.com/AliUnipal/chat/internal/service/usersvc"
+	"github.com/AliUnipal/chat/internal/service/usersvc/mocks"
+	"github.com/AliUnipal/chat/internal/service/usersvc/repo"
+	"github.com/AliUnipal/chat/internal/service/usersvc/repo/inmemuserrepo"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestBlockUser_ReturnErrorOnSelf(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockRepo := mocks.NewUserRepository(t)
+	service := usersvc.NewService(mockRepo)
+
+	if err := service.BlockUser(ctx, userID, userID); err == nil {
+		t.Fatalf("Expected error got %v", err)
+	}
+}
+
+func TestBlockUser_BlockInRepo(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	blockedID := uuid.New()
+
+	mockRepo := mocks.NewUserRepository(t)
+	mockRepo.EXPECT().BlockUser(ctx, userID, blockedID).Return(nil)
+	service := usersvc.NewService(mockRepo)
+
+	if err := service.BlockUser(ctx, userID, blockedID); err != nil {
+		t.Fatalf("Expected no error got %v", err)
+	}
+}
+
+func TestGetContacts_SortByName(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	zed := repo.CreateUserInput{ID: uuid.New(), FirstName: "Zed", Username: "zed"}
+	amy := repo.CreateUserInput{ID: uuid.New(), FirstName: "Amy", Username: "amy"}
+
+	mockRepo := mocks.NewUserRepository(t)
+	mockRepo.EXPECT().GetContacts(ctx, userID).Return([]uuid.UUID{zed.ID, amy.ID}, nil)
+	mockRepo.EXPECT().GetUser(ctx, zed.ID).Return(zed, nil)
+	mockRepo.EXPECT().GetUser(ctx, amy.ID).Return(amy, nil)
+	mockRepo.EXPECT().IsBlocked(ctx, mock.Anything, userID).Return(false, nil)
+	mockRepo.EXPECT().GetPrivacySettings(ctx, mock.Anything).Return(repo.PrivacySettings{ProfileImage: user.Everyone}, nil)
+	service := usersvc.NewService(mockRepo)
+
+	contacts, err := service.GetContacts(ctx, userID)
+	if err != nil {
+		t.Fatalf("Expected no error got %v", err)
+	}
+	if len(contacts) != 2 || contacts[0].ID != amy.ID || contacts[1].ID != zed.ID {
+		t.Fatalf("Expected contacts sorted by name got %v", contacts)
+	}
+}
+
+func TestGetContacts_HideProfileImage(t *testing.T) {
+	ctx := context.Background()
+	service := usersvc.NewService(inmemuserrepo.New())
+	create := func(name string) uuid.UUID {
+		t.Helper()
+		id, err := service.CreateUser(ctx, usersvc.CreateUserInput{ImageURL: "https://" + name + ".png", FirstName: name, Username: name})
+		if err != nil {
+			t.Fatalf("Expected no error got %v", err)
+		}
+		return id
+	}
+	aliceID, bobID, carolID := create("alice"), create("bob"), create("carol")
+	for _, tt := range []struct {
+		id       uuid.UUID
+		audience user.Audience
+	}{{bobID, user.Nobody}, {carolID, user.Everyone}} {
+		if err := service.AddContact(ctx, aliceID, tt.id); err != nil {
+			t.Fatalf("Expected no error got %v", err)
+		}
+		if err := service.UpdatePrivacySettings(ctx, tt.id, user.PrivacySettings{ProfileImage: tt.audience}); err != nil {
+			t.Fatalf("Expected no error got %v", err)
+		}
+	}
+
+	contacts, err := service.GetContacts(ctx, aliceID)
+	if err != nil {
+		t.Fatalf("Expected no error got %v", err)
+	}
+	if len(contacts) != 2 || contacts[0].ImageURL != "" || contacts[1].ImageURL != "https://carol.png" {
+		t.Fatalf("Expected only carol's image got %+v", contacts)
+	}
+}
+
+func TestUpdatePrivacySettings_ReturnErrorOnInvalidAudience(t *testing.T) {
+	ctx := context.Background()
+
+	mockRepo := mocks.NewUserRepository(t)
+	service := usersvc.NewService(mockRepo)
+
+	if err := service.UpdatePrivacySettings(ctx, uuid.New(), user.PrivacySettings{StartChat: user.Audience(7)}); err == nil {
+		t.Fatalf("Expected error got %v", err)
+	}
+}
+
+func TestUpdatePrivacySettings_UpdateRepo(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockRepo := mocks.NewUserRepository(t)
+	mockRepo.EXPECT().UpdatePrivacySettings(ctx, userID, repo.PrivacySettings{
+		StartChat:    user.ContactsOnly,
+		ProfileImage: user.Nobody,
+	}).Return(nil)
+	service := usersvc.NewService(mockRepo)
+
+	if err := service.UpdatePrivacySettings(ctx, userID, user.PrivacySettings{
+		StartChat:    user.ContactsOnly,
+		ProfileImage: user.Nobody,
+	}); err != nil {
+		t.Fatalf("Expected no error got %v", err)
+	}
+}
+
+func TestCanStartChat(t *testing.T) {
+	ctx := context.Background()
+	senderID := uuid.New()
+	recipientID := uuid.New()
+
+	tests := []struct {
+		name            string
+		suspended       bool
+		recipientBlocks bool
+		senderBlocks    bool
+		audience        user.Audience
+		contact         bool
+		expected        error
+	}{
+		{name: "everyone", audience: user.Everyone},
+		{name: "suspended sender", suspended: true, expected: usersvc.ErrSuspended},
+		{name: "blocked by recipient", recipientBlocks: true, expected: usersvc.ErrBlocked},
+		{name: "recipient blocked by sender", senderBlocks: true, expected: usersvc.ErrBlocked},
+		{name: "contacts only as contact", audience: user.ContactsOnly, contact: true},
+		{name: "contacts only as stranger", audience: user.ContactsOnly, expected: usersvc.ErrChatNotAllowed},
+		{name: "nobody", audience: user.Nobody, expected: usersvc.ErrChatNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewUserRepository(t)
+			sender := repo.CreateUserInput{ID: senderID}
+			if tt.suspended {
+				sender.Status, sender.StatusReason = user.Suspended, "spam"
+			}
+			mockRepo.EXPECT().GetUser(ctx, senderID).Return(sender, nil)
+			if !tt.suspended {
+				mockRepo.EXPECT().GetUser(ctx, recipientID).Return(repo.CreateUserInput{ID: recipientID}, nil)
+				mockRepo.EXPECT().IsBlocked(ctx, recipientID, senderID).Return(tt.recipientBlocks, nil)
+			}
+			if !tt.suspended && !tt.recipientBlocks {
+				mockRepo.EXPECT().IsBlocked(ctx, senderID, recipientID).Return(tt.senderBlocks, nil)
+			}
+			if !tt.suspended && !tt.recipientBlocks && !tt.senderBlocks {
+				mockRepo.EXPECT().GetPrivacySettings(ctx, recipientID).Return(repo.PrivacySettings{StartChat: tt.audience}, nil)
+			}
+			if tt.audience == user.ContactsOnly {
+				mockRepo.EXPECT().IsContact(ctx, recipientID, senderID).Return(tt.contact, nil)
+			}
+			service := usersvc.NewService(mockRepo)
+
+			if err := service.CanStartChat(ctx, senderID, recipientID); !errors.Is(err, tt.expected) {
+				t.Fatalf("Expected %v got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestCanSeeProfileImage(t *testing.T) {
+	ctx := context.Background()
+	viewerID := uuid.New()
+	ownerID := uuid.New()
+
+	tests := []struct {
+		name     string
+		blocked  bool
+		audience user.Audience
+		contact  bool
+		expected bool
+	}{
+		{name: "everyone", audience: user.Everyone, expected: true},
+		{name: "blocked", blocked: true},
+		{name: "contacts only as contact", audience: user.ContactsOnly, contact: true, expected: true},
+		{name: "contacts only as stranger", audience: user.ContactsOnly},
+		{name: "nobody", audience: user.Nobody},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewUserRepository(t)
+			mockRepo.EXPECT().IsBlocked(ctx, ownerID, viewerID).Return(tt.blocked, nil)
+			if !tt.blocked {
+				mockRepo.EXPECT().GetPrivacySettings(ctx, ownerID).Return(repo.PrivacySettings{ProfileImage: tt.audience}, nil)
+			}
+			if tt.audience == user.ContactsOnly {
+				mockRepo.EXPECT().IsContact(ctx, ownerID, viewerID).Return(tt.contact, nil)
+			}
+			service := usersvc.NewService(mockRepo)
+
+			visible, err := service.CanSeeProfileImage(ctx, viewerID, ownerID)
+			if err != nil {
+				t.Fatalf("Expected no error got %v", err)
+			}
+			if visible != tt.expected {
+				t.Fatalf("Expected %v got %v", tt.expected, visible)
+			}
+		})
+	}
+}
+
+func TestCanSeeProfileImage_OwnImage(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockRepo := mocks.NewUserRepository(t)
+	service := usersvc.NewService(mockRepo)
+
+	visible, err := service.CanSeeProfileImage(ctx, userID, userID)
+	if err != nil || !visible {
+		t.Fatalf("Expected own image to be visible got %v, %v", visible, err)
+	}
+}
+
+func TestCanSeeLastSeen_UseLastSeenAudience(t *testing.T) {
+	ctx := context.Background()
+	viewerID := uuid.New()
+	ownerID := uuid.New()
+
+	mockRepo := mocks.NewUserRepository(t)
+	mockRepo.EXPECT().IsBlocked(ctx, ownerID, viewerID).Return(false, nil)
+	mockRepo.EXPECT().GetPrivacySettings(ctx, ownerID).Return(repo.PrivacySettings{LastSeen: user.Nobody}, nil)
+	service := usersvc.NewService(mockRepo)
+
+	visible, err := service.CanSeeLastSeen(ctx, viewerID, ownerID)
+	if err != nil {
+		t.Fatalf("Expected no error got %v", err)
+	}
+	if visible {
+		t.Fatal("Expected last seen to be hidden")
+	}
+}
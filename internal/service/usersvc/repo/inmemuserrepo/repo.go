@@ -1,10 +1,15 @@
 package inmemuserrepo
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"github.com/AliUnipal/chat/internal/service/usersvc/repo"
 	"github.com/google/uuid"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
 )
 
 func New(users ...repo.CreateUserInput) *repository {
@@ -13,14 +18,32 @@ func New(users ...repo.CreateUserInput) *repository {
 		v[user.ID] = user
 	}
 
-	return &repository{v}
+	return &repository{
+		users:    v,
+		blocked:  make(map[uuid.UUID]map[uuid.UUID]struct{}),
+		contacts: make(map[uuid.UUID]map[uuid.UUID]struct{}),
+		privacy:  make(map[uuid.UUID]repo.PrivacySettings),
+		keys:     make(map[uuid.UUID]repo.IdentityKeys),
+		preKeys:  make(map[uuid.UUID][]repo.OneTimePreKey),
+	}
 }
 
+// repository is safe for concurrent use, as background work such as sending
+// scheduled messages checks users while requests change them.
 type repository struct {
-	users map[uuid.UUID]repo.CreateUserInput
+	mu       sync.RWMutex
+	users    map[uuid.UUID]repo.CreateUserInput
+	blocked  map[uuid.UUID]map[uuid.UUID]struct{}
+	contacts map[uuid.UUID]map[uuid.UUID]struct{}
+	privacy  map[uuid.UUID]repo.PrivacySettings
+	keys     map[uuid.UUID]repo.IdentityKeys
+	preKeys  map[uuid.UUID][]repo.OneTimePreKey
 }
 
 func (r *repository) CreateUser(_ context.Context, in repo.CreateUserInput) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	if _, ok := r.users[in.ID]; ok {
 		return errors.New("user already exists")
 	}
@@ -39,10 +62,255 @@ func (r *repository) CreateUser(_ context.Context, in repo.CreateUserInput) erro
 }
 
 func (r *repository) GetUser(_ context.Context, id uuid.UUID) (repo.CreateUserInput, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	user, ok := r.users[id]
 	if !ok {
-		return repo.CreateUserInput{}, errors.New("user does not exist")
+		return repo.CreateUserInput{}, repo.ErrUserNotFound
 	}
 
 	return user, nil
 }
+
+// GetUserByUsername finds a user by username, ignoring case. Usernames are
+// not unique, so when several users share one the earliest created wins.
+func (r *repository) GetUserByUsername(_ context.Context, username string) (repo.CreateUserInput, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var found *repo.CreateUserInput
+	for _, u := range r.users {
+		if u.Username == "" || !strings.EqualFold(u.Username, username) {
+			continue
+		}
+		if found == nil || u.CreatedAt.Before(found.CreatedAt) ||
+			u.CreatedAt.Equal(found.CreatedAt) && u.ID.String() < found.ID.String() {
+			found = &u
+		}
+	}
+	if found == nil {
+		return repo.CreateUserInput{}, repo.ErrUserNotFound
+	}
+
+	return *found, nil
+}
+
+// GetUsersByOwner returns the bots and imported users ownerID created, oldest
+// first.
+func (r *repository) GetUsersByOwner(_ context.Context, ownerID uuid.UUID) ([]repo.CreateUserInput, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var users []repo.CreateUserInput
+	for _, u := range r.users {
+		if u.OwnerID == ownerID {
+			users = append(users, u)
+		}
+	}
+	slices.SortFunc(users, func(a, b repo.CreateUserInput) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	return users, nil
+}
+
+func (r *repository) BlockUser(_ context.Context, userID, blockedID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.add(r.blocked, userID, blockedID)
+}
+
+func (r *repository) UnblockUser(_ context.Context, userID, blockedID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	remove(r.blocked, userID, blockedID)
+	return nil
+}
+
+func (r *repository) IsBlocked(_ context.Context, userID, otherID uuid.UUID) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.blocked[userID][otherID]
+	return ok, nil
+}
+
+func (r *repository) GetBlockedUsers(_ context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Collect(maps.Keys(r.blocked[userID])), nil
+}
+
+func (r *repository) AddContact(_ context.Context, userID, contactID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.add(r.contacts, userID, contactID)
+}
+
+func (r *repository) RemoveContact(_ context.Context, userID, contactID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	remove(r.contacts, userID, contactID)
+	return nil
+}
+
+func (r *repository) GetContacts(_ context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Collect(maps.Keys(r.contacts[userID])), nil
+}
+
+func (r *repository) IsContact(_ context.Context, userID, contactID uuid.UUID) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.contacts[userID][contactID]
+	return ok, nil
+}
+
+func (r *repository) GetPrivacySettings(_ context.Context, userID uuid.UUID) (repo.PrivacySettings, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if _, ok := r.users[userID]; !ok {
+		return repo.PrivacySettings{}, errors.New("user does not exist")
+	}
+
+	return r.privacy[userID], nil
+}
+
+func (r *repository) UpdatePrivacySettings(_ context.Context, userID uuid.UUID, settings repo.PrivacySettings) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.users[userID]; !ok {
+		return errors.New("user does not exist")
+	}
+
+	r.privacy[userID] = settings
+	return nil
+}
+
+// SaveIdentityKeys replaces the user's identity keys. One-time prekeys
+// published under a different identity key are dropped, as they can no longer
+// be used.
+func (r *repository) SaveIdentityKeys(_ context.Context, userID uuid.UUID, keys repo.IdentityKeys) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.users[userID]; !ok {
+		return errors.New("user does not exist")
+	}
+
+	if old, ok := r.keys[userID]; ok && !bytes.Equal(old.IdentityKey, keys.IdentityKey) {
+		delete(r.preKeys, userID)
+	}
+	r.keys[userID] = keys
+	return nil
+}
+
+func (r *repository) GetIdentityKeys(_ context.Context, userID uuid.UUID) (repo.IdentityKeys, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	keys, ok := r.keys[userID]
+	if !ok {
+		return repo.IdentityKeys{}, repo.ErrKeysNotFound
+	}
+
+	return keys, nil
+}
+
+func (r *repository) AddOneTimePreKeys(_ context.Context, userID uuid.UUID, keys []repo.OneTimePreKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.keys[userID]; !ok {
+		return repo.ErrKeysNotFound
+	}
+
+	r.preKeys[userID] = append(r.preKeys[userID], keys...)
+	return nil
+}
+
+// TakeOneTimePreKey removes and returns the user's oldest one-time prekey,
+// reporting false when none are left.
+func (r *repository) TakeOneTimePreKey(_ context.Context, userID uuid.UUID) (repo.OneTimePreKey, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	keys := r.preKeys[userID]
+	if len(keys) == 0 {
+		return repo.OneTimePreKey{}, false, nil
+	}
+
+	r.preKeys[userID] = keys[1:]
+	return keys[0], true, nil
+}
+
+func (r *repository) CountOneTimePreKeys(_ context.Context, userID uuid.UUID) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.preKeys[userID]), nil
+}
+
+func (r *repository) UpdateUser(_ context.Context, in repo.CreateUserInput) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.users[in.ID]; !ok {
+		return errors.New("user does not exist")
+	}
+
+	r.users[in.ID] = in
+	return nil
+}
+
+// DeleteUserData forgets the user's contacts, blocks, privacy settings and
+// keys, including their entries in other users' contact and block lists.
+func (r *repository) DeleteUserData(_ context.Context, userID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, relations := range []map[uuid.UUID]map[uuid.UUID]struct{}{r.contacts, r.blocked} {
+		delete(relations, userID)
+		for _, others := range relations {
+			delete(others, userID)
+		}
+	}
+	delete(r.privacy, userID)
+	delete(r.keys, userID)
+	delete(r.preKeys, userID)
+
+	return nil
+}
+
+// add records the userID -> otherID relation, after checking both users exist.
+// The caller holds the write lock.
+func (r *repository) add(relations map[uuid.UUID]map[uuid.UUID]struct{}, userID, otherID uuid.UUID) error {
+	if _, ok := r.users[userID]; !ok {
+		return errors.New("user does not exist")
+	}
+	if _, ok := r.users[otherID]; !ok {
+		return errors.New("user does not exist")
+	}
+
+	if relations[userID] == nil {
+		relations[userID] = make(map[uuid.UUID]struct{})
+	}
+	relations[userID][otherID] = struct{}{}
+
+	return nil
+}
+
+func remove(relations map[uuid.UUID]map[uuid.UUID]struct{}, userID, otherID uuid.UUID) {
+	delete(relations[userID], otherID)
+	if len(relations[userID]) == 0 {
+		delete(relations, userID)
+	}
+}
@@ -1,6 +1,16 @@
 package repo
 
-import "github.com/google/uuid"
+import (
+	"errors"
+	"github.com/AliUnipal/chat/internal/models/user"
+	"github.com/google/uuid"
+	"time"
+)
+
+var (
+	ErrUserNotFound = errors.New("user does not exist")
+	ErrKeysNotFound = errors.New("user has not published encryption keys")
+)
 
 type CreateUserInput struct {
 	ID        uuid.UUID
@@ -8,4 +18,32 @@ type CreateUserInput struct {
 	FirstName string
 	LastName  string
 	Username  string
+	CreatedAt time.Time
+	Status    user.AccountStatus
+	// StatusReason and StatusUntil describe a suspension.
+	StatusReason string
+	StatusUntil  time.Time
+	Type         user.Type
+	// OwnerID is the user who created a bot or imported a user.
+	OwnerID uuid.UUID
+	// TokenHash is the SHA-256 hash of a bot's API token secret.
+	TokenHash []byte
+}
+
+type PrivacySettings struct {
+	StartChat    user.Audience
+	ProfileImage user.Audience
+	LastSeen     user.Audience
+}
+
+type IdentityKeys struct {
+	SigningKey   []byte
+	IdentityKey  []byte
+	SignedPreKey []byte
+	Signature    []byte
+}
+
+type OneTimePreKey struct {
+	ID  uuid.UUID
+	Key []byte
 }
@@ -7,8 +7,11 @@ import (
 	"github.com/AliUnipal/chat/internal/service/usersvc/repo"
 	"github.com/google/uuid"
 	"net/url"
+	"time"
 )
 
+var ErrUserNotFound = repo.ErrUserNotFound
+
 type CreateUserInput struct {
 	ImageURL  string
 	FirstName string
@@ -19,11 +22,60 @@ type CreateUserInput struct {
 type userService interface {
 	CreateUser(ctx context.Context, in CreateUserInput) (uuid.UUID, error)
 	GetUser(ctx context.Context, id uuid.UUID) (user.User, error)
+	GetUserByUsername(ctx context.Context, username string) (user.User, error)
+	BlockUser(ctx context.Context, userID, blockedID uuid.UUID) error
+	UnblockUser(ctx context.Context, userID, blockedID uuid.UUID) error
+	GetBlockedUsers(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+	AddContact(ctx context.Context, userID, contactID uuid.UUID) error
+	RemoveContact(ctx context.Context, userID, contactID uuid.UUID) error
+	GetContacts(ctx context.Context, userID uuid.UUID) ([]user.User, error)
+	IsContact(ctx context.Context, userID, contactID uuid.UUID) (bool, error)
+	GetPrivacySettings(ctx context.Context, userID uuid.UUID) (user.PrivacySettings, error)
+	UpdatePrivacySettings(ctx context.Context, userID uuid.UUID, settings user.PrivacySettings) error
+	CanStartChat(ctx context.Context, senderID, recipientID uuid.UUID) error
+	CanMessage(ctx context.Context, senderID, recipientID uuid.UUID) error
+	CanSeeProfileImage(ctx context.Context, viewerID, ownerID uuid.UUID) (bool, error)
+	CanSeeLastSeen(ctx context.Context, viewerID, ownerID uuid.UUID) (bool, error)
+	PublishKeys(ctx context.Context, userID uuid.UUID, keys user.IdentityKeys) error
+	AddOneTimePreKeys(ctx context.Context, userID uuid.UUID, keys []user.OneTimePreKey) error
+	CountOneTimePreKeys(ctx context.Context, userID uuid.UUID) (int, error)
+	HasKeys(ctx context.Context, userID uuid.UUID) (bool, error)
+	GetPreKeyBundle(ctx context.Context, requesterID, userID uuid.UUID) (user.PreKeyBundle, error)
+	GetAccountState(ctx context.Context, userID uuid.UUID) (user.AccountState, error)
+	SuspendUser(ctx context.Context, userID uuid.UUID, reason string, until time.Time) error
+	UnsuspendUser(ctx context.Context, userID uuid.UUID) error
+	DeactivateUser(ctx context.Context, userID uuid.UUID) error
+	ReactivateUser(ctx context.Context, userID uuid.UUID) error
+	DeleteUser(ctx context.Context, userID uuid.UUID) error
+	CreateBot(ctx context.Context, ownerID uuid.UUID, in CreateUserInput) (uuid.UUID, string, error)
+	GetBots(ctx context.Context, ownerID uuid.UUID) ([]user.User, error)
+	CreateImportedUser(ctx context.Context, ownerID uuid.UUID, in CreateUserInput) (uuid.UUID, error)
+	RotateBotToken(ctx context.Context, ownerID, botID uuid.UUID) (string, error)
+	AuthenticateBot(ctx context.Context, token string) (uuid.UUID, error)
 }
 
 type userRepository interface {
 	CreateUser(ctx context.Context, in repo.CreateUserInput) error
 	GetUser(ctx context.Context, id uuid.UUID) (repo.CreateUserInput, error)
+	GetUserByUsername(ctx context.Context, username string) (repo.CreateUserInput, error)
+	GetUsersByOwner(ctx context.Context, ownerID uuid.UUID) ([]repo.CreateUserInput, error)
+	BlockUser(ctx context.Context, userID, blockedID uuid.UUID) error
+	UnblockUser(ctx context.Context, userID, blockedID uuid.UUID) error
+	IsBlocked(ctx context.Context, userID, otherID uuid.UUID) (bool, error)
+	GetBlockedUsers(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+	AddContact(ctx context.Context, userID, contactID uuid.UUID) error
+	RemoveContact(ctx context.Context, userID, contactID uuid.UUID) error
+	GetContacts(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+	IsContact(ctx context.Context, userID, contactID uuid.UUID) (bool, error)
+	GetPrivacySettings(ctx context.Context, userID uuid.UUID) (repo.PrivacySettings, error)
+	UpdatePrivacySettings(ctx context.Context, userID uuid.UUID, settings repo.PrivacySettings) error
+	SaveIdentityKeys(ctx context.Context, userID uuid.UUID, keys repo.IdentityKeys) error
+	GetIdentityKeys(ctx context.Context, userID uuid.UUID) (repo.IdentityKeys, error)
+	AddOneTimePreKeys(ctx context.Context, userID uuid.UUID, keys []repo.OneTimePreKey) error
+	TakeOneTimePreKey(ctx context.Context, userID uuid.UUID) (repo.OneTimePreKey, bool, error)
+	CountOneTimePreKeys(ctx context.Context, userID uuid.UUID) (int, error)
+	UpdateUser(ctx context.Context, in repo.CreateUserInput) error
+	DeleteUserData(ctx context.Context, userID uuid.UUID) error
 }
 
 type service struct {
@@ -37,15 +89,8 @@ func NewService(repo userRepository) *service {
 var _ userService = (*service)(nil)
 
 func (s *service) CreateUser(ctx context.Context, in CreateUserInput) (uuid.UUID, error) {
-	if in.FirstName == "" {
-		return uuid.Nil, errors.New("first name is required")
-	}
-	if in.Username == "" {
-		return uuid.Nil, errors.New("username is required")
-	}
-	u, err := url.ParseRequestURI(in.ImageURL)
-	if err != nil || u == nil || u.Scheme == "" || u.Host == "" {
-		return uuid.Nil, errors.New("image url is invalid")
+	if err := validateProfile(in); err != nil {
+		return uuid.Nil, err
 	}
 
 	userID := uuid.New()
@@ -55,6 +100,7 @@ func (s *service) CreateUser(ctx context.Context, in CreateUserInput) (uuid.UUID
 		FirstName: in.FirstName,
 		LastName:  in.LastName,
 		Username:  in.Username,
+		CreatedAt: time.Now().UTC(),
 	}); err != nil {
 		return uuid.Nil, err
 	}
@@ -68,11 +114,45 @@ func (s *service) GetUser(ctx context.Context, id uuid.UUID) (user.User, error)
 		return user.User{}, err
 	}
 
+	return toUser(u), nil
+}
+
+// GetUserByUsername finds a user by username, ignoring case. It returns
+// ErrUserNotFound when nobody has the username.
+func (s *service) GetUserByUsername(ctx context.Context, username string) (user.User, error) {
+	u, err := s.repo.GetUserByUsername(ctx, username)
+	if err != nil {
+		return user.User{}, err
+	}
+
+	return toUser(u), nil
+}
+
+func toUser(u repo.CreateUserInput) user.User {
 	return user.User{
 		ID:        u.ID,
 		ImageURL:  u.ImageURL,
 		FirstName: u.FirstName,
 		LastName:  u.LastName,
 		Username:  u.Username,
-	}, nil
+		CreatedAt: u.CreatedAt,
+		Status:    accountState(u, time.Now()).Status,
+		Type:      u.Type,
+		OwnerID:   u.OwnerID,
+	}
+}
+
+func validateProfile(in CreateUserInput) error {
+	if in.FirstName == "" {
+		return errors.New("first name is required")
+	}
+	if in.Username == "" {
+		return errors.New("username is required")
+	}
+	u, err := url.ParseRequestURI(in.ImageURL)
+	if err != nil || u == nil || u.Scheme == "" || u.Host == "" {
+		return errors.New("image url is invalid")
+	}
+
+	return nil
 }
@@ -139,3 +139,15 @@ func TestGetUser_ReturnUser(t *testing.T) {
 		t.Fatalf("Expected user %v got %v", expectedUser, usr)
 	}
 }
+
+func TestGetUserByUsername_ReturnErrorOnUnknownUsername(t *testing.T) {
+	ctx := context.Background()
+
+	mockRepo := mocks.NewUserRepository(t)
+	mockRepo.EXPECT().GetUserByUsername(ctx, "nobody").Return(repo.CreateUserInput{}, repo.ErrUserNotFound)
+	service := usersvc.NewService(mockRepo)
+
+	if _, err := service.GetUserByUsername(ctx, "nobody"); !errors.Is(err, usersvc.ErrUserNotFound) {
+		t.Fatalf("Expected %v got %v", usersvc.ErrUserNotFound, err)
+	}
+}
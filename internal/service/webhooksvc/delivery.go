@@ -0,0 +1,208 @@
+package webhooksvc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/AliUnipal/chat/internal/models/webhook"
+	"github.com/AliUnipal/chat/internal/service/webhooksvc/repo"
+	"github.com/google/uuid"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"slices"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// maxResponseBody caps how much of a receiver's response is read before the
+// connection is reused.
+const maxResponseBody = 64 << 10
+
+// newClient returns the client deliveries are sent with. Webhook URLs are
+// chosen by users, so it only connects to addresses config does not block,
+// checked when connecting so DNS cannot be used to sneak past the check, and
+// follows a bounded number of redirects.
+func newClient(config Config) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: config.Timeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			ap, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			if config.Blocked(ap.Addr().Unmap()) {
+				return ErrBlockedAddress
+			}
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Transport: &http.Transport{
+			// Going through a proxy would hide the address connected to.
+			Proxy:                  nil,
+			DialContext:            dialer.DialContext,
+			TLSHandshakeTimeout:    config.Timeout,
+			ResponseHeaderTimeout:  config.Timeout,
+			MaxResponseHeaderBytes: 64 << 10,
+			MaxIdleConns:           10,
+			IdleConnTimeout:        time.Minute,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) > config.MaxRedirects {
+				return ErrTooManyRedirects
+			}
+			if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+				return ErrInvalidURL
+			}
+			return nil
+		},
+	}
+}
+
+// Deliver makes an attempt at every delivery due at now. Subscriptions are
+// delivered to by up to config.Workers at once, but each subscription's
+// deliveries are attempted one after another, oldest first, so a receiver
+// sees its events in the order they happened. A failed attempt is retried
+// after an exponentially growing delay until the delivery runs out of
+// attempts and is dead-lettered.
+//
+// Every delivery is claimed before it is attempted, so concurrent Deliver
+// calls never send the same delivery twice.
+func (s *service) Deliver(ctx context.Context, now time.Time) error {
+	due, err := s.repo.GetDueDeliveries(ctx, now)
+	if err != nil {
+		return err
+	}
+	var order []uuid.UUID
+	bySubscription := make(map[uuid.UUID][]repo.Delivery)
+	for _, d := range due {
+		if _, ok := bySubscription[d.SubscriptionID]; !ok {
+			order = append(order, d.SubscriptionID)
+		}
+		bySubscription[d.SubscriptionID] = append(bySubscription[d.SubscriptionID], d)
+	}
+
+	queue := make(chan []repo.Delivery)
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	for range max(s.config.Workers, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ds := range queue {
+				for _, d := range ds {
+					if err := s.claimAndAttempt(ctx, d.ID, now); err != nil {
+						mu.Lock()
+						errs = append(errs, err)
+						mu.Unlock()
+					}
+				}
+			}
+		}()
+	}
+	for _, id := range order {
+		queue <- bySubscription[id]
+	}
+	close(queue)
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// Run delivers every interval until ctx is done.
+func (s *service) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			_ = s.Deliver(ctx, now.UTC())
+		}
+	}
+}
+
+// claimAndAttempt attempts the delivery unless another Deliver call claimed it
+// first. The claim outlasts the attempt, which the timeout bounds.
+func (s *service) claimAndAttempt(ctx context.Context, id uuid.UUID, now time.Time) error {
+	d, err := s.repo.ClaimDelivery(ctx, id, now, now.Add(2*s.config.Timeout))
+	if errors.Is(err, repo.ErrDeliveryClaimed) || errors.Is(err, repo.ErrDeliveryNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.attempt(ctx, d, now)
+}
+
+func (s *service) attempt(ctx context.Context, d repo.Delivery, now time.Time) error {
+	sub, err := s.repo.GetSubscription(ctx, d.SubscriptionID)
+	if errors.Is(err, repo.ErrSubscriptionNotFound) {
+		// Unsubscribed since the delivery was fetched.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	a := webhook.Attempt{At: now}
+	a.StatusCode, err = s.post(ctx, sub, d, now)
+	a.Duration = time.Since(start)
+	if err != nil {
+		a.Error = err.Error()
+	}
+
+	d.Attempts = append(slices.Clip(d.Attempts), a)
+	d.ClaimedUntil = time.Time{}
+	if err == nil {
+		d.Status = webhook.Succeeded
+		return s.repo.UpdateDelivery(ctx, d)
+	}
+
+	d.Failures++
+	if d.Failures >= s.config.MaxAttempts {
+		d.Status = webhook.DeadLettered
+	} else {
+		d.NextAttemptAt = now.Add(s.config.Backoff(d.Failures))
+	}
+	return s.repo.UpdateDelivery(ctx, d)
+}
+
+// post sends the payload, signed as of now, and returns the response status.
+// Anything but a 2xx status is an error.
+func (s *service) post(ctx context.Context, sub repo.Subscription, d repo.Delivery, now time.Time) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, string(d.EventType))
+	req.Header.Set(DeliveryHeader, d.ID.String())
+	req.Header.Set(SignatureHeader, Sign(sub.Secret, now, d.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBody))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,213 @@
+package inmemwebhookrepo
+
+import (
+	"context"
+	"errors"
+	"github.com/AliUnipal/chat/internal/models/webhook"
+	"github.com/AliUnipal/chat/internal/service/webhooksvc/repo"
+	"github.com/google/uuid"
+	"slices"
+	"sync"
+	"time"
+)
+
+func New() *repository {
+	return &repository{
+		subscriptions: make(map[uuid.UUID]repo.Subscription),
+		deliveries:    make(map[uuid.UUID]repo.Delivery),
+	}
+}
+
+// repository is safe for concurrent use, as deliveries are queued by the
+// services publishing events and sent in the background.
+type repository struct {
+	mu            sync.RWMutex
+	subscriptions map[uuid.UUID]repo.Subscription
+	deliveries    map[uuid.UUID]repo.Delivery
+}
+
+func (r *repository) CreateSubscription(_ context.Context, sub repo.Subscription) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.subscriptions[sub.ID]; ok {
+		return errors.New("webhook subscription already exists")
+	}
+	r.subscriptions[sub.ID] = sub
+	return nil
+}
+
+func (r *repository) GetSubscription(_ context.Context, id uuid.UUID) (repo.Subscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	sub, ok := r.subscriptions[id]
+	if !ok {
+		return repo.Subscription{}, repo.ErrSubscriptionNotFound
+	}
+	return sub, nil
+}
+
+// GetSubscriptions returns the subscriptions of the given owners, oldest
+// first.
+func (r *repository) GetSubscriptions(_ context.Context, ownerIDs []uuid.UUID) ([]repo.Subscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var subs []repo.Subscription
+	for _, sub := range r.subscriptions {
+		if slices.Contains(ownerIDs, sub.OwnerID) {
+			subs = append(subs, sub)
+		}
+	}
+	slices.SortFunc(subs, func(a, b repo.Subscription) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	return subs, nil
+}
+
+func (r *repository) DeleteSubscription(_ context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.subscriptions[id]; !ok {
+		return repo.ErrSubscriptionNotFound
+	}
+	delete(r.subscriptions, id)
+	return nil
+}
+
+func (r *repository) CreateDelivery(_ context.Context, d repo.Delivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.deliveries[d.ID]; ok {
+		return errors.New("webhook delivery already exists")
+	}
+	r.deliveries[d.ID] = d
+	return nil
+}
+
+func (r *repository) GetDelivery(_ context.Context, id uuid.UUID) (repo.Delivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	d, ok := r.deliveries[id]
+	if !ok {
+		return repo.Delivery{}, repo.ErrDeliveryNotFound
+	}
+	return d, nil
+}
+
+// GetDeliveries returns the subscription's deliveries, oldest first.
+func (r *repository) GetDeliveries(_ context.Context, subscriptionID uuid.UUID) ([]repo.Delivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var ds []repo.Delivery
+	for _, d := range r.deliveries {
+		if d.SubscriptionID == subscriptionID {
+			ds = append(ds, d)
+		}
+	}
+	sortDeliveries(ds)
+	return ds, nil
+}
+
+// GetDueDeliveries returns the pending deliveries whose next attempt is due
+// at now and that are not claimed, oldest first.
+func (r *repository) GetDueDeliveries(_ context.Context, now time.Time) ([]repo.Delivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var ds []repo.Delivery
+	for _, d := range r.deliveries {
+		if due(d, now) {
+			ds = append(ds, d)
+		}
+	}
+	sortDeliveries(ds)
+	return ds, nil
+}
+
+// ClaimDelivery marks a due delivery as being attempted until the given time
+// and returns it. Only one of concurrent claims on a delivery succeeds.
+func (r *repository) ClaimDelivery(_ context.Context, id uuid.UUID, now, until time.Time) (repo.Delivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	d, ok := r.deliveries[id]
+	if !ok {
+		return repo.Delivery{}, repo.ErrDeliveryNotFound
+	}
+	if !due(d, now) {
+		return repo.Delivery{}, repo.ErrDeliveryClaimed
+	}
+	d.ClaimedUntil = until
+	r.deliveries[id] = d
+	return d, nil
+}
+
+// UpdateDelivery saves the state of a delivery. It keeps the stored payload,
+// which only SetPayload changes, so an attempt finishing cannot bring back
+// content redacted while it was in flight.
+func (r *repository) UpdateDelivery(_ context.Context, d repo.Delivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.deliveries[d.ID]
+	if !ok {
+		return repo.ErrDeliveryNotFound
+	}
+	d.Payload = stored.Payload
+	r.deliveries[d.ID] = d
+	return nil
+}
+
+// GetMessageDeliveries returns the deliveries of events about the message.
+func (r *repository) GetMessageDeliveries(_ context.Context, messageID uuid.UUID) ([]repo.Delivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var ds []repo.Delivery
+	for _, d := range r.deliveries {
+		if d.MessageID == messageID {
+			ds = append(ds, d)
+		}
+	}
+	sortDeliveries(ds)
+	return ds, nil
+}
+
+func (r *repository) SetPayload(_ context.Context, id uuid.UUID, payload []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	d, ok := r.deliveries[id]
+	if !ok {
+		return repo.ErrDeliveryNotFound
+	}
+	d.Payload = payload
+	r.deliveries[id] = d
+	return nil
+}
+
+// DeleteDeliveries removes the subscription's deliveries along with their
+// logs.
+func (r *repository) DeleteDeliveries(_ context.Context, subscriptionID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, d := range r.deliveries {
+		if d.SubscriptionID == subscriptionID {
+			delete(r.deliveries, id)
+		}
+	}
+	return nil
+}
+
+func due(d repo.Delivery, now time.Time) bool {
+	return d.Status == webhook.Pending && !d.NextAttemptAt.After(now) && !d.ClaimedUntil.After(now)
+}
+
+func sortDeliveries(ds []repo.Delivery) {
+	slices.SortFunc(ds, func(a, b repo.Delivery) int { return a.CreatedAt.Compare(b.CreatedAt) })
+}
@@ -0,0 +1,49 @@
+package repo
+
+import (
+	"errors"
+	"github.com/AliUnipal/chat/internal/events"
+	"github.com/AliUnipal/chat/internal/models/webhook"
+	"github.com/google/uuid"
+	"time"
+)
+
+var (
+	ErrSubscriptionNotFound = errors.New("webhook subscription does not exist")
+	ErrDeliveryNotFound     = errors.New("webhook delivery does not exist")
+	// ErrDeliveryClaimed is returned when claiming a delivery that is not
+	// due or is being attempted already.
+	ErrDeliveryClaimed = errors.New("webhook delivery is not due or already claimed")
+)
+
+type Subscription struct {
+	ID         uuid.UUID
+	OwnerID    uuid.UUID
+	ChatID     uuid.UUID
+	URL        string
+	EventTypes []events.Type
+	Secret     string
+	CreatedAt  time.Time
+}
+
+type Delivery struct {
+	ID             uuid.UUID
+	SubscriptionID uuid.UUID
+	EventID        uuid.UUID
+	EventType      events.Type
+	// MessageID is the message the event is about, if any.
+	MessageID uuid.UUID
+	// Payload is the JSON body, the same on every attempt unless the message
+	// is deleted and its content redacted from it.
+	Payload []byte
+	Status  webhook.DeliveryStatus
+	// Failures counts the failed attempts since the delivery was last
+	// queued.
+	Failures      int
+	Attempts      []webhook.Attempt
+	NextAttemptAt time.Time
+	// ClaimedUntil is when the attempt in flight is given up on, so that a
+	// delivery claimed by a sender that never finished is attempted again.
+	ClaimedUntil time.Time
+	CreatedAt    time.Time
+}
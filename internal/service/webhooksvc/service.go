@@ -0,0 +1,480 @@
+package webhooksvc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"github.com/AliUnipal/chat/internal/events"
+	"github.com/AliUnipal/chat/internal/linkpreview"
+	"github.com/AliUnipal/chat/internal/models/chat"
+	"github.com/AliUnipal/chat/internal/models/message"
+	"github.com/AliUnipal/chat/internal/models/webhook"
+	"github.com/AliUnipal/chat/internal/service/webhooksvc/repo"
+	"github.com/google/uuid"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"slices"
+	"time"
+)
+
+var (
+	ErrInvalidURL       = errors.New("webhook url must be an absolute http or https url")
+	ErrBlockedAddress   = errors.New("webhook url points to an address that is not public")
+	ErrTooManyRedirects = errors.New("webhook url redirects too many times")
+	ErrUnknownEventType = errors.New("unknown event type")
+	ErrNotDeadLettered  = errors.New("webhook delivery is not dead-lettered")
+)
+
+type Config struct {
+	// MaxAttempts is how many times a delivery is tried before it is
+	// dead-lettered.
+	MaxAttempts int
+	// BaseDelay is the wait after the first failed attempt. It doubles after
+	// every further failure, up to MaxDelay.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// Timeout bounds every attempt.
+	Timeout time.Duration
+	// MaxRedirects bounds the redirects followed by one attempt.
+	MaxRedirects int
+	// Blocked reports whether delivering to an address is forbidden.
+	Blocked func(addr netip.Addr) bool
+	// Workers bounds the subscriptions delivered to at once.
+	Workers int
+}
+
+var DefaultConfig = Config{
+	MaxAttempts:  8,
+	BaseDelay:    10 * time.Second,
+	MaxDelay:     time.Hour,
+	Timeout:      10 * time.Second,
+	MaxRedirects: 3,
+	Blocked:      linkpreview.IsPrivate,
+	Workers:      8,
+}
+
+// Backoff returns how long to wait before retrying a delivery that failed
+// failures times in a row.
+func (c Config) Backoff(failures int) time.Duration {
+	d := c.BaseDelay
+	for i := 1; i < failures && d < c.MaxDelay; i++ {
+		d *= 2
+	}
+	return min(d, c.MaxDelay)
+}
+
+type CreateSubscriptionInput struct {
+	OwnerID uuid.UUID
+	// ChatID limits the subscription to one of the owner's chats.
+	ChatID     uuid.UUID
+	URL        string
+	EventTypes []events.Type
+}
+
+// Payload is the JSON body of a delivery.
+type Payload struct {
+	ID        uuid.UUID       `json:"id"`
+	Type      events.Type     `json:"type"`
+	ChatID    uuid.UUID       `json:"chat_id"`
+	UserID    uuid.UUID       `json:"user_id"`
+	CreatedAt time.Time       `json:"created_at"`
+	Message   *MessagePayload `json:"message,omitempty"`
+	// DisappearAfter is the chat's new disappearing messages timer in
+	// seconds, zero for off. It is only set on
+	// chat.disappearing_messages_changed events.
+	DisappearAfter *int64 `json:"disappear_after,omitempty"`
+	// Silent is set on message.created events when the subscriber muted the
+	// chat and is not mentioned in the message, so it should not alert them.
+	Silent bool `json:"silent,omitempty"`
+}
+
+type MessagePayload struct {
+	ID       uuid.UUID `json:"id"`
+	SenderID uuid.UUID `json:"sender_id"`
+	// Type is "text", "image", "file" or "encrypted". It is empty for deleted
+	// messages, which only carry their IDs.
+	Type string `json:"type,omitempty"`
+	Text string `json:"text,omitempty"`
+	// Content holds everything but text, base64 encoded.
+	Content   []byte    `json:"content,omitempty"`
+	Timestamp time.Time `json:"timestamp,omitzero"`
+	// Previews are the cards of the links in the text.
+	Previews []PreviewPayload `json:"previews,omitempty"`
+}
+
+type PreviewPayload struct {
+	URL         string `json:"url"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	ImageURL    string `json:"image_url,omitempty"`
+	SiteName    string `json:"site_name,omitempty"`
+}
+
+type webhookService interface {
+	Subscribe(ctx context.Context, in CreateSubscriptionInput) (webhook.Subscription, error)
+	GetSubscriptions(ctx context.Context, ownerID uuid.UUID) ([]webhook.Subscription, error)
+	Unsubscribe(ctx context.Context, ownerID, subscriptionID uuid.UUID) error
+	UnsubscribeAll(ctx context.Context, ownerID uuid.UUID) error
+	GetDeliveries(ctx context.Context, ownerID, subscriptionID uuid.UUID) ([]webhook.Delivery, error)
+	GetDeadLetters(ctx context.Context, ownerID uuid.UUID) ([]webhook.Delivery, error)
+	Redeliver(ctx context.Context, ownerID, deliveryID uuid.UUID) error
+	HandleEvent(ctx context.Context, e events.Event) error
+	Deliver(ctx context.Context, now time.Time) error
+}
+
+type webhookRepository interface {
+	CreateSubscription(ctx context.Context, sub repo.Subscription) error
+	GetSubscription(ctx context.Context, id uuid.UUID) (repo.Subscription, error)
+	GetSubscriptions(ctx context.Context, ownerIDs []uuid.UUID) ([]repo.Subscription, error)
+	DeleteSubscription(ctx context.Context, id uuid.UUID) error
+	CreateDelivery(ctx context.Context, d repo.Delivery) error
+	GetDelivery(ctx context.Context, id uuid.UUID) (repo.Delivery, error)
+	GetDeliveries(ctx context.Context, subscriptionID uuid.UUID) ([]repo.Delivery, error)
+	GetDueDeliveries(ctx context.Context, now time.Time) ([]repo.Delivery, error)
+	ClaimDelivery(ctx context.Context, id uuid.UUID, now, until time.Time) (repo.Delivery, error)
+	UpdateDelivery(ctx context.Context, d repo.Delivery) error
+	GetMessageDeliveries(ctx context.Context, messageID uuid.UUID) ([]repo.Delivery, error)
+	SetPayload(ctx context.Context, id uuid.UUID, payload []byte) error
+	DeleteDeliveries(ctx context.Context, subscriptionID uuid.UUID) error
+}
+
+type chatService interface {
+	GetChat(ctx context.Context, id, userID uuid.UUID) (chat.Chat, error)
+}
+
+type service struct {
+	repo   webhookRepository
+	chats  chatService
+	client *http.Client
+	config Config
+}
+
+var _ webhookService = (*service)(nil)
+
+func NewService(repo webhookRepository, chats chatService, config Config) *service {
+	return &service{repo: repo, chats: chats, client: newClient(config), config: config}
+}
+
+// Subscribe registers a URL for the owner's events, or for the events of one
+// of their chats. The returned subscription carries the secret its payloads
+// are signed with; it cannot be read back later.
+func (s *service) Subscribe(ctx context.Context, in CreateSubscriptionInput) (webhook.Subscription, error) {
+	u, err := url.Parse(in.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return webhook.Subscription{}, ErrInvalidURL
+	}
+	// Host names are checked once resolved, when delivering.
+	if addr, err := netip.ParseAddr(u.Hostname()); err == nil && s.config.Blocked(addr.Unmap()) {
+		return webhook.Subscription{}, ErrBlockedAddress
+	}
+	for _, t := range in.EventTypes {
+		if !slices.Contains(events.Types, t) {
+			return webhook.Subscription{}, ErrUnknownEventType
+		}
+	}
+	if in.ChatID != uuid.Nil {
+		if _, err := s.chats.GetChat(ctx, in.ChatID, in.OwnerID); err != nil {
+			return webhook.Subscription{}, err
+		}
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return webhook.Subscription{}, err
+	}
+	sub := repo.Subscription{
+		ID:         uuid.New(),
+		OwnerID:    in.OwnerID,
+		ChatID:     in.ChatID,
+		URL:        in.URL,
+		EventTypes: slices.Clone(in.EventTypes),
+		Secret:     hex.EncodeToString(secret),
+		CreatedAt:  time.Now().UTC(),
+	}
+	if err := s.repo.CreateSubscription(ctx, sub); err != nil {
+		return webhook.Subscription{}, err
+	}
+
+	res := toSubscription(sub)
+	res.Secret = sub.Secret
+	return res, nil
+}
+
+func (s *service) GetSubscriptions(ctx context.Context, ownerID uuid.UUID) ([]webhook.Subscription, error) {
+	subs, err := s.repo.GetSubscriptions(ctx, []uuid.UUID{ownerID})
+	if err != nil {
+		return nil, err
+	}
+
+	r := make([]webhook.Subscription, len(subs))
+	for i, sub := range subs {
+		r[i] = toSubscription(sub)
+	}
+	return r, nil
+}
+
+// Unsubscribe deletes the subscription along with its deliveries, pending
+// ones included.
+func (s *service) Unsubscribe(ctx context.Context, ownerID, subscriptionID uuid.UUID) error {
+	if _, err := s.getSubscription(ctx, ownerID, subscriptionID); err != nil {
+		return err
+	}
+	if err := s.repo.DeleteDeliveries(ctx, subscriptionID); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteSubscription(ctx, subscriptionID)
+}
+
+// UnsubscribeAll deletes every subscription of the owner along with its
+// deliveries, for example when their account is deleted.
+func (s *service) UnsubscribeAll(ctx context.Context, ownerID uuid.UUID) error {
+	subs, err := s.repo.GetSubscriptions(ctx, []uuid.UUID{ownerID})
+	if err != nil {
+		return err
+	}
+	for _, sub := range subs {
+		if err := s.repo.DeleteDeliveries(ctx, sub.ID); err != nil {
+			return err
+		}
+		if err := s.repo.DeleteSubscription(ctx, sub.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetDeliveries returns the subscription's deliveries with a log of their
+// attempts, oldest first.
+func (s *service) GetDeliveries(ctx context.Context, ownerID, subscriptionID uuid.UUID) ([]webhook.Delivery, error) {
+	if _, err := s.getSubscription(ctx, ownerID, subscriptionID); err != nil {
+		return nil, err
+	}
+	ds, err := s.repo.GetDeliveries(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	r := make([]webhook.Delivery, len(ds))
+	for i, d := range ds {
+		r[i] = toDelivery(d)
+	}
+	return r, nil
+}
+
+// GetDeadLetters returns the deliveries to the owner's subscriptions that ran
+// out of attempts.
+func (s *service) GetDeadLetters(ctx context.Context, ownerID uuid.UUID) ([]webhook.Delivery, error) {
+	subs, err := s.repo.GetSubscriptions(ctx, []uuid.UUID{ownerID})
+	if err != nil {
+		return nil, err
+	}
+
+	var r []webhook.Delivery
+	for _, sub := range subs {
+		ds, err := s.repo.GetDeliveries(ctx, sub.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range ds {
+			if d.Status == webhook.DeadLettered {
+				r = append(r, toDelivery(d))
+			}
+		}
+	}
+	return r, nil
+}
+
+// Redeliver queues a dead-lettered delivery again with a fresh set of
+// attempts.
+func (s *service) Redeliver(ctx context.Context, ownerID, deliveryID uuid.UUID) error {
+	d, err := s.repo.GetDelivery(ctx, deliveryID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.getSubscription(ctx, ownerID, d.SubscriptionID); err != nil {
+		if errors.Is(err, repo.ErrSubscriptionNotFound) {
+			return repo.ErrDeliveryNotFound
+		}
+		return err
+	}
+	if d.Status != webhook.DeadLettered {
+		return ErrNotDeadLettered
+	}
+
+	d.Status = webhook.Pending
+	d.Failures = 0
+	d.NextAttemptAt = time.Now().UTC()
+	return s.repo.UpdateDelivery(ctx, d)
+}
+
+// HandleEvent queues a delivery of the event to every subscription of its
+// recipients that asked for it, first redacting the earlier deliveries of a
+// deleted message. It is meant to be subscribed to the event bus.
+func (s *service) HandleEvent(ctx context.Context, e events.Event) error {
+	if e.Type == events.MessageDeleted && e.Message != nil {
+		if err := s.redact(ctx, e.Message.ID); err != nil {
+			return err
+		}
+	}
+	subs, err := s.repo.GetSubscriptions(ctx, e.Recipients)
+	if err != nil {
+		return err
+	}
+	// Muted subscribers get the same payload marked silent.
+	var payloads [2][]byte
+	var errs []error
+	for _, sub := range subs {
+		if sub.ChatID != uuid.Nil && sub.ChatID != e.ChatID {
+			continue
+		}
+		if len(sub.EventTypes) > 0 && !slices.Contains(sub.EventTypes, e.Type) {
+			continue
+		}
+		silent := e.Type == events.MessageCreated && slices.Contains(e.Silent, sub.OwnerID)
+		i := 0
+		if silent {
+			i = 1
+		}
+		if payloads[i] == nil {
+			p := NewPayload(e)
+			p.Silent = silent
+			if payloads[i], err = json.Marshal(p); err != nil {
+				return err
+			}
+		}
+		payload := payloads[i]
+
+		errs = append(errs, s.repo.CreateDelivery(ctx, repo.Delivery{
+			ID:             uuid.New(),
+			SubscriptionID: sub.ID,
+			EventID:        e.ID,
+			EventType:      e.Type,
+			MessageID:      messageID(e),
+			Payload:        payload,
+			Status:         webhook.Pending,
+			NextAttemptAt:  e.Timestamp,
+			CreatedAt:      e.Timestamp,
+		}))
+	}
+
+	return errors.Join(errs...)
+}
+
+// redact strips the content of a deleted message from the payloads of the
+// deliveries about it, whether they are still pending or logged.
+func (s *service) redact(ctx context.Context, messageID uuid.UUID) error {
+	ds, err := s.repo.GetMessageDeliveries(ctx, messageID)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, d := range ds {
+		var p Payload
+		if err := json.Unmarshal(d.Payload, &p); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if p.Message == nil {
+			continue
+		}
+		p.Message = &MessagePayload{ID: p.Message.ID, SenderID: p.Message.SenderID, Timestamp: p.Message.Timestamp}
+		payload, err := json.Marshal(p)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		errs = append(errs, s.repo.SetPayload(ctx, d.ID, payload))
+	}
+
+	return errors.Join(errs...)
+}
+
+func messageID(e events.Event) uuid.UUID {
+	if e.Message == nil {
+		return uuid.Nil
+	}
+	return e.Message.ID
+}
+
+// getSubscription returns the subscription, hiding other users'
+// subscriptions as if they did not exist.
+func (s *service) getSubscription(ctx context.Context, ownerID, subscriptionID uuid.UUID) (repo.Subscription, error) {
+	sub, err := s.repo.GetSubscription(ctx, subscriptionID)
+	if err != nil {
+		return repo.Subscription{}, err
+	}
+	if sub.OwnerID != ownerID {
+		return repo.Subscription{}, repo.ErrSubscriptionNotFound
+	}
+
+	return sub, nil
+}
+
+func toSubscription(sub repo.Subscription) webhook.Subscription {
+	return webhook.Subscription{
+		ID:         sub.ID,
+		OwnerID:    sub.OwnerID,
+		ChatID:     sub.ChatID,
+		URL:        sub.URL,
+		EventTypes: slices.Clone(sub.EventTypes),
+		CreatedAt:  sub.CreatedAt,
+	}
+}
+
+func toDelivery(d repo.Delivery) webhook.Delivery {
+	return webhook.Delivery{
+		ID:             d.ID,
+		SubscriptionID: d.SubscriptionID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Status:         d.Status,
+		Attempts:       slices.Clone(d.Attempts),
+		NextAttemptAt:  d.NextAttemptAt,
+		CreatedAt:      d.CreatedAt,
+	}
+}
+
+// NewPayload returns the JSON shape of the event that webhooks and bots receive.
+func NewPayload(e events.Event) Payload {
+	p := Payload{
+		ID:        e.ID,
+		Type:      e.Type,
+		ChatID:    e.ChatID,
+		UserID:    e.UserID,
+		CreatedAt: e.Timestamp,
+	}
+	if e.Type == events.DisappearingMessagesChanged {
+		seconds := int64(e.DisappearAfter / time.Second)
+		p.DisappearAfter = &seconds
+	}
+	if m := e.Message; m != nil {
+		p.Message = &MessagePayload{ID: m.ID, SenderID: m.SenderID}
+		if e.Type != events.MessageDeleted {
+			p.Message.Timestamp = m.Timestamp
+			switch m.ContentType {
+			case message.TextContentType, message.RichTextContentType:
+				p.Message.Type = "text"
+				p.Message.Text = string(m.Content)
+				for _, pv := range m.Previews {
+					p.Message.Previews = append(p.Message.Previews, PreviewPayload(pv))
+				}
+			case message.ImageContentType:
+				p.Message.Type = "image"
+				p.Message.Content = m.Content
+			case message.FileContentType:
+				p.Message.Type = "file"
+				p.Message.Content = m.Content
+			default:
+				p.Message.Type = "encrypted"
+				p.Message.Content = m.Content
+			}
+		}
+	}
+
+	return p
+}
@@ -0,0 +1,613 @@
+package webhooksvc_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/AliUnipal/chat/internal/events"
+	"github.com/AliUnipal/chat/internal/models/chat"
+	"github.com/AliUnipal/chat/internal/models/message"
+	"github.com/AliUnipal/chat/internal/models/webhook"
+	"github.com/AliUnipal/chat/internal/service/chatsvc"
+	"github.com/AliUnipal/chat/internal/service/webhooksvc"
+	"github.com/AliUnipal/chat/internal/service/webhooksvc/mocks"
+	"github.com/AliUnipal/chat/internal/service/webhooksvc/repo"
+	"github.com/AliUnipal/chat/internal/service/webhooksvc/repo/inmemwebhookrepo"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testConfig delivers to the loopback addresses test servers listen on.
+var testConfig = webhooksvc.Config{
+	MaxAttempts:  3,
+	BaseDelay:    time.Second,
+	MaxDelay:     time.Minute,
+	Timeout:      time.Second,
+	MaxRedirects: 2,
+	Blocked:      func(netip.Addr) bool { return false },
+	Workers:      4,
+}
+
+// receiver records the requests it gets and answers them with the next of
+// its statuses, repeating the last one.
+type receiver struct {
+	mu       sync.Mutex
+	statuses []int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	body, _ := io.ReadAll(r.Body)
+	rc.requests = append(rc.requests, r)
+	rc.bodies = append(rc.bodies, body)
+	status := rc.statuses[min(len(rc.requests), len(rc.statuses))-1]
+	w.WriteHeader(status)
+}
+
+func messageEvent(chatID, senderID uuid.UUID, recipients ...uuid.UUID) events.Event {
+	now := time.Now().UTC()
+	return events.Event{
+		ID:         uuid.New(),
+		Type:       events.MessageCreated,
+		ChatID:     chatID,
+		UserID:     senderID,
+		Recipients: recipients,
+		Message: &message.Message{
+			ID:          uuid.New(),
+			SenderID:    senderID,
+			ChatID:      chatID,
+			Content:     []byte("hello"),
+			ContentType: message.TextContentType,
+			Timestamp:   now,
+		},
+		Timestamp: now,
+	}
+}
+
+func TestWebhook_DeliversSignedPayload(t *testing.T) {
+	ctx := context.Background()
+	ownerID, otherID, chatID := uuid.New(), uuid.New(), uuid.New()
+	rc := &receiver{statuses: []int{http.StatusNoContent}}
+	srv := httptest.NewServer(rc)
+	defer srv.Close()
+
+	svc := webhooksvc.NewService(inmemwebhookrepo.New(), mocks.NewChatService(t), testConfig)
+	sub, err := svc.Subscribe(ctx, webhooksvc.CreateSubscriptionInput{OwnerID: ownerID, URL: srv.URL})
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if sub.Secret == "" {
+		t.Fatalf("expected a secret got none")
+	}
+
+	e := messageEvent(chatID, otherID, ownerID, otherID)
+	if err := svc.HandleEvent(ctx, e); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	now := time.Now().UTC()
+	if err := svc.Deliver(ctx, now); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	if len(rc.requests) != 1 {
+		t.Fatalf("expected 1 request got %d", len(rc.requests))
+	}
+	req, body := rc.requests[0], rc.bodies[0]
+	if err := webhooksvc.Verify(sub.Secret, req.Header.Get(webhooksvc.SignatureHeader), body, now, time.Minute); err != nil {
+		t.Fatalf("expected a valid signature got %v", err)
+	}
+	if req.Header.Get(webhooksvc.EventHeader) != string(events.MessageCreated) {
+		t.Fatalf("expected the event type header got %q", req.Header.Get(webhooksvc.EventHeader))
+	}
+	var p webhooksvc.Payload
+	if err := json.Unmarshal(body, &p); err != nil {
+		t.Fatalf("expected a JSON payload got %v", err)
+	}
+	if p.ID != e.ID || p.ChatID != chatID || p.Message == nil || p.Message.Text != "hello" || p.Message.Type != "text" {
+		t.Fatalf("unexpected payload %+v", p)
+	}
+
+	ds, err := svc.GetDeliveries(ctx, ownerID, sub.ID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(ds) != 1 || ds[0].Status != webhook.Succeeded || len(ds[0].Attempts) != 1 || ds[0].Attempts[0].StatusCode != http.StatusNoContent {
+		t.Fatalf("expected a logged successful delivery got %+v", ds)
+	}
+	if err := svc.Deliver(ctx, now.Add(time.Hour)); err != nil || len(rc.requests) != 1 {
+		t.Fatalf("expected a delivered event not to be sent again got %d requests", len(rc.requests))
+	}
+}
+
+func TestWebhook_MarksMutedRecipientsSilent(t *testing.T) {
+	ctx := context.Background()
+	mutedID, mentionedID, senderID, chatID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	rc := &receiver{statuses: []int{http.StatusNoContent}}
+	srv := httptest.NewServer(rc)
+	defer srv.Close()
+
+	svc := webhooksvc.NewService(inmemwebhookrepo.New(), mocks.NewChatService(t), testConfig)
+	for _, ownerID := range []uuid.UUID{mutedID, mentionedID} {
+		if _, err := svc.Subscribe(ctx, webhooksvc.CreateSubscriptionInput{OwnerID: ownerID, URL: srv.URL + "/" + ownerID.String()}); err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+	}
+
+	e := messageEvent(chatID, senderID, mutedID, mentionedID)
+	e.Silent = []uuid.UUID{mutedID}
+	if err := svc.HandleEvent(ctx, e); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if err := svc.Deliver(ctx, time.Now().UTC()); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	if len(rc.requests) != 2 {
+		t.Fatalf("expected 2 requests got %d", len(rc.requests))
+	}
+	for i, req := range rc.requests {
+		var p webhooksvc.Payload
+		if err := json.Unmarshal(rc.bodies[i], &p); err != nil {
+			t.Fatalf("expected a JSON payload got %v", err)
+		}
+		expected := req.URL.Path == "/"+mutedID.String()
+		if p.Silent != expected {
+			t.Fatalf("expected silent %v for %s got %v", expected, req.URL.Path, p.Silent)
+		}
+	}
+}
+
+func TestWebhook_RetriesThenDeadLetters(t *testing.T) {
+	ctx := context.Background()
+	ownerID, chatID := uuid.New(), uuid.New()
+	rc := &receiver{statuses: []int{http.StatusInternalServerError}}
+	srv := httptest.NewServer(rc)
+	defer srv.Close()
+
+	svc := webhooksvc.NewService(inmemwebhookrepo.New(), mocks.NewChatService(t), testConfig)
+	sub, err := svc.Subscribe(ctx, webhooksvc.CreateSubscriptionInput{OwnerID: ownerID, URL: srv.URL})
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	e := messageEvent(chatID, ownerID, ownerID)
+	if err := svc.HandleEvent(ctx, e); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	now := e.Timestamp
+	for _, wait := range []time.Duration{0, time.Second - time.Nanosecond, time.Nanosecond, 2 * time.Second} {
+		now = now.Add(wait)
+		if err := svc.Deliver(ctx, now); err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+	}
+	if len(rc.requests) != 3 {
+		t.Fatalf("expected an attempt, a retry after 1s and another after 2s got %d requests", len(rc.requests))
+	}
+	if rc.requests[0].Header.Get(webhooksvc.DeliveryHeader) != rc.requests[2].Header.Get(webhooksvc.DeliveryHeader) {
+		t.Fatalf("expected retries to keep the delivery ID")
+	}
+
+	dead, err := svc.GetDeadLetters(ctx, ownerID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(dead) != 1 || dead[0].SubscriptionID != sub.ID || len(dead[0].Attempts) != 3 || dead[0].Attempts[2].StatusCode != http.StatusInternalServerError {
+		t.Fatalf("expected a dead-lettered delivery with 3 attempts got %+v", dead)
+	}
+	if err := svc.Deliver(ctx, now.Add(time.Hour)); err != nil || len(rc.requests) != 3 {
+		t.Fatalf("expected no further attempts got %d requests", len(rc.requests))
+	}
+
+	rc.statuses = []int{http.StatusOK}
+	if err := svc.Redeliver(ctx, ownerID, dead[0].ID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if err := svc.Redeliver(ctx, ownerID, dead[0].ID); !errors.Is(err, webhooksvc.ErrNotDeadLettered) {
+		t.Fatalf("expected ErrNotDeadLettered got %v", err)
+	}
+	if err := svc.Deliver(ctx, time.Now().UTC()); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	ds, _ := svc.GetDeliveries(ctx, ownerID, sub.ID)
+	if len(ds) != 1 || ds[0].Status != webhook.Succeeded || len(ds[0].Attempts) != 4 {
+		t.Fatalf("expected the redelivery to succeed got %+v", ds)
+	}
+}
+
+func TestWebhook_DeliversConcurrentlyAndOnce(t *testing.T) {
+	ctx := context.Background()
+	chatID := uuid.New()
+	n := testConfig.Workers
+	owners := make([]uuid.UUID, n)
+	for i := range owners {
+		owners[i] = uuid.New()
+	}
+
+	// Every request waits for all n to arrive, which only happens when the
+	// subscriptions are delivered to at once.
+	var (
+		mu      sync.Mutex
+		arrived = make(chan struct{})
+		sent    = make(map[string]int)
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		sent[r.Header.Get(webhooksvc.DeliveryHeader)]++
+		if len(sent) == n {
+			close(arrived)
+		}
+		mu.Unlock()
+		select {
+		case <-arrived:
+		case <-time.After(testConfig.Timeout / 2):
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	svc := webhooksvc.NewService(inmemwebhookrepo.New(), mocks.NewChatService(t), testConfig)
+	subs := make([]webhook.Subscription, n)
+	for i, ownerID := range owners {
+		sub, err := svc.Subscribe(ctx, webhooksvc.CreateSubscriptionInput{OwnerID: ownerID, URL: srv.URL})
+		if err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+		subs[i] = sub
+	}
+	if err := svc.HandleEvent(ctx, messageEvent(chatID, owners[0], owners...)); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	now := time.Now().UTC()
+	var wg sync.WaitGroup
+	errs := make([]error, 3)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = svc.Deliver(ctx, now)
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+	}
+	if len(sent) != n {
+		t.Fatalf("expected %d deliveries sent got %d", n, len(sent))
+	}
+	for id, c := range sent {
+		if c != 1 {
+			t.Fatalf("expected delivery %s sent once got %d", id, c)
+		}
+	}
+	for i, sub := range subs {
+		ds, _ := svc.GetDeliveries(ctx, owners[i], sub.ID)
+		for _, d := range ds {
+			if d.Status != webhook.Succeeded {
+				t.Fatalf("expected every delivery to succeed got %+v", d)
+			}
+		}
+	}
+}
+
+func TestWebhook_DeliversInOrder(t *testing.T) {
+	ctx := context.Background()
+	ownerID, chatID := uuid.New(), uuid.New()
+	rc := &receiver{statuses: []int{http.StatusOK}}
+	srv := httptest.NewServer(rc)
+	defer srv.Close()
+
+	svc := webhooksvc.NewService(inmemwebhookrepo.New(), mocks.NewChatService(t), testConfig)
+	if _, err := svc.Subscribe(ctx, webhooksvc.CreateSubscriptionInput{OwnerID: ownerID, URL: srv.URL}); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	var expected []uuid.UUID
+	start := time.Now().UTC().Add(-time.Minute)
+	for i := range 2 * testConfig.Workers {
+		e := messageEvent(chatID, ownerID, ownerID)
+		e.Timestamp = start.Add(time.Duration(i) * time.Millisecond)
+		if err := svc.HandleEvent(ctx, e); err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+		expected = append(expected, e.ID)
+	}
+
+	if err := svc.Deliver(ctx, time.Now().UTC()); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(rc.bodies) != len(expected) {
+		t.Fatalf("expected %d deliveries got %d", len(expected), len(rc.bodies))
+	}
+	for i, body := range rc.bodies {
+		var p webhooksvc.Payload
+		if err := json.Unmarshal(body, &p); err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+		if p.ID != expected[i] {
+			t.Fatalf("delivery %d: expected event %v got %v", i, expected[i], p.ID)
+		}
+	}
+}
+
+func TestWebhook_RedactDeletedMessage(t *testing.T) {
+	ctx := context.Background()
+	ownerID, chatID := uuid.New(), uuid.New()
+	rc := &receiver{statuses: []int{http.StatusOK}}
+	srv := httptest.NewServer(rc)
+	defer srv.Close()
+
+	webhookRepo := inmemwebhookrepo.New()
+	svc := webhooksvc.NewService(webhookRepo, mocks.NewChatService(t), testConfig)
+	sub, err := svc.Subscribe(ctx, webhooksvc.CreateSubscriptionInput{OwnerID: ownerID, URL: srv.URL})
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	created := messageEvent(chatID, ownerID, ownerID)
+	kept := messageEvent(chatID, ownerID, ownerID)
+	for _, e := range []events.Event{created, kept} {
+		if err := svc.HandleEvent(ctx, e); err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+	}
+	now := time.Now().UTC()
+	if err := svc.Deliver(ctx, now); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	m := created.Message
+	deleted := events.Event{
+		ID:         uuid.New(),
+		Type:       events.MessageDeleted,
+		ChatID:     chatID,
+		UserID:     ownerID,
+		Recipients: []uuid.UUID{ownerID},
+		Message:    &message.Message{ID: m.ID, SenderID: m.SenderID, ChatID: chatID, ExpiresAt: now},
+		Timestamp:  now,
+	}
+	if err := svc.HandleEvent(ctx, deleted); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	ds, err := webhookRepo.GetDeliveries(ctx, sub.ID)
+	if err != nil || len(ds) != 3 {
+		t.Fatalf("expected 3 deliveries got %+v, %v", ds, err)
+	}
+	for _, d := range ds {
+		var p webhooksvc.Payload
+		if err := json.Unmarshal(d.Payload, &p); err != nil {
+			t.Fatalf("expected a JSON payload got %v", err)
+		}
+		switch p.ID {
+		case created.ID:
+			if p.Message == nil || p.Message.ID != m.ID || p.Message.Text != "" || p.Message.Type != "" {
+				t.Fatalf("expected the deleted message's content redacted got %+v", p.Message)
+			}
+		case kept.ID:
+			if p.Message == nil || p.Message.Text != "hello" {
+				t.Fatalf("expected other messages untouched got %+v", p.Message)
+			}
+		}
+	}
+}
+
+func TestWebhook_DeliversOnlyToPublicAddresses(t *testing.T) {
+	ctx := context.Background()
+	ownerID, chatID := uuid.New(), uuid.New()
+	rc := &receiver{statuses: []int{http.StatusOK}}
+	srv := httptest.NewServer(rc)
+	defer srv.Close()
+
+	svc := webhooksvc.NewService(inmemwebhookrepo.New(), mocks.NewChatService(t), webhooksvc.DefaultConfig)
+	for _, u := range []string{srv.URL, "http://10.0.0.1/hook", "http://[::1]/hook", "http://169.254.169.254/latest/meta-data"} {
+		if _, err := svc.Subscribe(ctx, webhooksvc.CreateSubscriptionInput{OwnerID: ownerID, URL: u}); !errors.Is(err, webhooksvc.ErrBlockedAddress) {
+			t.Fatalf("expected %v for %s got %v", webhooksvc.ErrBlockedAddress, u, err)
+		}
+	}
+
+	// A host name is only resolved when delivering.
+	sub, err := svc.Subscribe(ctx, webhooksvc.CreateSubscriptionInput{OwnerID: ownerID, URL: strings.Replace(srv.URL, "127.0.0.1", "localhost", 1)})
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if err := svc.HandleEvent(ctx, messageEvent(chatID, ownerID, ownerID)); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if err := svc.Deliver(ctx, time.Now().UTC()); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	if len(rc.requests) != 0 {
+		t.Fatalf("expected nothing delivered to a loopback address got %d requests", len(rc.requests))
+	}
+	ds, _ := svc.GetDeliveries(ctx, ownerID, sub.ID)
+	if len(ds) != 1 || len(ds[0].Attempts) != 1 || !strings.Contains(ds[0].Attempts[0].Error, webhooksvc.ErrBlockedAddress.Error()) {
+		t.Fatalf("expected a failed attempt at a blocked address got %+v", ds)
+	}
+}
+
+func TestWebhook_CapsRedirects(t *testing.T) {
+	ctx := context.Background()
+	ownerID, chatID := uuid.New(), uuid.New()
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		http.Redirect(w, r, r.URL.String(), http.StatusTemporaryRedirect)
+	}))
+	defer srv.Close()
+
+	svc := webhooksvc.NewService(inmemwebhookrepo.New(), mocks.NewChatService(t), testConfig)
+	sub, err := svc.Subscribe(ctx, webhooksvc.CreateSubscriptionInput{OwnerID: ownerID, URL: srv.URL})
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if err := svc.HandleEvent(ctx, messageEvent(chatID, ownerID, ownerID)); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if err := svc.Deliver(ctx, time.Now().UTC()); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	if requests != testConfig.MaxRedirects+1 {
+		t.Fatalf("expected the request and %d redirects got %d requests", testConfig.MaxRedirects, requests)
+	}
+	ds, _ := svc.GetDeliveries(ctx, ownerID, sub.ID)
+	if len(ds) != 1 || len(ds[0].Attempts) != 1 || !strings.Contains(ds[0].Attempts[0].Error, webhooksvc.ErrTooManyRedirects.Error()) {
+		t.Fatalf("expected a failed attempt got %+v", ds)
+	}
+}
+
+func TestWebhook_Filters(t *testing.T) {
+	ctx := context.Background()
+	ownerID, otherID, chatID, otherChatID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	mockChats := mocks.NewChatService(t)
+	mockChats.EXPECT().GetChat(mock.Anything, chatID, ownerID).Return(chat.Chat{ID: chatID}, nil)
+	webhookRepo := inmemwebhookrepo.New()
+	svc := webhooksvc.NewService(webhookRepo, mockChats, testConfig)
+
+	chatSub, err := svc.Subscribe(ctx, webhooksvc.CreateSubscriptionInput{OwnerID: ownerID, ChatID: chatID, URL: "https://example.com/chat"})
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	deleteSub, err := svc.Subscribe(ctx, webhooksvc.CreateSubscriptionInput{OwnerID: ownerID, URL: "https://example.com/deleted", EventTypes: []events.Type{events.MessageDeleted}})
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	for _, e := range []events.Event{
+		messageEvent(chatID, otherID, ownerID, otherID),
+		messageEvent(otherChatID, otherID, ownerID, otherID),
+		messageEvent(chatID, otherID, otherID),
+		{ID: uuid.New(), Type: events.MessageDeleted, ChatID: otherChatID, Recipients: []uuid.UUID{ownerID}, Timestamp: time.Now()},
+	} {
+		if err := svc.HandleEvent(ctx, e); err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+	}
+
+	for _, tt := range []struct {
+		sub  webhook.Subscription
+		want int
+	}{{chatSub, 1}, {deleteSub, 1}} {
+		ds, err := svc.GetDeliveries(ctx, ownerID, tt.sub.ID)
+		if err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+		if len(ds) != tt.want {
+			t.Fatalf("expected %d deliveries to %s got %d", tt.want, tt.sub.URL, len(ds))
+		}
+	}
+
+	if err := svc.Unsubscribe(ctx, ownerID, chatSub.ID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if ds, _ := webhookRepo.GetDeliveries(ctx, chatSub.ID); len(ds) != 0 {
+		t.Fatalf("expected the deliveries to be removed got %+v", ds)
+	}
+	subs, _ := svc.GetSubscriptions(ctx, ownerID)
+	if len(subs) != 1 || subs[0].ID != deleteSub.ID || subs[0].Secret != "" {
+		t.Fatalf("expected the remaining subscription without its secret got %+v", subs)
+	}
+}
+
+func TestWebhook_Subscribe_Rejects(t *testing.T) {
+	ownerID, chatID := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name    string
+		in      webhooksvc.CreateSubscriptionInput
+		setup   func(chats *mocks.ChatService)
+		wantErr error
+	}{
+		{name: "relative url", in: webhooksvc.CreateSubscriptionInput{OwnerID: ownerID, URL: "/hooks"}, wantErr: webhooksvc.ErrInvalidURL},
+		{name: "unsupported scheme", in: webhooksvc.CreateSubscriptionInput{OwnerID: ownerID, URL: "ftp://example.com"}, wantErr: webhooksvc.ErrInvalidURL},
+		{name: "unknown event type", in: webhooksvc.CreateSubscriptionInput{OwnerID: ownerID, URL: "https://example.com", EventTypes: []events.Type{"message.read"}}, wantErr: webhooksvc.ErrUnknownEventType},
+		{
+			name: "not a member",
+			in:   webhooksvc.CreateSubscriptionInput{OwnerID: ownerID, ChatID: chatID, URL: "https://example.com"},
+			setup: func(chats *mocks.ChatService) {
+				chats.EXPECT().GetChat(mock.Anything, chatID, ownerID).Return(chat.Chat{}, chatsvc.ErrMemberNotFound)
+			},
+			wantErr: chatsvc.ErrMemberNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockChats := mocks.NewChatService(t)
+			if tt.setup != nil {
+				tt.setup(mockChats)
+			}
+
+			svc := webhooksvc.NewService(inmemwebhookrepo.New(), mockChats, testConfig)
+			_, err := svc.Subscribe(context.Background(), tt.in)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestWebhook_HidesOtherUsersSubscriptions(t *testing.T) {
+	ctx := context.Background()
+	ownerID := uuid.New()
+	svc := webhooksvc.NewService(inmemwebhookrepo.New(), mocks.NewChatService(t), testConfig)
+	sub, err := svc.Subscribe(ctx, webhooksvc.CreateSubscriptionInput{OwnerID: ownerID, URL: "https://example.com"})
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	if _, err := svc.GetDeliveries(ctx, uuid.New(), sub.ID); !errors.Is(err, repo.ErrSubscriptionNotFound) {
+		t.Fatalf("expected ErrSubscriptionNotFound got %v", err)
+	}
+	if err := svc.Unsubscribe(ctx, uuid.New(), sub.ID); !errors.Is(err, repo.ErrSubscriptionNotFound) {
+		t.Fatalf("expected ErrSubscriptionNotFound got %v", err)
+	}
+}
+
+func TestWebhook_UnsubscribeAll(t *testing.T) {
+	ctx := context.Background()
+	ownerID, otherID, chatID := uuid.New(), uuid.New(), uuid.New()
+	webhookRepo := inmemwebhookrepo.New()
+	svc := webhooksvc.NewService(webhookRepo, mocks.NewChatService(t), testConfig)
+	var subs []webhook.Subscription
+	for _, id := range []uuid.UUID{ownerID, ownerID, otherID} {
+		sub, err := svc.Subscribe(ctx, webhooksvc.CreateSubscriptionInput{OwnerID: id, URL: "https://example.com"})
+		if err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+		subs = append(subs, sub)
+	}
+	if err := svc.HandleEvent(ctx, messageEvent(chatID, otherID, ownerID, otherID)); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	if err := svc.UnsubscribeAll(ctx, ownerID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if got, _ := svc.GetSubscriptions(ctx, ownerID); len(got) != 0 {
+		t.Fatalf("expected no subscriptions left got %+v", got)
+	}
+	for _, sub := range subs[:2] {
+		if ds, _ := webhookRepo.GetDeliveries(ctx, sub.ID); len(ds) != 0 {
+			t.Fatalf("expected the deliveries deleted got %+v", ds)
+		}
+	}
+	if ds, _ := svc.GetDeliveries(ctx, otherID, subs[2].ID); len(ds) != 1 {
+		t.Fatalf("expected other owners' deliveries kept got %+v", ds)
+	}
+}
@@ -0,0 +1,47 @@
+package webhooksvc_test
+
+import (
+	"errors"
+	"github.com/AliUnipal/chat/internal/service/webhooksvc"
+	"testing"
+	"time"
+)
+
+func TestSignature(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	payload := []byte(`{"id":"1"}`)
+	header := webhooksvc.Sign("secret", now, payload)
+
+	tests := []struct {
+		name    string
+		secret  string
+		header  string
+		payload []byte
+		now     time.Time
+		wantErr error
+	}{
+		{name: "valid", secret: "secret", header: header, payload: payload, now: now.Add(time.Minute)},
+		{name: "wrong secret", secret: "other", header: header, payload: payload, now: now, wantErr: webhooksvc.ErrInvalidSignature},
+		{name: "tampered payload", secret: "secret", header: header, payload: []byte(`{"id":"2"}`), now: now, wantErr: webhooksvc.ErrInvalidSignature},
+		{name: "malformed", secret: "secret", header: "v1=abc", payload: payload, now: now, wantErr: webhooksvc.ErrInvalidSignature},
+		{name: "replayed", secret: "secret", header: header, payload: payload, now: now.Add(10 * time.Minute), wantErr: webhooksvc.ErrSignatureExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := webhooksvc.Verify(tt.secret, tt.header, tt.payload, tt.now, 5*time.Minute)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestConfig_Backoff(t *testing.T) {
+	c := webhooksvc.Config{BaseDelay: time.Second, MaxDelay: 10 * time.Second}
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second}
+	for i, w := range want {
+		if got := c.Backoff(i + 1); got != w {
+			t.Fatalf("expected %v after %d failures got %v", w, i+1, got)
+		}
+	}
+}
@@ -0,0 +1,1873 @@
+package service_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/AliUnipal/chat/internal/commands"
+	"github.com/AliUnipal/chat/internal/e2ee"
+	"github.com/AliUnipal/chat/internal/events"
+	"github.com/AliUnipal/chat/internal/kms"
+	"github.com/AliUnipal/chat/internal/linkpreview"
+	"github.com/AliUnipal/chat/internal/models/chat"
+	"github.com/AliUnipal/chat/internal/models/export"
+	"github.com/AliUnipal/chat/internal/models/message"
+	"github.com/AliUnipal/chat/internal/models/report"
+	"github.com/AliUnipal/chat/internal/models/user"
+	"github.com/AliUnipal/chat/internal/moderation"
+	"github.com/AliUnipal/chat/internal/ratelimit"
+	"github.com/AliUnipal/chat/internal/service/accountsvc"
+	"github.com/AliUnipal/chat/internal/service/botsvc"
+	"github.com/AliUnipal/chat/internal/service/botsvc/repo/inmembotrepo"
+	"github.com/AliUnipal/chat/internal/service/chatsvc"
+	chatrepo "github.com/AliUnipal/chat/internal/service/chatsvc/repo"
+	"github.com/AliUnipal/chat/internal/service/chatsvc/repo/inmemchatrepo"
+	"github.com/AliUnipal/chat/internal/service/exportsvc"
+	exportrepo "github.com/AliUnipal/chat/internal/service/exportsvc/repo"
+	"github.com/AliUnipal/chat/internal/service/exportsvc/repo/inmemexportrepo"
+	"github.com/AliUnipal/chat/internal/service/importsvc"
+	"github.com/AliUnipal/chat/internal/service/msgsvc"
+	msgrepo "github.com/AliUnipal/chat/internal/service/msgsvc/repo"
+	"github.com/AliUnipal/chat/internal/service/msgsvc/repo/encryptedmessagerepo"
+	"github.com/AliUnipal/chat/internal/service/msgsvc/repo/filescheduledrepo"
+	"github.com/AliUnipal/chat/internal/service/msgsvc/repo/inmemdatakeyrepo"
+	"github.com/AliUnipal/chat/internal/service/msgsvc/repo/inmemmessagerepo"
+	"github.com/AliUnipal/chat/internal/service/presencesvc"
+	"github.com/AliUnipal/chat/internal/service/presencesvc/repo/inmempresencerepo"
+	"github.com/AliUnipal/chat/internal/service/previewsvc"
+	"github.com/AliUnipal/chat/internal/service/previewsvc/repo/inmempreviewrepo"
+	"github.com/AliUnipal/chat/internal/service/reportsvc"
+	"github.com/AliUnipal/chat/internal/service/reportsvc/repo/inmemreportrepo"
+	"github.com/AliUnipal/chat/internal/service/usersvc"
+	"github.com/AliUnipal/chat/internal/service/usersvc/repo/inmemuserrepo"
+	"github.com/AliUnipal/chat/internal/service/webhooksvc"
+	"github.com/AliUnipal/chat/internal/service/webhooksvc/repo/inmemwebhookrepo"
+	"github.com/google/uuid"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type userCreator interface {
+	CreateUser(ctx context.Context, in usersvc.CreateUserInput) (uuid.UUID, error)
+	AddContact(ctx context.Context, userID, contactID uuid.UUID) error
+}
+
+func createUser(t *testing.T, users userCreator, firstName, username string) uuid.UUID {
+	t.Helper()
+	id, err := users.CreateUser(context.Background(), usersvc.CreateUserInput{
+		ImageURL:  "https://" + strings.ToLower(firstName) + ".png",
+		FirstName: firstName,
+		Username:  username,
+	})
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	return id
+}
+
+// addContacts puts the two users in each other's contact list, so chats
+// between them skip the message request inbox.
+func addContacts(t *testing.T, users userCreator, userA, userB uuid.UUID) {
+	t.Helper()
+	if err := users.AddContact(context.Background(), userA, userB); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if err := users.AddContact(context.Background(), userB, userA); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+}
+
+// The services and repositories of a stack, as the tests and the services
+// they build on top of the stack use them.
+type (
+	userService interface {
+		CreateUser(ctx context.Context, in usersvc.CreateUserInput) (uuid.UUID, error)
+		GetUser(ctx context.Context, id uuid.UUID) (user.User, error)
+		GetUserByUsername(ctx context.Context, username string) (user.User, error)
+		BlockUser(ctx context.Context, userID, blockedID uuid.UUID) error
+		UnblockUser(ctx context.Context, userID, blockedID uuid.UUID) error
+		GetBlockedUsers(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+		AddContact(ctx context.Context, userID, contactID uuid.UUID) error
+		RemoveContact(ctx context.Context, userID, contactID uuid.UUID) error
+		GetContacts(ctx context.Context, userID uuid.UUID) ([]user.User, error)
+		IsContact(ctx context.Context, userID, contactID uuid.UUID) (bool, error)
+		GetPrivacySettings(ctx context.Context, userID uuid.UUID) (user.PrivacySettings, error)
+		UpdatePrivacySettings(ctx context.Context, userID uuid.UUID, settings user.PrivacySettings) error
+		CanStartChat(ctx context.Context, senderID, recipientID uuid.UUID) error
+		CanMessage(ctx context.Context, senderID, recipientID uuid.UUID) error
+		CanSeeProfileImage(ctx context.Context, viewerID, ownerID uuid.UUID) (bool, error)
+		CanSeeLastSeen(ctx context.Context, viewerID, ownerID uuid.UUID) (bool, error)
+		PublishKeys(ctx context.Context, userID uuid.UUID, keys user.IdentityKeys) error
+		AddOneTimePreKeys(ctx context.Context, userID uuid.UUID, keys []user.OneTimePreKey) error
+		CountOneTimePreKeys(ctx context.Context, userID uuid.UUID) (int, error)
+		HasKeys(ctx context.Context, userID uuid.UUID) (bool, error)
+		GetPreKeyBundle(ctx context.Context, requesterID, userID uuid.UUID) (user.PreKeyBundle, error)
+		GetAccountState(ctx context.Context, userID uuid.UUID) (user.AccountState, error)
+		SuspendUser(ctx context.Context, userID uuid.UUID, reason string, until time.Time) error
+		UnsuspendUser(ctx context.Context, userID uuid.UUID) error
+		DeactivateUser(ctx context.Context, userID uuid.UUID) error
+		ReactivateUser(ctx context.Context, userID uuid.UUID) error
+		DeleteUser(ctx context.Context, userID uuid.UUID) error
+		CreateBot(ctx context.Context, ownerID uuid.UUID, in usersvc.CreateUserInput) (uuid.UUID, string, error)
+		GetBots(ctx context.Context, ownerID uuid.UUID) ([]user.User, error)
+		CreateImportedUser(ctx context.Context, ownerID uuid.UUID, in usersvc.CreateUserInput) (uuid.UUID, error)
+		RotateBotToken(ctx context.Context, ownerID, botID uuid.UUID) (string, error)
+		AuthenticateBot(ctx context.Context, token string) (uuid.UUID, error)
+	}
+	chatService interface {
+		CreateChat(ctx context.Context, currentUserID, otherUserID uuid.UUID) (uuid.UUID, error)
+		GetChat(ctx context.Context, id, userID uuid.UUID) (chat.Chat, error)
+		FindDirectChat(ctx context.Context, userA, userB uuid.UUID) (chat.Chat, error)
+		GetChats(ctx context.Context, in chatsvc.GetChatsInput) (chatsvc.ChatsPage, error)
+		SetNickname(ctx context.Context, chatID, userID uuid.UUID, nickname string) error
+		PinChat(ctx context.Context, chatID, userID uuid.UUID) error
+		UnpinChat(ctx context.Context, chatID, userID uuid.UUID) error
+		ReorderPinnedChats(ctx context.Context, userID uuid.UUID, chatIDs []uuid.UUID) error
+		ArchiveChat(ctx context.Context, chatID, userID uuid.UUID) error
+		UnarchiveChat(ctx context.Context, chatID, userID uuid.UUID) error
+		MuteChat(ctx context.Context, chatID, userID uuid.UUID, until time.Time) error
+		UnmuteChat(ctx context.Context, chatID, userID uuid.UUID) error
+		ReadMentions(ctx context.Context, chatID, userID uuid.UUID) error
+		DeleteChatForMe(ctx context.Context, chatID, userID uuid.UUID) error
+		DeleteChatForEveryone(ctx context.Context, chatID, userID uuid.UUID) error
+		GetChatIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+		RemoveUser(ctx context.Context, userID uuid.UUID) error
+		AcceptRequest(ctx context.Context, chatID, userID uuid.UUID) error
+		DeclineRequest(ctx context.Context, chatID, userID uuid.UUID) error
+		BlockRequest(ctx context.Context, chatID, userID uuid.UUID) error
+		EnableEncryption(ctx context.Context, chatID, userID uuid.UUID) error
+		SetDisappearingMessages(ctx context.Context, chatID, userID uuid.UUID, after time.Duration) error
+		AddBot(ctx context.Context, chatID, userID, botID uuid.UUID) error
+		AcceptBot(ctx context.Context, chatID, userID, botID uuid.UUID) error
+		DeclineBot(ctx context.Context, chatID, userID, botID uuid.UUID) error
+		RemoveBot(ctx context.Context, chatID, userID, botID uuid.UUID) error
+		HandleEvent(ctx context.Context, e events.Event) error
+		RecordImport(ctx context.Context, chatID uuid.UUID, lastMessageAt time.Time) error
+	}
+	messageService interface {
+		CreateMessage(ctx context.Context, in msgsvc.MessageInput) (uuid.UUID, error)
+		GetMessages(ctx context.Context, chatID, userID uuid.UUID) ([]message.Message, error)
+		GetMentions(ctx context.Context, userID uuid.UUID) ([]message.Message, error)
+		RemoveMessage(ctx context.Context, chatID, id uuid.UUID) error
+		ReviewMessage(ctx context.Context, chatID, id, reviewerID uuid.UUID, release bool) error
+		RemoveMessagesBySender(ctx context.Context, chatID, senderID uuid.UUID) error
+		StartTyping(ctx context.Context, chatID, userID uuid.UUID) error
+		StopTyping(ctx context.Context, chatID, userID uuid.UUID) error
+		SubscribeTyping(ctx context.Context, userID uuid.UUID) (<-chan message.TypingEvent, func())
+		GetCommands(ctx context.Context, chatID, userID uuid.UUID) ([]commands.Command, error)
+		EnableCommand(ctx context.Context, chatID, userID uuid.UUID, name string) error
+		DisableCommand(ctx context.Context, chatID, userID uuid.UUID, name string) error
+		ScheduleMessage(ctx context.Context, in msgsvc.MessageInput, sendAt time.Time) (uuid.UUID, error)
+		GetScheduledMessages(ctx context.Context, userID uuid.UUID) ([]message.ScheduledMessage, error)
+		EditScheduledMessage(ctx context.Context, userID, id uuid.UUID, content []byte, sendAt time.Time) error
+		CancelScheduledMessage(ctx context.Context, userID, id uuid.UUID) error
+		CancelScheduledMessages(ctx context.Context, userID uuid.UUID) error
+		SendScheduledMessages(ctx context.Context) error
+		DeleteExpiredMessages(ctx context.Context) error
+	}
+	chatStore interface {
+		GetChat(ctx context.Context, id uuid.UUID) (chatrepo.Chat, error)
+		GetMember(ctx context.Context, chatID, userID uuid.UUID) (chatrepo.Member, error)
+		GetMembers(ctx context.Context, chatID uuid.UUID) ([]chatrepo.Member, error)
+		GetChatsByUser(ctx context.Context, userID uuid.UUID) ([]chatrepo.UserChat, error)
+		UseRequestMessage(ctx context.Context, chatID, senderID uuid.UUID) error
+	}
+	messageStore interface {
+		CreateMessage(ctx context.Context, in msgrepo.CreateMessageInput) error
+		GetMessage(ctx context.Context, id, chatID uuid.UUID) (msgrepo.Message, error)
+		GetMessages(ctx context.Context, chatID uuid.UUID) ([]msgrepo.Message, error)
+		GetLastMessages(ctx context.Context, chatIDs []uuid.UUID, now time.Time) (map[uuid.UUID]msgrepo.Message, error)
+		GetMentions(ctx context.Context, userID uuid.UUID, since map[uuid.UUID]time.Time) ([]msgrepo.Message, error)
+		CountMentions(ctx context.Context, userID uuid.UUID, since map[uuid.UUID]time.Time, now time.Time) (map[uuid.UUID]msgrepo.MentionCount, error)
+		SetPreviews(ctx context.Context, chatID, id uuid.UUID, previews []message.Preview) error
+		ReleaseMessage(ctx context.Context, chatID, id uuid.UUID) error
+		DeleteMessage(ctx context.Context, chatID, id uuid.UUID) error
+		DeleteMessagesBySender(ctx context.Context, chatID, senderID uuid.UUID) error
+		DeleteMessages(ctx context.Context, chatID uuid.UUID) error
+		DeleteMessagesBefore(ctx context.Context, chatID uuid.UUID, before time.Time) error
+		DeleteExpiredMessages(ctx context.Context, now time.Time) ([]msgrepo.Message, error)
+	}
+	scheduledStore interface {
+		CreateScheduledMessage(ctx context.Context, m msgrepo.ScheduledMessage) error
+		GetScheduledMessage(ctx context.Context, id uuid.UUID) (msgrepo.ScheduledMessage, error)
+		GetScheduledMessages(ctx context.Context, senderID uuid.UUID) ([]msgrepo.ScheduledMessage, error)
+		GetDueScheduledMessages(ctx context.Context, now time.Time) ([]msgrepo.ScheduledMessage, error)
+		UpdateScheduledMessage(ctx context.Context, m msgrepo.ScheduledMessage) error
+		DeleteScheduledMessage(ctx context.Context, id uuid.UUID) error
+	}
+)
+
+// stack is the user, chat and message services wired together over
+// in-memory repositories, the way the server wires them. Tests build the
+// other services they need on top of it.
+type stack struct {
+	bus      *events.Bus
+	chatRepo chatStore
+	// msgRepo encrypts message content at rest in storedMsgs.
+	msgRepo    messageStore
+	storedMsgs messageStore
+	users      userService
+	chats      chatService
+	msgs       messageService
+	// commands is the registry msgs runs slash commands from. None are
+	// registered.
+	commands *commands.Registry
+	// scheduledPath is the file msgs keeps scheduled messages in, and
+	// openScheduled reads it back encrypted like msgRepo.
+	scheduledPath string
+	openScheduled func() (scheduledStore, error)
+	// now is the time msgs sees. It follows the wall clock while zero.
+	now time.Time
+}
+
+func newStack(t *testing.T) *stack {
+	t.Helper()
+	userRepo := inmemuserrepo.New()
+	chatRepo := inmemchatrepo.New(userRepo)
+	storedMsgs := inmemmessagerepo.New(chatRepo, nil)
+	masterKey, err := kms.NewKeyfile("k1", map[string][]byte{"k1": bytes.Repeat([]byte{1}, 32)})
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	msgRepo := encryptedmessagerepo.New(storedMsgs, inmemdatakeyrepo.New(), masterKey)
+
+	s := &stack{
+		bus:           events.NewBus(),
+		chatRepo:      chatRepo,
+		msgRepo:       msgRepo,
+		storedMsgs:    storedMsgs,
+		commands:      commands.NewRegistry(commands.NewMemoryStore()),
+		scheduledPath: filepath.Join(t.TempDir(), "scheduled.json"),
+	}
+	s.openScheduled = func() (scheduledStore, error) {
+		file, err := filescheduledrepo.New(s.scheduledPath)
+		if err != nil {
+			return nil, err
+		}
+		return encryptedmessagerepo.NewScheduled(file, msgRepo), nil
+	}
+	s.users = usersvc.NewService(userRepo)
+	s.chats = chatsvc.NewService(chatRepo, msgRepo, s.users, s.bus)
+	s.bus.Subscribe(func(ctx context.Context, e events.Event) {
+		if err := s.chats.HandleEvent(ctx, e); err != nil {
+			t.Errorf("expected no error got %v", err)
+		}
+	})
+	s.msgs = s.newMessageService(t)
+
+	return s
+}
+
+// newMessageService builds a message service over the stack, reading the
+// scheduled messages back from disk like a restarted server.
+func (s *stack) newMessageService(t *testing.T) messageService {
+	t.Helper()
+	scheduled, err := s.openScheduled()
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	clock := func() time.Time {
+		if s.now.IsZero() {
+			return time.Now()
+		}
+		return s.now
+	}
+
+	return msgsvc.NewService(s.msgRepo, s.chatRepo, s.users, ratelimit.New(ratelimit.NewMemoryStore(), ratelimit.DefaultConfig), moderation.NewPipeline(moderation.NewMemoryStore()), s.bus, s.commands, scheduled, clock)
+}
+
+func TestWiring_UserChatMessage(t *testing.T) {
+	ctx := context.Background()
+
+	s := newStack(t)
+
+	aliceID := createUser(t, s.users, "Alice", "+97311111111")
+	bobID := createUser(t, s.users, "Bob", "+97322222222")
+	eveID := createUser(t, s.users, "Eve", "+97333333333")
+	addContacts(t, s.users, aliceID, bobID)
+	addContacts(t, s.users, aliceID, eveID)
+
+	chatID, err := s.chats.CreateChat(ctx, aliceID, bobID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	againID, err := s.chats.CreateChat(ctx, bobID, aliceID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if againID != chatID {
+		t.Fatalf("expected existing chat %v got %v", chatID, againID)
+	}
+
+	c, err := s.chats.GetChat(ctx, chatID, aliceID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if c.CurrentUser.ID != aliceID || c.OtherUser.ID != bobID || c.DisplayName != "Bob" {
+		t.Fatalf("expected alice's view of the chat with bob got %v", c)
+	}
+	if _, err := s.chats.GetChat(ctx, chatID, eveID); !errors.Is(err, chatsvc.ErrMemberNotFound) {
+		t.Fatalf("expected %v got %v", chatsvc.ErrMemberNotFound, err)
+	}
+
+	if err := s.chats.SetNickname(ctx, chatID, bobID, "Ally"); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if err := s.chats.MuteChat(ctx, chatID, bobID, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	bobChats, err := s.chats.GetChats(ctx, chatsvc.GetChatsInput{UserID: bobID})
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(bobChats.Chats) != 1 ||
+		bobChats.Chats[0].CurrentUser.ID != bobID ||
+		bobChats.Chats[0].OtherUser.ID != aliceID ||
+		bobChats.Chats[0].DisplayName != "Ally" ||
+		!bobChats.Chats[0].Muted {
+		t.Fatalf("expected bob's view of the chat with alice got %v", bobChats)
+	}
+	aliceChats, err := s.chats.GetChats(ctx, chatsvc.GetChatsInput{UserID: aliceID})
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(aliceChats.Chats) != 1 || aliceChats.Chats[0].DisplayName != "Bob" || aliceChats.Chats[0].Muted {
+		t.Fatalf("expected bob's settings not to leak into alice's view got %v", aliceChats)
+	}
+
+	direct, err := s.chats.FindDirectChat(ctx, bobID, aliceID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if direct.ID != chatID || direct.CurrentUser.ID != bobID {
+		t.Fatalf("expected chat %v got %v", chatID, direct.ID)
+	}
+	if _, err := s.chats.FindDirectChat(ctx, aliceID, eveID); !errors.Is(err, chatsvc.ErrChatNotFound) {
+		t.Fatalf("expected %v got %v", chatsvc.ErrChatNotFound, err)
+	}
+
+	if history, err := s.msgs.GetMessages(ctx, chatID, aliceID); err != nil || len(history) != 0 {
+		t.Fatalf("expected no messages got %v, %v", history, err)
+	}
+
+	msgID, err := s.msgs.CreateMessage(ctx, msgsvc.MessageInput{
+		SenderID:    bobID,
+		ChatID:      chatID,
+		Content:     []byte("Hello Alice"),
+		ContentType: message.TextContentType,
+	})
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	if _, err := s.msgs.CreateMessage(ctx, msgsvc.MessageInput{
+		SenderID:    eveID,
+		ChatID:      chatID,
+		Content:     []byte("Hello"),
+		ContentType: message.TextContentType,
+	}); err == nil {
+		t.Fatal("expected error for a sender outside the chat, got nil")
+	}
+	if _, err := s.msgs.CreateMessage(ctx, msgsvc.MessageInput{
+		SenderID:    aliceID,
+		ChatID:      uuid.New(),
+		Content:     []byte("Hello"),
+		ContentType: message.TextContentType,
+	}); !errors.Is(err, chatsvc.ErrChatNotFound) {
+		t.Fatalf("expected %v got %v", chatsvc.ErrChatNotFound, err)
+	}
+
+	got, err := s.msgs.GetMessages(ctx, chatID, aliceID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(got) != 1 || got[0].ID != msgID || got[0].SenderID != bobID || !bytes.Equal(got[0].Content, []byte("Hello Alice")) {
+		t.Fatalf("expected message %v got %v", msgID, got)
+	}
+	stored, err := s.storedMsgs.GetMessages(ctx, chatID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(stored) != 1 || bytes.Contains(stored[0].Content, []byte("Hello Alice")) {
+		t.Fatalf("expected the message encrypted at rest got %v", stored)
+	}
+
+	eveChatID, err := s.chats.CreateChat(ctx, eveID, aliceID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	aliceChats, err = s.chats.GetChats(ctx, chatsvc.GetChatsInput{UserID: aliceID})
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(aliceChats.Chats) != 2 || aliceChats.Chats[0].ID != eveChatID || aliceChats.Chats[0].LastMessage != nil {
+		t.Fatalf("expected the new chat with eve first got %v", aliceChats)
+	}
+
+	if _, err := s.msgs.CreateMessage(ctx, msgsvc.MessageInput{
+		SenderID:    aliceID,
+		ChatID:      chatID,
+		Content:     []byte("Hi Bob"),
+		ContentType: message.TextContentType,
+	}); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	aliceChats, err = s.chats.GetChats(ctx, chatsvc.GetChatsInput{UserID: aliceID})
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(aliceChats.Chats) != 2 ||
+		aliceChats.Chats[0].ID != chatID ||
+		aliceChats.Chats[0].LastMessage == nil ||
+		aliceChats.Chats[0].LastMessage.Text != "Hi Bob" {
+		t.Fatalf("expected the chat with bob first with a preview got %v", aliceChats)
+	}
+}
+
+func TestWiring_DeleteChat(t *testing.T) {
+	ctx := context.Background()
+
+	s := newStack(t)
+
+	aliceID := createUser(t, s.users, "Alice", "+97311111111")
+	bobID := createUser(t, s.users, "Bob", "+97322222222")
+	addContacts(t, s.users, aliceID, bobID)
+
+	chatID, err := s.chats.CreateChat(ctx, aliceID, bobID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	send := func(senderID uuid.UUID, text string) {
+		t.Helper()
+		if _, err := s.msgs.CreateMessage(ctx, msgsvc.MessageInput{
+			SenderID:    senderID,
+			ChatID:      chatID,
+			Content:     []byte(text),
+			ContentType: message.TextContentType,
+		}); err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+	}
+	countChats := func(userID uuid.UUID) int {
+		t.Helper()
+		page, err := s.chats.GetChats(ctx, chatsvc.GetChatsInput{UserID: userID})
+		if err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+		return len(page.Chats)
+	}
+	history := func(userID uuid.UUID) []string {
+		t.Helper()
+		got, err := s.msgs.GetMessages(ctx, chatID, userID)
+		if err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+		texts := make([]string, len(got))
+		for i, m := range got {
+			texts[i] = string(m.Content)
+		}
+		return texts
+	}
+
+	send(aliceID, "one")
+	if err := s.chats.DeleteChatForMe(ctx, chatID, bobID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if n := countChats(bobID); n != 0 {
+		t.Fatalf("expected the chat to be hidden from bob got %d chats", n)
+	}
+	if n := countChats(aliceID); n != 1 {
+		t.Fatalf("expected the chat to stay visible to alice got %d chats", n)
+	}
+	if got := history(bobID); len(got) != 0 {
+		t.Fatalf("expected no history for bob got %v", got)
+	}
+
+	time.Sleep(time.Millisecond)
+	send(aliceID, "two")
+	if n := countChats(bobID); n != 1 {
+		t.Fatalf("expected a new message to resurface the chat for bob got %d chats", n)
+	}
+	if got := history(bobID); len(got) != 1 || got[0] != "two" {
+		t.Fatalf("expected bob to see only the new message got %v", got)
+	}
+	if got := history(aliceID); len(got) != 2 {
+		t.Fatalf("expected alice to keep the full history got %v", got)
+	}
+
+	if err := s.chats.DeleteChatForMe(ctx, chatID, aliceID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if got, err := s.msgRepo.GetMessages(ctx, chatID); err != nil || len(got) != 1 || string(got[0].Content) != "two" {
+		t.Fatalf("expected only the history bob can still see to be kept got %v, %v", got, err)
+	}
+
+	if err := s.chats.DeleteChatForEveryone(ctx, chatID, bobID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if n := countChats(aliceID) + countChats(bobID); n != 0 {
+		t.Fatalf("expected the chat to be gone for everyone got %d chats", n)
+	}
+	if _, err := s.msgs.GetMessages(ctx, chatID, aliceID); !errors.Is(err, chatsvc.ErrChatNotFound) {
+		t.Fatalf("expected %v got %v", chatsvc.ErrChatNotFound, err)
+	}
+	if _, err := s.chats.FindDirectChat(ctx, aliceID, bobID); !errors.Is(err, chatsvc.ErrChatNotFound) {
+		t.Fatalf("expected %v got %v", chatsvc.ErrChatNotFound, err)
+	}
+	if _, err := s.chats.CreateChat(ctx, aliceID, bobID); err != nil {
+		t.Fatalf("expected the chat to be creatable again got %v", err)
+	}
+}
+
+func TestWiring_BlockAndPrivacy(t *testing.T) {
+	ctx := context.Background()
+
+	s := newStack(t)
+
+	aliceID := createUser(t, s.users, "Alice", "+97311111111")
+	bobID := createUser(t, s.users, "Bob", "+97322222222")
+	eveID := createUser(t, s.users, "Eve", "+97333333333")
+	if err := s.users.AddContact(ctx, bobID, aliceID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	chatID, err := s.chats.CreateChat(ctx, aliceID, bobID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if err := s.users.BlockUser(ctx, aliceID, bobID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if _, err := s.msgs.CreateMessage(ctx, msgsvc.MessageInput{
+		SenderID:    bobID,
+		ChatID:      chatID,
+		Content:     []byte("Hello"),
+		ContentType: message.TextContentType,
+	}); !errors.Is(err, usersvc.ErrBlocked) {
+		t.Fatalf("expected %v got %v", usersvc.ErrBlocked, err)
+	}
+	if err := s.users.UnblockUser(ctx, aliceID, bobID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if _, err := s.msgs.CreateMessage(ctx, msgsvc.MessageInput{
+		SenderID:    bobID,
+		ChatID:      chatID,
+		Content:     []byte("Hello"),
+		ContentType: message.TextContentType,
+	}); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	if err := s.users.UpdatePrivacySettings(ctx, aliceID, user.PrivacySettings{
+		StartChat:    user.ContactsOnly,
+		ProfileImage: user.ContactsOnly,
+	}); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if _, err := s.chats.CreateChat(ctx, eveID, aliceID); !errors.Is(err, usersvc.ErrChatNotAllowed) {
+		t.Fatalf("expected %v got %v", usersvc.ErrChatNotAllowed, err)
+	}
+	c, err := s.chats.GetChat(ctx, chatID, bobID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if c.OtherUser.ImageURL != "" {
+		t.Fatalf("expected alice's image to be hidden from bob got %v", c.OtherUser.ImageURL)
+	}
+
+	if err := s.users.AddContact(ctx, aliceID, eveID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	eveChatID, err := s.chats.CreateChat(ctx, eveID, aliceID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	c, err = s.chats.GetChat(ctx, eveChatID, eveID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if c.OtherUser.ImageURL != "https://alice.png" {
+		t.Fatalf("expected alice's image to be visible to a contact got %q", c.OtherUser.ImageURL)
+	}
+}
+
+func TestWiring_MessageRequests(t *testing.T) {
+	ctx := context.Background()
+
+	s := newStack(t)
+
+	aliceID := createUser(t, s.users, "Alice", "+97311111111")
+	bobID := createUser(t, s.users, "Bob", "+97322222222")
+	eveID := createUser(t, s.users, "Eve", "+97333333333")
+
+	send := func(chatID, senderID uuid.UUID) error {
+		_, err := s.msgs.CreateMessage(ctx, msgsvc.MessageInput{
+			SenderID:    senderID,
+			ChatID:      chatID,
+			Content:     []byte("Hello"),
+			ContentType: message.TextContentType,
+		})
+		return err
+	}
+	list := func(userID uuid.UUID, filter chatsvc.ChatFilter) []uuid.UUID {
+		t.Helper()
+		page, err := s.chats.GetChats(ctx, chatsvc.GetChatsInput{UserID: userID, Filter: filter})
+		if err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+		ids := make([]uuid.UUID, len(page.Chats))
+		for i, c := range page.Chats {
+			ids[i] = c.ID
+		}
+		return ids
+	}
+
+	bobChatID, err := s.chats.CreateChat(ctx, bobID, aliceID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if err := send(bobChatID, bobID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if err := send(bobChatID, bobID); !errors.Is(err, chatsvc.ErrRequestPending) {
+		t.Fatalf("expected %v got %v", chatsvc.ErrRequestPending, err)
+	}
+	// Removing the request does not let bob send another.
+	history, err := s.msgs.GetMessages(ctx, bobChatID, bobID)
+	if err != nil || len(history) != 1 {
+		t.Fatalf("expected bob's request got %v, %v", history, err)
+	}
+	if err := s.msgs.RemoveMessage(ctx, bobChatID, history[0].ID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if err := send(bobChatID, bobID); !errors.Is(err, chatsvc.ErrRequestPending) {
+		t.Fatalf("expected %v got %v", chatsvc.ErrRequestPending, err)
+	}
+	if err := send(bobChatID, aliceID); !errors.Is(err, chatsvc.ErrRequestPending) {
+		t.Fatalf("expected %v got %v", chatsvc.ErrRequestPending, err)
+	}
+	if got := list(aliceID, chatsvc.AllChats); len(got) != 0 {
+		t.Fatalf("expected the request to stay out of alice's chats got %v", got)
+	}
+	if got := list(aliceID, chatsvc.RequestChats); len(got) != 1 || got[0] != bobChatID {
+		t.Fatalf("expected bob's request in alice's requests got %v", got)
+	}
+	c, err := s.chats.GetChat(ctx, bobChatID, bobID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if c.Request != chat.OutgoingRequest {
+		t.Fatalf("expected an outgoing request for bob got %v", c.Request)
+	}
+
+	if err := s.chats.AcceptRequest(ctx, bobChatID, bobID); err == nil {
+		t.Fatal("expected the requester not to be able to accept, got nil")
+	}
+	if err := s.chats.AcceptRequest(ctx, bobChatID, aliceID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if got := list(aliceID, chatsvc.AllChats); len(got) != 1 || got[0] != bobChatID {
+		t.Fatalf("expected the accepted chat in alice's chats got %v", got)
+	}
+	if err := send(bobChatID, aliceID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if err := send(bobChatID, bobID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	eveChatID, err := s.chats.CreateChat(ctx, eveID, aliceID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if err := send(eveChatID, eveID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if err := s.chats.BlockRequest(ctx, eveChatID, aliceID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if got := list(aliceID, chatsvc.RequestChats); len(got) != 0 {
+		t.Fatalf("expected the blocked request to be gone got %v", got)
+	}
+	if got := list(eveID, chatsvc.AllChats); len(got) != 1 || got[0] != eveChatID {
+		t.Fatalf("expected eve to still see her chat got %v", got)
+	}
+	if err := send(eveChatID, eveID); !errors.Is(err, usersvc.ErrBlocked) {
+		t.Fatalf("expected %v got %v", usersvc.ErrBlocked, err)
+	}
+
+	if err := s.users.AddContact(ctx, aliceID, eveID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	contacts, err := s.users.GetContacts(ctx, aliceID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(contacts) != 1 || contacts[0].ID != eveID {
+		t.Fatalf("expected eve in alice's contacts got %v", contacts)
+	}
+}
+
+func TestWiring_Presence(t *testing.T) {
+	ctx := context.Background()
+
+	s := newStack(t)
+	presence := presencesvc.NewService(inmempresencerepo.New(), s.chatRepo, s.users)
+
+	aliceID := createUser(t, s.users, "Alice", "+97311111111")
+	bobID := createUser(t, s.users, "Bob", "+97322222222")
+	eveID := createUser(t, s.users, "Eve", "+97333333333")
+	addContacts(t, s.users, aliceID, bobID)
+	if _, err := s.chats.CreateChat(ctx, aliceID, bobID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if err := s.users.UpdatePrivacySettings(ctx, aliceID, user.PrivacySettings{LastSeen: user.Nobody}); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	bobEvents, stopBob := presence.Subscribe(ctx, bobID)
+	defer stopBob()
+	eveEvents, stopEve := presence.Subscribe(ctx, eveID)
+	defer stopEve()
+
+	connID, err := presence.Connect(ctx, aliceID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if err := presence.Disconnect(ctx, connID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	for _, want := range []user.Status{user.Online, user.Offline} {
+		select {
+		case p := <-bobEvents:
+			if p.UserID != aliceID || p.Status != want || !p.LastSeen.IsZero() {
+				t.Fatalf("expected alice %v without last seen got %v", want, p)
+			}
+		default:
+			t.Fatalf("expected bob to be told alice is %v", want)
+		}
+	}
+	select {
+	case p := <-eveEvents:
+		t.Fatalf("expected eve not to hear about alice got %v", p)
+	default:
+	}
+
+	p, err := presence.GetPresence(ctx, aliceID, aliceID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if p.Status != user.Offline || p.LastSeen.IsZero() {
+		t.Fatalf("expected alice to see her own last seen got %v", p)
+	}
+}
+
+func TestWiring_EncryptedChat(t *testing.T) {
+	ctx := context.Background()
+
+	s := newStack(t)
+
+	aliceID := createUser(t, s.users, "Alice", "+97311111111")
+	bobID := createUser(t, s.users, "Bob", "+97322222222")
+	addContacts(t, s.users, aliceID, bobID)
+	chatID, err := s.chats.CreateChat(ctx, aliceID, bobID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if err := s.chats.EnableEncryption(ctx, chatID, aliceID); !errors.Is(err, chatsvc.ErrKeysMissing) {
+		t.Fatalf("expected %v got %v", chatsvc.ErrKeysMissing, err)
+	}
+
+	clients := make(map[uuid.UUID]*e2ee.Client)
+	for _, id := range []uuid.UUID{aliceID, bobID} {
+		c, err := e2ee.NewClient()
+		if err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+		preKeys, err := c.GenerateOneTimePreKeys(5)
+		if err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+		if err := s.users.PublishKeys(ctx, id, c.IdentityKeys()); err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+		if err := s.users.AddOneTimePreKeys(ctx, id, preKeys); err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+		clients[id] = c
+	}
+	if err := s.chats.EnableEncryption(ctx, chatID, aliceID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	bundle, err := s.users.GetPreKeyBundle(ctx, aliceID, bobID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if n, err := s.users.CountOneTimePreKeys(ctx, bobID); err != nil || n != 4 {
+		t.Fatalf("expected a one-time prekey to be handed out got %d, %v", n, err)
+	}
+	if err := clients[aliceID].StartSession(bundle); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	if _, err := s.msgs.CreateMessage(ctx, msgsvc.MessageInput{
+		SenderID:    aliceID,
+		ChatID:      chatID,
+		Content:     []byte("plaintext"),
+		ContentType: message.TextContentType,
+	}); !errors.Is(err, msgsvc.ErrEncryptionMismatch) {
+		t.Fatalf("expected %v got %v", msgsvc.ErrEncryptionMismatch, err)
+	}
+
+	conversation := []struct {
+		from, to uuid.UUID
+		text     string
+	}{
+		{aliceID, bobID, "the secret is 4242"},
+		{bobID, aliceID, "got the secret"},
+		{aliceID, bobID, "delete this chat"},
+	}
+	for i, line := range conversation {
+		envelope, err := clients[line.from].Encrypt(line.to, []byte(line.text))
+		if err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+		if _, err := s.msgs.CreateMessage(ctx, msgsvc.MessageInput{
+			SenderID:    line.from,
+			ChatID:      chatID,
+			Content:     envelope,
+			ContentType: message.EncryptedContentType,
+		}); err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+
+		got, err := s.msgs.GetMessages(ctx, chatID, line.to)
+		if err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+		plaintext, err := clients[line.to].Decrypt(line.from, got[i].Content)
+		if err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+		if string(plaintext) != line.text {
+			t.Fatalf("expected %q got %q", line.text, plaintext)
+		}
+	}
+
+	stored, err := s.msgRepo.GetMessages(ctx, chatID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	for _, m := range stored {
+		for _, line := range conversation {
+			if bytes.Contains(m.Content, []byte(line.text)) {
+				t.Fatalf("expected the server to never see plaintext, found %q", line.text)
+			}
+		}
+	}
+	page, err := s.chats.GetChats(ctx, chatsvc.GetChatsInput{UserID: bobID})
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(page.Chats) != 1 || !page.Chats[0].Encrypted || page.Chats[0].LastMessage.Text != "[encrypted message]" {
+		t.Fatalf("expected an encrypted chat with an opaque preview got %v", page.Chats)
+	}
+}
+
+func TestWiring_ReportAndModerate(t *testing.T) {
+	ctx := context.Background()
+
+	s := newStack(t)
+
+	aliceID := createUser(t, s.users, "Alice", "+97311111111")
+	bobID := createUser(t, s.users, "Bob", "+97322222222")
+	modID := createUser(t, s.users, "Mod", "+97344444444")
+	addContacts(t, s.users, aliceID, bobID)
+	reports := reportsvc.NewService(inmemreportrepo.New(modID), s.msgs, s.users)
+
+	chatID, err := s.chats.CreateChat(ctx, aliceID, bobID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if _, err := s.msgs.CreateMessage(ctx, msgsvc.MessageInput{SenderID: aliceID, ChatID: chatID, Content: []byte("hi bob")}); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	abuseID, err := s.msgs.CreateMessage(ctx, msgsvc.MessageInput{SenderID: bobID, ChatID: chatID, Content: []byte("something abusive")})
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	if _, err := reports.Report(ctx, modID, report.Target{ChatID: chatID, MessageID: abuseID}, report.Harassment); !errors.Is(err, chatsvc.ErrMemberNotFound) {
+		t.Fatalf("expected outsiders unable to report got %v", err)
+	}
+	reportID, err := reports.Report(ctx, aliceID, report.Target{ChatID: chatID, MessageID: abuseID}, report.Harassment)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if _, err := reports.GetQueue(ctx, aliceID, report.Open); !errors.Is(err, reportsvc.ErrNotModerator) {
+		t.Fatalf("expected %v got %v", reportsvc.ErrNotModerator, err)
+	}
+	queue, err := reports.GetQueue(ctx, modID, report.Open)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(queue) != 1 || queue[0].ID != reportID || queue[0].Target.UserID != bobID || len(queue[0].Snapshot) != 2 {
+		t.Fatalf("expected the report with its snapshot got %v", queue)
+	}
+
+	if err := reports.AssignReport(ctx, modID, reportID, modID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if err := reports.DeleteMessage(ctx, modID, reportID, "harassment"); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	history, err := s.msgs.GetMessages(ctx, chatID, aliceID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(history) != 1 {
+		t.Fatalf("expected the reported message removed got %v", history)
+	}
+	got, err := reports.GetReport(ctx, modID, reportID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if got.Status != report.Resolved || len(got.Snapshot) != 2 {
+		t.Fatalf("expected a resolved report keeping its snapshot got %v", got)
+	}
+
+	userReportID, err := reports.Report(ctx, aliceID, report.Target{UserID: bobID, ChatID: chatID}, report.Harassment)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if err := reports.SuspendUser(ctx, modID, userReportID, time.Now().Add(time.Hour), "repeated harassment"); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if _, err := s.msgs.CreateMessage(ctx, msgsvc.MessageInput{SenderID: bobID, ChatID: chatID, Content: []byte("again")}); !errors.Is(err, usersvc.ErrSuspended) {
+		t.Fatalf("expected %v got %v", usersvc.ErrSuspended, err)
+	}
+
+	log, err := reports.GetAuditLog(ctx, modID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(log) != 3 || log[0].Action != report.ReportAssigned || log[1].Action != report.MessageDeleted || log[2].Action != report.UserSuspended {
+		t.Fatalf("expected assignment, deletion and suspension audited got %v", log)
+	}
+}
+
+func TestWiring_AccountLifecycle(t *testing.T) {
+	ctx := context.Background()
+
+	s := newStack(t)
+	hooks := webhooksvc.NewService(inmemwebhookrepo.New(), s.chats, webhooksvc.DefaultConfig)
+	exports := exportsvc.NewService(inmemexportrepo.New(), s.users, s.chats, s.msgs)
+	accounts := accountsvc.NewService(s.users, s.chats, s.msgs, hooks, exports, accountsvc.DeleteMessages)
+
+	aliceID := createUser(t, s.users, "Alice", "+97311111111")
+	bobID := createUser(t, s.users, "Bob", "+97322222222")
+	addContacts(t, s.users, aliceID, bobID)
+	chatID, err := s.chats.CreateChat(ctx, aliceID, bobID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	send := func(senderID uuid.UUID, content string) error {
+		_, err := s.msgs.CreateMessage(ctx, msgsvc.MessageInput{SenderID: senderID, ChatID: chatID, Content: []byte(content)})
+		return err
+	}
+
+	if err := s.users.DeactivateUser(ctx, bobID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if err := send(aliceID, "are you there?"); !errors.Is(err, usersvc.ErrAccountClosed) {
+		t.Fatalf("expected %v got %v", usersvc.ErrAccountClosed, err)
+	}
+	if err := s.users.ReactivateUser(ctx, bobID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if err := send(aliceID, "welcome back"); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if err := send(bobID, "thanks"); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if _, err := s.msgs.ScheduleMessage(ctx, msgsvc.MessageInput{SenderID: bobID, ChatID: chatID, Content: []byte("happy birthday")}, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if _, err := hooks.Subscribe(ctx, webhooksvc.CreateSubscriptionInput{OwnerID: bobID, URL: "https://bob.example/hook"}); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	_, token, err := s.users.CreateBot(ctx, bobID, usersvc.CreateUserInput{FirstName: "Helper", Username: "helper_bot", ImageURL: "https://example.com/bot.png"})
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	jobID, err := exports.StartExport(ctx, bobID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	exports.Wait()
+
+	if err := accounts.DeleteAccount(ctx, bobID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if subs, _ := hooks.GetSubscriptions(ctx, bobID); len(subs) != 0 {
+		t.Fatalf("expected bob's webhooks deleted got %+v", subs)
+	}
+	if _, err := s.users.AuthenticateBot(ctx, token); !errors.Is(err, usersvc.ErrInvalidToken) {
+		t.Fatalf("expected %v got %v", usersvc.ErrInvalidToken, err)
+	}
+	if _, err := exports.GetExport(ctx, bobID, jobID); !errors.Is(err, exportrepo.ErrJobNotFound) {
+		t.Fatalf("expected %v got %v", exportrepo.ErrJobNotFound, err)
+	}
+	if scheduled, _ := s.msgs.GetScheduledMessages(ctx, bobID); len(scheduled) != 0 {
+		t.Fatalf("expected bob's scheduled messages cancelled got %+v", scheduled)
+	}
+	bob, err := s.users.GetUser(ctx, bobID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if bob.Status != user.Deleted || bob.Username != "" || bob.FirstName != "Deleted" {
+		t.Fatalf("expected an anonymized profile got %v", bob)
+	}
+	bobChats, err := s.chats.GetChats(ctx, chatsvc.GetChatsInput{UserID: bobID})
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(bobChats.Chats) != 0 {
+		t.Fatalf("expected bob removed from his chats got %v", bobChats)
+	}
+	c, err := s.chats.GetChat(ctx, chatID, aliceID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if c.OtherUser.ID != bobID || c.DisplayName != "Deleted Account" {
+		t.Fatalf("expected alice to keep the chat with the deleted account got %v", c)
+	}
+	history, err := s.msgs.GetMessages(ctx, chatID, aliceID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(history) != 1 || history[0].SenderID != aliceID {
+		t.Fatalf("expected only alice's message left got %v", history)
+	}
+	if err := send(aliceID, "hello?"); !errors.Is(err, usersvc.ErrAccountClosed) {
+		t.Fatalf("expected %v got %v", usersvc.ErrAccountClosed, err)
+	}
+	var published []events.Event
+	s.bus.Subscribe(func(_ context.Context, e events.Event) { published = append(published, e) })
+	if err := s.chats.SetDisappearingMessages(ctx, chatID, aliceID, time.Hour); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(published) != 1 || !slices.Equal(published[0].Recipients, []uuid.UUID{aliceID}) {
+		t.Fatalf("expected only alice told about the chat got %+v", published)
+	}
+	if err := s.users.ReactivateUser(ctx, bobID); !errors.Is(err, usersvc.ErrAccountDeleted) {
+		t.Fatalf("expected %v got %v", usersvc.ErrAccountDeleted, err)
+	}
+}
+
+func TestWiring_DataExport(t *testing.T) {
+	ctx := context.Background()
+
+	s := newStack(t)
+	exports := exportsvc.NewService(inmemexportrepo.New(), s.users, s.chats, s.msgs)
+
+	aliceID := createUser(t, s.users, "Alice", "+97311111111")
+	bobID := createUser(t, s.users, "Bob", "+97322222222")
+	addContacts(t, s.users, aliceID, bobID)
+	chatID, err := s.chats.CreateChat(ctx, aliceID, bobID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if _, err := s.msgs.CreateMessage(ctx, msgsvc.MessageInput{SenderID: bobID, ChatID: chatID, Content: []byte("hi alice")}); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	carolID := createUser(t, s.users, "Carol", "+97333333333")
+	addContacts(t, s.users, aliceID, carolID)
+	deletedID, err := s.chats.CreateChat(ctx, aliceID, carolID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if err := s.chats.DeleteChatForMe(ctx, deletedID, aliceID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	jobID, err := exports.StartExport(ctx, aliceID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	exports.Wait()
+	job, err := exports.GetExport(ctx, aliceID, jobID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if job.Status != export.Completed {
+		t.Fatalf("expected a completed export got %+v", job)
+	}
+	archive, err := exports.GetArchive(ctx, aliceID, jobID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	f, err := zr.Open("chats/" + chatID.String() + "/messages.json")
+	if err != nil {
+		t.Fatalf("expected the chat's messages got %v", err)
+	}
+	defer f.Close()
+	b, _ := io.ReadAll(f)
+	if !bytes.Contains(b, []byte("hi alice")) {
+		t.Fatalf("expected bob's message got %s", b)
+	}
+	if _, err := zr.Open("chats/" + deletedID.String() + "/messages.json"); err != nil {
+		t.Fatalf("expected the chat alice deleted for herself got %v", err)
+	}
+}
+
+func TestWiring_ImportHistory(t *testing.T) {
+	ctx := context.Background()
+
+	s := newStack(t)
+	importer := importsvc.NewService(s.users, s.chats, s.msgRepo, "https://example.com/imported.png")
+
+	aliceID := createUser(t, s.users, "Alice", "+97311111111")
+	h, err := importsvc.ParseWhatsApp(strings.NewReader(`31/12/2023, 21:41 - Alice: Happy new year!
+31/12/2023, 21:42 - Bob: You too!
+`), nil)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	in := importsvc.ImportInput{OwnerID: aliceID, History: h, Users: map[string]uuid.UUID{"Alice": aliceID}}
+
+	report, err := importer.Import(ctx, in)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	bobID := report.Participants[1].UserID
+	got, err := s.msgs.GetMessages(ctx, report.ChatID, bobID)
+	if err != nil {
+		t.Fatalf("expected the imported chat got %v", err)
+	}
+	if len(got) != 2 || string(got[1].Content) != "You too!" || !got[1].Timestamp.Equal(time.Date(2023, 12, 31, 21, 42, 0, 0, time.UTC)) || !got[1].Imported {
+		t.Fatalf("expected the imported messages with their timestamps got %+v", got)
+	}
+	c, err := s.chats.GetChat(ctx, report.ChatID, aliceID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if c.Request != chat.NoRequest {
+		t.Fatalf("expected the imported chat not to be a request got %v", c.Request)
+	}
+
+	// Older history slots in before what the chat already holds.
+	if _, err := s.msgs.CreateMessage(ctx, msgsvc.MessageInput{SenderID: aliceID, ChatID: report.ChatID, Content: []byte("Still there?")}); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	older, err := importsvc.ParseWhatsApp(strings.NewReader(`01/06/2023, 09:00 - Bob: Hi Alice
+`), nil)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if _, err := importer.Import(ctx, importsvc.ImportInput{OwnerID: aliceID, History: older, Users: map[string]uuid.UUID{"Bob": bobID}}); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	got, err = s.msgs.GetMessages(ctx, report.ChatID, aliceID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(got) != 4 || string(got[0].Content) != "Hi Alice" || string(got[3].Content) != "Still there?" {
+		t.Fatalf("expected the history in order got %+v", got)
+	}
+	page, err := s.chats.GetChats(ctx, chatsvc.GetChatsInput{UserID: aliceID})
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(page.Chats) != 1 || page.Chats[0].LastMessage == nil || page.Chats[0].LastMessage.Text != "Still there?" {
+		t.Fatalf("expected the latest message previewed got %+v", page.Chats)
+	}
+
+	// Nobody else can be made to have written the export.
+	carolID := createUser(t, s.users, "Carol", "+97333333333")
+	in.Users["Bob"] = carolID
+	if _, err := importer.Import(ctx, in); !errors.Is(err, importsvc.ErrForeignParticipant) {
+		t.Fatalf("expected %v got %v", importsvc.ErrForeignParticipant, err)
+	}
+
+	// Importing the same export again adds nothing.
+	in.Users["Bob"] = bobID
+	report, err = importer.Import(ctx, in)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if report.NewChat || report.Messages != 0 || report.Duplicates != 2 {
+		t.Fatalf("expected every message to be a duplicate got %+v", report)
+	}
+}
+
+func TestWiring_Webhooks(t *testing.T) {
+	ctx := context.Background()
+
+	s := newStack(t)
+
+	var (
+		mu       sync.Mutex
+		received []webhooksvc.Payload
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var p webhooksvc.Payload
+		if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		received = append(received, p)
+		mu.Unlock()
+	}))
+	defer srv.Close()
+	config := webhooksvc.DefaultConfig
+	config.Blocked = func(netip.Addr) bool { return false }
+	hooks := webhooksvc.NewService(inmemwebhookrepo.New(), s.chats, config)
+	s.bus.Subscribe(func(ctx context.Context, e events.Event) {
+		if err := hooks.HandleEvent(ctx, e); err != nil {
+			t.Errorf("expected no error got %v", err)
+		}
+	})
+
+	aliceID := createUser(t, s.users, "Alice", "+97311111111")
+	bobID := createUser(t, s.users, "Bob", "+97322222222")
+	addContacts(t, s.users, aliceID, bobID)
+	if _, err := hooks.Subscribe(ctx, webhooksvc.CreateSubscriptionInput{OwnerID: aliceID, URL: srv.URL}); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	chatID, err := s.chats.CreateChat(ctx, aliceID, bobID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if _, err := s.msgs.CreateMessage(ctx, msgsvc.MessageInput{SenderID: bobID, ChatID: chatID, Content: []byte("hi alice")}); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	if err := hooks.Deliver(ctx, time.Now().UTC()); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(received) != 2 || received[0].Type != events.ChatCreated || received[1].Type != events.MessageCreated {
+		t.Fatalf("expected the chat and message events got %+v", received)
+	}
+	if received[1].Message.Text != "hi alice" || received[1].Message.SenderID != bobID {
+		t.Fatalf("expected Bob's message got %+v", received[1].Message)
+	}
+}
+
+func TestWiring_Bots(t *testing.T) {
+	ctx := context.Background()
+
+	s := newStack(t)
+	hooks := webhooksvc.NewService(inmemwebhookrepo.New(), s.chats, webhooksvc.DefaultConfig)
+	bots := botsvc.NewService(inmembotrepo.New(), s.users, s.msgs, hooks)
+	s.bus.Subscribe(func(ctx context.Context, e events.Event) {
+		if err := bots.HandleEvent(ctx, e); err != nil {
+			t.Errorf("expected no error got %v", err)
+		}
+	})
+
+	aliceID := createUser(t, s.users, "Alice", "+97311111111")
+	bobID := createUser(t, s.users, "Bob", "+97322222222")
+	addContacts(t, s.users, aliceID, bobID)
+	botID, token, err := s.users.CreateBot(ctx, aliceID, usersvc.CreateUserInput{ImageURL: "https://bot.png", FirstName: "Echo", Username: "echo_bot"})
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if _, err := s.chats.CreateChat(ctx, botID, bobID); !errors.Is(err, usersvc.ErrBotNotInvited) {
+		t.Fatalf("expected ErrBotNotInvited got %v", err)
+	}
+
+	chatID, err := s.chats.CreateChat(ctx, aliceID, bobID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if err := s.chats.AddBot(ctx, chatID, aliceID, botID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	c, err := s.chats.GetChat(ctx, chatID, bobID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(c.InvitedBots) != 1 || c.InvitedBots[0].BotID != botID || c.InvitedBots[0].InviterID != aliceID {
+		t.Fatalf("expected Bob to be asked about the bot got %+v", c.InvitedBots)
+	}
+	if _, err := bots.SendMessage(ctx, token, chatID, []byte("hi"), message.TextContentType); err == nil {
+		t.Fatalf("expected the invited bot to be unable to send before Bob accepts got %v", err)
+	}
+	if err := s.chats.AcceptBot(ctx, chatID, aliceID, botID); err == nil {
+		t.Fatalf("expected Alice to be unable to accept her own invitation got %v", err)
+	}
+	if err := s.chats.AcceptBot(ctx, chatID, bobID, botID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if c, err = s.chats.GetChat(ctx, chatID, bobID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if c.OtherUser.ID != aliceID || len(c.InvitedBots) != 0 {
+		t.Fatalf("expected Bob to still see Alice in the chat and no invitation got %+v", c)
+	}
+	if _, err := s.msgs.CreateMessage(ctx, msgsvc.MessageInput{SenderID: bobID, ChatID: chatID, Content: []byte("ping")}); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	updates, err := bots.GetUpdates(ctx, token, 0, 0)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(updates) != 2 || updates[0].Event.Type != events.MemberAdded || updates[1].Event.Message.Text != "ping" {
+		t.Fatalf("expected the bot to be added and get Bob's message got %+v", updates)
+	}
+	if _, err := bots.SendMessage(ctx, token, chatID, []byte("pong"), message.TextContentType); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	ms, err := s.msgs.GetMessages(ctx, chatID, bobID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(ms) != 2 || !slices.ContainsFunc(ms, func(m message.Message) bool { return m.SenderID == botID && string(m.Content) == "pong" }) {
+		t.Fatalf("expected Bob to see the bot's reply got %+v", ms)
+	}
+
+	if err := s.chats.RemoveBot(ctx, chatID, bobID, botID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if _, err := bots.SendMessage(ctx, token, chatID, []byte("still here?"), message.TextContentType); err == nil {
+		t.Fatalf("expected the removed bot to be unable to send got %v", err)
+	}
+}
+
+func TestWiring_SlashCommands(t *testing.T) {
+	ctx := context.Background()
+
+	s := newStack(t)
+	for _, c := range []commands.Command{commands.Me(s.users), commands.Shrug(), commands.Mute(s.chats)} {
+		if err := s.commands.Register(c); err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+	}
+	var replies []events.Event
+	s.bus.Subscribe(func(_ context.Context, e events.Event) {
+		if e.Type == events.EphemeralMessage {
+			replies = append(replies, e)
+		}
+	})
+
+	aliceID := createUser(t, s.users, "Alice", "+97311111111")
+	bobID := createUser(t, s.users, "Bob", "+97322222222")
+	addContacts(t, s.users, aliceID, bobID)
+	chatID, err := s.chats.CreateChat(ctx, aliceID, bobID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	s.now = time.Now().UTC().Add(-time.Minute).Truncate(time.Second)
+	if id, err := s.msgs.CreateMessage(ctx, msgsvc.MessageInput{SenderID: aliceID, ChatID: chatID, Content: []byte("/mute 1h")}); err != nil || id != uuid.Nil {
+		t.Fatalf("expected /mute to send nothing got %v, %v", id, err)
+	}
+	c, err := s.chats.GetChat(ctx, chatID, aliceID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if !c.Muted || !c.MutedUntil.Equal(s.now.Add(time.Hour)) {
+		t.Fatalf("expected the chat muted for Alice got %+v", c)
+	}
+	if len(replies) != 1 || replies[0].Recipients[0] != aliceID {
+		t.Fatalf("expected a reply to Alice only got %+v", replies)
+	}
+
+	if _, err := s.msgs.CreateMessage(ctx, msgsvc.MessageInput{SenderID: bobID, ChatID: chatID, Content: []byte("/me shrugs")}); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if err := s.msgs.DisableCommand(ctx, chatID, bobID, "shrug"); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if _, err := s.msgs.CreateMessage(ctx, msgsvc.MessageInput{SenderID: aliceID, ChatID: chatID, Content: []byte("/shrug")}); !errors.Is(err, commands.ErrCommandDisabled) {
+		t.Fatalf("expected ErrCommandDisabled got %v", err)
+	}
+	cs, err := s.msgs.GetCommands(ctx, chatID, aliceID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(cs) != 2 || cs[0].Name != "me" || cs[1].Name != "mute" {
+		t.Fatalf("expected /me and /mute got %+v", cs)
+	}
+
+	ms, err := s.msgs.GetMessages(ctx, chatID, aliceID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(ms) != 1 || string(ms[0].Content) != "* Bob shrugs" {
+		t.Fatalf("expected only Bob's action got %+v", ms)
+	}
+}
+
+func TestWiring_Mentions(t *testing.T) {
+	ctx := context.Background()
+
+	s := newStack(t)
+
+	aliceID := createUser(t, s.users, "Alice", "+97311111111")
+	bobID := createUser(t, s.users, "Bob", "+97322222222")
+	addContacts(t, s.users, aliceID, bobID)
+	chatID, err := s.chats.CreateChat(ctx, aliceID, bobID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if err := s.chats.MuteChat(ctx, chatID, aliceID, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if err := s.chats.ArchiveChat(ctx, chatID, aliceID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	if _, err := s.msgs.CreateMessage(ctx, msgsvc.MessageInput{SenderID: bobID, ChatID: chatID, Content: []byte("ping @+97311111111 and @all")}); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	mentions, err := s.msgs.GetMentions(ctx, aliceID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	expected := []message.Mention{{UserID: aliceID, Offset: 5, Length: 13}}
+	if len(mentions) != 1 || !slices.Equal(mentions[0].Mentions, expected) {
+		t.Fatalf("expected Alice mentioned once got %+v", mentions)
+	}
+
+	page, err := s.chats.GetChats(ctx, chatsvc.GetChatsInput{UserID: aliceID})
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(page.Chats) != 1 || page.Chats[0].Mentions != 1 || !page.Chats[0].Muted {
+		t.Fatalf("expected the muted chat back in the inbox with a mention got %+v", page.Chats)
+	}
+
+	if err := s.chats.ReadMentions(ctx, chatID, aliceID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	c, err := s.chats.GetChat(ctx, chatID, aliceID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if c.Mentions != 0 {
+		t.Fatalf("expected no unread mentions got %d", c.Mentions)
+	}
+}
+
+func TestWiring_ArchivedChats(t *testing.T) {
+	ctx := context.Background()
+
+	s := newStack(t)
+
+	aliceID := createUser(t, s.users, "Alice", "+97311111111")
+	bobID := createUser(t, s.users, "Bob", "+97322222222")
+	addContacts(t, s.users, aliceID, bobID)
+	chatID, err := s.chats.CreateChat(ctx, aliceID, bobID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	archived := func() bool {
+		t.Helper()
+		c, err := s.chats.GetChat(ctx, chatID, aliceID)
+		if err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+		return c.Archived
+	}
+
+	if err := s.chats.ArchiveChat(ctx, chatID, aliceID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if _, err := s.msgs.CreateMessage(ctx, msgsvc.MessageInput{SenderID: bobID, ChatID: chatID, Content: []byte("hello")}); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if archived() {
+		t.Fatalf("expected the message to unarchive the chat")
+	}
+
+	// Muting later must not send the chat back to the archive.
+	if err := s.chats.MuteChat(ctx, chatID, aliceID, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if archived() {
+		t.Fatalf("expected the chat to stay in the inbox after muting it")
+	}
+
+	if err := s.chats.ArchiveChat(ctx, chatID, aliceID); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if _, err := s.msgs.CreateMessage(ctx, msgsvc.MessageInput{SenderID: bobID, ChatID: chatID, Content: []byte("still there?")}); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if !archived() {
+		t.Fatalf("expected the muted chat to stay archived")
+	}
+}
+
+func TestWiring_RichText(t *testing.T) {
+	ctx := context.Background()
+
+	s := newStack(t)
+
+	aliceID := createUser(t, s.users, "Alice", "+97311111111")
+	bobID := createUser(t, s.users, "Bob", "+97322222222")
+	addContacts(t, s.users, aliceID, bobID)
+	chatID, err := s.chats.CreateChat(ctx, aliceID, bobID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	if _, err := s.msgs.CreateMessage(ctx, msgsvc.MessageInput{
+		SenderID:    aliceID,
+		ChatID:      chatID,
+		Content:     []byte("**Lunch?** see [the menu](https://example.com/menu)"),
+		ContentType: message.RichTextContentType,
+	}); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	ms, err := s.msgs.GetMessages(ctx, chatID, bobID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	expected := []message.Entity{
+		{Type: message.BoldEntity, Offset: 0, Length: 6},
+		{Type: message.LinkEntity, Offset: 11, Length: 8, URL: "https://example.com/menu"},
+	}
+	if len(ms) != 1 || string(ms[0].Content) != "Lunch? see the menu" || !slices.Equal(ms[0].Entities, expected) {
+		t.Fatalf("expected the message stored as plain text and entities got %+v", ms)
+	}
+
+	c, err := s.chats.GetChat(ctx, chatID, bobID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if c.LastMessage == nil || c.LastMessage.Text != "Lunch? see the menu" {
+		t.Fatalf("expected a plain text preview got %+v", c.LastMessage)
+	}
+}
+
+func TestWiring_LinkPreviews(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	site := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = io.WriteString(w, `<html><head><meta property="og:title" content="Menu"><meta property="og:image" content="/menu.png"></head></html>`)
+	}))
+	defer site.Close()
+
+	s := newStack(t)
+	// The test site listens on loopback, which the default config blocks.
+	fetcherConfig := linkpreview.DefaultConfig
+	fetcherConfig.Blocked = func(netip.Addr) bool { return false }
+	previews := previewsvc.NewService(inmempreviewrepo.New(), s.msgRepo, linkpreview.NewFetcher(fetcherConfig), s.bus, previewsvc.DefaultConfig)
+	updated := make(chan events.Event, 1)
+	s.bus.Subscribe(func(ctx context.Context, e events.Event) {
+		if err := previews.HandleEvent(ctx, e); err != nil {
+			t.Errorf("expected no error got %v", err)
+		}
+		if e.Type == events.MessageUpdated {
+			updated <- e
+		}
+	})
+	go previews.Run(ctx, 2)
+
+	aliceID := createUser(t, s.users, "Alice", "+97311111111")
+	bobID := createUser(t, s.users, "Bob", "+97322222222")
+	addContacts(t, s.users, aliceID, bobID)
+	chatID, err := s.chats.CreateChat(ctx, aliceID, bobID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	link := site.URL + "/menu"
+	if _, err := s.msgs.CreateMessage(ctx, msgsvc.MessageInput{SenderID: aliceID, ChatID: chatID, Content: []byte("lunch? " + link)}); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	expected := []message.Preview{{URL: link, Title: "Menu", ImageURL: site.URL + "/menu.png"}}
+	select {
+	case e := <-updated:
+		if !slices.Equal(e.Recipients, []uuid.UUID{aliceID, bobID}) || !slices.Equal(e.Message.Previews, expected) {
+			t.Fatalf("expected the preview sent to both participants got %+v", e)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected a message.updated event")
+	}
+	ms, err := s.msgs.GetMessages(ctx, chatID, bobID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(ms) != 1 || !slices.Equal(ms[0].Previews, expected) {
+		t.Fatalf("expected the preview stored with the message got %+v", ms)
+	}
+	stored, err := s.storedMsgs.GetMessages(ctx, chatID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(stored) != 1 || len(stored[0].Previews) != 1 || stored[0].Previews[0].Title == "Menu" || stored[0].Previews[0].URL == link {
+		t.Fatalf("expected the preview encrypted at rest got %+v", stored)
+	}
+}
+
+func TestWiring_ScheduledMessages(t *testing.T) {
+	ctx := context.Background()
+
+	s := newStack(t)
+	s.now = time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	aliceID := createUser(t, s.users, "Alice", "+97311111111")
+	bobID := createUser(t, s.users, "Bob", "+97322222222")
+	addContacts(t, s.users, aliceID, bobID)
+	chatID, err := s.chats.CreateChat(ctx, aliceID, bobID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	in := msgsvc.MessageInput{SenderID: aliceID, ChatID: chatID, Content: []byte("Happy new year"), ContentType: message.TextContentType}
+	id, err := s.msgs.ScheduleMessage(ctx, in, s.now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	cancelled, err := s.msgs.ScheduleMessage(ctx, in, s.now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if err := s.msgs.EditScheduledMessage(ctx, aliceID, id, []byte("Happy new year, Bob"), s.now.Add(2*time.Hour)); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if err := s.msgs.CancelScheduledMessage(ctx, bobID, cancelled); !errors.Is(err, msgrepo.ErrScheduledMessageNotFound) {
+		t.Fatalf("expected %v got %v", msgrepo.ErrScheduledMessageNotFound, err)
+	}
+	if err := s.msgs.CancelScheduledMessage(ctx, aliceID, cancelled); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	// The service restarts, reading the schedule back from disk.
+	s.msgs = s.newMessageService(t)
+	scheduled, err := s.msgs.GetScheduledMessages(ctx, aliceID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(scheduled) != 1 || scheduled[0].ID != id || string(scheduled[0].Content) != "Happy new year, Bob" {
+		t.Fatalf("expected the edited message to survive the restart got %+v", scheduled)
+	}
+
+	s.now = s.now.Add(time.Hour)
+	if err := s.msgs.SendScheduledMessages(ctx); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if ms, _ := s.msgs.GetMessages(ctx, chatID, bobID); len(ms) != 0 {
+		t.Fatalf("expected nothing sent before it is due got %+v", ms)
+	}
+
+	s.now = s.now.Add(time.Hour)
+	if err := s.msgs.SendScheduledMessages(ctx); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	ms, err := s.msgs.GetMessages(ctx, chatID, bobID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(ms) != 1 || ms[0].SenderID != aliceID || string(ms[0].Content) != "Happy new year, Bob" || !ms[0].Timestamp.Equal(s.now) {
+		t.Fatalf("expected the scheduled message delivered at %v got %+v", s.now, ms)
+	}
+	if scheduled, _ := s.msgs.GetScheduledMessages(ctx, aliceID); len(scheduled) != 0 {
+		t.Fatalf("expected nothing left scheduled got %+v", scheduled)
+	}
+}
+
+func TestWiring_DisappearingMessages(t *testing.T) {
+	ctx := context.Background()
+
+	s := newStack(t)
+	s.now = time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	modID := uuid.New()
+	webhookRepo := inmemwebhookrepo.New()
+	hooks := webhooksvc.NewService(webhookRepo, s.chats, webhooksvc.DefaultConfig)
+	reports := reportsvc.NewService(inmemreportrepo.New(modID), s.msgs, s.users)
+	var deleted, changed []events.Event
+	s.bus.Subscribe(func(ctx context.Context, e events.Event) {
+		switch e.Type {
+		case events.MessageDeleted:
+			deleted = append(deleted, e)
+		case events.DisappearingMessagesChanged:
+			changed = append(changed, e)
+		}
+		if err := hooks.HandleEvent(ctx, e); err != nil {
+			t.Errorf("expected no error got %v", err)
+		}
+		if err := reports.HandleEvent(ctx, e); err != nil {
+			t.Errorf("expected no error got %v", err)
+		}
+	})
+
+	aliceID := createUser(t, s.users, "Alice", "+97311111111")
+	bobID := createUser(t, s.users, "Bob", "+97322222222")
+	addContacts(t, s.users, aliceID, bobID)
+	chatID, err := s.chats.CreateChat(ctx, aliceID, bobID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	sub, err := hooks.Subscribe(ctx, webhooksvc.CreateSubscriptionInput{OwnerID: bobID, URL: "https://bob.example/hook"})
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	if err := s.chats.SetDisappearingMessages(ctx, chatID, bobID, chatsvc.DisappearAfterDay); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	c, err := s.chats.GetChat(ctx, chatID, aliceID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if c.DisappearAfter != chatsvc.DisappearAfterDay {
+		t.Fatalf("expected messages to disappear after a day got %v", c.DisappearAfter)
+	}
+	disappearing, err := s.msgs.CreateMessage(ctx, msgsvc.MessageInput{SenderID: aliceID, ChatID: chatID, Content: []byte("gone tomorrow"), ContentType: message.TextContentType})
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if err := s.chats.SetDisappearingMessages(ctx, chatID, aliceID, chatsvc.DisappearOff); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(changed) != 2 || changed[0].DisappearAfter != chatsvc.DisappearAfterDay || changed[1].UserID != aliceID || changed[1].DisappearAfter != chatsvc.DisappearOff {
+		t.Fatalf("expected both timer changes announced got %+v", changed)
+	}
+	reportID, err := reports.Report(ctx, bobID, report.Target{ChatID: chatID, MessageID: disappearing}, report.Spam)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	kept, err := s.msgs.CreateMessage(ctx, msgsvc.MessageInput{SenderID: aliceID, ChatID: chatID, Content: []byte("here to stay"), ContentType: message.TextContentType})
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	s.now = s.now.Add(time.Hour)
+	if err := s.msgs.DeleteExpiredMessages(ctx); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(deleted) != 0 {
+		t.Fatalf("expected nothing deleted before it expires got %+v", deleted)
+	}
+
+	s.now = s.now.Add(chatsvc.DisappearAfterDay)
+	if err := s.msgs.DeleteExpiredMessages(ctx); err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(deleted) != 1 || deleted[0].Message.ID != disappearing || !slices.Contains(deleted[0].Recipients, bobID) {
+		t.Fatalf("expected the deletion announced to Bob got %+v", deleted)
+	}
+	ms, err := s.msgs.GetMessages(ctx, chatID, bobID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(ms) != 1 || ms[0].ID != kept || !ms[0].ExpiresAt.IsZero() {
+		t.Fatalf("expected only the message sent with the timer off left got %+v", ms)
+	}
+	stored, err := s.storedMsgs.GetMessages(ctx, chatID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	if len(stored) != 1 || stored[0].ID != kept || bytes.Contains(stored[0].Content, []byte("here to stay")) {
+		t.Fatalf("expected only the kept message left, encrypted at rest got %+v", stored)
+	}
+
+	// Nothing else keeps the content of the message once it is gone.
+	ds, err := webhookRepo.GetDeliveries(ctx, sub.ID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	for _, d := range ds {
+		if bytes.Contains(d.Payload, []byte("gone tomorrow")) {
+			t.Fatalf("expected the disappeared message redacted from webhook deliveries got %s", d.Payload)
+		}
+	}
+	rep, err := reports.GetReport(ctx, modID, reportID)
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+	for _, m := range rep.Snapshot {
+		if m.ID == disappearing && m.Content != nil {
+			t.Fatalf("expected the disappeared message redacted from the report got %q", m.Content)
+		}
+	}
+}
+
+func TestWiring_ScheduledMessagesWhileUsersChange(t *testing.T) {
+	ctx := context.Background()
+
+	s := newStack(t)
+	s.now = time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	chatIDs := make(map[uuid.UUID]uuid.UUID)
+	for i := range 20 {
+		senderID := createUser(t, s.users, "Sender", fmt.Sprintf("+9733%07d", i))
+		recipientID := createUser(t, s.users, "Recipient", fmt.Sprintf("+9734%07d", i))
+		addContacts(t, s.users, senderID, recipientID)
+		chatID, err := s.chats.CreateChat(ctx, senderID, recipientID)
+		if err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+		in := msgsvc.MessageInput{SenderID: senderID, ChatID: chatID, Content: []byte("on time"), ContentType: message.TextContentType}
+		if _, err := s.msgs.ScheduleMessage(ctx, in, s.now.Add(time.Minute)); err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+		chatIDs[chatID] = recipientID
+	}
+	s.now = s.now.Add(time.Minute)
+
+	// The scheduler sends while requests keep changing users and chats.
+	sent := make(chan error)
+	go func() { sent <- s.msgs.SendScheduledMessages(ctx) }()
+	var err error
+	for i, done := 0, false; !done; i++ {
+		select {
+		case err = <-sent:
+			done = true
+		default:
+			aliceID := createUser(t, s.users, "Alice", fmt.Sprintf("+9735%07d", i))
+			bobID := createUser(t, s.users, "Bob", fmt.Sprintf("+9736%07d", i))
+			addContacts(t, s.users, aliceID, bobID)
+			if _, err := s.chats.CreateChat(ctx, aliceID, bobID); err != nil {
+				t.Fatalf("expected no error got %v", err)
+			}
+			if err := s.users.BlockUser(ctx, aliceID, bobID); err != nil {
+				t.Fatalf("expected no error got %v", err)
+			}
+		}
+	}
+	if err != nil {
+		t.Fatalf("expected no error got %v", err)
+	}
+
+	for chatID, recipientID := range chatIDs {
+		ms, err := s.msgs.GetMessages(ctx, chatID, recipientID)
+		if err != nil {
+			t.Fatalf("expected no error got %v", err)
+		}
+		if len(ms) != 1 || string(ms[0].Content) != "on time" {
+			t.Fatalf("expected the scheduled message delivered to chat %v got %+v", chatID, ms)
+		}
+	}
+}